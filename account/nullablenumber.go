@@ -96,6 +96,13 @@ func (n NullableNumber) IsNull() bool {
 	return n == NumberNull
 }
 
+// IsZero returns true if the string is empty or only whitespace
+// which is marshalled as JSON null, so that the number
+// is omitted by encoding/json with the omitzero tag option.
+func (n NullableNumber) IsZero() bool {
+	return strings.TrimSpace(string(n)) == ""
+}
+
 // IsNotNull returns true if the string is not empty.
 func (n NullableNumber) IsNotNull() bool {
 	return n != NumberNull
@@ -4,7 +4,9 @@
 // - IBAN (International Bank Account Number) validation and parsing
 // - BIC (Bank Identifier Code) validation and parsing
 // - Bank account management with validation
+// - SEPA creditor identifier (CI) validation
 // - CAMT53 bank statement parsing
+// - PAIN002 payment status report parsing
 // - Database integration (Scanner/Valuer interfaces)
 // - JSON marshalling/unmarshalling
 // - Nullable banking types support
@@ -21,6 +21,15 @@ import (
 	"github.com/domonda/go-types/country"
 )
 
+// Error codes of BIC validation errors
+// that can be used to look up localized messages.
+// See types.CodedError.
+const (
+	ErrCodeBICLength  types.ErrorCode = "bank.bic.length"
+	ErrCodeBICInvalid types.ErrorCode = "bank.bic.invalid"
+	ErrCodeBICBlocked types.ErrorCode = "bank.bic.blocked"
+)
+
 // Compile-time check that BIC implements types.NormalizableValidator[BIC]
 var _ types.NormalizableValidator[BIC] = BIC("")
 
@@ -69,15 +78,15 @@ func (bic BIC) ValidAndNormalized() bool {
 func (bic BIC) Validate() error {
 	length := len(bic)
 	if length != BICMinLength && length != BICMaxLength {
-		return fmt.Errorf("invalid BIC %q length: %d", string(bic), length)
+		return types.NewCodedError(ErrCodeBICLength, fmt.Sprintf("invalid BIC %q length: %d", string(bic), length), "value", string(bic), "length", length)
 	}
 
 	_, _, _, isValid := bic.Parse()
 	if !isValid {
-		return fmt.Errorf("invalid BIC %q", string(bic))
+		return types.NewCodedError(ErrCodeBICInvalid, fmt.Sprintf("invalid BIC %q", string(bic)), "value", string(bic))
 	}
 	if _, isFalse := falseBICs[bic]; isFalse {
-		return fmt.Errorf("BIC %q is in list of invalid BICs", string(bic))
+		return types.NewCodedError(ErrCodeBICBlocked, fmt.Sprintf("BIC %q is in list of invalid BICs", string(bic)), "value", string(bic))
 	}
 	return nil
 }
@@ -42,7 +42,10 @@ type CAMT53Entry struct {
 	Status        string       `xml:"Sts"`       // BOOK, PDNG, INFO
 	BookingDate   date.Date    `xml:"BookgDt>Dt"`
 	ValueDate     date.Date    `xml:"ValDt>Dt"`
-	ReferenceCode string       `xml:"AcctSvcrRef"`
+	// Some banks deliver date times instead of dates
+	BookingDateTime ISODateTime `xml:"BookgDt>DtTm,omitempty"`
+	ValueDateTime   ISODateTime `xml:"ValDt>DtTm,omitempty"`
+	ReferenceCode   string      `xml:"AcctSvcrRef"`
 	// BkTxCd
 	DebitorName  string   `xml:"NtryDtls>TxDtls>RltdPties>Dbtr>Nm"`
 	DebitorAddr  []string `xml:"NtryDtls>TxDtls>RltdPties>Dbtr>PstlAdr>AdrLine,omitempty"`
@@ -54,3 +57,65 @@ type CAMT53Entry struct {
 	CreditorBIC  BIC      `xml:"NtryDtls>TxDtls>RltdAgts>CdtrAgt>FinInstnId>BIC"`
 	Reference    string   `xml:"NtryDtls>TxDtls>RmtInf>Strd>CdtrRefInf>Ref"`
 }
+
+// EffectiveBookingDate returns the date when the entry was booked
+// from BookingDate or BookingDateTime.
+// Returns an empty date if none is available.
+func (e *CAMT53Entry) EffectiveBookingDate() date.Date {
+	if e.BookingDate != "" || e.BookingDateTime.IsZero() {
+		return e.BookingDate
+	}
+	return date.OfTime(e.BookingDateTime.Time)
+}
+
+// EffectiveValueDate returns the date from which on the amount
+// of the entry is available or accrues interest
+// from ValueDate or ValueDateTime with
+// EffectiveBookingDate as fallback.
+func (e *CAMT53Entry) EffectiveValueDate() date.Date {
+	switch {
+	case e.ValueDate != "":
+		return e.ValueDate
+	case !e.ValueDateTime.IsZero():
+		return date.OfTime(e.ValueDateTime.Time)
+	}
+	return e.EffectiveBookingDate()
+}
+
+// ValueDateOffset returns the number of days from the booking date
+// until the value date. Positive days mean the amount was booked
+// before it became available, negative days mean
+// it was booked with a value date in the past.
+func (e *CAMT53Entry) ValueDateOffset() int {
+	return actualDays(e.EffectiveBookingDate(), e.EffectiveValueDate())
+}
+
+// InTransitOn returns true if the entry was booked on or before
+// the passed date, but its value date is after the date.
+func (e *CAMT53Entry) InTransitOn(on date.Date) bool {
+	booking := e.EffectiveBookingDate()
+	return booking != "" && !booking.After(on) && e.EffectiveValueDate().After(on)
+}
+
+// SignedAmount returns the amount of the entry
+// as negative value for debit entries.
+func (e *CAMT53Entry) SignedAmount() money.Amount {
+	if e.CreditOrDebit == "DBIT" {
+		return -e.Amount.Amount.Abs()
+	}
+	return e.Amount.Amount.Abs()
+}
+
+// InterestDays returns the number of interest relevant days
+// from the value date of the entry until the passed date.
+func (e *CAMT53Entry) InterestDays(until date.Date) int {
+	return actualDays(e.EffectiveValueDate(), until)
+}
+
+// AccruedInterest returns the not rounded interest of the signed amount
+// of the entry for the passed annual interest rate
+// from the value date until the passed date using
+// the passed day count convention.
+func (e *CAMT53Entry) AccruedInterest(annualRate float64, until date.Date, convention DayCountConvention) money.Amount {
+	return money.Amount(float64(e.SignedAmount()) * annualRate * convention.YearFraction(e.EffectiveValueDate(), until))
+}
@@ -0,0 +1,74 @@
+package bank
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+)
+
+func TestCAMT53EntryDates(t *testing.T) {
+	const entryXML = `<Ntry>
+		<Amt Ccy="EUR">1000.00</Amt>
+		<CdtDbtInd>DBIT</CdtDbtInd>
+		<Sts>BOOK</Sts>
+		<BookgDt><Dt>2024-03-28</Dt></BookgDt>
+		<ValDt><DtTm>2024-04-02T00:00:00Z</DtTm></ValDt>
+	</Ntry>`
+	var entry CAMT53Entry
+	require.NoError(t, xml.Unmarshal([]byte(entryXML), &entry))
+
+	assert.Equal(t, date.Date("2024-03-28"), entry.EffectiveBookingDate())
+	assert.Equal(t, date.Date("2024-04-02"), entry.EffectiveValueDate())
+	assert.Equal(t, 5, entry.ValueDateOffset())
+	assert.Equal(t, money.Amount(-1000), entry.SignedAmount())
+
+	assert.False(t, entry.InTransitOn("2024-03-27"))
+	assert.True(t, entry.InTransitOn("2024-03-28"))
+	assert.True(t, entry.InTransitOn("2024-04-01"))
+	assert.False(t, entry.InTransitOn("2024-04-02"))
+
+	assert.Equal(t, 30, entry.InterestDays("2024-05-02"))
+	assert.InDelta(t, -1000*0.036*30/360, float64(entry.AccruedInterest(0.036, "2024-05-02", DayCountAct360)), 1e-9)
+	assert.InDelta(t, -1000*0.0365*30/365, float64(entry.AccruedInterest(0.0365, "2024-05-02", DayCountAct365)), 1e-9)
+
+	// Value date falls back to booking date
+	entry = CAMT53Entry{
+		CreditOrDebit:   "CRDT",
+		Amount:          CAMT53Amount{Amount: 50, Currency: "EUR"},
+		BookingDateTime: ISODateTime{time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, date.Date("2024-01-15"), entry.EffectiveValueDate())
+	assert.Equal(t, 0, entry.ValueDateOffset())
+	assert.Equal(t, money.Amount(50), entry.SignedAmount())
+	assert.False(t, entry.InTransitOn("2024-01-15"))
+}
+
+func TestCAMT53EntryDateTimeWithoutOffset(t *testing.T) {
+	const entryXML = `<Ntry>
+		<Amt Ccy="EUR">10.00</Amt>
+		<CdtDbtInd>CRDT</CdtDbtInd>
+		<Sts>BOOK</Sts>
+		<BookgDt><DtTm>2024-04-02T10:15:00</DtTm></BookgDt>
+		<ValDt><DtTm>2024-04-03T23:59:59.123</DtTm></ValDt>
+	</Ntry>`
+	var entry CAMT53Entry
+	require.NoError(t, xml.Unmarshal([]byte(entryXML), &entry))
+	assert.Equal(t, time.Date(2024, 4, 2, 10, 15, 0, 0, time.UTC), entry.BookingDateTime.Time)
+	assert.Equal(t, date.Date("2024-04-02"), entry.EffectiveBookingDate())
+	assert.Equal(t, date.Date("2024-04-03"), entry.EffectiveValueDate())
+
+	for _, valid := range []string{"", "2024-04-02T10:15:00Z", "2024-04-02T10:15:00.5+02:00", "2024-04-02T10:15"} {
+		_, err := ParseISODateTime(valid)
+		assert.NoError(t, err, "ParseISODateTime(%q)", valid)
+	}
+	for _, invalid := range []string{"2024-04-02", "02.04.2024 10:15", "xxx"} {
+		_, err := ParseISODateTime(invalid)
+		assert.Error(t, err, "ParseISODateTime(%q)", invalid)
+	}
+}
@@ -0,0 +1,246 @@
+package bank
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/domonda/go-types"
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/strutil"
+)
+
+const (
+	CreditorIDRegex     = `^([A-Z]{2})(\d{2})([A-Z\d]{3})([A-Z\d]{1,28})$`
+	CreditorIDMinLength = 8
+	CreditorIDMaxLength = 35
+
+	// CreditorIDDefaultBusinessCode is the business code
+	// used by creditors that don't distinguish business lines.
+	CreditorIDDefaultBusinessCode = "ZZZ"
+)
+
+var creditorIDRegexp = regexp.MustCompile(CreditorIDRegex)
+
+// Compile-time check that CreditorID implements types.NormalizableValidator[CreditorID]
+var _ types.NormalizableValidator[CreditorID] = CreditorID("")
+
+// NormalizeCreditorID returns str as normalized CreditorID or an error.
+func NormalizeCreditorID(str string) (CreditorID, error) {
+	return CreditorID(str).Normalized()
+}
+
+// CreditorID is a SEPA creditor identifier (CI) used
+// to identify the creditor of SEPA direct debit mandates.
+//
+// The structure is a two letter ISO 3166 country code,
+// two check digits, a three character business code
+// that is not part of the check digit calculation,
+// and the national identifier of the creditor,
+// for example "DE98ZZZ09999999999".
+//
+// CreditorID implements the database/sql.Scanner and database/sql/driver.Valuer interfaces,
+// and will treat an empty CreditorID string as SQL NULL value.
+type CreditorID string
+
+// ScanString tries to parse and assign the passed
+// source string as value of the implementing type.
+//
+// If validate is true, the source string is checked
+// for validity before it is assigned to the type.
+//
+// If validate is false and the source string
+// can still be assigned in some non-normalized way
+// it will be assigned without returning an error.
+func (id *CreditorID) ScanString(source string, validate bool) error {
+	newID, err := CreditorID(source).Normalized()
+	if err != nil {
+		if validate {
+			return err
+		}
+		newID = CreditorID(source)
+	}
+	*id = newID
+	return nil
+}
+
+// Valid returns if this is a valid SEPA creditor identifier
+func (id CreditorID) Valid() bool {
+	return id.Validate() == nil
+}
+
+// Validate returns an error if this is not a valid SEPA creditor identifier
+func (id CreditorID) Validate() error {
+	_, err := id.Normalized()
+	return err
+}
+
+func (id CreditorID) ValidAndNormalized() bool {
+	norm, err := id.Normalized()
+	return err == nil && id == norm
+}
+
+// Normalized returns the creditor identifier in upper case without spaces,
+// or an error if it is not valid.
+// Returns the CreditorID unchanged in case of an error.
+func (id CreditorID) Normalized() (CreditorID, error) {
+	normalized := CreditorID(strings.ToUpper(strutil.RemoveRunesString(string(id), strutil.IsSpace)))
+	switch {
+	case normalized == "":
+		return id, errors.New("empty SEPA creditor identifier")
+	case len(normalized) < CreditorIDMinLength:
+		return id, errors.New("SEPA creditor identifier too short")
+	case len(normalized) > CreditorIDMaxLength:
+		return id, errors.New("SEPA creditor identifier too long")
+	case !creditorIDRegexp.MatchString(string(normalized)):
+		return id, errors.New("invalid SEPA creditor identifier characters")
+	case !country.Code(normalized[:2]).Valid():
+		return id, errors.New("invalid SEPA creditor identifier country code")
+	case !normalized.isCheckSumValid():
+		return id, errors.New("invalid SEPA creditor identifier check digits")
+	}
+	return normalized, nil
+}
+
+// CountryCode returns the country code of the creditor identifier.
+// May be invalid if the creditor identifier is invalid.
+func (id CreditorID) CountryCode() country.Code {
+	norm, err := id.Normalized()
+	if err != nil {
+		return country.Invalid
+	}
+	return country.Code(norm[:2])
+}
+
+// BusinessCode returns the three character business code
+// of the creditor identifier, usually CreditorIDDefaultBusinessCode.
+// Returns an empty string if the creditor identifier is invalid.
+func (id CreditorID) BusinessCode() string {
+	norm, err := id.Normalized()
+	if err != nil {
+		return ""
+	}
+	return string(norm[4:7])
+}
+
+// NationalID returns the national identifier of the creditor
+// which is the creditor identifier without the country code,
+// check digits and business code.
+// Returns an empty string if the creditor identifier is invalid.
+func (id CreditorID) NationalID() string {
+	norm, err := id.Normalized()
+	if err != nil {
+		return ""
+	}
+	return string(norm[7:])
+}
+
+// CreditorSchemeID returns the creditor identifier without the business code
+// that identifies the creditor independently of the business line
+// and can be used to compare creditor identifiers.
+// Returns an empty string if the creditor identifier is invalid.
+func (id CreditorID) CreditorSchemeID() string {
+	norm, err := id.Normalized()
+	if err != nil {
+		return ""
+	}
+	return string(norm[:4] + norm[7:])
+}
+
+// String returns the normalized creditor identifier string if possible,
+// else it will be returned unchanged as string.
+// String implements the fmt.Stringer interface.
+func (id CreditorID) String() string {
+	norm, err := id.Normalized()
+	if err != nil {
+		return string(id)
+	}
+	return string(norm)
+}
+
+// isCheckSumValid checks the ISO 7064 MOD 97-10 check digits
+// calculated over the national identifier followed by
+// the country code and the check digits, excluding the business code.
+func (id CreditorID) isCheckSumValid() bool {
+	const creditorIDCheckSumModulo = 97
+
+	var remainder int
+	for _, r := range []byte(id[7:] + id[:4]) {
+		switch {
+		case isNum(r):
+			remainder = (remainder*10 + int(r-'0')) % creditorIDCheckSumModulo
+		case isUpperAZ(r):
+			n := int(r - 'A' + 10)
+			remainder = (remainder*100 + n) % creditorIDCheckSumModulo
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (id *CreditorID) Scan(value any) error {
+	switch x := value.(type) {
+	case string:
+		*id = CreditorID(x)
+	case []byte:
+		*id = CreditorID(x)
+	case nil:
+		*id = ""
+	default:
+		return fmt.Errorf("can't scan SQL value of type %T as CreditorID", value)
+	}
+	return nil
+}
+
+// Value implements the driver database/sql/driver.Valuer interface
+// returning SQL NULL for an empty string.
+func (id CreditorID) Value() (driver.Value, error) {
+	if id == "" {
+		return nil, nil
+	}
+	return string(id), nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// by returning the JSON null value for an empty string.
+func (id CreditorID) MarshalJSON() ([]byte, error) {
+	if id == "" {
+		return []byte(`null`), nil
+	}
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// by normalizing and validating the JSON string.
+// JSON null or an empty string result in an empty CreditorID.
+func (id *CreditorID) UnmarshalJSON(j []byte) error {
+	if bytes.Equal(j, []byte(`null`)) {
+		*id = ""
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(j, &str); err != nil {
+		return fmt.Errorf("can't unmarshal JSON %s as bank.CreditorID: %w", j, err)
+	}
+	if str == "" {
+		*id = ""
+		return nil
+	}
+	return id.ScanString(str, true)
+}
+
+func (CreditorID) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Title:   "SEPA Creditor Identifier",
+		Type:    "string",
+		Pattern: CreditorIDRegex,
+	}
+}
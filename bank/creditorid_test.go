@@ -0,0 +1,84 @@
+package bank
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/country"
+)
+
+func TestCreditorID_Normalized(t *testing.T) {
+	valid := map[CreditorID]CreditorID{
+		"DE98ZZZ09999999999":     "DE98ZZZ09999999999",
+		"de98 zzz 0999 9999 999": "DE98ZZZ09999999999",
+		"AT61ZZZ01234567890":     "AT61ZZZ01234567890",
+		"AT61ABC01234567890":     "AT61ABC01234567890", // business code is not checked
+		"NL69ZZZ123456780000":    "NL69ZZZ123456780000",
+		"ES97ZZZB12345678":       "ES97ZZZB12345678",
+		"IT10ZZZ123456789012345": "IT10ZZZ123456789012345",
+		"  FR72ZZZ123456  ":      "FR72ZZZ123456",
+	}
+	for id, expected := range valid {
+		normalized, err := id.Normalized()
+		require.NoError(t, err, "CreditorID(%q)", id)
+		require.Equal(t, expected, normalized)
+		require.True(t, id.Valid())
+	}
+
+	for _, id := range []CreditorID{
+		"",
+		"DE98ZZZ",
+		"DE99ZZZ09999999999",
+		"XX98ZZZ09999999999",
+		"DE98ZZZ0999999999-",
+		"DEXXZZZ09999999999",
+		"DE98ZZZ09999999999099999999990999999",
+	} {
+		require.False(t, id.Valid(), "CreditorID(%q)", id)
+	}
+}
+
+func TestCreditorID_Parts(t *testing.T) {
+	id := CreditorID("de98 abc 09999999999")
+	require.Equal(t, country.Code("DE"), id.CountryCode())
+	require.Equal(t, "ABC", id.BusinessCode())
+	require.Equal(t, "09999999999", id.NationalID())
+	require.Equal(t, "DE9809999999999", id.CreditorSchemeID())
+	require.Equal(t, CreditorID("DE98ZZZ09999999999").CreditorSchemeID(), id.CreditorSchemeID())
+	require.Equal(t, "DE98ABC09999999999", id.String())
+
+	invalid := CreditorID("invalid")
+	require.Equal(t, country.Invalid, invalid.CountryCode())
+	require.Equal(t, "", invalid.BusinessCode())
+	require.Equal(t, "invalid", invalid.String())
+}
+
+func TestCreditorID_JSON_SQL(t *testing.T) {
+	var s struct {
+		CI    CreditorID `json:"ci"`
+		Empty CreditorID `json:"empty"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"ci":"de98zzz09999999999","empty":null}`), &s))
+	require.Equal(t, CreditorID("DE98ZZZ09999999999"), s.CI)
+	require.Equal(t, CreditorID(""), s.Empty)
+	j, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.Equal(t, `{"ci":"DE98ZZZ09999999999","empty":null}`, string(j))
+	require.Error(t, json.Unmarshal([]byte(`{"ci":"DE99ZZZ09999999999"}`), &s))
+
+	value, err := s.CI.Value()
+	require.NoError(t, err)
+	require.Equal(t, "DE98ZZZ09999999999", value)
+	value, err = s.Empty.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	var scanned CreditorID
+	require.NoError(t, scanned.Scan([]byte("DE98ZZZ09999999999")))
+	require.Equal(t, s.CI, scanned)
+	require.NoError(t, scanned.Scan(nil))
+	require.Equal(t, CreditorID(""), scanned)
+	require.Error(t, scanned.Scan(1))
+}
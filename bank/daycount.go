@@ -0,0 +1,69 @@
+package bank
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/domonda/go-types/date"
+)
+
+// DayCountConvention determines how interest accrues
+// over the actual days between two dates.
+type DayCountConvention string
+
+const (
+	// DayCountAct360 uses the actual number of days
+	// in a year of 360 days as used by the money market.
+	DayCountAct360 DayCountConvention = "ACT/360"
+	// DayCountAct365 uses the actual number of days
+	// in a fixed year of 365 days, also for leap years.
+	DayCountAct365 DayCountConvention = "ACT/365"
+)
+
+// Valid returns true if the convention is one of the defined constants.
+func (c DayCountConvention) Valid() bool {
+	return c.Validate() == nil
+}
+
+// Validate returns an error if the convention is not one of the defined constants.
+func (c DayCountConvention) Validate() error {
+	switch c {
+	case DayCountAct360, DayCountAct365:
+		return nil
+	}
+	return fmt.Errorf("invalid bank.DayCountConvention %q", string(c))
+}
+
+// DaysInYear returns the number of days of a year for the convention
+// or zero for an invalid convention.
+func (c DayCountConvention) DaysInYear() int {
+	switch c {
+	case DayCountAct360:
+		return 360
+	case DayCountAct365:
+		return 365
+	}
+	return 0
+}
+
+// Days returns the actual number of days from the date
+// until the other date excluding the until date.
+// The result is negative if until is before from.
+func (DayCountConvention) Days(from, until date.Date) int {
+	return actualDays(from, until)
+}
+
+func actualDays(from, until date.Date) int {
+	return int(until.Sub(from) / (24 * time.Hour))
+}
+
+// YearFraction returns the actual number of days from the date
+// until the other date divided by the days in a year
+// of the convention, or zero for an invalid convention.
+func (c DayCountConvention) YearFraction(from, until date.Date) float64 {
+	daysInYear := c.DaysInYear()
+	if daysInYear == 0 {
+		return 0
+	}
+	return float64(c.Days(from, until)) / float64(daysInYear)
+}
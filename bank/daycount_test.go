@@ -0,0 +1,20 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDayCountConvention(t *testing.T) {
+	assert.True(t, DayCountAct360.Valid())
+	assert.True(t, DayCountAct365.Valid())
+	assert.False(t, DayCountConvention("30/360").Valid())
+
+	// 2024 is a leap year
+	assert.Equal(t, 366, DayCountAct360.Days("2024-01-01", "2025-01-01"))
+	assert.Equal(t, -31, DayCountAct365.Days("2024-02-01", "2024-01-01"))
+	assert.InDelta(t, 366.0/360, DayCountAct360.YearFraction("2024-01-01", "2025-01-01"), 1e-12)
+	assert.InDelta(t, 366.0/365, DayCountAct365.YearFraction("2024-01-01", "2025-01-01"), 1e-12)
+	assert.Zero(t, DayCountConvention("").YearFraction("2024-01-01", "2025-01-01"))
+}
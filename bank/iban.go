@@ -17,10 +17,26 @@ import (
 const (
 	IBANRegex     = `^([A-Z]{2})(\d{2})([A-Z\d]{8,30})$`
 	IBANMinLength = 15
-	IBANMaxLength = 32
+	IBANMaxLength = 34
 )
 
-var ibanRegexp = regexp.MustCompile(IBANRegex)
+// Error codes of IBAN validation errors
+// that can be used to look up localized messages.
+// See types.CodedError.
+const (
+	ErrCodeIBANEmpty         types.ErrorCode = "bank.iban.empty"
+	ErrCodeIBANTooShort      types.ErrorCode = "bank.iban.too_short"
+	ErrCodeIBANCountryCode   types.ErrorCode = "bank.iban.country_code"
+	ErrCodeIBANLength        types.ErrorCode = "bank.iban.length"
+	ErrCodeIBANCharacters    types.ErrorCode = "bank.iban.characters"
+	ErrCodeIBANCheckSum      types.ErrorCode = "bank.iban.checksum"
+	ErrCodeIBANBBANStructure types.ErrorCode = "bank.iban.bban_structure"
+)
+
+var (
+	ibanRegexp   = regexp.MustCompile(IBANRegex)
+	errEmptyIBAN = types.NewCodedError(ErrCodeIBANEmpty, "empty IBAN")
+)
 
 // Compile-time check that IBAN implements types.NormalizableValidator[IBAN]
 var _ types.NormalizableValidator[IBAN] = IBAN("")
@@ -94,24 +110,26 @@ func (iban IBAN) CountryCode() country.Code {
 func (iban IBAN) Normalized() (IBAN, error) {
 	switch {
 	case iban.Nullable().IsNull():
-		return iban, errors.New("empty IBAN")
+		return iban, errEmptyIBAN
 	case len(iban) < IBANMinLength:
-		return iban, errors.New("IBAN too short")
+		return iban, types.NewCodedError(ErrCodeIBANTooShort, "IBAN too short", "value", string(iban), "minLength", IBANMinLength)
 	}
-	countryLength, found := countryIBANLength[country.Code(iban[:2])]
-	if !found {
-		return iban, errors.New("invalid IBAN country code")
+	format := ibanCountryFormat(country.Code(iban[:2]))
+	if format == nil {
+		return iban, types.NewCodedError(ErrCodeIBANCountryCode, "invalid IBAN country code", "value", string(iban), "countryCode", string(iban[:2]))
 	}
 	normalized := IBAN(strutil.RemoveRunesString(string(iban), strutil.IsSpace))
-	if len(normalized) != countryLength {
-		// fmt.Println(normalized, len(normalized), countryLength)
-		return iban, errors.New("wrong IBAN length")
+	if len(normalized) != format.Length {
+		return iban, types.NewCodedError(ErrCodeIBANLength, "wrong IBAN length", "value", string(iban), "length", len(normalized), "expectedLength", format.Length)
 	}
 	if !ibanRegexp.MatchString(string(normalized)) {
-		return iban, errors.New("invalid IBAN characters")
+		return iban, types.NewCodedError(ErrCodeIBANCharacters, "invalid IBAN characters", "value", string(iban))
 	}
 	if !normalized.isCheckSumValid() {
-		return iban, errors.New("invalid IBAN check sum")
+		return iban, types.NewCodedError(ErrCodeIBANCheckSum, "invalid IBAN check sum", "value", string(iban))
+	}
+	if format.bban != nil && !matchBBANSegments(format.bban, string(normalized[4:])) {
+		return iban, types.NewCodedError(ErrCodeIBANBBANStructure, "invalid IBAN BBAN structure", "value", string(iban))
 	}
 	return normalized, nil
 }
@@ -229,6 +247,11 @@ func (iban *IBAN) BankAndAccountNumbers() (bankNo, accountNo string, err error)
 	}
 	getNumbers, found := getBankAndAccountNumbers[country]
 	if !found {
+		// Use the bank identifier position of a registered format
+		if format := ibanCountryFormat(country); format != nil && format.BankIDEnd > 0 && len(*iban) == format.Length {
+			bban := string(*iban)[4:]
+			return bban[format.BankIDStart:format.BankIDEnd], bban[format.BankIDEnd:], nil
+		}
 		return "", "", fmt.Errorf("can't extract bank and account numbers from IBAN: %q", string(*iban))
 	}
 	return getNumbers(string(*iban))
@@ -255,6 +278,8 @@ var getBankAndAccountNumbers = map[country.Code]func(string) (string, string, er
 	},
 }
 
+// countryIBANLength is the built-in IBAN length data,
+// see RegisterCountryFormat for runtime extensions.
 var countryIBANLength = map[country.Code]int{
 	country.AL: 28,
 	country.AD: 24,
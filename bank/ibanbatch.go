@@ -0,0 +1,152 @@
+package bank
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/domonda/go-types/country"
+)
+
+// ibanBatchMinChunk is the minimum number of IBANs
+// validated by one goroutine of ValidateIBANs.
+const ibanBatchMinChunk = 1024
+
+// IBANValidationResult is the result of validating
+// one entry passed to ValidateIBANs.
+type IBANValidationResult struct {
+	// IBAN is the normalized IBAN if Err is nil,
+	// else the unchanged input string.
+	IBAN IBAN
+	// Err is the validation error or nil for a valid IBAN.
+	Err error
+	// Empty is true if the input was empty or only whitespace.
+	Empty bool
+	// Changed is true if the input was valid
+	// but not in normalized form.
+	Changed bool
+}
+
+// Valid returns if the entry is a valid IBAN.
+func (r *IBANValidationResult) Valid() bool {
+	return r.Err == nil
+}
+
+// IBANValidationStats summarizes the results of ValidateIBANs.
+type IBANValidationStats struct {
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	// Empty counts empty or whitespace only entries,
+	// they are also counted as Invalid.
+	Empty int `json:"empty"`
+	// Changed counts valid entries that were not in normalized form.
+	Changed int `json:"changed"`
+	// Duplicates counts valid entries whose normalized IBAN
+	// already occurred at a lower index.
+	Duplicates int `json:"duplicates"`
+	// Countries counts the valid IBANs per country code.
+	Countries map[country.Code]int `json:"countries,omitempty"`
+}
+
+func (s *IBANValidationStats) add(r *IBANValidationResult) {
+	s.Total++
+	switch {
+	case r.Err == nil:
+		s.Valid++
+		if r.Changed {
+			s.Changed++
+		}
+		if s.Countries == nil {
+			s.Countries = make(map[country.Code]int)
+		}
+		s.Countries[country.Code(r.IBAN[:2])]++
+	case r.Empty:
+		s.Invalid++
+		s.Empty++
+	default:
+		s.Invalid++
+	}
+}
+
+func (s *IBANValidationStats) merge(other *IBANValidationStats) {
+	s.Total += other.Total
+	s.Valid += other.Valid
+	s.Invalid += other.Invalid
+	s.Empty += other.Empty
+	s.Changed += other.Changed
+	for code, n := range other.Countries {
+		if s.Countries == nil {
+			s.Countries = make(map[country.Code]int, len(other.Countries))
+		}
+		s.Countries[code] += n
+	}
+}
+
+// ValidateIBANs validates and normalizes every string of ibans
+// and returns a result per entry with the same index as in ibans
+// together with summary statistics over all entries.
+//
+// Large inputs like uploaded supplier master files
+// are validated in parallel using up to runtime.GOMAXPROCS goroutines.
+func ValidateIBANs(ibans []string) ([]IBANValidationResult, IBANValidationStats) {
+	results := make([]IBANValidationResult, len(ibans))
+
+	workers := min(runtime.GOMAXPROCS(0), (len(ibans)+ibanBatchMinChunk-1)/ibanBatchMinChunk)
+	if workers < 1 {
+		workers = 1
+	}
+	chunk := (len(ibans) + workers - 1) / workers
+	workerStats := make([]IBANValidationStats, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		start := min(w*chunk, len(ibans))
+		end := min(start+chunk, len(ibans))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats := &workerStats[w]
+			for i := start; i < end; i++ {
+				r := &results[i]
+				validateIBANInto(ibans[i], r)
+				stats.add(r)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var stats IBANValidationStats
+	for i := range workerStats {
+		stats.merge(&workerStats[i])
+	}
+
+	// Counting duplicates needs all results
+	// so it is done after the parallel validation
+	if stats.Valid > 1 {
+		seen := make(map[IBAN]struct{}, stats.Valid)
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			if _, ok := seen[results[i].IBAN]; ok {
+				stats.Duplicates++
+				continue
+			}
+			seen[results[i].IBAN] = struct{}{}
+		}
+	}
+
+	return results, stats
+}
+
+func validateIBANInto(str string, r *IBANValidationResult) {
+	if strings.TrimSpace(str) == "" {
+		r.IBAN = IBAN(str)
+		r.Err = errEmptyIBAN
+		r.Empty = true
+		return
+	}
+	r.IBAN, r.Err = IBAN(str).Normalized()
+	r.Changed = r.Err == nil && string(r.IBAN) != str
+}
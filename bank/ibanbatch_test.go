@@ -0,0 +1,68 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/country"
+)
+
+func TestValidateIBANs(t *testing.T) {
+	results, stats := ValidateIBANs(nil)
+	require.Empty(t, results)
+	require.Equal(t, IBANValidationStats{}, stats)
+
+	ibans := []string{
+		"AT61 1904 3002 3457 3201",
+		"AT611904300234573201",
+		"DE89370400440532013000",
+		"  ",
+		"DE89370400440532013001",
+	}
+	results, stats = ValidateIBANs(ibans)
+	require.Len(t, results, len(ibans))
+
+	require.True(t, results[0].Valid())
+	require.True(t, results[0].Changed)
+	require.Equal(t, IBAN("AT611904300234573201"), results[0].IBAN)
+	require.True(t, results[1].Valid())
+	require.False(t, results[1].Changed)
+	require.True(t, results[2].Valid())
+	require.False(t, results[3].Valid())
+	require.True(t, results[3].Empty)
+	require.False(t, results[4].Valid())
+	require.Equal(t, IBAN("DE89370400440532013001"), results[4].IBAN)
+
+	require.Equal(t, IBANValidationStats{
+		Total:      5,
+		Valid:      3,
+		Invalid:    2,
+		Empty:      1,
+		Changed:    1,
+		Duplicates: 1,
+		Countries:  map[country.Code]int{"AT": 2, "DE": 1},
+	}, stats)
+}
+
+func TestValidateIBANsParallel(t *testing.T) {
+	const n = ibanBatchMinChunk*3 + 7
+	ibans := make([]string, n)
+	for i := range ibans {
+		if i%2 == 0 {
+			ibans[i] = "DE89370400440532013000"
+		} else {
+			ibans[i] = "invalid"
+		}
+	}
+	results, stats := ValidateIBANs(ibans)
+	require.Len(t, results, n)
+	for i := range results {
+		require.Equal(t, i%2 == 0, results[i].Valid(), "index %d", i)
+	}
+	require.Equal(t, n, stats.Total)
+	require.Equal(t, n/2+1, stats.Valid)
+	require.Equal(t, n/2, stats.Invalid)
+	require.Equal(t, n/2, stats.Duplicates)
+	require.Equal(t, map[country.Code]int{"DE": n/2 + 1}, stats.Countries)
+}
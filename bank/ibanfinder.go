@@ -11,9 +11,8 @@ func (ibanFinder) FindAllIndex(str []byte, n int) (result [][]int) {
 	max := strLen - IBANMinLength
 	for i := 0; i <= max; i++ {
 		countryCode := country.Code(str[i : i+2])
-		countryLength, found := countryIBANLength[countryCode]
-		if found {
-			end := i + countryLength
+		if format := ibanCountryFormat(countryCode); format != nil {
+			end := i + format.Length
 			if end <= strLen {
 				if IBAN(str[i:end]).Valid() {
 					result = append(result, []int{i, end})
@@ -0,0 +1,136 @@
+package bank
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+const ibanPseudonymRounds = 10
+
+// PseudonymizeIBAN returns a structurally valid but fake IBAN
+// deterministically derived from the passed IBAN and key
+// for producing realistic anonymized test data from production data.
+//
+// The BBAN is encrypted with a format-preserving Feistel network
+// keyed with HMAC-SHA256 so that every digit stays a digit and
+// every letter stays a letter. The country code is kept and the
+// check digits are recalculated, so the result passes IBAN validation
+// including registered BBAN structures.
+// The same IBAN and key always result in the same pseudonym
+// and DepseudonymizeIBAN with the same key returns the original IBAN.
+//
+// Note that the bank identifier of the BBAN is also encrypted,
+// so the pseudonym doesn't belong to a real bank.
+// The scheme is not a standardized FPE mode like NIST FF1
+// and not intended to protect against targeted cryptanalysis.
+func PseudonymizeIBAN(iban IBAN, key []byte) (IBAN, error) {
+	return pseudonymizeIBAN(iban, key, true)
+}
+
+// DepseudonymizeIBAN returns the original IBAN
+// of a pseudonym returned by PseudonymizeIBAN
+// with the same key.
+func DepseudonymizeIBAN(pseudonym IBAN, key []byte) (IBAN, error) {
+	return pseudonymizeIBAN(pseudonym, key, false)
+}
+
+func pseudonymizeIBAN(iban IBAN, key []byte, encrypt bool) (IBAN, error) {
+	if len(key) == 0 {
+		return iban, errors.New("empty IBAN pseudonymization key")
+	}
+	norm, err := iban.Normalized()
+	if err != nil {
+		return iban, err
+	}
+	countryCode := string(norm[:2])
+	bban := []byte(norm[4:])
+
+	// The first and second half of the BBAN are the
+	// alternating left and right sides of the Feistel network
+	half := len(bban) / 2
+	for i := range ibanPseudonymRounds {
+		round := i
+		if !encrypt {
+			round = ibanPseudonymRounds - 1 - i
+		}
+		target, source := bban[:half], bban[half:]
+		if round%2 == 1 {
+			target, source = source, target
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte{byte(round)})
+		mac.Write([]byte(countryCode))
+		mac.Write(source)
+		f := new(big.Int).SetBytes(mac.Sum(nil))
+
+		value, modulus := bbanCharsValue(target)
+		f.Mod(f, modulus)
+		if encrypt {
+			value.Add(value, f)
+		} else {
+			value.Sub(value, f)
+		}
+		value.Mod(value, modulus)
+		setBBANCharsValue(target, value)
+	}
+
+	return IBAN(countryCode + ibanCheckDigits(countryCode, string(bban)) + string(bban)), nil
+}
+
+// bbanCharsValue returns the mixed radix value of the passed
+// digits and upper case letters using radix 10 for digits
+// and radix 26 for letters and the modulus of all radices.
+func bbanCharsValue(chars []byte) (value, modulus *big.Int) {
+	value, modulus = new(big.Int), big.NewInt(1)
+	for _, c := range chars {
+		radix, digit := bbanCharRadixDigit(c)
+		value.Mul(value, big.NewInt(radix))
+		value.Add(value, big.NewInt(digit))
+		modulus.Mul(modulus, big.NewInt(radix))
+	}
+	return value, modulus
+}
+
+// setBBANCharsValue sets chars to the mixed radix value
+// keeping the radix of every character.
+func setBBANCharsValue(chars []byte, value *big.Int) {
+	digit := new(big.Int)
+	for i := len(chars) - 1; i >= 0; i-- {
+		radix, _ := bbanCharRadixDigit(chars[i])
+		value.DivMod(value, big.NewInt(radix), digit)
+		if radix == 10 {
+			chars[i] = '0' + byte(digit.Int64())
+		} else {
+			chars[i] = 'A' + byte(digit.Int64())
+		}
+	}
+}
+
+func bbanCharRadixDigit(c byte) (radix, digit int64) {
+	if isNum(c) {
+		return 10, int64(c - '0')
+	}
+	return 26, int64(c - 'A')
+}
+
+// ibanCheckDigits returns the two ISO 7064 MOD 97-10
+// check digits for a normalized country code and BBAN.
+func ibanCheckDigits(countryCode, bban string) string {
+	var remainder int
+	for _, c := range []byte(bban + countryCode + "00") {
+		if isNum(c) {
+			remainder = (remainder*10 + int(c-'0')) % 97
+			continue
+		}
+		n := int(c - 'A' + 10)
+		remainder = (remainder*100 + n) % 97
+	}
+	check := 98 - remainder
+	if check < 10 {
+		return "0" + strconv.Itoa(check)
+	}
+	return strconv.Itoa(check)
+}
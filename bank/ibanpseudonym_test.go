@@ -0,0 +1,45 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPseudonymizeIBAN(t *testing.T) {
+	key := []byte("test key")
+
+	for _, iban := range toNormalizeIBANs {
+		pseudonym, err := PseudonymizeIBAN(iban, key)
+		require.NoError(t, err, iban)
+		require.NoError(t, pseudonym.Validate(), "pseudonym %s of %s", pseudonym, iban)
+		require.NotEqual(t, iban, pseudonym)
+		require.Equal(t, iban[:2], pseudonym[:2], "country code kept")
+		for i := 4; i < len(iban); i++ {
+			require.Equal(t, isNum(iban[i]), isNum(pseudonym[i]), "character class kept at %d of %s", i, pseudonym)
+		}
+
+		again, err := PseudonymizeIBAN(iban, key)
+		require.NoError(t, err)
+		require.Equal(t, pseudonym, again, "deterministic")
+
+		other, err := PseudonymizeIBAN(iban, []byte("other key"))
+		require.NoError(t, err)
+		require.NotEqual(t, pseudonym, other)
+
+		original, err := DepseudonymizeIBAN(pseudonym, key)
+		require.NoError(t, err)
+		require.Equal(t, iban, original)
+	}
+
+	_, err := PseudonymizeIBAN("AT61 1904 3002 3457 3201", nil)
+	require.Error(t, err, "empty key")
+	_, err = PseudonymizeIBAN("AT61 1904 3002 3457 3200", key)
+	require.Error(t, err, "invalid IBAN")
+}
+
+func TestIBANCheckDigits(t *testing.T) {
+	for _, iban := range toNormalizeIBANs {
+		require.Equal(t, string(iban[2:4]), ibanCheckDigits(string(iban[:2]), string(iban[4:])), iban)
+	}
+}
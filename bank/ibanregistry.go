@@ -0,0 +1,319 @@
+package bank
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/strutil"
+)
+
+// IBANCountryFormat describes the IBAN format of a country
+// as published in the SWIFT IBAN registry.
+type IBANCountryFormat struct {
+	// Country is the ISO 3166 country code used as IBAN prefix.
+	Country country.Code
+	// Length is the total length of the IBAN including
+	// the country code and the two check digits.
+	Length int
+	// BBANStructure is the optional structure of the BBAN
+	// (the IBAN without the country code and check digits)
+	// in the SWIFT notation used by the registry like "8!n10!n".
+	// If not empty, the BBAN of an IBAN is validated against it.
+	BBANStructure string
+	// BankIDStart and BankIDEnd are the optional zero based
+	// start and exclusive end positions of the bank identifier
+	// within the BBAN. BankIDEnd is zero if not available.
+	BankIDStart, BankIDEnd int
+
+	bban []bbanSegment
+}
+
+// Validate returns an error if the format is not valid.
+func (f *IBANCountryFormat) Validate() error {
+	if err := f.Country.Validate(); err != nil {
+		return err
+	}
+	if f.Length < IBANMinLength || f.Length > IBANMaxLength {
+		return fmt.Errorf("IBAN length %d of country %s not in range %d to %d", f.Length, f.Country, IBANMinLength, IBANMaxLength)
+	}
+	if f.BankIDStart < 0 || f.BankIDEnd < 0 || f.BankIDEnd > f.Length-4 || (f.BankIDEnd != 0 && f.BankIDStart >= f.BankIDEnd) {
+		return fmt.Errorf("invalid bank identifier position %d-%d for IBAN country %s", f.BankIDStart, f.BankIDEnd, f.Country)
+	}
+	if f.BBANStructure != "" {
+		segments, err := parseBBANStructure(f.BBANStructure)
+		if err != nil {
+			return err
+		}
+		if minLen, maxLen := bbanLengthRange(segments); f.Length-4 < minLen || f.Length-4 > maxLen {
+			return fmt.Errorf("BBAN structure %q of country %s does not match IBAN length %d", f.BBANStructure, f.Country, f.Length)
+		}
+	}
+	return nil
+}
+
+// ValidateBBAN returns an error if the passed BBAN
+// does not match the BBANStructure of the format.
+// Any BBAN is valid if the format has no BBANStructure.
+func (f *IBANCountryFormat) ValidateBBAN(bban string) error {
+	if len(bban) != f.Length-4 {
+		return errors.New("wrong BBAN length")
+	}
+	if f.BBANStructure == "" {
+		return nil
+	}
+	segments := f.bban
+	if segments == nil {
+		var err error
+		segments, err = parseBBANStructure(f.BBANStructure)
+		if err != nil {
+			return err
+		}
+	}
+	if !matchBBANSegments(segments, bban) {
+		return fmt.Errorf("BBAN does not match structure %s", f.BBANStructure)
+	}
+	return nil
+}
+
+// ibanCountryFormatsMap is an immutable snapshot of the registry
+type ibanCountryFormatsMap = map[country.Code]*IBANCountryFormat
+
+var (
+	// ibanCountryFormats is read without locking on the hot path
+	// of IBAN validation and replaced by a modified copy on write
+	ibanCountryFormats = newIBANCountryFormatsPointer()
+	// ibanCountryFormatsWriteMutex serializes the copy on write
+	ibanCountryFormatsWriteMutex sync.Mutex
+)
+
+func newIBANCountryFormatsPointer() *atomic.Pointer[ibanCountryFormatsMap] {
+	formats := builtinIBANCountryFormats()
+	p := new(atomic.Pointer[ibanCountryFormatsMap])
+	p.Store(&formats)
+	return p
+}
+
+func builtinIBANCountryFormats() ibanCountryFormatsMap {
+	formats := make(ibanCountryFormatsMap, len(countryIBANLength))
+	for code, length := range countryIBANLength {
+		formats[code] = &IBANCountryFormat{Country: code, Length: length}
+	}
+	return formats
+}
+
+// RegisterCountryFormat adds or replaces the IBAN format
+// of a country used for IBAN validation at runtime,
+// so that new IBAN countries or format changes of the
+// SWIFT IBAN registry don't require a library upgrade.
+//
+// RegisterCountryFormat is safe for concurrent use.
+func RegisterCountryFormat(format IBANCountryFormat) error {
+	if err := format.Validate(); err != nil {
+		return err
+	}
+	format.Country, _ = format.Country.Normalized()
+	if format.BBANStructure != "" {
+		format.bban, _ = parseBBANStructure(format.BBANStructure)
+	}
+
+	ibanCountryFormatsWriteMutex.Lock()
+	defer ibanCountryFormatsWriteMutex.Unlock()
+
+	formats := maps.Clone(*ibanCountryFormats.Load())
+	formats[format.Country] = &format
+	ibanCountryFormats.Store(&formats)
+	return nil
+}
+
+// GetIBANCountryFormat returns the registered IBAN format
+// for a country and if one was found.
+func GetIBANCountryFormat(code country.Code) (format IBANCountryFormat, found bool) {
+	if f := ibanCountryFormat(code); f != nil {
+		return *f, true
+	}
+	return IBANCountryFormat{}, false
+}
+
+// IBANCountryFormats returns all registered IBAN formats
+// sorted by country code.
+func IBANCountryFormats() []IBANCountryFormat {
+	registry := *ibanCountryFormats.Load()
+	formats := make([]IBANCountryFormat, 0, len(registry))
+	for _, code := range slices.Sorted(maps.Keys(registry)) {
+		formats = append(formats, *registry[code])
+	}
+	return formats
+}
+
+func ibanCountryFormat(code country.Code) *IBANCountryFormat {
+	return (*ibanCountryFormats.Load())[code]
+}
+
+// ParseIBANRegistry parses the tab separated text file
+// of the official SWIFT IBAN registry
+// (https://www.swift.com/standards/data-standards/iban)
+// with one column per country and one row per data element.
+func ParseIBANRegistry(r io.Reader) (formats []IBANCountryFormat, err error) {
+	reader := csv.NewReader(r)
+	reader.Comma = '\t'
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("can't read IBAN registry: %w", err)
+	}
+
+	row := func(label string) []string {
+		for _, record := range records {
+			if len(record) > 0 && strings.HasPrefix(strings.ToLower(strutil.TrimSpace(record[0])), label) {
+				return record
+			}
+		}
+		return nil
+	}
+	var (
+		countries = row("iban prefix country code")
+		lengths   = row("iban length")
+		bbans     = row("bban structure")
+		bankIDs   = row("bank identifier position within the bban")
+	)
+	if countries == nil || lengths == nil {
+		return nil, errors.New("IBAN registry is missing the country code or IBAN length row")
+	}
+	cell := func(record []string, col int) string {
+		if col >= len(record) {
+			return ""
+		}
+		return strutil.TrimSpace(record[col])
+	}
+
+	for col := 1; col < len(countries); col++ {
+		code := cell(countries, col)
+		if code == "" {
+			continue
+		}
+		format := IBANCountryFormat{
+			Country:       country.Code(code),
+			BBANStructure: strings.ReplaceAll(cell(bbans, col), " ", ""),
+		}
+		format.Length, err = strconv.Atoi(cell(lengths, col))
+		if err != nil {
+			return nil, fmt.Errorf("invalid IBAN length %q for country %s in IBAN registry", cell(lengths, col), code)
+		}
+		if start, end, ok := strings.Cut(cell(bankIDs, col), "-"); ok {
+			s, errS := strconv.Atoi(strutil.TrimSpace(start))
+			e, errE := strconv.Atoi(strutil.TrimSpace(end))
+			if errS == nil && errE == nil && s > 0 && e >= s {
+				format.BankIDStart, format.BankIDEnd = s-1, e
+			}
+		}
+		if err := format.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid IBAN registry entry: %w", err)
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// RegisterIBANRegistry parses the SWIFT IBAN registry text file
+// using ParseIBANRegistry and registers all country formats
+// with RegisterCountryFormat.
+// Returns the number of registered formats.
+func RegisterIBANRegistry(r io.Reader) (n int, err error) {
+	formats, err := ParseIBANRegistry(r)
+	if err != nil {
+		return 0, err
+	}
+	for _, format := range formats {
+		err = RegisterCountryFormat(format)
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// bbanSegment is one element of the SWIFT structure notation:
+//
+//	n: digits, a: upper case letters, c: upper case letters and digits, e: space
+//	<length>!<type>: fixed length, <length><type>: maximum length
+type bbanSegment struct {
+	length int
+	fixed  bool
+	kind   byte
+}
+
+func parseBBANStructure(structure string) ([]bbanSegment, error) {
+	var segments []bbanSegment
+	for s := structure; s != ""; {
+		i := 0
+		for i < len(s) && isNum(s[i]) {
+			i++
+		}
+		length, err := strconv.Atoi(s[:i])
+		if err != nil || length == 0 {
+			return nil, fmt.Errorf("invalid BBAN structure %q", structure)
+		}
+		seg := bbanSegment{length: length}
+		if i < len(s) && s[i] == '!' {
+			seg.fixed = true
+			i++
+		}
+		if i >= len(s) || !strings.ContainsRune("nace", rune(s[i])) {
+			return nil, fmt.Errorf("invalid BBAN structure %q", structure)
+		}
+		seg.kind = s[i]
+		segments = append(segments, seg)
+		s = s[i+1:]
+	}
+	return segments, nil
+}
+
+func bbanLengthRange(segments []bbanSegment) (minLen, maxLen int) {
+	for _, seg := range segments {
+		if seg.fixed {
+			minLen += seg.length
+		}
+		maxLen += seg.length
+	}
+	return minLen, maxLen
+}
+
+func matchBBANSegments(segments []bbanSegment, bban string) bool {
+	pos := 0
+	for _, seg := range segments {
+		n := 0
+		for n < seg.length && pos+n < len(bban) && matchBBANKind(seg.kind, bban[pos+n]) {
+			n++
+		}
+		if seg.fixed && n != seg.length {
+			return false
+		}
+		pos += n
+	}
+	return pos == len(bban)
+}
+
+func matchBBANKind(kind, b byte) bool {
+	switch kind {
+	case 'n':
+		return isNum(b)
+	case 'a':
+		return isUpperAZ(b)
+	case 'c':
+		return isUpperAZ0to9(b)
+	case 'e':
+		return b == ' '
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package bank
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/country"
+)
+
+const testIBANRegistry = "Data element\tExample\tExample\n" +
+	"Name of country\tGermany\tRussian Federation\n" +
+	"IBAN prefix country code (ISO 3166)\tDE\tRU\n" +
+	"Country code includes other countries/territories\tN/A\t\"N/A\"\n" +
+	"BBAN structure \t8!n10!n\t9!n5!n15!c\n" +
+	"BBAN length\t18\t29\n" +
+	"Bank identifier position within the BBAN\t1-8\t1-9\n" +
+	"IBAN structure\tDE2!n8!n10!n\tRU2!n9!n5!n15!c\n" +
+	"IBAN length\t22\t33\n" +
+	"IBAN electronic format example\tDE89370400440532013000\tRU0304452522540817810538091310419\n"
+
+func TestParseIBANRegistry(t *testing.T) {
+	formats, err := ParseIBANRegistry(strings.NewReader(testIBANRegistry))
+	require.NoError(t, err)
+	require.Len(t, formats, 2)
+	require.Equal(t, country.DE, formats[0].Country)
+	require.Equal(t, 22, formats[0].Length)
+	require.Equal(t, "8!n10!n", formats[0].BBANStructure)
+	require.Equal(t, 0, formats[0].BankIDStart)
+	require.Equal(t, 8, formats[0].BankIDEnd)
+	require.NoError(t, formats[0].ValidateBBAN("370400440532013000"))
+	require.Error(t, formats[0].ValidateBBAN("3704004405320130AA"))
+
+	_, err = ParseIBANRegistry(strings.NewReader("Name of country\tGermany\n"))
+	require.Error(t, err, "missing rows")
+}
+
+func TestRegisterIBANRegistry(t *testing.T) {
+	const ruIBAN = "RU0304452522540817810538091310419"
+	t.Cleanup(func() {
+		formats := builtinIBANCountryFormats()
+		ibanCountryFormats.Store(&formats)
+	})
+
+	require.False(t, IBAN(ruIBAN).Valid(), "RU not built-in")
+
+	n, err := RegisterIBANRegistry(strings.NewReader(testIBANRegistry))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	require.True(t, IBAN(ruIBAN).Valid())
+	require.True(t, IBAN("DE89 3704 0044 0532 0130 00").Valid())
+	format, found := GetIBANCountryFormat(country.RU)
+	require.True(t, found)
+	require.Equal(t, 33, format.Length)
+
+	iban := IBAN(ruIBAN)
+	bankNo, accountNo, err := iban.BankAndAccountNumbers()
+	require.NoError(t, err)
+	require.Equal(t, "044525225", bankNo)
+	require.Equal(t, "40817810538091310419", accountNo)
+
+	require.Len(t, IBANFinder.FindAllIndex([]byte("IBAN: "+ruIBAN+"."), -1), 1)
+
+	err = RegisterCountryFormat(IBANCountryFormat{Country: "XX", Length: 20})
+	require.Error(t, err, "invalid country code")
+	err = RegisterCountryFormat(IBANCountryFormat{Country: country.DE, Length: 22, BBANStructure: "8!n8!n"})
+	require.Error(t, err, "structure does not match length")
+}
@@ -0,0 +1,61 @@
+package bank
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// isoDateTimeLayouts are the accepted forms of the ISO 20022 ISODateTime
+// with and without zone offset and fractional seconds.
+var isoDateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+}
+
+// ISODateTime is an ISO 20022 ISODateTime like
+// "2024-04-02T10:15:00+02:00" that is also parsed
+// without zone offset like "2024-04-02T10:15:00"
+// and with fractional seconds.
+// Date times without zone offset are interpreted as UTC
+// which keeps their date and clock time.
+type ISODateTime struct {
+	time.Time
+}
+
+// ParseISODateTime parses an ISO 20022 ISODateTime
+// with or without zone offset and fractional seconds.
+// An empty string results in a zero ISODateTime.
+func ParseISODateTime(s string) (ISODateTime, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ISODateTime{}, nil
+	}
+	for _, layout := range isoDateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return ISODateTime{t}, nil
+		}
+	}
+	return ISODateTime{}, fmt.Errorf("invalid ISO 20022 date time %q", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+// using RFC 3339 or an empty string for the zero time.
+func (dt ISODateTime) MarshalText() ([]byte, error) {
+	if dt.IsZero() {
+		return nil, nil
+	}
+	return dt.Time.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+// using ParseISODateTime.
+func (dt *ISODateTime) UnmarshalText(text []byte) error {
+	parsed, err := ParseISODateTime(string(text))
+	if err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
@@ -0,0 +1,421 @@
+package bank
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+)
+
+// PAIN001AZVNamespace is the XML namespace of the pain.001.001.03
+// customer credit transfer initiation used for foreign payments
+// according to the AZV (Auslandszahlungsverkehr) profile
+// of the German DFÜ-Abkommen Annex 3 that replaces the
+// fixed-record DTAZV format.
+const PAIN001AZVNamespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+
+// ChargeBearer defines who pays the fees of a foreign payment.
+type ChargeBearer string
+
+const (
+	// ChargeBearerDebtor means the debtor pays all fees (OUR).
+	ChargeBearerDebtor ChargeBearer = "DEBT"
+	// ChargeBearerCreditor means the creditor pays all fees (BEN).
+	ChargeBearerCreditor ChargeBearer = "CRED"
+	// ChargeBearerShared means the debtor pays the fees
+	// of the debtor bank and the creditor all other fees (SHA).
+	ChargeBearerShared ChargeBearer = "SHAR"
+)
+
+// Valid returns true if the charge bearer is one of the defined constants.
+func (c ChargeBearer) Valid() bool {
+	switch c {
+	case ChargeBearerDebtor, ChargeBearerCreditor, ChargeBearerShared:
+		return true
+	}
+	return false
+}
+
+// AZVOrder is a foreign payment order of a debtor account
+// with one or more AZVPayment transactions
+// that is marshalled as pain.001 AZV message.
+type AZVOrder struct {
+	MessageID       string    // Max 35 characters
+	Created         time.Time // Current time if zero
+	InitiatingParty string    // Max 70 characters
+	PaymentInfoID   string    // Max 35 characters, MessageID if empty
+	ExecutionDate   date.Date
+
+	DebtorName string // Max 70 characters
+	DebtorIBAN IBAN
+	DebtorBIC  NullableBIC
+
+	Payments []AZVPayment
+}
+
+// AZVPayment is a non-SEPA credit transfer of an AZVOrder.
+type AZVPayment struct {
+	EndToEndID   string // Max 35 characters, "NOTPROVIDED" if empty
+	Amount       money.Amount
+	Currency     money.Currency
+	ChargeBearer ChargeBearer // ChargeBearerShared if empty
+	// Urgent requests an urgent payment (service level URGP).
+	Urgent bool
+
+	CreditorName    string       // Max 70 characters
+	CreditorCountry country.Code // Country of the creditor address
+	// CreditorAddress are up to 2 address lines
+	// with max 70 characters each.
+	CreditorAddress []string
+	// CreditorIBAN is used as creditor account if not empty,
+	// else CreditorAccount.
+	CreditorIBAN    NullableIBAN
+	CreditorAccount string // Max 34 characters
+	// CreditorBIC identifies the creditor bank.
+	// If null, then CreditorBankName and CreditorBankCountry are required.
+	CreditorBIC         NullableBIC
+	CreditorBankName    string       // Max 70 characters
+	CreditorBankCountry country.Code // Required without CreditorBIC
+
+	// ReportingCode is the optional code of the foreign trade
+	// payments reporting (AWV Meldewesen) like "150".
+	ReportingCode string // Max 10 characters
+	ReportingInfo string // Max 35 characters
+
+	RemittanceInfo string // Max 140 characters
+}
+
+// Validate returns an error if the payment is not valid.
+func (p *AZVPayment) Validate() error {
+	if err := checkAZVText("EndToEndID", p.EndToEndID, 35); err != nil {
+		return err
+	}
+	if !p.Amount.ValidAndGreaterZero() {
+		return fmt.Errorf("invalid AZV payment amount %v", p.Amount)
+	}
+	if !p.Currency.Valid() {
+		return fmt.Errorf("invalid AZV payment currency %q", p.Currency)
+	}
+	// Also rejects currencies without ISO 4217 minor units
+	// like precious metals that can't be rounded to cents
+	if err := p.Currency.ValidateTransactional(); err != nil {
+		return fmt.Errorf("invalid AZV payment currency: %w", err)
+	}
+	if p.ChargeBearer != "" && !p.ChargeBearer.Valid() {
+		return fmt.Errorf("invalid AZV charge bearer %q", p.ChargeBearer)
+	}
+	if p.CreditorName == "" {
+		return errors.New("missing AZV creditor name")
+	}
+	if err := checkAZVText("CreditorName", p.CreditorName, 70); err != nil {
+		return err
+	}
+	if !p.CreditorCountry.Valid() {
+		return fmt.Errorf("invalid AZV creditor country %q", p.CreditorCountry)
+	}
+	if len(p.CreditorAddress) > 2 {
+		return errors.New("more than 2 AZV creditor address lines")
+	}
+	for _, line := range p.CreditorAddress {
+		if err := checkAZVText("CreditorAddress", line, 70); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.CreditorIBAN.IsNotNull():
+		if err := p.CreditorIBAN.Validate(); err != nil {
+			return err
+		}
+	case p.CreditorAccount == "":
+		return errors.New("missing AZV creditor IBAN or account")
+	default:
+		if err := checkAZVText("CreditorAccount", p.CreditorAccount, 34); err != nil {
+			return err
+		}
+	}
+	if p.CreditorBIC.IsNotNull() {
+		if err := p.CreditorBIC.Validate(); err != nil {
+			return err
+		}
+	} else {
+		if p.CreditorBankName == "" {
+			return errors.New("missing AZV creditor bank name without BIC")
+		}
+		if !p.CreditorBankCountry.Valid() {
+			return fmt.Errorf("invalid AZV creditor bank country %q", p.CreditorBankCountry)
+		}
+	}
+	if err := checkAZVText("CreditorBankName", p.CreditorBankName, 70); err != nil {
+		return err
+	}
+	if err := checkAZVText("ReportingCode", p.ReportingCode, 10); err != nil {
+		return err
+	}
+	if err := checkAZVText("ReportingInfo", p.ReportingInfo, 35); err != nil {
+		return err
+	}
+	return checkAZVText("RemittanceInfo", p.RemittanceInfo, 140)
+}
+
+// Validate returns an error if the order or one of its payments is not valid.
+func (o *AZVOrder) Validate() error {
+	if o.MessageID == "" {
+		return errors.New("missing AZV message ID")
+	}
+	if err := checkAZVText("MessageID", o.MessageID, 35); err != nil {
+		return err
+	}
+	if err := checkAZVText("PaymentInfoID", o.PaymentInfoID, 35); err != nil {
+		return err
+	}
+	if o.InitiatingParty == "" {
+		return errors.New("missing AZV initiating party")
+	}
+	if err := checkAZVText("InitiatingParty", o.InitiatingParty, 70); err != nil {
+		return err
+	}
+	if err := o.ExecutionDate.Validate(); err != nil {
+		return fmt.Errorf("invalid AZV execution date: %w", err)
+	}
+	if o.DebtorName == "" {
+		return errors.New("missing AZV debtor name")
+	}
+	if err := checkAZVText("DebtorName", o.DebtorName, 70); err != nil {
+		return err
+	}
+	if err := o.DebtorIBAN.Validate(); err != nil {
+		return err
+	}
+	if err := o.DebtorBIC.Validate(); err != nil {
+		return err
+	}
+	if len(o.Payments) == 0 {
+		return errors.New("AZV order without payments")
+	}
+	for i := range o.Payments {
+		if err := o.Payments[i].Validate(); err != nil {
+			return fmt.Errorf("AZV payment %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// MarshalPAIN001 validates the order and returns it
+// as pain.001.001.03 XML document according to the AZV profile.
+func (o *AZVOrder) MarshalPAIN001() ([]byte, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	created := o.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	executionDate, _ := o.ExecutionDate.Normalized()
+	paymentInfoID := o.PaymentInfoID
+	if paymentInfoID == "" {
+		paymentInfoID = o.MessageID
+	}
+
+	// The control sum is summed up as integer units of the
+	// largest number of decimals of the batch so that it
+	// exactly matches the sum of the formatted amounts
+	ctrlSumDecimals := 0
+	for i := range o.Payments {
+		currency, _ := o.Payments[i].Currency.Normalized()
+		ctrlSumDecimals = max(ctrlSumDecimals, currency.MinorUnits())
+	}
+	ctrlSumScale := math.Pow10(ctrlSumDecimals)
+
+	var (
+		numTx        = strconv.Itoa(len(o.Payments))
+		ctrlSumUnits int64
+		txs          = make([]pain001Transaction, len(o.Payments))
+	)
+	for i := range o.Payments {
+		p := &o.Payments[i]
+		currency, _ := p.Currency.Normalized()
+		amount := p.Amount.RoundToDecimals(currency.MinorUnits())
+		ctrlSumUnits += int64(math.Round(float64(amount) * ctrlSumScale))
+
+		tx := &txs[i]
+		tx.EndToEndID = p.EndToEndID
+		if tx.EndToEndID == "" {
+			tx.EndToEndID = "NOTPROVIDED"
+		}
+		if p.Urgent {
+			tx.PaymentType = &pain001PaymentType{ServiceLevel: "URGP"}
+		}
+		tx.Amount = pain001Amount{
+			Currency: currency,
+			Amount:   amount.Format(0, '.', currency.MinorUnits()),
+		}
+		tx.ChargeBearer = p.ChargeBearer
+		if tx.ChargeBearer == "" {
+			tx.ChargeBearer = ChargeBearerShared
+		}
+		if p.CreditorBIC.IsNotNull() {
+			tx.CreditorAgent.BIC = p.CreditorBIC.String()
+		} else {
+			tx.CreditorAgent.Name = p.CreditorBankName
+			tx.CreditorAgent.Address = &pain001Address{Country: p.CreditorBankCountry}
+		}
+		tx.Creditor = pain001Party{
+			Name: p.CreditorName,
+			Address: &pain001Address{
+				Country:      p.CreditorCountry,
+				AddressLines: p.CreditorAddress,
+			},
+		}
+		if p.CreditorIBAN.IsNotNull() {
+			tx.CreditorAccount.IBAN = p.CreditorIBAN.String()
+		} else {
+			tx.CreditorAccount.Other = &pain001OtherID{ID: p.CreditorAccount}
+		}
+		if p.ReportingCode != "" || p.ReportingInfo != "" {
+			tx.Reporting = &pain001Reporting{Code: p.ReportingCode, Info: p.ReportingInfo}
+		}
+		if p.RemittanceInfo != "" {
+			tx.RemittanceInfo = &pain001RemittanceInfo{Unstructured: p.RemittanceInfo}
+		}
+	}
+
+	ctrlSum := (money.Amount(ctrlSumUnits) / money.Amount(ctrlSumScale)).Format(0, '.', ctrlSumDecimals)
+
+	doc := pain001Document{
+		Initiation: pain001Initiation{
+			GroupHeader: pain001GroupHeader{
+				MessageID:       o.MessageID,
+				Created:         created.Format("2006-01-02T15:04:05"),
+				NumTransactions: numTx,
+				ControlSum:      ctrlSum,
+				InitiatingParty: pain001Party{Name: o.InitiatingParty},
+			},
+			PaymentInfo: pain001PaymentInfo{
+				PaymentInfoID:   paymentInfoID,
+				PaymentMethod:   "TRF",
+				NumTransactions: numTx,
+				ControlSum:      ctrlSum,
+				ExecutionDate:   executionDate,
+				Debtor:          pain001Party{Name: o.DebtorName},
+				DebtorAccount:   pain001Account{IBAN: o.DebtorIBAN.String()},
+				Transactions:    txs,
+			},
+		},
+	}
+	if o.DebtorBIC.IsNotNull() {
+		doc.Initiation.PaymentInfo.DebtorAgent.BIC = o.DebtorBIC.String()
+	} else {
+		doc.Initiation.PaymentInfo.DebtorAgent.Other = &pain001OtherID{ID: "NOTPROVIDED"}
+	}
+
+	out, err := xml.MarshalIndent(&doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func checkAZVText(field, value string, maxLen int) error {
+	if utf8.RuneCountInString(value) > maxLen {
+		return fmt.Errorf("AZV %s longer than %d characters: %q", field, maxLen, value)
+	}
+	return nil
+}
+
+type pain001Document struct {
+	XMLName    xml.Name          `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 Document"`
+	Initiation pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Initiation struct {
+	GroupHeader pain001GroupHeader `xml:"GrpHdr"`
+	PaymentInfo pain001PaymentInfo `xml:"PmtInf"`
+}
+
+type pain001GroupHeader struct {
+	MessageID       string       `xml:"MsgId"`
+	Created         string       `xml:"CreDtTm"`
+	NumTransactions string       `xml:"NbOfTxs"`
+	ControlSum      string       `xml:"CtrlSum"`
+	InitiatingParty pain001Party `xml:"InitgPty"`
+}
+
+type pain001PaymentInfo struct {
+	PaymentInfoID   string               `xml:"PmtInfId"`
+	PaymentMethod   string               `xml:"PmtMtd"`
+	NumTransactions string               `xml:"NbOfTxs"`
+	ControlSum      string               `xml:"CtrlSum"`
+	ExecutionDate   date.Date            `xml:"ReqdExctnDt"`
+	Debtor          pain001Party         `xml:"Dbtr"`
+	DebtorAccount   pain001Account       `xml:"DbtrAcct>Id"`
+	DebtorAgent     pain001Agent         `xml:"DbtrAgt>FinInstnId"`
+	Transactions    []pain001Transaction `xml:"CdtTrfTxInf"`
+}
+
+type pain001Transaction struct {
+	EndToEndID      string                 `xml:"PmtId>EndToEndId"`
+	PaymentType     *pain001PaymentType    `xml:"PmtTpInf,omitempty"`
+	Amount          pain001Amount          `xml:"Amt>InstdAmt"`
+	ChargeBearer    ChargeBearer           `xml:"ChrgBr"`
+	CreditorAgent   pain001Agent           `xml:"CdtrAgt>FinInstnId"`
+	Creditor        pain001Party           `xml:"Cdtr"`
+	CreditorAccount pain001Account         `xml:"CdtrAcct>Id"`
+	Reporting       *pain001Reporting      `xml:"RgltryRptg,omitempty"`
+	RemittanceInfo  *pain001RemittanceInfo `xml:"RmtInf,omitempty"`
+}
+
+// Nested optional elements need pointers to structs
+// because encoding/xml writes the parents of
+// omitted a>b>c path elements.
+
+type pain001PaymentType struct {
+	ServiceLevel string `xml:"SvcLvl>Cd"`
+}
+
+type pain001RemittanceInfo struct {
+	Unstructured string `xml:"Ustrd"`
+}
+
+type pain001Amount struct {
+	Currency money.Currency `xml:"Ccy,attr"`
+	Amount   string         `xml:",chardata"`
+}
+
+type pain001Party struct {
+	Name    string          `xml:"Nm"`
+	Address *pain001Address `xml:"PstlAdr,omitempty"`
+}
+
+type pain001Address struct {
+	Country      country.Code `xml:"Ctry"`
+	AddressLines []string     `xml:"AdrLine,omitempty"`
+}
+
+type pain001Account struct {
+	IBAN  string          `xml:"IBAN,omitempty"`
+	Other *pain001OtherID `xml:"Othr,omitempty"`
+}
+
+type pain001Agent struct {
+	BIC     string          `xml:"BIC,omitempty"`
+	Name    string          `xml:"Nm,omitempty"`
+	Address *pain001Address `xml:"PstlAdr,omitempty"`
+	Other   *pain001OtherID `xml:"Othr,omitempty"`
+}
+
+type pain001OtherID struct {
+	ID string `xml:"Id"`
+}
+
+type pain001Reporting struct {
+	Code string `xml:"Dtls>Cd,omitempty"`
+	Info string `xml:"Dtls>Inf,omitempty"`
+}
@@ -0,0 +1,208 @@
+package bank
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/money"
+)
+
+func TestAZVOrder_MarshalPAIN001(t *testing.T) {
+	order := AZVOrder{
+		MessageID:       "MSG-2026-10-15-1",
+		Created:         time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC),
+		InitiatingParty: "Muster GmbH",
+		ExecutionDate:   "2026-10-16",
+		DebtorName:      "Muster GmbH",
+		DebtorIBAN:      "DE89 3704 0044 0532 0130 00",
+		DebtorBIC:       "COBADEFFXXX",
+		Payments: []AZVPayment{
+			{
+				EndToEndID:      "INV-1001",
+				Amount:          1250.5,
+				Currency:        "USD",
+				ChargeBearer:    ChargeBearerDebtor,
+				CreditorName:    "Example Inc.",
+				CreditorCountry: "US",
+				CreditorAddress: []string{"1 Main Street", "New York, NY 10001"},
+				CreditorAccount: "123456789",
+				CreditorBIC:     "CHASUS33",
+				ReportingCode:   "150",
+				RemittanceInfo:  "Invoice 1001",
+			},
+			{
+				Amount:              100000,
+				Currency:            "JPY",
+				Urgent:              true,
+				CreditorName:        "Example KK",
+				CreditorCountry:     "JP",
+				CreditorAccount:     "9876543",
+				CreditorBankName:    "Example Bank",
+				CreditorBankCountry: "JP",
+			},
+		},
+	}
+	xml, err := order.MarshalPAIN001()
+	require.NoError(t, err)
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>MSG-2026-10-15-1</MsgId>
+      <CreDtTm>2026-10-15T09:30:00</CreDtTm>
+      <NbOfTxs>2</NbOfTxs>
+      <CtrlSum>101250.50</CtrlSum>
+      <InitgPty>
+        <Nm>Muster GmbH</Nm>
+      </InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>MSG-2026-10-15-1</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <NbOfTxs>2</NbOfTxs>
+      <CtrlSum>101250.50</CtrlSum>
+      <ReqdExctnDt>2026-10-16</ReqdExctnDt>
+      <Dbtr>
+        <Nm>Muster GmbH</Nm>
+      </Dbtr>
+      <DbtrAcct>
+        <Id>
+          <IBAN>DE89370400440532013000</IBAN>
+        </Id>
+      </DbtrAcct>
+      <DbtrAgt>
+        <FinInstnId>
+          <BIC>COBADEFFXXX</BIC>
+        </FinInstnId>
+      </DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId>
+          <EndToEndId>INV-1001</EndToEndId>
+        </PmtId>
+        <Amt>
+          <InstdAmt Ccy="USD">1250.50</InstdAmt>
+        </Amt>
+        <ChrgBr>DEBT</ChrgBr>
+        <CdtrAgt>
+          <FinInstnId>
+            <BIC>CHASUS33XXX</BIC>
+          </FinInstnId>
+        </CdtrAgt>
+        <Cdtr>
+          <Nm>Example Inc.</Nm>
+          <PstlAdr>
+            <Ctry>US</Ctry>
+            <AdrLine>1 Main Street</AdrLine>
+            <AdrLine>New York, NY 10001</AdrLine>
+          </PstlAdr>
+        </Cdtr>
+        <CdtrAcct>
+          <Id>
+            <Othr>
+              <Id>123456789</Id>
+            </Othr>
+          </Id>
+        </CdtrAcct>
+        <RgltryRptg>
+          <Dtls>
+            <Cd>150</Cd>
+          </Dtls>
+        </RgltryRptg>
+        <RmtInf>
+          <Ustrd>Invoice 1001</Ustrd>
+        </RmtInf>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId>
+          <EndToEndId>NOTPROVIDED</EndToEndId>
+        </PmtId>
+        <PmtTpInf>
+          <SvcLvl>
+            <Cd>URGP</Cd>
+          </SvcLvl>
+        </PmtTpInf>
+        <Amt>
+          <InstdAmt Ccy="JPY">100000</InstdAmt>
+        </Amt>
+        <ChrgBr>SHAR</ChrgBr>
+        <CdtrAgt>
+          <FinInstnId>
+            <Nm>Example Bank</Nm>
+            <PstlAdr>
+              <Ctry>JP</Ctry>
+            </PstlAdr>
+          </FinInstnId>
+        </CdtrAgt>
+        <Cdtr>
+          <Nm>Example KK</Nm>
+          <PstlAdr>
+            <Ctry>JP</Ctry>
+          </PstlAdr>
+        </Cdtr>
+        <CdtrAcct>
+          <Id>
+            <Othr>
+              <Id>9876543</Id>
+            </Othr>
+          </Id>
+        </CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`, string(xml))
+
+	invalid := order
+	invalid.Payments = []AZVPayment{order.Payments[1]}
+	invalid.Payments[0].CreditorBankName = ""
+	_, err = invalid.MarshalPAIN001()
+	require.Error(t, err)
+
+	invalid.Payments = []AZVPayment{order.Payments[0]}
+	invalid.Payments[0].Currency = "XAU"
+	_, err = invalid.MarshalPAIN001()
+	require.Error(t, err, "precious metal without minor units")
+
+	invalid.Payments = nil
+	_, err = invalid.MarshalPAIN001()
+	require.Error(t, err)
+}
+
+func TestAZVOrder_MarshalPAIN001_ControlSumDecimals(t *testing.T) {
+	order := AZVOrder{
+		MessageID:       "MSG-2026-10-15-2",
+		Created:         time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC),
+		InitiatingParty: "Muster GmbH",
+		ExecutionDate:   "2026-10-16",
+		DebtorName:      "Muster GmbH",
+		DebtorIBAN:      "DE89 3704 0044 0532 0130 00",
+		DebtorBIC:       "COBADEFFXXX",
+	}
+	for _, p := range []struct {
+		amount   float64
+		currency string
+	}{{0.1, "KWD"}, {0.2, "KWD"}, {10.125, "KWD"}, {0.105, "EUR"}, {3, "JPY"}} {
+		order.Payments = append(order.Payments, AZVPayment{
+			Amount:          money.Amount(p.amount),
+			Currency:        money.Currency(p.currency),
+			CreditorName:    "Example",
+			CreditorCountry: "KW",
+			CreditorAccount: "123456789",
+			CreditorBIC:     "NBOKKWKW",
+		})
+	}
+	xml, err := order.MarshalPAIN001()
+	require.NoError(t, err)
+	for _, want := range []string{
+		`<InstdAmt Ccy="KWD">0.100</InstdAmt>`,
+		`<InstdAmt Ccy="KWD">10.125</InstdAmt>`,
+		`<InstdAmt Ccy="EUR">0.11</InstdAmt>`,
+		`<InstdAmt Ccy="JPY">3</InstdAmt>`,
+		`<CtrlSum>13.535</CtrlSum>`,
+	} {
+		require.Contains(t, string(xml), want)
+	}
+	require.Equal(t, 2, strings.Count(string(xml), `<CtrlSum>13.535</CtrlSum>`))
+}
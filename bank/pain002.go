@@ -0,0 +1,341 @@
+package bank
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/domonda/go-types/date"
+)
+
+// PAIN002 is a customer payment status report (pain.002)
+// that a bank sends as feedback to a submitted
+// payment initiation (pain.001 or pain.008) message.
+type PAIN002 struct {
+	MessageID         string               `xml:"CstmrPmtStsRpt>GrpHdr>MsgId"`
+	Created           ISODateTime          `xml:"CstmrPmtStsRpt>GrpHdr>CreDtTm"`
+	OriginalMessageID string               `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts>OrgnlMsgId"`
+	OriginalMsgNameID string               `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts>OrgnlMsgNmId"` // Like "pain.001.001.03"
+	GroupStatus       PAIN002Status        `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts>GrpSts,omitempty"`
+	GroupReasons      []PAIN002Reason      `xml:"CstmrPmtStsRpt>OrgnlGrpInfAndSts>StsRsnInf,omitempty"`
+	PaymentInfos      []PAIN002PaymentInfo `xml:"CstmrPmtStsRpt>OrgnlPmtInfAndSts"`
+}
+
+type PAIN002PaymentInfo struct {
+	OriginalPaymentInfoID string               `xml:"OrgnlPmtInfId"`
+	Status                PAIN002Status        `xml:"PmtInfSts,omitempty"`
+	Reasons               []PAIN002Reason      `xml:"StsRsnInf,omitempty"`
+	Transactions          []PAIN002Transaction `xml:"TxInfAndSts"`
+}
+
+type PAIN002Transaction struct {
+	StatusID               string          `xml:"StsId,omitempty"`
+	OriginalInstructionID  string          `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID     string          `xml:"OrgnlEndToEndId"`
+	Status                 PAIN002Status   `xml:"TxSts"`
+	Reasons                []PAIN002Reason `xml:"StsRsnInf,omitempty"`
+	Amount                 CAMT53Amount    `xml:"OrgnlTxRef>Amt>InstdAmt"`
+	RequestedExecutionDate PAIN002Date     `xml:"OrgnlTxRef>ReqdExctnDt"`
+	DebitorName            string          `xml:"OrgnlTxRef>Dbtr>Nm,omitempty"`
+	DebitorIBAN            IBAN            `xml:"OrgnlTxRef>DbtrAcct>Id>IBAN,omitempty"`
+	CreditorName           string          `xml:"OrgnlTxRef>Cdtr>Nm,omitempty"`
+	CreditorIBAN           IBAN            `xml:"OrgnlTxRef>CdtrAcct>Id>IBAN,omitempty"`
+}
+
+// PAIN002Date is a date that is the content of an element
+// in pain.002.001.03 or of its Dt child element
+// in pain.002.001.09 and later versions.
+type PAIN002Date struct {
+	Text string    `xml:",chardata"`
+	Dt   date.Date `xml:"Dt"`
+}
+
+// Date returns the date independent of the schema version.
+func (d PAIN002Date) Date() date.Date {
+	if d.Dt != "" {
+		return d.Dt
+	}
+	return date.Date(strings.TrimSpace(d.Text))
+}
+
+// PAIN002Reason is a status reason of a payment status report.
+type PAIN002Reason struct {
+	Code           StatusReasonCode `xml:"Rsn>Cd,omitempty"`
+	Proprietary    string           `xml:"Rsn>Prtry,omitempty"`
+	AdditionalInfo []string         `xml:"AddtlInf,omitempty"`
+	OriginatorName string           `xml:"Orgtr>Nm,omitempty"`
+	OriginatorBIC  BIC              `xml:"Orgtr>Id>OrgId>BICOrBEI,omitempty"`
+}
+
+// String returns the reason code or proprietary reason
+// with its description and additional information.
+func (r PAIN002Reason) String() string {
+	s := string(r.Code)
+	if s == "" {
+		s = r.Proprietary
+	}
+	if desc := r.Code.Description(); desc != "" {
+		s += ": " + desc
+	}
+	for _, info := range r.AdditionalInfo {
+		s += "; " + info
+	}
+	return s
+}
+
+// ParsePAIN002 parses a pain.002 payment status report XML document.
+// All versions of the pain.002.001 schema with the
+// CstmrPmtStsRpt root element are supported.
+func ParsePAIN002(data []byte) (*PAIN002, error) {
+	var report PAIN002
+	err := xml.Unmarshal(data, &report)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse pain.002 XML: %w", err)
+	}
+	if report.MessageID == "" {
+		return nil, fmt.Errorf("no pain.002 CstmrPmtStsRpt message ID found")
+	}
+	return &report, nil
+}
+
+// EndToEndStatus is the status of a payment
+// identified by its end-to-end ID.
+type EndToEndStatus struct {
+	EndToEndID            string
+	OriginalPaymentInfoID string
+	Status                PAIN002Status
+	Reasons               []PAIN002Reason
+}
+
+// Transactions returns the statuses of all transactions of the report
+// by their original end-to-end ID in the order of the report.
+// Reasons of the payment information or group are used
+// if the transaction has no own reasons.
+func (r *PAIN002) Transactions() []EndToEndStatus {
+	var statuses []EndToEndStatus
+	for _, info := range r.PaymentInfos {
+		for _, tx := range info.Transactions {
+			status := EndToEndStatus{
+				EndToEndID:            tx.OriginalEndToEndID,
+				OriginalPaymentInfoID: info.OriginalPaymentInfoID,
+				Status:                tx.Status,
+				Reasons:               tx.Reasons,
+			}
+			if status.Status == "" {
+				status.Status = info.Status
+			}
+			if len(status.Reasons) == 0 {
+				status.Reasons = info.Reasons
+			}
+			if len(status.Reasons) == 0 {
+				status.Reasons = r.GroupReasons
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// Rejected returns the statuses of all
+// rejected transactions of the report.
+func (r *PAIN002) Rejected() []EndToEndStatus {
+	var rejected []EndToEndStatus
+	for _, status := range r.Transactions() {
+		if status.Status.IsRejected() {
+			rejected = append(rejected, status)
+		}
+	}
+	return rejected
+}
+
+// StatusOfEndToEndIDs maps the passed end-to-end IDs
+// of a submitted payment message to their statuses.
+//
+// IDs without a transaction status in the report
+// get the group status if the whole message was
+// rejected or accepted with one status.
+// IDs are missing in the result if their status can't be
+// determined, for example if the group status is PART
+// which means that only some transactions were rejected.
+func (r *PAIN002) StatusOfEndToEndIDs(endToEndIDs []string) map[string]EndToEndStatus {
+	byID := make(map[string]EndToEndStatus)
+	for _, status := range r.Transactions() {
+		byID[status.EndToEndID] = status
+	}
+	result := make(map[string]EndToEndStatus, len(endToEndIDs))
+	for _, id := range endToEndIDs {
+		if status, ok := byID[id]; ok {
+			result[id] = status
+			continue
+		}
+		if r.GroupStatus != "" && r.GroupStatus != PAIN002StatusPartiallyAccepted {
+			result[id] = EndToEndStatus{
+				EndToEndID: id,
+				Status:     r.GroupStatus,
+				Reasons:    r.GroupReasons,
+			}
+		}
+	}
+	return result
+}
+
+// PAIN002Status is an ISO 20022 payment transaction status code
+// like "ACCP" or "RJCT" of a payment status report.
+type PAIN002Status string
+
+const (
+	PAIN002StatusAccepted               PAIN002Status = "ACCP" // Technical and customer profile validation successful
+	PAIN002StatusAcceptedSettlementDone PAIN002Status = "ACSC" // Settlement on the debtor's account completed
+	PAIN002StatusAcceptedSettlement     PAIN002Status = "ACSP" // Settlement in process
+	PAIN002StatusAcceptedTechnical      PAIN002Status = "ACTC" // Technical validation successful
+	PAIN002StatusAcceptedWithChange     PAIN002Status = "ACWC" // Accepted with changes like the execution date
+	PAIN002StatusAcceptedCreditSettled  PAIN002Status = "ACCC" // Settlement on the creditor's account completed
+	PAIN002StatusPartiallyAccepted      PAIN002Status = "PART" // Some transactions were accepted, some rejected
+	PAIN002StatusPending                PAIN002Status = "PDNG" // Further checks pending
+	PAIN002StatusReceived               PAIN002Status = "RCVD" // Received but not yet validated
+	PAIN002StatusRejected               PAIN002Status = "RJCT" // Rejected
+)
+
+// Valid returns true if the status is one of the defined constants.
+func (s PAIN002Status) Valid() bool {
+	switch s {
+	case PAIN002StatusAccepted,
+		PAIN002StatusAcceptedSettlementDone,
+		PAIN002StatusAcceptedSettlement,
+		PAIN002StatusAcceptedTechnical,
+		PAIN002StatusAcceptedWithChange,
+		PAIN002StatusAcceptedCreditSettled,
+		PAIN002StatusPartiallyAccepted,
+		PAIN002StatusPending,
+		PAIN002StatusReceived,
+		PAIN002StatusRejected:
+		return true
+	}
+	return false
+}
+
+// PaymentStatus returns the PaymentStatus of a payment with the status,
+// PaymentStatusCreated for not rejected statuses before
+// the completed settlement.
+func (s PAIN002Status) PaymentStatus() PaymentStatus {
+	switch s {
+	case PAIN002StatusRejected:
+		return PaymentStatusFailed
+	case PAIN002StatusAcceptedSettlementDone, PAIN002StatusAcceptedCreditSettled:
+		return PaymentStatusFinished
+	}
+	return PaymentStatusCreated
+}
+
+// IsRejected returns true for the RJCT status.
+func (s PAIN002Status) IsRejected() bool {
+	return s == PAIN002StatusRejected
+}
+
+// IsAccepted returns true for all accepted statuses
+// excluding partially accepted.
+func (s PAIN002Status) IsAccepted() bool {
+	switch s {
+	case PAIN002StatusAccepted,
+		PAIN002StatusAcceptedSettlementDone,
+		PAIN002StatusAcceptedSettlement,
+		PAIN002StatusAcceptedTechnical,
+		PAIN002StatusAcceptedWithChange,
+		PAIN002StatusAcceptedCreditSettled:
+		return true
+	}
+	return false
+}
+
+// IsPending returns true if the status is not final yet.
+func (s PAIN002Status) IsPending() bool {
+	return s == PAIN002StatusPending || s == PAIN002StatusReceived
+}
+
+// StatusReasonCode is an ISO 20022 external status reason code
+// like "AC01" or "AM04" explaining a rejected or returned payment.
+type StatusReasonCode string
+
+// Frequent SEPA status reason codes
+const (
+	StatusReasonIncorrectAccountNumber    StatusReasonCode = "AC01"
+	StatusReasonClosedAccountNumber       StatusReasonCode = "AC04"
+	StatusReasonBlockedAccount            StatusReasonCode = "AC06"
+	StatusReasonConsumerAccount           StatusReasonCode = "AC13"
+	StatusReasonTransactionForbidden      StatusReasonCode = "AG01"
+	StatusReasonInvalidBankOperation      StatusReasonCode = "AG02"
+	StatusReasonInsufficientFunds         StatusReasonCode = "AM04"
+	StatusReasonDuplication               StatusReasonCode = "AM05"
+	StatusReasonMissingCreditorAddress    StatusReasonCode = "BE04"
+	StatusReasonCreditorBankNotRegistered StatusReasonCode = "CNOR"
+	StatusReasonDebtorBankNotRegistered   StatusReasonCode = "DNOR"
+	StatusReasonDuplicatePayment          StatusReasonCode = "DUPL"
+	StatusReasonSettlementFailed          StatusReasonCode = "ED05"
+	StatusReasonInvalidFileFormat         StatusReasonCode = "FF01"
+	StatusReasonFollowingCancellation     StatusReasonCode = "FOCR"
+	StatusReasonNoMandate                 StatusReasonCode = "MD01"
+	StatusReasonMissingMandateInfo        StatusReasonCode = "MD02"
+	StatusReasonRefundRequestByDebtor     StatusReasonCode = "MD06"
+	StatusReasonEndCustomerDeceased       StatusReasonCode = "MD07"
+	StatusReasonNotSpecifiedByCustomer    StatusReasonCode = "MS02"
+	StatusReasonNotSpecifiedByAgent       StatusReasonCode = "MS03"
+	StatusReasonIncorrectBankIdentifier   StatusReasonCode = "RC01"
+	StatusReasonMissingDebtorAccount      StatusReasonCode = "RR01"
+	StatusReasonMissingDebtorName         StatusReasonCode = "RR02"
+	StatusReasonMissingCreditorName       StatusReasonCode = "RR03"
+	StatusReasonRegulatory                StatusReasonCode = "RR04"
+	StatusReasonSpecificServiceByDebtor   StatusReasonCode = "SL01"
+	StatusReasonCutOffTime                StatusReasonCode = "TM01"
+)
+
+var statusReasonDescriptions = map[StatusReasonCode]string{
+	StatusReasonIncorrectAccountNumber:    "Account identifier incorrect",
+	StatusReasonClosedAccountNumber:       "Account closed",
+	StatusReasonBlockedAccount:            "Account blocked",
+	StatusReasonConsumerAccount:           "Debtor account is a consumer account",
+	StatusReasonTransactionForbidden:      "Transaction forbidden on this type of account",
+	StatusReasonInvalidBankOperation:      "Invalid bank operation code",
+	StatusReasonInsufficientFunds:         "Insufficient funds",
+	StatusReasonDuplication:               "Duplicate collection",
+	StatusReasonMissingCreditorAddress:    "Creditor address missing or incorrect",
+	StatusReasonCreditorBankNotRegistered: "Creditor bank is not registered",
+	StatusReasonDebtorBankNotRegistered:   "Debtor bank is not registered",
+	StatusReasonDuplicatePayment:          "Duplicate payment",
+	StatusReasonSettlementFailed:          "Settlement failed",
+	StatusReasonInvalidFileFormat:         "Invalid file format",
+	StatusReasonFollowingCancellation:     "Return following a cancellation request",
+	StatusReasonNoMandate:                 "No valid mandate",
+	StatusReasonMissingMandateInfo:        "Mandate data missing or incorrect",
+	StatusReasonRefundRequestByDebtor:     "Refund request by end customer",
+	StatusReasonEndCustomerDeceased:       "End customer deceased",
+	StatusReasonNotSpecifiedByCustomer:    "Reason not specified by the customer",
+	StatusReasonNotSpecifiedByAgent:       "Reason not specified by the agent",
+	StatusReasonIncorrectBankIdentifier:   "Bank identifier incorrect",
+	StatusReasonMissingDebtorAccount:      "Debtor account or identification missing",
+	StatusReasonMissingDebtorName:         "Debtor name or address missing",
+	StatusReasonMissingCreditorName:       "Creditor name or address missing",
+	StatusReasonRegulatory:                "Regulatory reason",
+	StatusReasonSpecificServiceByDebtor:   "Specific service offered by the debtor agent",
+	StatusReasonCutOffTime:                "Received after the cut-off time",
+}
+
+// Description returns an English description of the reason code
+// or an empty string for unknown codes.
+func (c StatusReasonCode) Description() string {
+	return statusReasonDescriptions[c]
+}
+
+// IsAccountError returns true if the reason code indicates
+// that the account data of the counterparty
+// has to be corrected before a retry.
+func (c StatusReasonCode) IsAccountError() bool {
+	switch c {
+	case StatusReasonIncorrectAccountNumber,
+		StatusReasonClosedAccountNumber,
+		StatusReasonBlockedAccount,
+		StatusReasonIncorrectBankIdentifier,
+		StatusReasonMissingDebtorAccount:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package bank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+)
+
+const testPAIN002 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.03">
+  <CstmrPmtStsRpt>
+    <GrpHdr>
+      <MsgId>STATUS-0001</MsgId>
+      <CreDtTm>2024-03-05T10:15:00+01:00</CreDtTm>
+    </GrpHdr>
+    <OrgnlGrpInfAndSts>
+      <OrgnlMsgId>BATCH-2024-03-05</OrgnlMsgId>
+      <OrgnlMsgNmId>pain.001.001.03</OrgnlMsgNmId>
+      <GrpSts>PART</GrpSts>
+    </OrgnlGrpInfAndSts>
+    <OrgnlPmtInfAndSts>
+      <OrgnlPmtInfId>PMTINF-1</OrgnlPmtInfId>
+      <PmtInfSts>PART</PmtInfSts>
+      <TxInfAndSts>
+        <StsId>1</StsId>
+        <OrgnlEndToEndId>E2E-1</OrgnlEndToEndId>
+        <TxSts>RJCT</TxSts>
+        <StsRsnInf>
+          <Orgtr><Nm>Bank</Nm></Orgtr>
+          <Rsn><Cd>AC04</Cd></Rsn>
+          <AddtlInf>Account closed</AddtlInf>
+        </StsRsnInf>
+        <OrgnlTxRef>
+          <Amt><InstdAmt Ccy="EUR">120.50</InstdAmt></Amt>
+          <ReqdExctnDt>2024-03-06</ReqdExctnDt>
+          <Cdtr><Nm>Muster GmbH</Nm></Cdtr>
+          <CdtrAcct><Id><IBAN>DE89370400440532013000</IBAN></Id></CdtrAcct>
+        </OrgnlTxRef>
+      </TxInfAndSts>
+      <TxInfAndSts>
+        <OrgnlEndToEndId>E2E-2</OrgnlEndToEndId>
+        <TxSts>ACCP</TxSts>
+      </TxInfAndSts>
+    </OrgnlPmtInfAndSts>
+  </CstmrPmtStsRpt>
+</Document>`
+
+const testPAIN002GroupRejected = `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.10">
+  <CstmrPmtStsRpt>
+    <GrpHdr><MsgId>STATUS-0002</MsgId><CreDtTm>2024-03-05T10:15:00</CreDtTm></GrpHdr>
+    <OrgnlGrpInfAndSts>
+      <OrgnlMsgId>BATCH-2</OrgnlMsgId>
+      <OrgnlMsgNmId>pain.001.001.09</OrgnlMsgNmId>
+      <GrpSts>RJCT</GrpSts>
+      <StsRsnInf><Rsn><Cd>FF01</Cd></Rsn></StsRsnInf>
+    </OrgnlGrpInfAndSts>
+    <OrgnlPmtInfAndSts>
+      <OrgnlPmtInfId>PMTINF-1</OrgnlPmtInfId>
+      <TxInfAndSts>
+        <OrgnlEndToEndId>E2E-1</OrgnlEndToEndId>
+        <TxSts>RJCT</TxSts>
+        <OrgnlTxRef><ReqdExctnDt><Dt>2024-03-07</Dt></ReqdExctnDt></OrgnlTxRef>
+      </TxInfAndSts>
+    </OrgnlPmtInfAndSts>
+  </CstmrPmtStsRpt>
+</Document>`
+
+func TestParsePAIN002(t *testing.T) {
+	report, err := ParsePAIN002([]byte(testPAIN002))
+	require.NoError(t, err)
+	assert.Equal(t, "STATUS-0001", report.MessageID)
+	assert.Equal(t, "BATCH-2024-03-05", report.OriginalMessageID)
+	assert.Equal(t, PAIN002StatusPartiallyAccepted, report.GroupStatus)
+	require.Len(t, report.PaymentInfos, 1)
+	require.Len(t, report.PaymentInfos[0].Transactions, 2)
+
+	tx := report.PaymentInfos[0].Transactions[0]
+	assert.Equal(t, "E2E-1", tx.OriginalEndToEndID)
+	assert.Equal(t, money.Amount(120.5), tx.Amount.Amount)
+	assert.Equal(t, money.Currency("EUR"), tx.Amount.Currency)
+	assert.Equal(t, date.Date("2024-03-06"), tx.RequestedExecutionDate.Date())
+	assert.Equal(t, IBAN("DE89370400440532013000"), tx.CreditorIBAN)
+	require.Len(t, tx.Reasons, 1)
+	assert.Equal(t, StatusReasonClosedAccountNumber, tx.Reasons[0].Code)
+	assert.True(t, tx.Reasons[0].Code.IsAccountError())
+	assert.Equal(t, "AC04: Account closed; Account closed", tx.Reasons[0].String())
+
+	rejected := report.Rejected()
+	require.Len(t, rejected, 1)
+	assert.Equal(t, "E2E-1", rejected[0].EndToEndID)
+	assert.Equal(t, "PMTINF-1", rejected[0].OriginalPaymentInfoID)
+	assert.Equal(t, PaymentStatusFailed, rejected[0].Status.PaymentStatus())
+
+	statuses := report.StatusOfEndToEndIDs([]string{"E2E-1", "E2E-2", "E2E-3"})
+	assert.Equal(t, PAIN002StatusRejected, statuses["E2E-1"].Status)
+	assert.Equal(t, PAIN002StatusAccepted, statuses["E2E-2"].Status)
+	assert.NotContains(t, statuses, "E2E-3", "unknown status for partially accepted group")
+
+	_, err = ParsePAIN002([]byte(`<Document></Document>`))
+	assert.Error(t, err)
+	_, err = ParsePAIN002([]byte(`not XML`))
+	assert.Error(t, err)
+}
+
+func TestPAIN002GroupRejected(t *testing.T) {
+	report, err := ParsePAIN002([]byte(testPAIN002GroupRejected))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 5, 10, 15, 0, 0, time.UTC), report.Created.Time, "CreDtTm without time zone")
+	assert.Equal(t, date.Date("2024-03-07"), report.PaymentInfos[0].Transactions[0].RequestedExecutionDate.Date())
+
+	statuses := report.StatusOfEndToEndIDs([]string{"E2E-1", "E2E-2"})
+	require.Len(t, statuses, 2)
+	for _, status := range statuses {
+		assert.True(t, status.Status.IsRejected())
+		require.Len(t, status.Reasons, 1)
+		assert.Equal(t, StatusReasonInvalidFileFormat, status.Reasons[0].Code)
+	}
+}
+
+func TestPAIN002Status(t *testing.T) {
+	assert.True(t, PAIN002StatusAcceptedTechnical.Valid())
+	assert.False(t, PAIN002Status("XXXX").Valid())
+	assert.True(t, PAIN002StatusAcceptedWithChange.IsAccepted())
+	assert.False(t, PAIN002StatusPartiallyAccepted.IsAccepted())
+	assert.True(t, PAIN002StatusPending.IsPending())
+	assert.Equal(t, PaymentStatusFinished, PAIN002StatusAcceptedSettlementDone.PaymentStatus())
+	assert.Equal(t, PaymentStatusCreated, PAIN002StatusAcceptedTechnical.PaymentStatus())
+}
@@ -0,0 +1,253 @@
+package bank
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/strutil"
+)
+
+// OpenItem is an open receivable or payable like an invoice
+// that is expected to be settled by a bank transaction.
+type OpenItem struct {
+	Amount   money.Amount
+	Currency money.Currency
+	// Reference is the invoice number or payment reference
+	// expected in the remittance information of the transaction.
+	Reference string
+	// IBAN of the counterparty if known.
+	IBAN IBAN
+	// DueDate is the expected payment date.
+	DueDate date.Date
+}
+
+// Transaction is a booked bank statement transaction
+// that might settle one of the OpenItems.
+type Transaction struct {
+	Amount   money.Amount
+	Currency money.Currency
+	// Reference is the remittance information of the transaction.
+	Reference string
+	// IBAN of the counterparty.
+	IBAN IBAN
+	// Date is the booking or value date of the transaction.
+	Date date.Date
+}
+
+// PaymentMatch is a candidate match between an OpenItem
+// and a Transaction with its score and the partial scores
+// of the individual criteria, all in the range 0 to 1.
+type PaymentMatch struct {
+	OpenItemIndex    int
+	TransactionIndex int
+
+	Score          float64
+	AmountScore    float64
+	ReferenceScore float64
+	IBANScore      float64
+	DateScore      float64
+}
+
+// PaymentMatcher configures how OpenItems and Transactions
+// are scored against each other.
+//
+// The Score of a PaymentMatch is the weighted average
+// of the partial scores using the weight fields.
+type PaymentMatcher struct {
+	// AmountTolerance is the absolute difference of the amounts
+	// that still results in a partial AmountScore.
+	// Amounts within one cent always have an AmountScore of 1.
+	AmountTolerance money.Amount
+	// MaxDateDistance is the distance between the DueDate of an
+	// OpenItem and the Date of a Transaction that results
+	// in a DateScore of zero.
+	MaxDateDistance time.Duration
+	// MinScore is the minimum Score of a returned PaymentMatch.
+	MinScore float64
+
+	AmountWeight    float64
+	ReferenceWeight float64
+	IBANWeight      float64
+	DateWeight      float64
+}
+
+// DefaultPaymentMatcher is used by MatchPayments.
+var DefaultPaymentMatcher = PaymentMatcher{
+	AmountTolerance: 1,
+	MaxDateDistance: 60 * 24 * time.Hour,
+	MinScore:        0.5,
+	AmountWeight:    0.4,
+	ReferenceWeight: 0.35,
+	IBANWeight:      0.15,
+	DateWeight:      0.1,
+}
+
+// MatchPayments returns the candidate matches between the passed
+// openItems and transactions using DefaultPaymentMatcher
+// ranked by descending score.
+func MatchPayments(openItems []OpenItem, transactions []Transaction) []PaymentMatch {
+	return DefaultPaymentMatcher.Match(openItems, transactions)
+}
+
+// Match returns all candidate matches between the passed openItems
+// and transactions with a Score of at least MinScore
+// ranked by descending score.
+//
+// Amounts are compared by their absolute values
+// so that the sign convention of the transactions doesn't matter.
+// Pairs with different non empty currencies are never matched,
+// currencies are compared in normalized form.
+func (m *PaymentMatcher) Match(openItems []OpenItem, transactions []Transaction) []PaymentMatch {
+	itemKeys := make([]paymentMatchKey, len(openItems))
+	for o := range openItems {
+		item := &openItems[o]
+		itemKeys[o] = newPaymentMatchKey(item.Currency, item.Reference, item.IBAN)
+	}
+	var matches []PaymentMatch
+	for t := range transactions {
+		transaction := &transactions[t]
+		transactionKey := newPaymentMatchKey(transaction.Currency, transaction.Reference, transaction.IBAN)
+		for o := range openItems {
+			itemKey := &itemKeys[o]
+			if itemKey.currency != "" && transactionKey.currency != "" && itemKey.currency != transactionKey.currency {
+				continue
+			}
+			match := m.score(&openItems[o], itemKey, transaction, &transactionKey)
+			if match.Score < m.MinScore {
+				continue
+			}
+			match.OpenItemIndex = o
+			match.TransactionIndex = t
+			matches = append(matches, match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// paymentMatchKey holds the normalized fields of an OpenItem
+// or Transaction so that they are normalized only once
+// instead of for every compared pair.
+type paymentMatchKey struct {
+	currency  money.Currency // unchanged if not valid
+	reference string
+	iban      IBAN // empty if not valid
+}
+
+func newPaymentMatchKey(currency money.Currency, reference string, iban IBAN) paymentMatchKey {
+	key := paymentMatchKey{
+		currency:  currency,
+		reference: normalizeReference(reference),
+	}
+	if norm, err := currency.Normalized(); err == nil {
+		key.currency = norm
+	}
+	if norm, err := iban.Normalized(); err == nil {
+		key.iban = norm
+	}
+	return key
+}
+
+// score returns a PaymentMatch with the scores of the passed pair
+// without the indices being set.
+func (m *PaymentMatcher) score(item *OpenItem, itemKey *paymentMatchKey, transaction *Transaction, transactionKey *paymentMatchKey) (match PaymentMatch) {
+	match.AmountScore = m.amountScore(item.Amount, transaction.Amount)
+	match.ReferenceScore = referenceScore(itemKey.reference, transactionKey.reference)
+	match.IBANScore = ibanScore(itemKey.iban, transactionKey.iban)
+	match.DateScore = m.dateScore(item.DueDate, transaction.Date)
+
+	totalWeight := m.AmountWeight + m.ReferenceWeight + m.IBANWeight + m.DateWeight
+	if totalWeight > 0 {
+		match.Score = (match.AmountScore*m.AmountWeight +
+			match.ReferenceScore*m.ReferenceWeight +
+			match.IBANScore*m.IBANWeight +
+			match.DateScore*m.DateWeight) / totalWeight
+	}
+	return match
+}
+
+func (m *PaymentMatcher) amountScore(expected, actual money.Amount) float64 {
+	if !expected.Valid() || !actual.Valid() {
+		return 0
+	}
+	diff := math.Abs(expected.AbsFloat() - actual.AbsFloat())
+	if diff < 0.005 {
+		return 1
+	}
+	if tolerance := float64(m.AmountTolerance); diff < tolerance {
+		// Partial score between 0.5 and 1 within the tolerance
+		return 1 - 0.5*diff/tolerance
+	}
+	return 0
+}
+
+func (m *PaymentMatcher) dateScore(dueDate, transactionDate date.Date) float64 {
+	if dueDate.IsZero() || transactionDate.IsZero() || m.MaxDateDistance <= 0 {
+		return 0
+	}
+	distance := transactionDate.Sub(dueDate)
+	if distance < 0 {
+		distance = -distance
+	}
+	return max(0, 1-float64(distance)/float64(m.MaxDateDistance))
+}
+
+// ibanScore returns 1 if the normalized IBANs
+// are equal and not empty, else 0.
+func ibanScore(a, b IBAN) float64 {
+	if a == "" || a != b {
+		return 0
+	}
+	return 1
+}
+
+// referenceScore returns 1 if the normalized item reference
+// is contained in the transaction reference, 0.9 if it is
+// contained after removing spaces, else the best fuzzy
+// similarity with any word of the transaction reference.
+func referenceScore(itemRef, transactionRef string) float64 {
+	if itemRef == "" || transactionRef == "" {
+		return 0
+	}
+	if strings.Contains(transactionRef, itemRef) {
+		return 1
+	}
+	if strings.Contains(strings.ReplaceAll(transactionRef, " ", ""), strings.ReplaceAll(itemRef, " ", "")) {
+		return 0.9
+	}
+	best := 0.0
+	for _, word := range strings.Fields(transactionRef) {
+		best = max(best, strutil.Similarity(itemRef, word))
+	}
+	return best
+}
+
+// normalizeReference returns the upper case letters and digits
+// of ref in words separated by single spaces,
+// except for letters and digits that are only separated
+// by punctuation like in "RE-2024/001" which are joined.
+func normalizeReference(ref string) string {
+	var b strings.Builder
+	b.Grow(len(ref))
+	space := false
+	for _, r := range strings.ToUpper(ref) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			space = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/date"
+)
+
+func TestMatchPayments(t *testing.T) {
+	openItems := []OpenItem{
+		{Amount: 119.00, Currency: "EUR", Reference: "RE-2024-001", DueDate: date.Of(2024, 3, 1)},
+		{Amount: 500.00, Currency: "EUR", Reference: "RE-2024-002", IBAN: "DE89 3704 0044 0532 0130 00", DueDate: date.Of(2024, 3, 15)},
+		{Amount: 42.00, Currency: "USD", Reference: "INV 77"},
+	}
+	transactions := []Transaction{
+		{Amount: 500.00, Currency: "EUR", Reference: "Payment RE2024002 thanks", IBAN: "DE89370400440532013000", Date: date.Of(2024, 3, 14)},
+		{Amount: -119.00, Currency: "EUR", Reference: "Rechnung RE 2024 001", Date: date.Of(2024, 3, 3)},
+		{Amount: 42.00, Currency: "EUR", Reference: "INV 77"},
+	}
+
+	matches := MatchPayments(openItems, transactions)
+	require.Len(t, matches, 2)
+
+	require.Equal(t, 1, matches[0].OpenItemIndex)
+	require.Equal(t, 0, matches[0].TransactionIndex)
+	require.Equal(t, 1.0, matches[0].AmountScore)
+	require.Equal(t, 1.0, matches[0].ReferenceScore)
+	require.Equal(t, 1.0, matches[0].IBANScore)
+
+	require.Equal(t, 0, matches[1].OpenItemIndex)
+	require.Equal(t, 1, matches[1].TransactionIndex)
+	require.Equal(t, 1.0, matches[1].AmountScore, "sign of transaction amount is ignored")
+	require.Equal(t, 0.9, matches[1].ReferenceScore, "reference split by spaces")
+	require.Equal(t, 0.0, matches[1].IBANScore)
+	require.Greater(t, matches[0].Score, matches[1].Score)
+
+	require.Equal(t, "RE2024001 ABC", normalizeReference(" re-2024/001,  abc "))
+
+	// Currencies are compared normalized
+	matches = MatchPayments(
+		[]OpenItem{{Amount: 10, Currency: "eur", Reference: "RE-1"}},
+		[]Transaction{{Amount: 10, Currency: "EUR", Reference: "RE-1"}},
+	)
+	require.Len(t, matches, 1)
+}
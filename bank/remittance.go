@@ -0,0 +1,240 @@
+package bank
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// RemittanceFieldKind is the kind of a RemittanceField.
+type RemittanceFieldKind string
+
+const (
+	// RemittanceCreditorReference is an ISO 11649
+	// structured creditor reference like "RF18539007547034".
+	RemittanceCreditorReference RemittanceFieldKind = "CreditorReference"
+	// RemittanceEndToEndID is the SEPA end-to-end reference
+	// of the EREF+ keyword.
+	RemittanceEndToEndID RemittanceFieldKind = "EndToEndID"
+	// RemittanceMandateID is the SEPA direct debit mandate reference
+	// of the MREF+ keyword.
+	RemittanceMandateID RemittanceFieldKind = "MandateID"
+	// RemittanceCreditorID is the SEPA creditor identifier
+	// of the CRED+ keyword.
+	RemittanceCreditorID RemittanceFieldKind = "CreditorID"
+	// RemittanceCustomerReference is the customer reference
+	// of the KREF+ keyword.
+	RemittanceCustomerReference RemittanceFieldKind = "CustomerReference"
+	// RemittanceInvoiceNumber is an invoice number
+	// introduced by a keyword like "RE", "Rechnung", or "Invoice".
+	RemittanceInvoiceNumber RemittanceFieldKind = "InvoiceNumber"
+	// RemittanceCustomerNumber is a customer number
+	// introduced by a keyword like "Kd.-Nr." or "Customer No".
+	RemittanceCustomerNumber RemittanceFieldKind = "CustomerNumber"
+)
+
+// RemittanceField is a typed value found in remittance information.
+type RemittanceField struct {
+	Kind RemittanceFieldKind `json:"kind"`
+	// Value is the normalized value like a creditor
+	// reference without spaces in upper case.
+	Value string `json:"value"`
+	// Start and End are the byte offsets
+	// of the value in the parsed text.
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// RemittanceInfo is the result of ParseRemittanceInfo.
+type RemittanceInfo struct {
+	// Text is the parsed remittance information.
+	Text string `json:"text"`
+	// Purpose is the text of the SVWZ+ keyword
+	// or Text if there are no SEPA keywords.
+	Purpose string `json:"purpose"`
+	// Fields are the found values sorted by Start offset.
+	Fields []RemittanceField `json:"fields,omitempty"`
+}
+
+// Values returns the values of all fields of a kind.
+func (r *RemittanceInfo) Values(kind RemittanceFieldKind) []string {
+	var values []string
+	for _, f := range r.Fields {
+		if f.Kind == kind {
+			values = append(values, f.Value)
+		}
+	}
+	return values
+}
+
+// First returns the first field of a kind
+// or false if there is no such field.
+func (r *RemittanceInfo) First(kind RemittanceFieldKind) (RemittanceField, bool) {
+	for _, f := range r.Fields {
+		if f.Kind == kind {
+			return f, true
+		}
+	}
+	return RemittanceField{}, false
+}
+
+var (
+	sepaKeywordRegexp = regexp.MustCompile(`\b(EREF|KREF|MREF|CRED|SVWZ|ABWA|ABWE|DEBT|IBAN|BIC|PURP)\+`)
+
+	creditorReferenceRegexp = regexp.MustCompile(`(?i)\bRF\d{2}(?:\s?[0-9A-Z]){1,21}`)
+
+	remittanceValue       = `([0-9A-Z][0-9A-Z\-/._]*[0-9][0-9A-Z\-/_]*)`
+	remittanceValueList   = remittanceValue + `((?:\s*[,;&+]\s*|\s+(?:und|and|u\.)\s+)` + remittanceValue + `)*`
+	remittanceNumberLabel = `(?:[\s.:#-]*(?:Nr|No|Nummer|Number|Num)\b)?[\s.:#-]*`
+
+	invoiceNumberRegexp = regexp.MustCompile(`(?i)\b(?:Rechnungs?nr|Rechnungs?nummer|Rechnung|Rech|Rg|RNr|RE|Invoice|Inv|Faktura|Fakt)` +
+		remittanceNumberLabel + remittanceValueList)
+
+	customerNumberRegexp = regexp.MustCompile(`(?i)\b(?:Kundennr|Kundennummer|Kunden|Kd|KNr|Customer|Cust)` +
+		remittanceNumberLabel + remittanceValueList)
+
+	remittanceValueRegexp = regexp.MustCompile(`(?i)` + remittanceValue)
+)
+
+// ParseRemittanceInfo parses unstructured remittance information
+// of a bank transaction for SEPA keywords like "EREF+" or "SVWZ+",
+// ISO 11649 creditor references, invoice numbers like "RE 2024-123",
+// and customer numbers like "Kd.-Nr. 4711".
+//
+// Creditor references and creditor identifiers
+// are only returned if their check digits are valid.
+// Invoice and customer numbers must contain at least one digit.
+func ParseRemittanceInfo(text string) RemittanceInfo {
+	info := RemittanceInfo{Text: text, Purpose: text}
+
+	keywords := sepaKeywordRegexp.FindAllStringSubmatchIndex(text, -1)
+	for i, kw := range keywords {
+		start, end := kw[1], len(text)
+		if i+1 < len(keywords) {
+			end = keywords[i+1][0]
+		}
+		start, end = trimSpaceOffsets(text, start, end)
+		value := text[start:end]
+		switch text[kw[2]:kw[3]] {
+		case "SVWZ":
+			info.Purpose = value
+		case "EREF":
+			if value != "" && value != "NOTPROVIDED" {
+				info.addField(RemittanceEndToEndID, value, start, end)
+			}
+		case "MREF":
+			info.addField(RemittanceMandateID, value, start, end)
+		case "KREF":
+			info.addField(RemittanceCustomerReference, value, start, end)
+		case "CRED":
+			if id, err := CreditorID(value).Normalized(); err == nil {
+				info.addField(RemittanceCreditorID, string(id), start, end)
+			}
+		}
+	}
+
+	info.findCreditorReferences()
+	info.findNumbers(invoiceNumberRegexp, RemittanceInvoiceNumber)
+	info.findNumbers(customerNumberRegexp, RemittanceCustomerNumber)
+
+	slices.SortStableFunc(info.Fields, func(a, b RemittanceField) int {
+		return a.Start - b.Start
+	})
+	return info
+}
+
+func (r *RemittanceInfo) addField(kind RemittanceFieldKind, value string, start, end int) {
+	if value == "" {
+		return
+	}
+	r.Fields = append(r.Fields, RemittanceField{Kind: kind, Value: value, Start: start, End: end})
+}
+
+// overlaps returns if the range start to end overlaps
+// with any already found field.
+func (r *RemittanceInfo) overlaps(start, end int) bool {
+	for _, f := range r.Fields {
+		if start < f.End && end > f.Start {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RemittanceInfo) findCreditorReferences() {
+	for _, loc := range creditorReferenceRegexp.FindAllStringIndex(r.Text, -1) {
+		// Find the longest valid reference starting at loc[0]
+		// because the pattern may match following words
+		match := r.Text[loc[0]:loc[1]]
+		for end := len(match); end >= 5; end-- {
+			if unicode.IsSpace(rune(match[end-1])) {
+				continue
+			}
+			if ref, err := NormalizeCreditorReference(match[:end]); err == nil {
+				if !r.overlaps(loc[0], loc[0]+end) {
+					r.addField(RemittanceCreditorReference, ref, loc[0], loc[0]+end)
+				}
+				break
+			}
+		}
+	}
+}
+
+func (r *RemittanceInfo) findNumbers(re *regexp.Regexp, kind RemittanceFieldKind) {
+	for _, loc := range re.FindAllStringSubmatchIndex(r.Text, -1) {
+		// The first value starts at the first submatch,
+		// the following values of a list are found again
+		// in the remaining text of the match
+		start := loc[2]
+		for _, v := range remittanceValueRegexp.FindAllStringIndex(r.Text[start:loc[1]], -1) {
+			vStart, vEnd := start+v[0], start+v[1]
+			for vEnd > vStart && strings.ContainsRune(".-/_", rune(r.Text[vEnd-1])) {
+				vEnd--
+			}
+			if r.overlaps(vStart, vEnd) || !strings.ContainsAny(r.Text[vStart:vEnd], "0123456789") {
+				continue
+			}
+			r.addField(kind, strings.ToUpper(r.Text[vStart:vEnd]), vStart, vEnd)
+		}
+	}
+}
+
+func trimSpaceOffsets(text string, start, end int) (int, int) {
+	for start < end && unicode.IsSpace(rune(text[start])) {
+		start++
+	}
+	for end > start && unicode.IsSpace(rune(text[end-1])) {
+		end--
+	}
+	return start, end
+}
+
+// NormalizeCreditorReference returns an ISO 11649 structured
+// creditor reference like "RF18 5390 0754 7034" in upper case
+// without spaces or an error if the format or the check digits
+// are invalid.
+func NormalizeCreditorReference(ref string) (string, error) {
+	const creditorReferenceCheckSumModulo = 97
+
+	ref = strings.ToUpper(strings.Join(strings.Fields(ref), ""))
+	if len(ref) < 5 || len(ref) > 25 || !strings.HasPrefix(ref, "RF") || !isNum(ref[2]) || !isNum(ref[3]) {
+		return "", errors.New("invalid creditor reference format")
+	}
+	var remainder int
+	for _, c := range []byte(ref[4:] + ref[:4]) {
+		switch {
+		case isNum(c):
+			remainder = (remainder*10 + int(c-'0')) % creditorReferenceCheckSumModulo
+		case isUpperAZ(c):
+			remainder = (remainder*100 + int(c-'A'+10)) % creditorReferenceCheckSumModulo
+		default:
+			return "", errors.New("invalid creditor reference characters")
+		}
+	}
+	if remainder != 1 {
+		return "", errors.New("invalid creditor reference check digits")
+	}
+	return ref, nil
+}
@@ -0,0 +1,63 @@
+package bank
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCreditorReference(t *testing.T) {
+	ref, err := NormalizeCreditorReference("rf18 5390 0754 7034")
+	require.NoError(t, err)
+	require.Equal(t, "RF18539007547034", ref)
+
+	for _, invalid := range []string{"", "RF18", "RF19539007547034", "XX18539007547034", "RF18-5390"} {
+		_, err := NormalizeCreditorReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseRemittanceInfo(t *testing.T) {
+	text := "EREF+E2E-4711 MREF+M-001 CRED+DE98ZZZ09999999999 SVWZ+RE 2024-123, 2024-124 Kd.-Nr. 10042"
+	info := ParseRemittanceInfo(text)
+	require.Equal(t, "RE 2024-123, 2024-124 Kd.-Nr. 10042", info.Purpose)
+	require.Equal(t, []string{"E2E-4711"}, info.Values(RemittanceEndToEndID))
+	require.Equal(t, []string{"M-001"}, info.Values(RemittanceMandateID))
+	require.Equal(t, []string{"DE98ZZZ09999999999"}, info.Values(RemittanceCreditorID))
+	require.Equal(t, []string{"2024-123", "2024-124"}, info.Values(RemittanceInvoiceNumber))
+	require.Equal(t, []string{"10042"}, info.Values(RemittanceCustomerNumber))
+	for _, f := range info.Fields {
+		require.Equal(t, f.Value, text[f.Start:f.End], "offsets of %s", f.Kind)
+	}
+
+	tests := []struct {
+		text string
+		kind RemittanceFieldKind
+		want []string
+	}{
+		{"RF18 5390 0754 7034 Miete Mai", RemittanceCreditorReference, []string{"RF18539007547034"}},
+		{"Zahlung RF18539007547034", RemittanceCreditorReference, []string{"RF18539007547034"}},
+		{"RF19 5390 0754 7034", RemittanceCreditorReference, nil},
+		{"Rechnung Nr. 4711 vom 12.03.2024", RemittanceInvoiceNumber, []string{"4711"}},
+		{"Rechnungsnummer: R-2024/0815.", RemittanceInvoiceNumber, []string{"R-2024/0815"}},
+		{"Rg.-Nr. 123 und 124", RemittanceInvoiceNumber, []string{"123", "124"}},
+		{"Invoice No. inv-2024-001", RemittanceInvoiceNumber, []string{"INV-2024-001"}},
+		{"Rechnung vom 12.03.2024", RemittanceInvoiceNumber, nil},
+		{"RE: Ihre Bestellung", RemittanceInvoiceNumber, nil},
+		{"Kundennummer 987654 Rechnung 55", RemittanceCustomerNumber, []string{"987654"}},
+		{"Customer No: C-77", RemittanceCustomerNumber, []string{"C-77"}},
+		{"EREF+NOTPROVIDED SVWZ+Danke", RemittanceEndToEndID, nil},
+		{"CRED+DE00ZZZ09999999999", RemittanceCreditorID, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			info := ParseRemittanceInfo(tt.text)
+			assert.Equal(t, tt.want, info.Values(tt.kind))
+			for _, f := range info.Fields {
+				assert.Equal(t, f.Value, strings.ToUpper(strings.Join(strings.Fields(tt.text[f.Start:f.End]), "")))
+			}
+		})
+	}
+}
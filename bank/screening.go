@@ -0,0 +1,202 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/domonda/go-errs"
+
+	"github.com/domonda/go-types/strutil"
+)
+
+// ErrAccountBlocked is returned by ValidateForPayment
+// if an AccountScreener blocked the account.
+const ErrAccountBlocked errs.Sentinel = "bank account blocked by screening"
+
+// ScreeningStatus is the outcome of screening a bank account
+// against sanction lists or blacklists.
+// Higher values are more severe.
+type ScreeningStatus int
+
+const (
+	// ScreeningClear means no screening list matched.
+	ScreeningClear ScreeningStatus = iota
+	// ScreeningReview means a possible match was found
+	// that has to be reviewed before paying.
+	ScreeningReview
+	// ScreeningBlocked means the account must not be paid.
+	ScreeningBlocked
+)
+
+// String implements the fmt.Stringer interface.
+func (s ScreeningStatus) String() string {
+	switch s {
+	case ScreeningClear:
+		return "CLEAR"
+	case ScreeningReview:
+		return "REVIEW"
+	case ScreeningBlocked:
+		return "BLOCKED"
+	default:
+		return fmt.Sprintf("ScreeningStatus(%d)", int(s))
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ScreeningStatus) MarshalText() ([]byte, error) {
+	if s < ScreeningClear || s > ScreeningBlocked {
+		return nil, fmt.Errorf("invalid bank.ScreeningStatus %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ScreeningStatus) UnmarshalText(text []byte) error {
+	for status := ScreeningClear; status <= ScreeningBlocked; status++ {
+		if strings.EqualFold(string(text), status.String()) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid bank.ScreeningStatus %q", text)
+}
+
+// ScreeningHit is a match of a bank account on a screening list.
+type ScreeningHit struct {
+	// List is the name of the sanction list or blacklist.
+	List   string          `json:"list"`
+	Status ScreeningStatus `json:"status"`
+	// Reason describes what matched, like the IBAN or the holder name.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ScreeningResult is the combined result of one or more
+// AccountScreener implementations.
+// The zero value is a clear result without hits.
+type ScreeningResult struct {
+	// Status is the most severe Status of the Hits.
+	Status ScreeningStatus `json:"status"`
+	Hits   []ScreeningHit  `json:"hits,omitempty"`
+}
+
+// Add adds a hit and raises the Status of the result
+// if the hit is more severe.
+func (r *ScreeningResult) Add(hit ScreeningHit) {
+	r.Hits = append(r.Hits, hit)
+	r.Status = max(r.Status, hit.Status)
+}
+
+// Merge adds the hits of other to the result
+// and raises the Status to the Status of other if more severe.
+func (r *ScreeningResult) Merge(other ScreeningResult) {
+	r.Hits = append(r.Hits, other.Hits...)
+	r.Status = max(r.Status, other.Status)
+}
+
+// Clear returns true if the Status is ScreeningClear.
+func (r ScreeningResult) Clear() bool {
+	return r.Status == ScreeningClear
+}
+
+// Blocked returns true if the Status is ScreeningBlocked.
+func (r ScreeningResult) Blocked() bool {
+	return r.Status >= ScreeningBlocked
+}
+
+// Err returns an error wrapping ErrAccountBlocked
+// with the reasons of the blocking hits if the result is blocked,
+// else nil.
+func (r ScreeningResult) Err() error {
+	if !r.Blocked() {
+		return nil
+	}
+	var reasons []string
+	for _, hit := range r.Hits {
+		if hit.Status == ScreeningBlocked {
+			reasons = append(reasons, strings.TrimSpace(hit.List+" "+hit.Reason))
+		}
+	}
+	if len(reasons) == 0 {
+		return ErrAccountBlocked
+	}
+	return fmt.Errorf("%w: %s", ErrAccountBlocked, strings.Join(reasons, "; "))
+}
+
+// AccountScreener is implemented by services that check
+// bank accounts against sanction lists or blacklists.
+//
+// The passed iban is normalized and valid, bic is normalized
+// and valid or null, and name is the trimmed account holder name.
+// An error should only be returned if the screening
+// could not be performed, not for matches.
+type AccountScreener interface {
+	ScreenAccount(ctx context.Context, iban IBAN, bic NullableBIC, name string) (ScreeningResult, error)
+}
+
+// AccountScreenerFunc implements AccountScreener with a function.
+type AccountScreenerFunc func(ctx context.Context, iban IBAN, bic NullableBIC, name string) (ScreeningResult, error)
+
+// ScreenAccount implements AccountScreener.
+func (f AccountScreenerFunc) ScreenAccount(ctx context.Context, iban IBAN, bic NullableBIC, name string) (ScreeningResult, error) {
+	return f(ctx, iban, bic, name)
+}
+
+// AccountScreeners implements AccountScreener by calling
+// all screeners and merging their results.
+// Errors of the screeners are joined and
+// the merged result of the successful screeners is returned.
+type AccountScreeners []AccountScreener
+
+// ScreenAccount implements AccountScreener.
+func (s AccountScreeners) ScreenAccount(ctx context.Context, iban IBAN, bic NullableBIC, name string) (result ScreeningResult, err error) {
+	var errList []error
+	for _, screener := range s {
+		if err = ctx.Err(); err != nil {
+			return result, errors.Join(append(errList, err)...)
+		}
+		r, screenErr := screener.ScreenAccount(ctx, iban, bic, name)
+		if screenErr != nil {
+			errList = append(errList, screenErr)
+			continue
+		}
+		result.Merge(r)
+	}
+	return result, errors.Join(errList...)
+}
+
+// ValidateForPayment validates and normalizes the IBAN and
+// optional BIC of a payment receiver and checks that the holder name
+// is not empty, then screens the account with the passed screener
+// which can be nil to skip screening.
+//
+// The screening result is returned together with an error
+// wrapping ErrAccountBlocked if the account is blocked.
+// A result with ScreeningReview status is returned without error,
+// callers have to check for it to hold the payment for review.
+func ValidateForPayment(ctx context.Context, screener AccountScreener, iban IBAN, bic NullableBIC, name string) (result ScreeningResult, err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, screener, iban, bic, name)
+
+	iban, err = iban.Normalized()
+	if err != nil {
+		return ScreeningResult{}, err
+	}
+	bic, err = bic.Normalized()
+	if err != nil {
+		return ScreeningResult{}, err
+	}
+	name = strutil.TrimSpace(name)
+	if name == "" {
+		return ScreeningResult{}, errors.New("missing bank account holder name")
+	}
+	if screener == nil {
+		return ScreeningResult{}, nil
+	}
+
+	result, err = screener.ScreenAccount(ctx, iban, bic, name)
+	if err != nil {
+		return result, err
+	}
+	return result, result.Err()
+}
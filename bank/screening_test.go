@@ -0,0 +1,71 @@
+package bank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForPayment(t *testing.T) {
+	ctx := context.Background()
+	sanctions := AccountScreenerFunc(func(ctx context.Context, iban IBAN, bic NullableBIC, name string) (ScreeningResult, error) {
+		var result ScreeningResult
+		if iban == "DE89370400440532013000" {
+			result.Add(ScreeningHit{List: "Sanctions", Status: ScreeningBlocked, Reason: "IBAN " + string(iban)})
+		}
+		return result, nil
+	})
+	names := AccountScreenerFunc(func(ctx context.Context, iban IBAN, bic NullableBIC, name string) (ScreeningResult, error) {
+		var result ScreeningResult
+		if name == "John Doe" {
+			result.Add(ScreeningHit{List: "Names", Status: ScreeningReview, Reason: "similar name"})
+		}
+		return result, nil
+	})
+	screeners := AccountScreeners{sanctions, names}
+
+	result, err := ValidateForPayment(ctx, screeners, "AT61 1904 3002 3457 3201", "", "Jane Doe")
+	require.NoError(t, err)
+	require.True(t, result.Clear())
+
+	result, err = ValidateForPayment(ctx, screeners, "AT611904300234573201", "", " John Doe ")
+	require.NoError(t, err)
+	require.Equal(t, ScreeningReview, result.Status)
+	require.Len(t, result.Hits, 1)
+
+	result, err = ValidateForPayment(ctx, screeners, "DE89 3704 0044 0532 0130 00", "COBADEFFXXX", "John Doe")
+	require.ErrorIs(t, err, ErrAccountBlocked)
+	require.ErrorContains(t, err, "Sanctions IBAN DE89370400440532013000")
+	require.True(t, result.Blocked())
+	require.Len(t, result.Hits, 2)
+
+	_, err = ValidateForPayment(ctx, nil, "DE89370400440532013001", "", "John Doe")
+	require.Error(t, err, "invalid IBAN")
+	_, err = ValidateForPayment(ctx, nil, "DE89370400440532013000", "INVALID", "John Doe")
+	require.Error(t, err, "invalid BIC")
+	_, err = ValidateForPayment(ctx, nil, "DE89370400440532013000", "", " ")
+	require.Error(t, err, "missing name")
+
+	failing := AccountScreenerFunc(func(context.Context, IBAN, NullableBIC, string) (ScreeningResult, error) {
+		return ScreeningResult{}, errors.New("service unavailable")
+	})
+	result, err = ValidateForPayment(ctx, AccountScreeners{names, failing}, "AT611904300234573201", "", "John Doe")
+	require.ErrorContains(t, err, "service unavailable")
+	require.Equal(t, ScreeningReview, result.Status)
+}
+
+func TestScreeningResult_JSON(t *testing.T) {
+	result := ScreeningResult{}
+	result.Merge(ScreeningResult{Status: ScreeningReview, Hits: []ScreeningHit{{List: "PEP", Status: ScreeningReview}}})
+	j, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.Equal(t, `{"status":"REVIEW","hits":[{"list":"PEP","status":"REVIEW"}]}`, string(j))
+
+	var parsed ScreeningResult
+	require.NoError(t, json.Unmarshal(j, &parsed))
+	require.Equal(t, result, parsed)
+	require.Error(t, json.Unmarshal([]byte(`{"status":"UNKNOWN"}`), &parsed))
+}
@@ -0,0 +1,139 @@
+package bank
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+)
+
+// SEPAScheme is a SEPA payment scheme.
+type SEPAScheme string
+
+const (
+	// SEPACreditTransfer is the SEPA Credit Transfer scheme (SCT)
+	// settled on the next TARGET business day.
+	SEPACreditTransfer SEPAScheme = "SCT"
+	// SEPAInstantCreditTransfer is the SEPA Instant Credit Transfer
+	// scheme (SCT Inst) settled within seconds on every day of the year.
+	SEPAInstantCreditTransfer SEPAScheme = "SCT_INST"
+	// SEPADirectDebitCore is the SEPA Core Direct Debit scheme (SDD Core)
+	// that requires submission one TARGET business day before the due date.
+	SEPADirectDebitCore SEPAScheme = "SDD_CORE"
+	// SEPADirectDebitB2B is the SEPA Business to Business Direct Debit
+	// scheme (SDD B2B) that requires submission one TARGET business day
+	// before the due date.
+	SEPADirectDebitB2B SEPAScheme = "SDD_B2B"
+)
+
+// Valid returns if the scheme is one of the defined SEPAScheme constants.
+func (s SEPAScheme) Valid() bool {
+	switch s {
+	case SEPACreditTransfer, SEPAInstantCreditTransfer, SEPADirectDebitCore, SEPADirectDebitB2B:
+		return true
+	}
+	return false
+}
+
+// TARGET2Calendar is a date.HolidayCalendar with the closing days
+// of the TARGET2 payment system of the Eurosystem:
+// New Year's Day, Good Friday, Easter Monday,
+// 1 May (Labour Day), Christmas Day, and 26 December.
+// Weekends are always closing days.
+var TARGET2Calendar date.HolidayCalendar = date.HolidayFunc(isTARGET2Holiday)
+
+func isTARGET2Holiday(d date.Date) bool {
+	year, month, day := d.YearMonthDay()
+	switch {
+	case month == time.January && day == 1,
+		month == time.May && day == 1,
+		month == time.December && (day == 25 || day == 26):
+		return true
+	}
+	return d == date.GoodFriday(year) || d == date.EasterMonday(year)
+}
+
+// SEPAExecutionCalendar calculates settlement dates of SEPA payments.
+type SEPAExecutionCalendar struct {
+	// Calendar has the closing days in addition to weekends.
+	Calendar date.HolidayCalendar
+	// Cutoffs are the latest times of a business day in
+	// Central European Time for a submission to be processed
+	// the same day per scheme. Schemes without a cutoff
+	// are processed the same business day at any time.
+	// The instant scheme ignores cutoffs.
+	Cutoffs map[SEPAScheme]date.TimeOfDay
+}
+
+// DefaultSEPAExecutionCalendar uses the TARGET2Calendar
+// and cutoff times common for German banks.
+// Use an own SEPAExecutionCalendar with the cutoff times
+// of a bank for exact results.
+var DefaultSEPAExecutionCalendar = SEPAExecutionCalendar{
+	Calendar: TARGET2Calendar,
+	Cutoffs: map[SEPAScheme]date.TimeOfDay{
+		SEPACreditTransfer:  date.TimeOfDayOf(15, 0, 0),
+		SEPADirectDebitCore: date.TimeOfDayOf(15, 0, 0),
+		SEPADirectDebitB2B:  date.TimeOfDayOf(15, 0, 0),
+	},
+}
+
+// NextSEPAExecutionDate returns the earliest settlement date
+// of a SEPA payment submitted at the passed time
+// using the DefaultSEPAExecutionCalendar.
+// See SEPAExecutionCalendar.NextExecutionDate.
+func NextSEPAExecutionDate(submission time.Time, scheme SEPAScheme, currency money.Currency) (date.Date, error) {
+	return DefaultSEPAExecutionCalendar.NextExecutionDate(submission, scheme, currency)
+}
+
+// NextExecutionDate returns the earliest settlement date
+// of a SEPA payment submitted at the passed time
+// which is the date when the funds arrive at the payee's bank.
+//
+// The submission time is converted to Central European Time.
+// Submissions after the cutoff time of the scheme or on a closing day
+// count as received on the next business day.
+// Credit transfers and direct debits settle on the business day
+// after the receipt, instant credit transfers on the day of submission.
+//
+// Returns an error for an invalid scheme
+// or a currency other than EUR.
+func (c *SEPAExecutionCalendar) NextExecutionDate(submission time.Time, scheme SEPAScheme, currency money.Currency) (date.Date, error) {
+	if !scheme.Valid() {
+		return "", fmt.Errorf("invalid SEPA scheme %q", scheme)
+	}
+	if norm, err := currency.Normalized(); err != nil || norm != money.EUR {
+		return "", fmt.Errorf("SEPA payments must be in EUR, got %q", currency)
+	}
+	if submission.IsZero() {
+		return "", errors.New("zero SEPA submission time")
+	}
+	submission = centralEuropeanTime(submission)
+	if scheme == SEPAInstantCreditTransfer {
+		return date.OfTime(submission), nil
+	}
+	return date.ComputeDeadline(submission, 1, c.Cutoffs[scheme], nil, c.Calendar), nil
+}
+
+// centralEuropeanTime returns t in Central European Time
+// with daylight saving time according to the EU rules
+// without depending on the time zone database:
+// CEST from 01:00 UTC of the last Sunday in March
+// until 01:00 UTC of the last Sunday in October.
+func centralEuropeanTime(t time.Time) time.Time {
+	t = t.UTC()
+	year := t.Year()
+	dstStart := lastSundayUTC(year, time.March).Add(time.Hour)
+	dstEnd := lastSundayUTC(year, time.October).Add(time.Hour)
+	if !t.Before(dstStart) && t.Before(dstEnd) {
+		return t.In(time.FixedZone("CEST", 2*60*60))
+	}
+	return t.In(time.FixedZone("CET", 1*60*60))
+}
+
+func lastSundayUTC(year int, month time.Month) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	return last.AddDate(0, 0, -int(last.Weekday()))
+}
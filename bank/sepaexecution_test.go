@@ -0,0 +1,81 @@
+package bank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+)
+
+func TestTARGET2Calendar(t *testing.T) {
+	for _, d := range []date.Date{"2025-01-01", "2025-04-18", "2025-04-21", "2025-05-01", "2025-12-25", "2025-12-26"} {
+		require.True(t, TARGET2Calendar.IsHoliday(d), d)
+	}
+	for _, d := range []date.Date{"2025-01-02", "2025-04-17", "2025-10-03", "2025-12-24", "2025-12-31"} {
+		require.False(t, TARGET2Calendar.IsHoliday(d), d)
+	}
+}
+
+func TestNextSEPAExecutionDate(t *testing.T) {
+	utc := func(s string) time.Time {
+		t.Helper()
+		tm, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return tm
+	}
+	tests := []struct {
+		name       string
+		submission time.Time
+		scheme     SEPAScheme
+		want       date.Date
+	}{
+		{name: "SCT before cutoff", submission: utc("2025-03-12T10:00:00Z"), scheme: SEPACreditTransfer, want: "2025-03-13"},
+		// 14:30 UTC is 15:30 CET
+		{name: "SCT after cutoff in winter", submission: utc("2025-03-12T14:30:00Z"), scheme: SEPACreditTransfer, want: "2025-03-14"},
+		// 13:30 UTC is 15:30 CEST
+		{name: "SCT after cutoff in summer", submission: utc("2025-07-09T13:30:00Z"), scheme: SEPACreditTransfer, want: "2025-07-11"},
+		{name: "SCT before cutoff in summer", submission: utc("2025-07-09T12:30:00Z"), scheme: SEPACreditTransfer, want: "2025-07-10"},
+		{name: "SCT Friday after cutoff", submission: utc("2025-03-14T16:00:00Z"), scheme: SEPACreditTransfer, want: "2025-03-18"},
+		{name: "SCT Saturday", submission: utc("2025-03-15T09:00:00Z"), scheme: SEPACreditTransfer, want: "2025-03-18"},
+		{name: "SCT over Easter", submission: utc("2025-04-17T09:00:00Z"), scheme: SEPACreditTransfer, want: "2025-04-22"},
+		{name: "SCT Christmas", submission: utc("2025-12-24T09:00:00Z"), scheme: SEPACreditTransfer, want: "2025-12-29"},
+		{name: "SDD Core", submission: utc("2025-12-30T09:00:00Z"), scheme: SEPADirectDebitCore, want: "2025-12-31"},
+		{name: "SDD B2B New Year", submission: utc("2025-12-31T09:00:00Z"), scheme: SEPADirectDebitB2B, want: "2026-01-02"},
+		{name: "SCT Inst holiday", submission: utc("2025-12-25T20:00:00Z"), scheme: SEPAInstantCreditTransfer, want: "2025-12-25"},
+		// 23:30 UTC is 00:30 CET of the next day
+		{name: "SCT Inst CET midnight", submission: utc("2025-12-31T23:30:00Z"), scheme: SEPAInstantCreditTransfer, want: "2026-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextSEPAExecutionDate(tt.submission, tt.scheme, money.EUR)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := NextSEPAExecutionDate(time.Now(), SEPACreditTransfer, money.USD)
+	require.Error(t, err)
+	_, err = NextSEPAExecutionDate(time.Now(), "SWIFT", money.EUR)
+	require.Error(t, err)
+	_, err = NextSEPAExecutionDate(time.Time{}, SEPACreditTransfer, money.EUR)
+	require.Error(t, err)
+	got, err := NextSEPAExecutionDate(utc("2025-03-12T10:00:00Z"), SEPACreditTransfer, "eur")
+	require.NoError(t, err)
+	require.Equal(t, date.Date("2025-03-13"), got)
+}
+
+func TestCentralEuropeanTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	start := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	for tm := start; tm.Year() < 2027; tm = tm.Add(30 * time.Minute) {
+		_, wantOffset := tm.In(berlin).Zone()
+		_, gotOffset := centralEuropeanTime(tm).Zone()
+		require.Equal(t, wantOffset, gotOffset, tm)
+	}
+}
@@ -0,0 +1,204 @@
+package bank
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/strutil"
+)
+
+// IBANContextKeywords are the keywords that indicate
+// an IBAN when found near a match of FindIBANs.
+var IBANContextKeywords = []string{
+	"IBAN",
+	"Konto",
+	"Bankverbindung",
+	"Bankkonto",
+	"Account",
+	"Bank details",
+}
+
+// BICContextKeywords are the keywords that indicate
+// a BIC when found near a match of FindBICs.
+var BICContextKeywords = []string{
+	"BIC",
+	"SWIFT",
+	"Bankverbindung",
+	"Bank",
+}
+
+// contextWindow is the maximum number of bytes before and after
+// a match on the same line that are searched for context keywords.
+const contextWindow = 40
+
+// IBANMatch is an IBAN found in a text by FindIBANs.
+type IBANMatch struct {
+	// IBAN is the match without spaces.
+	IBAN IBAN `json:"iban"`
+	// Start is the byte offset of the match in the text.
+	Start int `json:"start"`
+	// End is the byte offset after the match in the text.
+	End int `json:"end"`
+	// CheckSumValid is true if the check digits of the IBAN are valid.
+	CheckSumValid bool `json:"checkSumValid"`
+	// Valid is true if the IBAN passes all validations
+	// including the checksum and the country specific BBAN structure.
+	Valid bool `json:"valid"`
+	// Context are the IBANContextKeywords found near the match.
+	Context []string `json:"context,omitempty"`
+}
+
+// BICMatch is a BIC found in a text by FindBICs.
+type BICMatch struct {
+	// BIC is the match as found in the text.
+	BIC BIC `json:"bic"`
+	// Start is the byte offset of the match in the text.
+	Start int `json:"start"`
+	// End is the byte offset after the match in the text.
+	End int `json:"end"`
+	// Context are the BICContextKeywords found near the match.
+	Context []string `json:"context,omitempty"`
+}
+
+// FindIBANs returns all IBAN candidates in a text
+// with the length of their country's IBAN format,
+// also when written in groups separated by single spaces
+// like "DE89 3704 0044 0532 0130 00".
+//
+// Candidates with an invalid checksum are returned too
+// with CheckSumValid set to false, so typos can be reported.
+// Matches with Context keywords like "IBAN:" before or after
+// them on the same line are more likely to be intended as IBAN.
+func FindIBANs(text string) []IBANMatch {
+	var matches []IBANMatch
+	for i := 0; i+IBANMinLength <= len(text); i++ {
+		if !isUpperAZ(text[i]) || !isUpperAZ(text[i+1]) || !isNum(text[i+2]) || !isNum(text[i+3]) {
+			continue
+		}
+		if !isWordStart(text, i) {
+			continue
+		}
+		format := ibanCountryFormat(country.Code(text[i : i+2]))
+		if format == nil {
+			continue
+		}
+		iban, end := scanSpacedIBAN(text, i, format.Length)
+		if iban == "" {
+			continue
+		}
+		_, err := iban.Normalized()
+		matches = append(matches, IBANMatch{
+			IBAN:          iban,
+			Start:         i,
+			End:           end,
+			CheckSumValid: iban.isCheckSumValid(),
+			Valid:         err == nil,
+			Context:       findContextKeywords(text, i, end, IBANContextKeywords),
+		})
+		i = end - 1
+	}
+	return matches
+}
+
+// scanSpacedIBAN collects length upper case letters and digits
+// from text starting at start allowing single spaces between them.
+// Returns an empty IBAN if there are not enough characters
+// or if the characters continue after length.
+func scanSpacedIBAN(text string, start, length int) (iban IBAN, end int) {
+	var b strings.Builder
+	b.Grow(length)
+	end = start
+	for end < len(text) && b.Len() < length {
+		c := text[end]
+		switch {
+		case isUpperAZ0to9(c):
+			b.WriteByte(c)
+		case c == ' ' && end+1 < len(text) && isUpperAZ0to9(text[end+1]) && b.Len() > 0:
+			// Single space between groups
+		default:
+			return "", 0
+		}
+		end++
+	}
+	if b.Len() < length || !isWordEnd(text, end) {
+		return "", 0
+	}
+	return IBAN(b.String()), end
+}
+
+// FindBICs returns all valid BICs in a text
+// that are surrounded by word separators.
+// Matches with Context keywords like "BIC:" before or after
+// them on the same line are more likely to be intended as BIC
+// and not just an upper case word like "RECHNUNG".
+func FindBICs(text string) []BICMatch {
+	indices := BICFinder.FindAllIndex([]byte(text), -1)
+	if len(indices) == 0 {
+		return nil
+	}
+	matches := make([]BICMatch, len(indices))
+	for i, index := range indices {
+		matches[i] = BICMatch{
+			BIC:     BIC(text[index[0]:index[1]]),
+			Start:   index[0],
+			End:     index[1],
+			Context: findContextKeywords(text, index[0], index[1], BICContextKeywords),
+		}
+	}
+	return matches
+}
+
+// findContextKeywords returns the keywords found case-insensitive
+// at the start of a word within contextWindow bytes
+// before start or after end of a match in text
+// on the same line as the match.
+func findContextKeywords(text string, start, end int, keywords []string) (found []string) {
+	before := text[max(0, start-contextWindow):start]
+	if i := strings.LastIndexByte(before, '\n'); i >= 0 {
+		before = before[i+1:]
+	}
+	after := text[end:min(len(text), end+contextWindow)]
+	if i := strings.IndexByte(after, '\n'); i >= 0 {
+		after = after[:i]
+	}
+	before, after = strings.ToLower(before), strings.ToLower(after)
+	for _, keyword := range keywords {
+		lower := strings.ToLower(keyword)
+		if containsWord(before, lower) || containsWord(after, lower) {
+			found = append(found, keyword)
+		}
+	}
+	return found
+}
+
+// containsWord returns true if word is found
+// at the start of a word in text.
+func containsWord(text, word string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		if isWordStart(text, offset+i) {
+			return true
+		}
+		offset += i + 1
+	}
+}
+
+func isWordStart(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return strutil.IsWordSeparator(r) || r == utf8.RuneError
+}
+
+func isWordEnd(text string, i int) bool {
+	if i == len(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return strutil.IsWordSeparator(r) || r == utf8.RuneError
+}
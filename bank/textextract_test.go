@@ -0,0 +1,53 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindIBANs(t *testing.T) {
+	text := "Bankverbindung Sparkasse IBAN: DE89 3704 0044 0532 0130 00 BIC: COBADEFFXXX\n" +
+		"Alt: AT611904300234573201, Tippfehler DE89370400440532013001.\n" +
+		"Bestellnummer XDE89370400440532013000 DE8937040044"
+	matches := FindIBANs(text)
+	require.Len(t, matches, 3)
+
+	assert.Equal(t, IBAN("DE89370400440532013000"), matches[0].IBAN)
+	assert.Equal(t, "DE89 3704 0044 0532 0130 00", text[matches[0].Start:matches[0].End])
+	assert.True(t, matches[0].CheckSumValid)
+	assert.True(t, matches[0].Valid)
+	assert.Equal(t, []string{"IBAN", "Bankverbindung"}, matches[0].Context)
+
+	assert.Equal(t, IBAN("AT611904300234573201"), matches[1].IBAN)
+	assert.Equal(t, "AT611904300234573201", text[matches[1].Start:matches[1].End])
+	assert.True(t, matches[1].Valid)
+	assert.Empty(t, matches[1].Context)
+
+	assert.Equal(t, IBAN("DE89370400440532013001"), matches[2].IBAN)
+	assert.False(t, matches[2].CheckSumValid)
+	assert.False(t, matches[2].Valid)
+
+	assert.Nil(t, FindIBANs(""))
+	assert.Nil(t, FindIBANs("DE89  3704 0044 0532 0130 00"), "double space")
+}
+
+func TestFindBICs(t *testing.T) {
+	text := "IBAN: DE89370400440532013000\nBIC: COBADEFFXXX\nRECHNUNG GENODEF1M04"
+	matches := FindBICs(text)
+	require.Len(t, matches, 3)
+
+	assert.Equal(t, BIC("COBADEFFXXX"), matches[0].BIC)
+	assert.Equal(t, "COBADEFFXXX", text[matches[0].Start:matches[0].End])
+	assert.Equal(t, []string{"BIC"}, matches[0].Context)
+
+	// Upper case word with valid BIC format but without context
+	assert.Equal(t, "RECHNUNG", text[matches[1].Start:matches[1].End])
+	assert.Empty(t, matches[1].Context)
+
+	assert.Equal(t, BIC("GENODEF1M04"), matches[2].BIC)
+	assert.Empty(t, matches[2].Context)
+
+	assert.Nil(t, FindBICs("no bank data"))
+}
@@ -0,0 +1,115 @@
+package charset
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// maxMojibakeRepairPasses limits how many layers
+// of repeated double encoding are reversed.
+const maxMojibakeRepairPasses = 3
+
+// mojibakeRuneToByte maps the runes that result from decoding
+// the bytes 0x80 to 0xFF as Windows-1252 or ISO 8859-1
+// back to their byte values.
+var mojibakeRuneToByte = func() map[rune]byte {
+	m := make(map[rune]byte, 2*128)
+	for b := 0x80; b <= 0xFF; b++ {
+		// ISO 8859-1 maps all bytes to the same code points
+		m[rune(b)] = byte(b)
+	}
+	for b := 0x80; b <= 0xFF; b++ {
+		if r := charmap.Windows1252.DecodeByte(byte(b)); r != utf8.RuneError {
+			m[r] = byte(b)
+		}
+	}
+	return m
+}()
+
+// RepairMojibake detects and reverses the double encoding artifacts
+// of UTF-8 text that was decoded as Windows-1252 or ISO 8859-1,
+// like "Ã¤" for "ä" or "â‚¬" for "€", also when the text was
+// double encoded multiple times or contains correctly encoded parts.
+//
+// The returned confidence in the range 0 to 1 is the fraction
+// of the non ASCII characters of str that were part of repaired
+// sequences. It is 0 if nothing was repaired, 1 if all non ASCII
+// characters were mojibake, and values in between indicate mixed
+// content where the repair might be a false positive,
+// so callers should only use the repaired string above
+// a confidence threshold fitting their data.
+func RepairMojibake(str string) (repaired string, confidence float64) {
+	nonASCII := 0
+	for _, r := range str {
+		if r >= utf8.RuneSelf {
+			nonASCII++
+		}
+	}
+	if nonASCII == 0 {
+		return str, 0
+	}
+
+	repaired = str
+	for pass := 0; pass < maxMojibakeRepairPasses; pass++ {
+		next, covered := repairMojibakePass(repaired)
+		if covered == 0 {
+			break
+		}
+		if pass == 0 {
+			confidence = float64(covered) / float64(nonASCII)
+		}
+		repaired = next
+	}
+	return repaired, confidence
+}
+
+// repairMojibakePass decodes all byte sequences of runes
+// from mojibakeRuneToByte that form valid multi-byte UTF-8
+// characters and returns the number of runes that were replaced.
+func repairMojibakePass(str string) (repaired string, covered int) {
+	var (
+		b     strings.Builder
+		run   []byte
+		runes []rune
+	)
+	flush := func() {
+		for i := 0; i < len(run); {
+			r, size := utf8.DecodeRune(run[i:])
+			if size > 1 && r != utf8.RuneError && !isC1Control(r) {
+				b.WriteRune(r)
+				covered += size
+				i += size
+				continue
+			}
+			b.WriteRune(runes[i])
+			i++
+		}
+		run = run[:0]
+		runes = runes[:0]
+	}
+
+	for _, r := range str {
+		if c, ok := mojibakeRuneToByte[r]; ok {
+			run = append(run, c)
+			runes = append(runes, r)
+			continue
+		}
+		if len(run) > 0 {
+			flush()
+		}
+		b.WriteRune(r)
+	}
+	if len(run) > 0 {
+		flush()
+	}
+	if covered == 0 {
+		return str, 0
+	}
+	return b.String(), covered
+}
+
+func isC1Control(r rune) bool {
+	return r >= 0x80 && r <= 0x9F
+}
@@ -0,0 +1,33 @@
+package charset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairMojibake(t *testing.T) {
+	tests := []struct {
+		str        string
+		repaired   string
+		confidence float64
+	}{
+		{str: "", repaired: "", confidence: 0},
+		{str: "Invoice 2024", repaired: "Invoice 2024", confidence: 0},
+		{str: "Größe für Übergänge", repaired: "Größe für Übergänge", confidence: 0},
+		{str: "GrÃ¶ÃŸe fÃ¼r ÃœbergÃ¤nge", repaired: "Größe für Übergänge", confidence: 1},
+		{str: "Preis: 10 â‚¬", repaired: "Preis: 10 €", confidence: 1},
+		{str: "â€žZitatâ€œ â€“ Ende", repaired: "„Zitat“ – Ende", confidence: 1},
+		{str: "CafÃ© Ã\u00a0 Paris", repaired: "Café à Paris", confidence: 1},
+		{str: "ÃƒÂ¤", repaired: "ä", confidence: 1},
+		{str: "MÃ¼ller and Müller", repaired: "Müller and Müller", confidence: 2.0 / 3},
+		{str: "日本 Ã¤", repaired: "日本 ä", confidence: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			repaired, confidence := RepairMojibake(tt.str)
+			require.Equal(t, tt.repaired, repaired)
+			require.InDelta(t, tt.confidence, confidence, 0.0001)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package types
+
+import "errors"
+
+// ErrorCode is a stable, machine readable identifier of an error
+// like "bank.iban.checksum" that can be used as key
+// to look up localized error messages.
+type ErrorCode string
+
+// CodedError is an error with a stable ErrorCode
+// and named parameters for localized message templates.
+//
+// Error returns the English default Message so that
+// a CodedError can replace a plain error without changing
+// the error string. Unwrap returns Err to keep
+// errors.Is working for sentinel errors.
+type CodedError struct {
+	// Code is the stable identifier of the error
+	Code ErrorCode
+	// Params are the named parameters of the error
+	// that can be used in message templates like "value".
+	Params map[string]any
+	// Message is the English default error message
+	Message string
+	// Err is an optional wrapped error
+	Err error
+}
+
+// NewCodedError returns a new CodedError.
+// Params are passed as alternating name and value pairs,
+// a trailing name without value is ignored.
+func NewCodedError(code ErrorCode, message string, params ...any) *CodedError {
+	err := &CodedError{Code: code, Message: message}
+	if len(params) > 1 {
+		err.Params = make(map[string]any, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			name, _ := params[i].(string)
+			err.Params[name] = params[i+1]
+		}
+	}
+	return err
+}
+
+// Wrap returns a copy of the error wrapping err.
+func (e *CodedError) Wrap(err error) *CodedError {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// Error implements the error interface by returning the default message.
+func (e *CodedError) Error() string {
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the wrapped error or nil.
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is a *CodedError with the same Code
+// so that errors.Is can be used with a CodedError
+// without parameters as code sentinel.
+func (e *CodedError) Is(target error) bool {
+	t, ok := target.(*CodedError)
+	return ok && t.Code == e.Code
+}
+
+// ErrorCodeOf returns the Code and Params of the first
+// CodedError in the tree of err or false if there is none.
+func ErrorCodeOf(err error) (code ErrorCode, params map[string]any, ok bool) {
+	var coded *CodedError
+	if !errors.As(err, &coded) {
+		return "", nil, false
+	}
+	return coded.Code, coded.Params, true
+}
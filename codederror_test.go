@@ -0,0 +1,32 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodedError(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	err := NewCodedError("test.code", "test message", "value", "abc", "length", 3, "ignored").Wrap(sentinel)
+	require.Equal(t, "test message", err.Error())
+	require.Equal(t, map[string]any{"value": "abc", "length": 3}, err.Params)
+	require.ErrorIs(t, err, sentinel)
+	require.ErrorIs(t, err, NewCodedError("test.code", ""), "same code")
+	require.NotErrorIs(t, err, NewCodedError("other.code", ""))
+
+	wrapped := fmt.Errorf("context: %w", err)
+	code, params, ok := ErrorCodeOf(wrapped)
+	require.True(t, ok)
+	require.Equal(t, ErrorCode("test.code"), code)
+	require.Equal(t, "abc", params["value"])
+
+	_, _, ok = ErrorCodeOf(sentinel)
+	require.False(t, ok)
+	_, _, ok = ErrorCodeOf(nil)
+	require.False(t, ok)
+
+	require.Equal(t, "sentinel", (&CodedError{Code: "x", Err: sentinel}).Error(), "message from wrapped error")
+}
@@ -5,6 +5,7 @@
 // - ISO 3166-1 alpha-2 country code validation and normalization
 // - Alternative country code mappings (ITU codes, German names, etc.)
 // - European Union membership checking
+// - IANA time zones of countries
 // - Database integration (Scanner/Valuer interfaces)
 // - JSON marshalling/unmarshalling
 // - Nullable country code support
@@ -0,0 +1,14 @@
+package country
+
+import "github.com/domonda/go-types"
+
+// Policy evaluates country codes against
+// sets of allowed and denied countries.
+// See types.AllowDenyPolicy.
+type Policy = types.AllowDenyPolicy[Code]
+
+// NewPolicy returns a Policy with the normalized allowed
+// and denied country codes or an error if a code is not valid.
+func NewPolicy(allow, deny []Code) (Policy, error) {
+	return types.NewAllowDenyPolicy(allow, deny)
+}
@@ -0,0 +1,45 @@
+package country
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := NewPolicy([]Code{"de", "AUT", "CH"}, []Code{"ch"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.PolicyAllowed, policy.Evaluate(DE))
+	assert.Equal(t, types.PolicyAllowed, policy.Evaluate("at"))
+	assert.Equal(t, types.PolicyDenied, policy.Evaluate(CH), "deny takes precedence")
+	assert.Equal(t, types.PolicyNotAllowed, policy.Evaluate(FR))
+	assert.Equal(t, types.PolicyInvalid, policy.Evaluate("XX"))
+
+	var zero Policy
+	assert.True(t, zero.Allows(FR), "zero policy allows all valid codes")
+	assert.False(t, zero.Allows("XX"))
+
+	_, err = NewPolicy([]Code{"XX"}, nil)
+	assert.Error(t, err)
+}
+
+func TestPolicy_JSON(t *testing.T) {
+	var policy Policy
+	err := json.Unmarshal([]byte(`{"allow":["de","AT"],"deny":["RU"]}`), &policy)
+	require.NoError(t, err)
+	require.NoError(t, policy.Validate())
+	assert.True(t, policy.Allows(DE))
+	assert.False(t, policy.Allows(RU))
+
+	data, err := json.Marshal(policy)
+	require.NoError(t, err)
+	assert.Equal(t, `{"allow":["AT","DE"],"deny":["RU"]}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"deny":["invalid"]}`), &policy)
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package country
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// primaryTimeZones holds the time zones of the capital
+// or the most populous region of countries with multiple time zones
+// where it is not the first one of countryTimeZones.
+var primaryTimeZones = map[Code]string{
+	"AU": "Australia/Sydney",
+	"BR": "America/Sao_Paulo",
+	"CA": "America/Toronto",
+	"FM": "Pacific/Pohnpei",
+	"RU": "Europe/Moscow",
+	"UA": "Europe/Kyiv",
+	"UZ": "Asia/Tashkent",
+}
+
+// TimeZones returns the IANA time zone names of the regions
+// of the country like ["Europe/Madrid", "Africa/Ceuta", "Atlantic/Canary"]
+// for Spain, starting with the PrimaryTimeZone.
+// Returns nil if the code is invalid or has no known time zone.
+func (c Code) TimeZones() []string {
+	norm, err := c.Normalized()
+	if err != nil {
+		return nil
+	}
+	zones := slices.Clone(countryTimeZones[norm])
+	if primary, ok := primaryTimeZones[norm]; ok {
+		i := slices.Index(zones, primary)
+		zones = slices.Insert(slices.Delete(zones, i, i+1), 0, primary)
+	}
+	return zones
+}
+
+// HasMultipleTimeZones returns true if the regions
+// of the country have different time zones.
+func (c Code) HasMultipleTimeZones() bool {
+	norm, err := c.Normalized()
+	if err != nil {
+		return false
+	}
+	return len(countryTimeZones[norm]) > 1
+}
+
+// PrimaryTimeZone returns the IANA time zone name
+// that is most likely for a country if no other
+// information about the region is available.
+// This is the time zone of the capital or the most
+// populous region for countries with multiple time zones.
+// Returns an empty string if the code is invalid
+// or has no known time zone.
+func (c Code) PrimaryTimeZone() string {
+	norm, err := c.Normalized()
+	if err != nil {
+		return ""
+	}
+	if primary, ok := primaryTimeZones[norm]; ok {
+		return primary
+	}
+	if zones := countryTimeZones[norm]; len(zones) > 0 {
+		return zones[0]
+	}
+	return ""
+}
+
+// PrimaryLocation returns the time.Location of the PrimaryTimeZone
+// of the country to localize timestamps.
+// Returns an error if the code is invalid, has no known time zone,
+// or the time zone database is not available.
+func (c Code) PrimaryLocation() (*time.Location, error) {
+	zone := c.PrimaryTimeZone()
+	if zone == "" {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no time zone known for country.Code '%s'", string(c))
+	}
+	return time.LoadLocation(zone)
+}
@@ -0,0 +1,59 @@
+package country
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeTimeZones(t *testing.T) {
+	assert.Equal(t, []string{"Europe/Vienna"}, AT.TimeZones())
+	assert.Equal(t, []string{"Europe/Vienna"}, Code("at").TimeZones())
+	assert.Equal(t, []string{"Europe/Madrid", "Africa/Ceuta", "Atlantic/Canary"}, ES.TimeZones())
+	assert.False(t, AT.HasMultipleTimeZones())
+	assert.True(t, US.HasMultipleTimeZones())
+	assert.Nil(t, Code("XX").TimeZones())
+
+	zones := AU.TimeZones()
+	assert.Equal(t, "Australia/Sydney", zones[0])
+	assert.Len(t, zones, len(countryTimeZones[AU]))
+	assert.Equal(t, "Australia/Lord_Howe", countryTimeZones[AU][0], "TimeZones must not modify the data")
+
+	for code, zones := range countryTimeZones {
+		assert.True(t, code.Valid(), "country code %s", code)
+		assert.NotEmpty(t, zones, "country code %s", code)
+		if primary, ok := primaryTimeZones[code]; ok {
+			assert.Contains(t, zones, primary, "country code %s", code)
+		}
+	}
+}
+
+func TestCodePrimaryTimeZone(t *testing.T) {
+	tests := []struct {
+		code Code
+		want string
+	}{
+		{code: AT, want: "Europe/Vienna"},
+		{code: "de", want: "Europe/Berlin"},
+		{code: US, want: "America/New_York"},
+		{code: CA, want: "America/Toronto"},
+		{code: RU, want: "Europe/Moscow"},
+		{code: BR, want: "America/Sao_Paulo"},
+		{code: "XX", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.code.PrimaryTimeZone())
+		})
+	}
+}
+
+func TestCodePrimaryLocation(t *testing.T) {
+	loc, err := DE.PrimaryLocation()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+
+	_, err = Code("XX").PrimaryLocation()
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package date
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/domonda/go-types"
+)
+
+var locationCtxKey int
+
+// ContextWithLocation returns a context with the passed time zone location
+// that is used by the context aware helpers like OfTodayContext
+// and ContextTime.ValueContext instead of time.Local.
+// This way services can use the time zone of the user per request.
+func ContextWithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, &locationCtxKey, loc)
+}
+
+// LocationFromContext returns the time zone location added
+// to the context with ContextWithLocation or time.Local.
+func LocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(&locationCtxKey).(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+// OfTodayContext returns the date of today
+// in the time zone location of the context.
+func OfTodayContext(ctx context.Context) Date {
+	return OfTodayIn(LocationFromContext(ctx))
+}
+
+// OfTimeContext returns the date part of the passed time
+// in the time zone location of the context
+// or an empty string if t.IsZero().
+func OfTimeContext(ctx context.Context, t time.Time) Date {
+	if t.IsZero() {
+		return ""
+	}
+	return OfTime(t.In(LocationFromContext(ctx)))
+}
+
+// Compile-time check that ContextTime implements types.ValuerContext
+var _ types.ValuerContext = ContextTime{}
+
+// ContextTime is a time.Time that implements types.ValuerContext
+// by converting the time to the time zone location of the context.
+// Use it for SQL timestamp columns without time zone
+// that should store the wall clock time of the user of a request.
+//
+// Wrap query arguments with types.ArgsWithContext
+// to make the database/sql package use ValueContext.
+type ContextTime time.Time
+
+// Value implements the database/sql/driver.Valuer interface.
+// Returns nil for a zero time, otherwise returns the unchanged time.Time.
+func (t ContextTime) Value() (driver.Value, error) {
+	if time.Time(t).IsZero() {
+		return nil, nil
+	}
+	return time.Time(t), nil
+}
+
+// ValueContext implements the types.ValuerContext interface.
+// Returns nil for a zero time, otherwise returns the time.Time
+// converted to the time zone location of the context.
+func (t ContextTime) ValueContext(ctx context.Context) (driver.Value, error) {
+	if time.Time(t).IsZero() {
+		return nil, nil
+	}
+	return time.Time(t).In(LocationFromContext(ctx)), nil
+}
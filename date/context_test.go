@@ -0,0 +1,34 @@
+package date
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithLocation(t *testing.T) {
+	require.Equal(t, time.Local, LocationFromContext(context.Background()))
+
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+	ctx := ContextWithLocation(context.Background(), vienna)
+	require.Equal(t, vienna, LocationFromContext(ctx))
+
+	utc := time.Date(2024, 3, 31, 23, 30, 0, 0, time.UTC)
+	require.Equal(t, Date("2024-04-01"), OfTimeContext(ctx, utc))
+	require.Equal(t, Date(""), OfTimeContext(ctx, time.Time{}))
+
+	value, err := ContextTime(utc).ValueContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "2024-04-01 01:30:00 +0200 CEST", value.(time.Time).String())
+
+	value, err = ContextTime(utc).Value()
+	require.NoError(t, err)
+	require.Equal(t, utc, value)
+
+	value, err = ContextTime{}.ValueContext(ctx)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
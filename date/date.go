@@ -5,7 +5,7 @@
 // - Date type with ISO 8601 format (YYYY-MM-DD) support
 // - Flexible date parsing with language hints
 // - Date arithmetic and comparison operations
-// - Period range calculations (year, quarter, month, week)
+// - Period range calculations (year, quarter, month, week, fiscal year)
 // - Database integration (Scanner/Valuer interfaces)
 // - JSON marshalling/unmarshalling
 // - Nullable date support
@@ -22,6 +22,7 @@ import (
 
 	"github.com/invopop/jsonschema"
 
+	"github.com/domonda/go-types"
 	"github.com/domonda/go-types/language"
 	"github.com/domonda/go-types/strutil"
 )
@@ -151,13 +152,13 @@ func Parse(layout, value string) (Date, error) {
 // - Period of week 1 2019: PeriodRange("2019-W01") == Date("2018-12-31"), Date("2019-01-06"), nil
 func PeriodRange(period string) (from, until Date, err error) {
 	if len(period) != 4 && len(period) != 7 && len(period) != 8 {
-		return "", "", fmt.Errorf("invalid period format length: %q", period)
+		return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format length: %q", period)
 	}
 
 	if len(period) == 4 {
 		year, err := strconv.Atoi(period)
 		if err != nil || year <= 0 {
-			return "", "", fmt.Errorf("invalid period format: %q", period)
+			return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format: %q", period)
 		}
 		from = Date(period + "-01-01")
 		until = Date(period + "-12-31")
@@ -165,19 +166,19 @@ func PeriodRange(period string) (from, until Date, err error) {
 	}
 
 	if period[4] != '-' {
-		return "", "", fmt.Errorf("invalid period format, expected '-' after year: %q", period)
+		return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format, expected '-' after year: %q", period)
 	}
 
 	year, err := strconv.Atoi(period[:4])
 	if err != nil {
-		return "", "", fmt.Errorf("invalid period format, can't parse year: %q", period)
+		return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format, can't parse year: %q", period)
 	}
 
 	switch period[5] {
 	case 'W', 'w':
 		week, err := strconv.Atoi(period[6:])
 		if err != nil || week < 1 || week > 53 {
-			return "", "", fmt.Errorf("invalid period format, can't parse week: %q", period)
+			return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format, can't parse week: %q", period)
 		}
 		from, until = YearWeekRange(year, week)
 		return from, until, nil
@@ -185,7 +186,7 @@ func PeriodRange(period string) (from, until Date, err error) {
 	case 'Q', 'q':
 		quarter, err := strconv.Atoi(period[6:])
 		if err != nil || quarter < 1 || quarter > 4 {
-			return "", "", fmt.Errorf("invalid period format, can't parse quarter: %q", period)
+			return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format, can't parse quarter: %q", period)
 		}
 		from = Of(year, time.Month(quarter-1)*3+1, 1)
 		until = Of(year, time.Month(quarter)*3+1, 0) // 0th day is the last day of the previous month
@@ -194,7 +195,7 @@ func PeriodRange(period string) (from, until Date, err error) {
 	case 'H', 'h':
 		half, err := strconv.Atoi(period[6:])
 		if err != nil || half < 1 || half > 2 {
-			return "", "", fmt.Errorf("invalid period format, can't parse half-year: %q", period)
+			return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format, can't parse half-year: %q", period)
 		}
 		from = Of(year, time.Month(half-1)*6+1, 1)
 		until = Of(year, time.Month(half)*6+1, 0) // 0th day is the last day of the previous month
@@ -203,7 +204,7 @@ func PeriodRange(period string) (from, until Date, err error) {
 
 	month, err := strconv.Atoi(period[5:])
 	if err != nil || month < 1 || month > 12 {
-		return "", "", fmt.Errorf("invalid period format, can't parse month: %q", period)
+		return "", "", codedError(ErrCodeInvalidPeriod, "invalid period format, can't parse month: %q", period)
 	}
 
 	from = Of(year, time.Month(month), 1)
@@ -759,6 +760,21 @@ func (Date) JSONSchema() *jsonschema.Schema {
 	}
 }
 
+// Error codes of date and period parsing errors
+// that can be used to look up localized messages.
+// See types.CodedError.
+const (
+	ErrCodeInvalid       types.ErrorCode = "date.invalid"
+	ErrCodeTooShort      types.ErrorCode = "date.too_short"
+	ErrCodeInvalidPeriod types.ErrorCode = "date.period.invalid"
+)
+
+// codedError returns a types.CodedError with the parameter "value"
+// and a message formatted from msgFormat with value quoted by %q.
+func codedError(code types.ErrorCode, msgFormat, value string) error {
+	return types.NewCodedError(code, fmt.Sprintf(msgFormat, value), "value", value)
+}
+
 func isDateSeparatorRune(r rune) bool {
 	return unicode.IsSpace(r) || r == '.' || r == '/' || r == '-'
 }
@@ -782,7 +798,7 @@ func normalizeAndCheckDate(str string, langHint language.Code) (Date, bool, erro
 	}
 	_, err = time.Parse(Layout, normalized)
 	if err != nil {
-		return "", monthMustBeFirst, err
+		return "", monthMustBeFirst, types.NewCodedError(ErrCodeInvalid, err.Error(), "value", str).Wrap(err)
 	}
 	return Date(normalized), monthMustBeFirst, nil
 }
@@ -791,7 +807,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 	trimmed := strings.TrimSuffix(str, "00:00:00") // Trim zero time part
 	trimmed = strings.TrimFunc(trimmed, isDateTrimRune)
 	if len(trimmed) < MinLength {
-		return "", false, fmt.Errorf("too short for a date: %q", str)
+		return "", false, codedError(ErrCodeTooShort, "too short for a date: %q", str)
 	}
 
 	if len(trimmed) > 10 && trimmed[10] == 'T' {
@@ -812,7 +828,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 		}
 	}
 	if len(parts) != 3 {
-		return "", false, fmt.Errorf("date must have 3 parts: %q", str)
+		return "", false, codedError(ErrCodeInvalid, "date must have 3 parts: %q", str)
 	}
 	dayHint := -1
 	totalLen := 0
@@ -839,7 +855,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 		}
 	}
 	if totalLen < 5 {
-		return "", false, fmt.Errorf("date is too short: %q", str)
+		return "", false, codedError(ErrCodeTooShort, "date is too short: %q", str)
 	}
 
 	len0 := len(parts[0])
@@ -875,7 +891,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 			expandVal2ToFullYear()
 		}
 		if !validDay(val1) || !validYear(val2) {
-			return "", false, fmt.Errorf("invalid date: %q", str)
+			return "", false, codedError(ErrCodeInvalid, "invalid date: %q", str)
 		}
 		// m DD YYYY
 		return fmt.Sprintf("%s-%02d-%s", parts[2], month0, parts[1]), false, nil
@@ -892,14 +908,14 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 
 	case len0 == 2 && month1 != 0 && len2 == 4:
 		if !validDay(val0) || !validYear(val2) {
-			return "", false, fmt.Errorf("invalid date: %q", str)
+			return "", false, codedError(ErrCodeInvalid, "invalid date: %q", str)
 		}
 		// DD m YYYY
 		return fmt.Sprintf("%s-%02d-%s", parts[2], month1, parts[0]), false, nil
 
 	case len0 == 4 && month1 != 0 && len2 == 2:
 		if !validYear(val0) || !validDay(val2) {
-			return "", false, fmt.Errorf("invalid date: %q", str)
+			return "", false, codedError(ErrCodeInvalid, "invalid date: %q", str)
 		}
 		// YYYY m DD
 		return fmt.Sprintf("%s-%02d-%s", parts[0], month1, parts[2]), false, nil
@@ -921,7 +937,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 
 	case len0 == 4 && len1 == 2 && len2 == 2:
 		if !validYear(val0) || !validMonth(val1) || !validDay(val2) {
-			return "", false, fmt.Errorf("invalid date: %q", str)
+			return "", false, codedError(ErrCodeInvalid, "invalid date: %q", str)
 		}
 		return strings.Join(parts, "-"), false, nil
 
@@ -938,7 +954,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 			// DD MM YYYY
 		}
 		if !validDay(val0) || !validMonth(val1) || !validYear(val2) {
-			return "", false, fmt.Errorf("invalid date: %q", str)
+			return "", false, codedError(ErrCodeInvalid, "invalid date: %q", str)
 		}
 		// DD MM YYYY
 		parts[0], parts[2] = parts[2], parts[0]
@@ -946,7 +962,7 @@ func normalizeDate(str string, langHint language.Code) (string, bool, error) {
 		return strings.Join(parts, "-"), monthMustBeFirst, nil
 	}
 
-	return "", false, fmt.Errorf("invalid date: %q", str)
+	return "", false, codedError(ErrCodeInvalid, "invalid date: %q", str)
 }
 
 func validYear(year int) bool {
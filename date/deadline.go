@@ -0,0 +1,65 @@
+package date
+
+import "time"
+
+// IsBusinessDay returns if the date is a weekday
+// from Monday to Friday and not a holiday of the calendar
+// which can be nil.
+func (date Date) IsBusinessDay(calendar HolidayCalendar) bool {
+	return !date.IsWeekend() && (calendar == nil || !calendar.IsHoliday(date))
+}
+
+// NextBusinessDay returns the first business day after the date.
+// See IsBusinessDay.
+func (date Date) NextBusinessDay(calendar HolidayCalendar) Date {
+	next := date.AddDays(1)
+	for !next.IsBusinessDay(calendar) {
+		next = next.AddDays(1)
+	}
+	return next
+}
+
+// AddBusinessDays returns the date after adding the passed number
+// of business days, not counting weekends and holidays of the calendar.
+// If the date itself is not a business day, then positive days
+// are counted from the previous business day, so adding one business day
+// to a Saturday results in the next Monday.
+// Negative days are subtracted counting from the next business day,
+// so subtracting one business day from a Saturday results in the Friday before.
+func (date Date) AddBusinessDays(days int, calendar HolidayCalendar) Date {
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	for ; days > 0; days-- {
+		date = date.AddDays(step)
+		for !date.IsBusinessDay(calendar) {
+			date = date.AddDays(step)
+		}
+	}
+	return date
+}
+
+// ComputeDeadline returns the last day of a period of businessDays
+// after a submission at start, as used for statutory response periods.
+//
+// The submission is received on the date of start in the time zone tz,
+// or in the location of start if tz is nil.
+// A submission after the cutoff time of day or on a day that is not
+// a business day counts as received on the next business day.
+// A zero cutoff means no cutoff time.
+// Business days are Monday to Friday excluding the holidays
+// of the calendar which can be nil.
+//
+// With zero businessDays the deadline is the receipt day,
+// negative businessDays are treated as zero.
+func ComputeDeadline(start time.Time, businessDays int, cutoff TimeOfDay, tz *time.Location, calendar HolidayCalendar) Date {
+	if tz != nil {
+		start = start.In(tz)
+	}
+	received := OfTime(start)
+	if !received.IsBusinessDay(calendar) || (cutoff > 0 && TimeOfDayOfTime(start) >= cutoff) {
+		received = received.NextBusinessDay(calendar)
+	}
+	return received.AddBusinessDays(max(businessDays, 0), calendar)
+}
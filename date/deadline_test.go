@@ -0,0 +1,84 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDeadline(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+	holidays := Holidays{
+		"2024-12-25": "Christmas",
+		"2024-12-26": "St. Stephen's Day",
+		"2025-01-01": "New Year",
+	}
+	cutoff := TimeOfDayOf(16, 0, 0)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		days  int
+		want  Date
+	}{
+		{name: "before cutoff", start: time.Date(2024, 12, 2, 15, 59, 0, 0, vienna), days: 3, want: "2024-12-05"},
+		{name: "at cutoff", start: time.Date(2024, 12, 2, 16, 0, 0, 0, vienna), days: 3, want: "2024-12-06"},
+		{name: "over weekend", start: time.Date(2024, 12, 5, 10, 0, 0, 0, vienna), days: 3, want: "2024-12-10"},
+		{name: "received on Saturday", start: time.Date(2024, 12, 7, 10, 0, 0, 0, vienna), days: 1, want: "2024-12-10"},
+		{name: "zero days", start: time.Date(2024, 12, 6, 17, 0, 0, 0, vienna), days: 0, want: "2024-12-09"},
+		{name: "holidays", start: time.Date(2024, 12, 23, 17, 0, 0, 0, vienna), days: 3, want: "2024-12-31"},
+		{name: "new year", start: time.Date(2024, 12, 31, 9, 0, 0, 0, vienna), days: 1, want: "2025-01-02"},
+		// 15:30 UTC is 16:30 in Vienna, after the cutoff
+		{name: "time zone", start: time.Date(2024, 12, 2, 15, 30, 0, 0, time.UTC), days: 1, want: "2024-12-04"},
+		{name: "negative days", start: time.Date(2024, 12, 2, 10, 0, 0, 0, vienna), days: -5, want: "2024-12-02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeDeadline(tt.start, tt.days, cutoff, vienna, holidays)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// No cutoff, no calendar and the location of start
+	start := time.Date(2024, 12, 2, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, Date("2024-12-03"), ComputeDeadline(start, 1, 0, nil, nil))
+	assert.Equal(t, Date("2024-12-04"), ComputeDeadline(start, 1, 0, vienna, nil), "00:00 on 2024-12-03 in Vienna")
+}
+
+func TestDate_AddBusinessDays(t *testing.T) {
+	holidays := Holidays{"2024-05-01": "Labour Day"}
+	assert.Equal(t, Date("2024-05-02"), Date("2024-04-30").AddBusinessDays(1, holidays))
+	assert.Equal(t, Date("2024-05-06"), Date("2024-04-30").AddBusinessDays(3, holidays))
+	assert.Equal(t, Date("2024-04-30"), Date("2024-05-02").AddBusinessDays(-1, holidays))
+	assert.Equal(t, Date("2024-05-03"), Date("2024-05-03").AddBusinessDays(0, holidays))
+	assert.Equal(t, Date("2024-05-06"), Date("2024-05-04").NextBusinessDay(nil))
+	assert.False(t, Date("2024-05-01").IsBusinessDay(holidays))
+	assert.True(t, Date("2024-05-01").IsBusinessDay(nil))
+}
+
+func TestTimeOfDay(t *testing.T) {
+	tod, err := ParseTimeOfDay("16:30")
+	require.NoError(t, err)
+	assert.Equal(t, TimeOfDayOf(16, 30, 0), tod)
+	assert.Equal(t, "16:30:00", tod.String())
+	tod, err = ParseTimeOfDay("08:05:09")
+	require.NoError(t, err)
+	hour, minute, second := tod.Clock()
+	assert.Equal(t, []int{8, 5, 9}, []int{hour, minute, second})
+	_, err = ParseTimeOfDay("25:00")
+	assert.Error(t, err)
+
+	assert.True(t, TimeOfDayOf(23, 59, 59).Valid())
+	assert.False(t, TimeOfDayOf(24, 0, 0).Valid())
+	assert.False(t, TimeOfDay(-1).Valid())
+
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+	// Wall clock time on the day of the DST change
+	on := TimeOfDayOf(12, 0, 0).On("2024-03-31", vienna)
+	assert.Equal(t, time.Date(2024, 3, 31, 12, 0, 0, 0, vienna), on)
+	assert.Equal(t, TimeOfDayOf(12, 0, 0), TimeOfDayOfTime(on))
+}
@@ -0,0 +1,118 @@
+package date
+
+import "time"
+
+// EasterSunday returns the date of Easter Sunday
+// of the Gregorian calendar for the passed year
+// using the anonymous Gregorian algorithm
+// (Meeus/Jones/Butcher).
+//
+// All movable feasts of the western churches
+// like AscensionDay or CorpusChristi are derived from it,
+// so it can be used to build regional holiday calendars.
+func EasterSunday(year int) Date {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Of(year, time.Month(month), day)
+}
+
+// OrthodoxEasterSunday returns the date of Easter Sunday
+// of the Eastern Orthodox churches for the passed year
+// calculated with the Julian calendar and converted
+// to the Gregorian calendar (valid for the years 1900 to 2099).
+func OrthodoxEasterSunday(year int) Date {
+	a := year % 4
+	b := year % 7
+	c := year % 19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	// 13 days difference between the Julian and Gregorian calendar
+	return Of(year, time.Month(month), day).AddDays(13)
+}
+
+// ShroveTuesday returns the date of Shrove Tuesday (Carnival),
+// 47 days before Easter Sunday.
+func ShroveTuesday(year int) Date {
+	return EasterSunday(year).AddDays(-47)
+}
+
+// AshWednesday returns the date of Ash Wednesday,
+// 46 days before Easter Sunday.
+func AshWednesday(year int) Date {
+	return EasterSunday(year).AddDays(-46)
+}
+
+// MaundyThursday returns the date of Maundy Thursday,
+// 3 days before Easter Sunday.
+func MaundyThursday(year int) Date {
+	return EasterSunday(year).AddDays(-3)
+}
+
+// GoodFriday returns the date of Good Friday,
+// 2 days before Easter Sunday.
+func GoodFriday(year int) Date {
+	return EasterSunday(year).AddDays(-2)
+}
+
+// EasterMonday returns the date of Easter Monday,
+// the day after Easter Sunday.
+func EasterMonday(year int) Date {
+	return EasterSunday(year).AddDays(1)
+}
+
+// AscensionDay returns the date of Ascension Day,
+// the Thursday 39 days after Easter Sunday.
+func AscensionDay(year int) Date {
+	return EasterSunday(year).AddDays(39)
+}
+
+// PentecostSunday returns the date of Pentecost (Whit Sunday),
+// 49 days after Easter Sunday.
+func PentecostSunday(year int) Date {
+	return EasterSunday(year).AddDays(49)
+}
+
+// PentecostMonday returns the date of Whit Monday,
+// 50 days after Easter Sunday.
+func PentecostMonday(year int) Date {
+	return EasterSunday(year).AddDays(50)
+}
+
+// CorpusChristi returns the date of the feast of Corpus Christi,
+// the Thursday 60 days after Easter Sunday.
+func CorpusChristi(year int) Date {
+	return EasterSunday(year).AddDays(60)
+}
+
+// MovableFeasts returns the movable Christian feasts
+// of the western churches in the passed year
+// that are public holidays in at least some regions
+// with their English names, from Good Friday to Corpus Christi.
+//
+// Add the subset of a region together with its fixed date
+// holidays to a Holidays map to build a HolidayCalendar.
+func MovableFeasts(year int) Holidays {
+	return Holidays{
+		GoodFriday(year):      "Good Friday",
+		EasterSunday(year):    "Easter Sunday",
+		EasterMonday(year):    "Easter Monday",
+		AscensionDay(year):    "Ascension Day",
+		PentecostSunday(year): "Whit Sunday",
+		PentecostMonday(year): "Whit Monday",
+		CorpusChristi(year):   "Corpus Christi",
+	}
+}
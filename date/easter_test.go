@@ -0,0 +1,48 @@
+package date
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasterSunday(t *testing.T) {
+	tests := map[int]Date{
+		1818: "1818-03-22", // Earliest possible date
+		1943: "1943-04-25", // Latest possible date
+		2000: "2000-04-23",
+		2019: "2019-04-21",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+		2038: "2038-04-25",
+	}
+	for year, want := range tests {
+		assert.Equal(t, want, EasterSunday(year), "year %d", year)
+	}
+}
+
+func TestOrthodoxEasterSunday(t *testing.T) {
+	assert.Equal(t, Date("2024-05-05"), OrthodoxEasterSunday(2024))
+	assert.Equal(t, Date("2025-04-20"), OrthodoxEasterSunday(2025))
+	assert.Equal(t, Date("2026-04-12"), OrthodoxEasterSunday(2026))
+}
+
+func TestMovableFeasts(t *testing.T) {
+	assert.Equal(t, Date("2024-02-13"), ShroveTuesday(2024))
+	assert.Equal(t, Date("2024-02-14"), AshWednesday(2024))
+	assert.Equal(t, Date("2024-03-28"), MaundyThursday(2024))
+	assert.Equal(t, Date("2024-03-29"), GoodFriday(2024))
+	assert.Equal(t, Date("2024-04-01"), EasterMonday(2024))
+	assert.Equal(t, Date("2024-05-09"), AscensionDay(2024))
+	assert.Equal(t, Date("2024-05-19"), PentecostSunday(2024))
+	assert.Equal(t, Date("2024-05-20"), PentecostMonday(2024))
+	assert.Equal(t, Date("2024-05-30"), CorpusChristi(2024))
+
+	feasts := MovableFeasts(2024)
+	assert.Len(t, feasts, 7)
+	assert.Equal(t, "Corpus Christi", feasts["2024-05-30"])
+	for _, d := range []Date{"2024-05-09", "2024-05-30"} {
+		assert.Equal(t, testHolidays2024[d], feasts[d], d)
+	}
+}
@@ -0,0 +1,179 @@
+package date
+
+import (
+	"fmt"
+	"time"
+)
+
+// FiscalYear is a twelve month accounting period
+// starting on the first day of StartMonth.
+//
+// Year is the calendar year in which the fiscal year ends,
+// so a fiscal year starting in October 2023 and ending
+// in September 2024 is FiscalYear{Year: 2024, StartMonth: time.October}.
+// A fiscal year with StartMonth January is the calendar year.
+type FiscalYear struct {
+	Year       int        `json:"year"`
+	StartMonth time.Month `json:"startMonth"`
+}
+
+// FiscalYearOf returns the fiscal year starting with startMonth
+// that contains the passed date.
+// Returns the zero FiscalYear if the date is not valid.
+func FiscalYearOf(date Date, startMonth time.Month) FiscalYear {
+	year, month, _ := date.YearMonthDay()
+	if year == 0 {
+		return FiscalYear{}
+	}
+	if startMonth > time.January && month >= startMonth {
+		year++
+	}
+	return FiscalYear{Year: year, StartMonth: startMonth}
+}
+
+// FiscalYearOfTime returns the fiscal year starting with startMonth
+// that contains the date of the passed time.Time.
+// Returns the zero FiscalYear if t.IsZero().
+func FiscalYearOfTime(t time.Time, startMonth time.Month) FiscalYear {
+	if t.IsZero() {
+		return FiscalYear{}
+	}
+	return FiscalYearOf(OfTime(t), startMonth)
+}
+
+// Validate returns an error if StartMonth is not in the range
+// January to December or Year is not in the range 1 to 3000.
+func (fy FiscalYear) Validate() error {
+	if fy.StartMonth < time.January || fy.StartMonth > time.December {
+		return fmt.Errorf("invalid fiscal year start month: %d", fy.StartMonth)
+	}
+	if fy.Year < 1 || fy.Year > 3000 {
+		return fmt.Errorf("invalid fiscal year: %d", fy.Year)
+	}
+	return nil
+}
+
+// Valid returns true if the fiscal year is valid.
+func (fy FiscalYear) Valid() bool {
+	return fy.Validate() == nil
+}
+
+// IsZero returns true if the fiscal year is the zero value.
+func (fy FiscalYear) IsZero() bool {
+	return fy == FiscalYear{}
+}
+
+// String returns the fiscal year in the format "FY2024"
+// for fiscal years aligned with the calendar year
+// and "FY2023/24" else.
+// String implements the fmt.Stringer interface.
+func (fy FiscalYear) String() string {
+	if fy.StartMonth == time.January {
+		return fmt.Sprintf("FY%04d", fy.Year)
+	}
+	return fmt.Sprintf("FY%04d/%02d", fy.Year-1, fy.Year%100)
+}
+
+// StartYear returns the calendar year in which the fiscal year starts.
+func (fy FiscalYear) StartYear() int {
+	if fy.StartMonth > time.January {
+		return fy.Year - 1
+	}
+	return fy.Year
+}
+
+// Period returns the first and last date of the fiscal year.
+// Returns empty dates if the fiscal year is not valid.
+func (fy FiscalYear) Period() (from, until Date) {
+	if !fy.Valid() {
+		return "", ""
+	}
+	from = Of(fy.StartYear(), fy.StartMonth, 1)
+	return from, from.AddMonths(12).AddDays(-1)
+}
+
+// Contains returns true if the passed date is within the fiscal year.
+func (fy FiscalYear) Contains(date Date) bool {
+	if !fy.Valid() || !date.Valid() {
+		return false
+	}
+	return FiscalYearOf(date, fy.StartMonth) == fy
+}
+
+// ContainsTime returns true if the date of the passed time
+// is within the fiscal year.
+func (fy FiscalYear) ContainsTime(t time.Time) bool {
+	return !t.IsZero() && fy.Contains(OfTime(t))
+}
+
+// AddYears returns the fiscal year with the specified
+// number of years added and the same StartMonth.
+func (fy FiscalYear) AddYears(years int) FiscalYear {
+	return FiscalYear{Year: fy.Year + years, StartMonth: fy.StartMonth}
+}
+
+// Month returns the calendar YearMonth of the passed
+// fiscal month from 1 to 12 of the fiscal year.
+// Returns an empty YearMonth if the fiscal year or month is not valid.
+func (fy FiscalYear) Month(fiscalMonth int) YearMonth {
+	if !fy.Valid() || fiscalMonth < 1 || fiscalMonth > 12 {
+		return ""
+	}
+	return YearMonthFrom(fy.StartYear(), fy.StartMonth).AddMonths(fiscalMonth - 1)
+}
+
+// YearMonths returns the twelve calendar months of the fiscal year.
+// Returns nil if the fiscal year is not valid.
+func (fy FiscalYear) YearMonths() []YearMonth {
+	if !fy.Valid() {
+		return nil
+	}
+	months := make([]YearMonth, 12)
+	for i := range months {
+		months[i] = fy.Month(i + 1)
+	}
+	return months
+}
+
+// FiscalMonth returns the fiscal month from 1 to 12
+// of the passed date or 0 if the date is not within the fiscal year.
+func (fy FiscalYear) FiscalMonth(date Date) int {
+	if !fy.Contains(date) {
+		return 0
+	}
+	return (int(date.Month())-int(fy.StartMonth)+12)%12 + 1
+}
+
+// FiscalQuarter returns the fiscal quarter from 1 to 4
+// of the passed date or 0 if the date is not within the fiscal year.
+func (fy FiscalYear) FiscalQuarter(date Date) int {
+	month := fy.FiscalMonth(date)
+	if month == 0 {
+		return 0
+	}
+	return (month-1)/3 + 1
+}
+
+// QuarterPeriod returns the first and last date
+// of the passed fiscal quarter from 1 to 4.
+// Returns empty dates if the fiscal year or quarter is not valid.
+func (fy FiscalYear) QuarterPeriod(fiscalQuarter int) (from, until Date) {
+	if fiscalQuarter < 1 || fiscalQuarter > 4 {
+		return "", ""
+	}
+	firstMonth := fy.Month((fiscalQuarter-1)*3 + 1)
+	if firstMonth == "" {
+		return "", ""
+	}
+	from = firstMonth.Date(1)
+	return from, from.AddMonths(3).AddDays(-1)
+}
+
+// Compare compares the fiscal year with another FiscalYear
+// by their start dates.
+// Returns -1 if fy starts before other, +1 if after, 0 if equal.
+func (fy FiscalYear) Compare(other FiscalYear) int {
+	fyFrom, _ := fy.Period()
+	otherFrom, _ := other.Period()
+	return fyFrom.Compare(otherFrom)
+}
@@ -0,0 +1,92 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiscalYearOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       Date
+		startMonth time.Month
+		want       FiscalYear
+	}{
+		{name: "calendar year", date: "2024-03-15", startMonth: time.January, want: FiscalYear{2024, time.January}},
+		{name: "before April start", date: "2024-03-31", startMonth: time.April, want: FiscalYear{2024, time.April}},
+		{name: "first day April start", date: "2024-04-01", startMonth: time.April, want: FiscalYear{2025, time.April}},
+		{name: "October start", date: "2023-10-01", startMonth: time.October, want: FiscalYear{2024, time.October}},
+		{name: "last day October start", date: "2024-09-30", startMonth: time.October, want: FiscalYear{2024, time.October}},
+		{name: "invalid date", date: "invalid", startMonth: time.April, want: FiscalYear{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FiscalYearOf(tt.date, tt.startMonth))
+		})
+	}
+	assert.Equal(t, FiscalYear{2025, time.April}, FiscalYearOfTime(time.Date(2024, 12, 24, 18, 0, 0, 0, time.UTC), time.April))
+	assert.True(t, FiscalYearOfTime(time.Time{}, time.April).IsZero())
+}
+
+func TestFiscalYear_Period(t *testing.T) {
+	from, until := FiscalYear{2024, time.October}.Period()
+	assert.Equal(t, Date("2023-10-01"), from)
+	assert.Equal(t, Date("2024-09-30"), until)
+
+	from, until = FiscalYear{2024, time.March}.Period()
+	assert.Equal(t, Date("2023-03-01"), from)
+	assert.Equal(t, Date("2024-02-29"), until)
+
+	from, until = FiscalYear{2024, time.January}.Period()
+	assert.Equal(t, Date("2024-01-01"), from)
+	assert.Equal(t, Date("2024-12-31"), until)
+
+	from, until = FiscalYear{2024, 13}.Period()
+	assert.Equal(t, Date(""), from)
+	assert.Equal(t, Date(""), until)
+}
+
+func TestFiscalYear_Contains(t *testing.T) {
+	fy := FiscalYear{2024, time.April}
+	assert.False(t, fy.Contains("2023-03-31"))
+	assert.True(t, fy.Contains("2023-04-01"))
+	assert.True(t, fy.Contains("2024-03-31"))
+	assert.False(t, fy.Contains("2024-04-01"))
+	assert.False(t, fy.Contains("invalid"))
+	assert.True(t, fy.ContainsTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, FiscalYear{}.Contains("2024-01-01"))
+}
+
+func TestFiscalYear_Conversions(t *testing.T) {
+	fy := FiscalYear{2024, time.October}
+	assert.Equal(t, "FY2023/24", fy.String())
+	assert.Equal(t, "FY2024", FiscalYear{2024, time.January}.String())
+	assert.Equal(t, 2023, fy.StartYear())
+	assert.Equal(t, FiscalYear{2025, time.October}, fy.AddYears(1))
+
+	assert.Equal(t, YearMonth("2023-10"), fy.Month(1))
+	assert.Equal(t, YearMonth("2024-09"), fy.Month(12))
+	assert.Equal(t, YearMonth(""), fy.Month(13))
+	months := fy.YearMonths()
+	assert.Len(t, months, 12)
+	assert.Equal(t, YearMonth("2024-01"), months[3])
+
+	assert.Equal(t, 1, fy.FiscalMonth("2023-10-15"))
+	assert.Equal(t, 4, fy.FiscalMonth("2024-01-15"))
+	assert.Equal(t, 0, fy.FiscalMonth("2024-10-01"))
+	assert.Equal(t, 2, fy.FiscalQuarter("2024-01-15"))
+	assert.Equal(t, 4, fy.FiscalQuarter("2024-09-30"))
+
+	from, until := fy.QuarterPeriod(2)
+	assert.Equal(t, Date("2024-01-01"), from)
+	assert.Equal(t, Date("2024-03-31"), until)
+	from, until = fy.QuarterPeriod(5)
+	assert.Equal(t, Date(""), from)
+	assert.Equal(t, Date(""), until)
+
+	assert.Equal(t, -1, fy.Compare(fy.AddYears(1)))
+	assert.Equal(t, 1, FiscalYear{2024, time.January}.Compare(fy))
+	assert.Equal(t, 0, fy.Compare(fy))
+}
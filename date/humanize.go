@@ -0,0 +1,197 @@
+package date
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/domonda/go-types/language"
+)
+
+// HumanizeUnit is a unit of time used
+// to humanize spans like "3 days ago".
+type HumanizeUnit int
+
+// HumanizeUnit values from the smallest to the largest unit.
+const (
+	HumanizeSecond HumanizeUnit = iota + 1
+	HumanizeMinute
+	HumanizeHour
+	HumanizeDay
+	HumanizeWeek
+	HumanizeMonth
+	HumanizeYear
+)
+
+// duration returns the length of the unit
+// with 30 days per month and 365 days per year.
+func (u HumanizeUnit) duration() time.Duration {
+	switch u {
+	case HumanizeSecond:
+		return time.Second
+	case HumanizeMinute:
+		return time.Minute
+	case HumanizeHour:
+		return time.Hour
+	case HumanizeDay:
+		return 24 * time.Hour
+	case HumanizeWeek:
+		return 7 * 24 * time.Hour
+	case HumanizeMonth:
+		return 30 * 24 * time.Hour
+	case HumanizeYear:
+		return 365 * 24 * time.Hour
+	}
+	return 0
+}
+
+// HumanizeGranularity limits the units used to humanize spans.
+// The zero value uses all units from HumanizeSecond to HumanizeYear.
+type HumanizeGranularity struct {
+	// MinUnit is the smallest unit.
+	// Spans shorter than one MinUnit are humanized as "now".
+	// Zero means HumanizeSecond.
+	MinUnit HumanizeUnit
+	// MaxUnit is the largest unit, so with HumanizeDay
+	// a span of two months is humanized as "60 days ago".
+	// Zero means HumanizeYear.
+	MaxUnit HumanizeUnit
+}
+
+func (g HumanizeGranularity) units() (minUnit, maxUnit HumanizeUnit) {
+	minUnit, maxUnit = g.MinUnit, g.MaxUnit
+	if minUnit < HumanizeSecond || minUnit > HumanizeYear {
+		minUnit = HumanizeSecond
+	}
+	if maxUnit < minUnit || maxUnit > HumanizeYear {
+		maxUnit = HumanizeYear
+	}
+	return minUnit, maxUnit
+}
+
+type humanizeLocale struct {
+	now, today, yesterday, tomorrow string
+	// singular and plural unit names indexed by HumanizeUnit
+	singular, plural [HumanizeYear + 1]string
+	past, future     func(count, unit string) string
+}
+
+var humanizeLocales = map[language.Code]*humanizeLocale{
+	language.EN: {
+		now:       "now",
+		today:     "today",
+		yesterday: "yesterday",
+		tomorrow:  "tomorrow",
+		singular:  [...]string{"", "second", "minute", "hour", "day", "week", "month", "year"},
+		plural:    [...]string{"", "seconds", "minutes", "hours", "days", "weeks", "months", "years"},
+		past:      func(count, unit string) string { return count + " " + unit + " ago" },
+		future:    func(count, unit string) string { return "in " + count + " " + unit },
+	},
+	language.DE: {
+		now:       "jetzt",
+		today:     "heute",
+		yesterday: "gestern",
+		tomorrow:  "morgen",
+		// Dative case after "vor" and "in"
+		singular: [...]string{"", "Sekunde", "Minute", "Stunde", "Tag", "Woche", "Monat", "Jahr"},
+		plural:   [...]string{"", "Sekunden", "Minuten", "Stunden", "Tagen", "Wochen", "Monaten", "Jahren"},
+		past:     func(count, unit string) string { return "vor " + count + " " + unit },
+		future:   func(count, unit string) string { return "in " + count + " " + unit },
+	},
+}
+
+// humanizeLocaleFor returns the locale for the first two
+// letters of lang so that "de-AT" uses German.
+func humanizeLocaleFor(lang language.Code) *humanizeLocale {
+	base := language.Code(strings.ToLower(string(lang[:min(2, len(lang))])))
+	if locale, ok := humanizeLocales[base]; ok {
+		return locale
+	}
+	return humanizeLocales[language.EN]
+}
+
+// HumanizeDuration returns a relative description like "in 2 weeks"
+// for a positive duration or "vor 3 Tagen" for a negative one
+// in German or English depending on lang with English as fallback.
+//
+// The largest unit of the granularity that fits at least once
+// into the duration is used and the count is truncated,
+// so 13 days are "1 week ago".
+// Months are counted as 30 days and years as 365 days.
+func HumanizeDuration(d time.Duration, lang language.Code, granularity HumanizeGranularity) string {
+	locale := humanizeLocaleFor(lang)
+	minUnit, maxUnit := granularity.units()
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < minUnit.duration() {
+		return locale.now
+	}
+	unit := maxUnit
+	for abs < unit.duration() {
+		unit--
+	}
+	count := int64(abs / unit.duration())
+	name := locale.plural[unit]
+	if count == 1 {
+		name = locale.singular[unit]
+	}
+	if d < 0 {
+		return locale.past(strconv.FormatInt(count, 10), name)
+	}
+	return locale.future(strconv.FormatInt(count, 10), name)
+}
+
+// HumanizeSince returns a description of the time
+// relative to now like "3 days ago" for a time in the past.
+// See HumanizeDuration.
+func HumanizeSince(t time.Time, lang language.Code, granularity HumanizeGranularity) string {
+	return HumanizeDuration(-time.Since(t), lang, granularity)
+}
+
+// HumanizeUntil returns a description of the time
+// relative to now like "in 2 weeks" for a time in the future.
+// See HumanizeDuration.
+func HumanizeUntil(t time.Time, lang language.Code, granularity HumanizeGranularity) string {
+	return HumanizeDuration(time.Until(t), lang, granularity)
+}
+
+// HumanizeRelativeTo returns a description of the date
+// relative to the reference date like "vor 3 Tagen" or "in 2 weeks"
+// in German or English depending on lang with English as fallback.
+// Dates one day apart are described as "yesterday", "today", or "tomorrow".
+// Units smaller than HumanizeDay of the granularity are ignored.
+// Returns an empty string if any of the dates is not valid.
+// See HumanizeDuration.
+func (date Date) HumanizeRelativeTo(reference Date, lang language.Code, granularity HumanizeGranularity) string {
+	if !date.Valid() || !reference.Valid() {
+		return ""
+	}
+	locale := humanizeLocaleFor(lang)
+	days := int(date.Sub(reference) / (24 * time.Hour))
+	switch days {
+	case 0:
+		return locale.today
+	case -1:
+		return locale.yesterday
+	case 1:
+		return locale.tomorrow
+	}
+	granularity.MinUnit = max(granularity.MinUnit, HumanizeDay)
+	return HumanizeDuration(time.Duration(days)*24*time.Hour, lang, granularity)
+}
+
+// HumanizeSince returns a description of the date
+// relative to today like "vor 3 Tagen" for a date in the past.
+// See HumanizeRelativeTo.
+func (date Date) HumanizeSince(lang language.Code, granularity HumanizeGranularity) string {
+	return date.HumanizeRelativeTo(OfToday(), lang, granularity)
+}
+
+// HumanizeUntil returns a description of the date
+// relative to today like "in 2 weeks" for a date in the future.
+// See HumanizeRelativeTo.
+func (date Date) HumanizeUntil(lang language.Code, granularity HumanizeGranularity) string {
+	return date.HumanizeRelativeTo(OfToday(), lang, granularity)
+}
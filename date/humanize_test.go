@@ -0,0 +1,58 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/language"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		d           time.Duration
+		lang        language.Code
+		granularity HumanizeGranularity
+		want        string
+	}{
+		{d: -3 * day, lang: language.DE, want: "vor 3 Tagen"},
+		{d: -3 * day, lang: language.EN, want: "3 days ago"},
+		{d: 14 * day, lang: language.EN, want: "in 2 weeks"},
+		{d: 14 * day, lang: language.DE, want: "in 2 Wochen"},
+		{d: -day, lang: language.DE, want: "vor 1 Tag"},
+		{d: 13 * day, lang: language.EN, want: "in 1 week"},
+		{d: -90 * time.Second, lang: language.EN, want: "1 minute ago"},
+		{d: -500 * time.Millisecond, lang: language.DE, want: "jetzt"},
+		{d: 400 * day, lang: language.EN, want: "in 1 year"},
+		{d: -60 * day, lang: language.DE, want: "vor 2 Monaten"},
+		{d: -60 * day, lang: language.EN, granularity: HumanizeGranularity{MaxUnit: HumanizeDay}, want: "60 days ago"},
+		{d: -5 * time.Hour, lang: language.EN, granularity: HumanizeGranularity{MinUnit: HumanizeDay}, want: "now"},
+		{d: -3 * day, lang: "de-AT", want: "vor 3 Tagen"},
+		{d: -3 * day, lang: language.FR, want: "3 days ago"},
+		{d: -3 * day, lang: "", want: "3 days ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, HumanizeDuration(tt.d, tt.lang, tt.granularity))
+		})
+	}
+
+	assert.Equal(t, "vor 2 Stunden", HumanizeSince(time.Now().Add(-2*time.Hour-time.Minute), language.DE, HumanizeGranularity{}))
+	assert.Equal(t, "in 2 weeks", HumanizeUntil(time.Now().Add(15*day), language.EN, HumanizeGranularity{}))
+}
+
+func TestDate_HumanizeRelativeTo(t *testing.T) {
+	ref := Date("2025-06-15")
+	assert.Equal(t, "heute", ref.HumanizeRelativeTo(ref, language.DE, HumanizeGranularity{}))
+	assert.Equal(t, "yesterday", Date("2025-06-14").HumanizeRelativeTo(ref, language.EN, HumanizeGranularity{}))
+	assert.Equal(t, "morgen", Date("2025-06-16").HumanizeRelativeTo(ref, language.DE, HumanizeGranularity{}))
+	assert.Equal(t, "vor 3 Tagen", Date("2025-06-12").HumanizeRelativeTo(ref, language.DE, HumanizeGranularity{}))
+	assert.Equal(t, "in 2 weeks", Date("2025-06-29").HumanizeRelativeTo(ref, language.EN, HumanizeGranularity{}))
+	assert.Equal(t, "14 days ago", Date("2025-06-01").HumanizeRelativeTo(ref, language.EN, HumanizeGranularity{MaxUnit: HumanizeDay}))
+	assert.Empty(t, Date("invalid").HumanizeRelativeTo(ref, language.EN, HumanizeGranularity{}))
+
+	assert.Equal(t, "today", OfToday().HumanizeSince(language.EN, HumanizeGranularity{}))
+	assert.Equal(t, "in 3 days", OfToday().AddDays(3).HumanizeUntil(language.EN, HumanizeGranularity{}))
+}
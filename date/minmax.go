@@ -0,0 +1,148 @@
+package date
+
+import "time"
+
+// MinDate returns the earliest of the passed dates in normalized form.
+// Zero and invalid dates are ignored.
+// Returns an empty string if no date is valid.
+func MinDate(dates ...Date) Date {
+	return extremeDate(dates, -1)
+}
+
+// MaxDate returns the latest of the passed dates in normalized form.
+// Zero and invalid dates are ignored.
+// Returns an empty string if no date is valid.
+func MaxDate(dates ...Date) Date {
+	return extremeDate(dates, +1)
+}
+
+func extremeDate(dates []Date, sign int) (result Date) {
+	for _, date := range dates {
+		if date.IsZero() {
+			continue
+		}
+		norm, err := date.Normalized()
+		if err != nil {
+			continue
+		}
+		if result == "" || norm.Compare(result)*sign > 0 {
+			result = norm
+		}
+	}
+	return result
+}
+
+// MinNullableDate returns the earliest of the passed dates in normalized form.
+// If ignoreNull is true, then null dates are ignored,
+// else Null is returned if any date is null
+// because the earliest date is unknown in that case.
+// Invalid dates are always ignored.
+// Returns Null if no date is valid.
+func MinNullableDate(ignoreNull bool, dates ...NullableDate) NullableDate {
+	return extremeNullableDate(ignoreNull, dates, -1)
+}
+
+// MaxNullableDate returns the latest of the passed dates in normalized form.
+// If ignoreNull is true, then null dates are ignored,
+// else Null is returned if any date is null
+// because the latest date is unknown in that case.
+// Invalid dates are always ignored.
+// Returns Null if no date is valid.
+func MaxNullableDate(ignoreNull bool, dates ...NullableDate) NullableDate {
+	return extremeNullableDate(ignoreNull, dates, +1)
+}
+
+func extremeNullableDate(ignoreNull bool, dates []NullableDate, sign int) NullableDate {
+	if !ignoreNull {
+		for _, date := range dates {
+			if date.IsNull() {
+				return Null
+			}
+		}
+	}
+	result := make([]Date, len(dates))
+	for i, date := range dates {
+		result[i] = Date(date)
+	}
+	return NullableDate(extremeDate(result, sign))
+}
+
+// ClampToRange returns from if the date is before from,
+// until if the date is after until, else the date itself.
+// Zero from or until values are treated as open range bounds.
+func (date Date) ClampToRange(from, until Date) Date {
+	switch {
+	case !from.IsZero() && date.Before(from):
+		return from
+	case !until.IsZero() && date.After(until):
+		return until
+	}
+	return date
+}
+
+// ClampToRange returns from if the date is before from,
+// until if the date is after until, else the date itself.
+// Null from or until values are treated as open range bounds.
+// Returns Null if the NullableDate is null.
+func (n NullableDate) ClampToRange(from, until NullableDate) NullableDate {
+	if n.IsNull() {
+		return Null
+	}
+	return Date(n).ClampToRange(Date(from), Date(until)).Nullable()
+}
+
+// IsEndOfMonth returns true if the date is the last day of its month.
+func (date Date) IsEndOfMonth() bool {
+	return !date.IsZero() && date.NormalizedEqual(date.EndOfMonth())
+}
+
+// AddMonthsClamped returns the date with the passed number of months added
+// clamping the day to the last day of the resulting month
+// instead of overflowing into the following month like AddMonths.
+//
+//	Date("2024-01-31").AddMonthsClamped(1) == "2024-02-29"
+//	Date("2024-01-31").AddMonths(1) == "2024-03-02"
+func (date Date) AddMonthsClamped(months int) Date {
+	if date.IsZero() {
+		return date
+	}
+	y, m, d := date.YearMonthDay()
+	first := Of(y, m, 1).AddMonths(months)
+	end := first.EndOfMonth()
+	_, _, endDay := end.YearMonthDay()
+	if d >= endDay {
+		return end
+	}
+	return first.AddDays(d - 1)
+}
+
+// AddMonthsSnapToEnd returns the date with the passed number of months added
+// like AddMonthsClamped, but if the date is the last day of its month,
+// then the result is snapped to the last day of the resulting month.
+// This is the end-of-month rule used for payment schedules and interest periods.
+//
+//	Date("2024-02-29").AddMonthsSnapToEnd(1) == "2024-03-31"
+//	Date("2024-02-29").AddMonthsClamped(1) == "2024-03-29"
+func (date Date) AddMonthsSnapToEnd(months int) Date {
+	if date.IsEndOfMonth() {
+		y, m, _ := date.YearMonthDay()
+		return Of(y, m, 1).AddMonths(months).EndOfMonth()
+	}
+	return date.AddMonthsClamped(months)
+}
+
+// SnapToMonthEnd returns the last day of the month
+// if the date is within the passed number of days
+// before the end of its month, else the date itself.
+// Useful to normalize month-end dates of documents
+// like "2024-04-29" for the April closing with a tolerance of 2 days.
+func (date Date) SnapToMonthEnd(toleranceDays int) Date {
+	if date.IsZero() {
+		return date
+	}
+	end := date.EndOfMonth()
+	if end.Sub(date) <= time.Duration(toleranceDays)*24*time.Hour {
+		return end
+	}
+	return date
+}
@@ -0,0 +1,53 @@
+package date
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinMaxDate(t *testing.T) {
+	require.Equal(t, Date("2024-01-02"), MinDate("2024-03-01", "", "2024-01-02", "invalid", "2024-02-01"))
+	require.Equal(t, Date("2024-03-01"), MaxDate("2024-03-01", "", "2024-01-02", "invalid", "2024-02-01"))
+	require.Equal(t, Date(""), MinDate())
+	require.Equal(t, Date(""), MaxDate("", "invalid"))
+
+	dates := []NullableDate{"2024-03-01", Null, "2024-01-02"}
+	require.Equal(t, NullableDate("2024-01-02"), MinNullableDate(true, dates...))
+	require.Equal(t, NullableDate("2024-03-01"), MaxNullableDate(true, dates...))
+	require.Equal(t, Null, MinNullableDate(false, dates...))
+	require.Equal(t, Null, MaxNullableDate(false, dates...))
+	require.Equal(t, NullableDate("2024-03-01"), MaxNullableDate(false, "2024-03-01", "2024-01-02"))
+	require.Equal(t, Null, MinNullableDate(true, Null, Null))
+}
+
+func TestDate_ClampToRange(t *testing.T) {
+	require.Equal(t, Date("2024-01-01"), Date("2023-12-31").ClampToRange("2024-01-01", "2024-12-31"))
+	require.Equal(t, Date("2024-12-31"), Date("2025-01-01").ClampToRange("2024-01-01", "2024-12-31"))
+	require.Equal(t, Date("2024-06-15"), Date("2024-06-15").ClampToRange("2024-01-01", "2024-12-31"))
+	require.Equal(t, Date("2030-01-01"), Date("2030-01-01").ClampToRange("2024-01-01", ""))
+	require.Equal(t, Date("2000-01-01"), Date("2000-01-01").ClampToRange("", "2024-12-31"))
+
+	require.Equal(t, NullableDate("2024-01-01"), NullableDate("2023-12-31").ClampToRange("2024-01-01", Null))
+	require.Equal(t, Null, Null.ClampToRange("2024-01-01", "2024-12-31"))
+}
+
+func TestDate_MonthEnd(t *testing.T) {
+	require.True(t, Date("2024-02-29").IsEndOfMonth())
+	require.False(t, Date("2023-02-28").AddDays(-1).IsEndOfMonth())
+	require.False(t, Date("").IsEndOfMonth())
+
+	require.Equal(t, Date("2024-02-29"), Date("2024-01-31").AddMonthsClamped(1))
+	require.Equal(t, Date("2023-02-28"), Date("2023-01-31").AddMonthsClamped(1))
+	require.Equal(t, Date("2024-03-29"), Date("2024-02-29").AddMonthsClamped(1))
+	require.Equal(t, Date("2023-11-30"), Date("2024-01-30").AddMonthsClamped(-2))
+	require.Equal(t, Date("2024-05-15"), Date("2024-01-15").AddMonthsClamped(4))
+
+	require.Equal(t, Date("2024-03-31"), Date("2024-02-29").AddMonthsSnapToEnd(1))
+	require.Equal(t, Date("2024-02-29"), Date("2024-01-31").AddMonthsSnapToEnd(1))
+	require.Equal(t, Date("2024-03-30"), Date("2024-01-30").AddMonthsSnapToEnd(2))
+
+	require.Equal(t, Date("2024-04-30"), Date("2024-04-28").SnapToMonthEnd(2))
+	require.Equal(t, Date("2024-04-27"), Date("2024-04-27").SnapToMonthEnd(2))
+	require.Equal(t, Date("2024-04-30"), Date("2024-04-30").SnapToMonthEnd(0))
+}
@@ -0,0 +1,73 @@
+package date
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeOfDay is a wall clock time of a day
+// given as duration since midnight.
+type TimeOfDay time.Duration
+
+// TimeOfDayOf returns the TimeOfDay for hour, minute, and second.
+// The values are not validated.
+func TimeOfDayOf(hour, minute, second int) TimeOfDay {
+	return TimeOfDay(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second)
+}
+
+// TimeOfDayOfTime returns the wall clock time of t
+// in the location of t.
+func TimeOfDayOfTime(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return TimeOfDayOf(hour, minute, second) + TimeOfDay(t.Nanosecond())
+}
+
+// ParseTimeOfDay parses a time of day in the format "15:04" or "15:04:05".
+func ParseTimeOfDay(str string) (TimeOfDay, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, str); err == nil {
+			return TimeOfDayOfTime(t), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day: %q", str)
+}
+
+// Validate returns an error if the time of day
+// is not in the range from midnight to before the next midnight.
+func (t TimeOfDay) Validate() error {
+	if t < 0 || time.Duration(t) >= 24*time.Hour {
+		return fmt.Errorf("invalid time of day: %s", time.Duration(t))
+	}
+	return nil
+}
+
+// Valid returns true if the time of day is valid.
+func (t TimeOfDay) Valid() bool {
+	return t.Validate() == nil
+}
+
+// Duration returns the time of day as duration since midnight.
+func (t TimeOfDay) Duration() time.Duration {
+	return time.Duration(t)
+}
+
+// Clock returns the hour, minute, and second of the time of day.
+func (t TimeOfDay) Clock() (hour, minute, second int) {
+	d := time.Duration(t)
+	return int(d / time.Hour), int(d % time.Hour / time.Minute), int(d % time.Minute / time.Second)
+}
+
+// On returns the time of the time of day at the passed date
+// in the passed location using the wall clock time,
+// so the result is correct on daylight saving time changes.
+func (t TimeOfDay) On(date Date, loc *time.Location) time.Time {
+	year, month, day := date.YearMonthDay()
+	return time.Date(year, month, day, 0, 0, 0, int(t), loc)
+}
+
+// String returns the time of day in the format "15:04:05".
+// String implements the fmt.Stringer interface.
+func (t TimeOfDay) String() string {
+	hour, minute, second := t.Clock()
+	return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+}
@@ -0,0 +1,160 @@
+package date
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WeekNumbering is a scheme for numbering the weeks of a year.
+type WeekNumbering string
+
+const (
+	// WeekNumberingISO numbers weeks like ISO 8601:
+	// week 1 is the first week with at least four days
+	// in the new year, so the first and last days of a year
+	// can belong to a week of the previous or next year.
+	// Weeks are numbered from 1 to 53.
+	WeekNumberingISO WeekNumbering = "ISO"
+
+	// WeekNumberingUS numbers weeks like common US calendars
+	// and the WEEKNUM function of spreadsheets:
+	// week 1 is the week containing January 1st
+	// and every day belongs to a week of its calendar year,
+	// so the first and last week of a year can be shorter than seven days.
+	// Weeks are numbered from 1 to 54.
+	WeekNumberingUS WeekNumbering = "US"
+)
+
+// Valid returns true if the week numbering is
+// WeekNumberingISO or WeekNumberingUS.
+func (n WeekNumbering) Valid() bool {
+	return n == WeekNumberingISO || n == WeekNumberingUS
+}
+
+// WeekConfig configures the first day of the week
+// and the week numbering scheme used by week based
+// calculations and formatting.
+type WeekConfig struct {
+	Start     time.Weekday  `json:"start"`
+	Numbering WeekNumbering `json:"numbering"`
+}
+
+var (
+	// ISOWeekConfig has weeks starting on Monday
+	// numbered according to ISO 8601.
+	ISOWeekConfig = WeekConfig{Start: time.Monday, Numbering: WeekNumberingISO}
+
+	// USWeekConfig has weeks starting on Sunday
+	// with week 1 containing January 1st.
+	USWeekConfig = WeekConfig{Start: time.Sunday, Numbering: WeekNumberingUS}
+)
+
+// Validate returns an error if Start is not a valid
+// time.Weekday or Numbering is not valid.
+func (c WeekConfig) Validate() error {
+	if c.Start < time.Sunday || c.Start > time.Saturday {
+		return fmt.Errorf("invalid week start day: %d", c.Start)
+	}
+	if !c.Numbering.Valid() {
+		return fmt.Errorf("invalid week numbering: %q", c.Numbering)
+	}
+	return nil
+}
+
+// Valid returns true if the week config is valid.
+func (c WeekConfig) Valid() bool {
+	return c.Validate() == nil
+}
+
+// BeginningOfWeek returns the date of the first day
+// of the week containing the passed date.
+func (c WeekConfig) BeginningOfWeek(date Date) Date {
+	return date.BeginningOfWeek(c.Start)
+}
+
+// EndOfWeek returns the date of the last day
+// of the week containing the passed date.
+func (c WeekConfig) EndOfWeek(date Date) Date {
+	return date.EndOfWeek(c.Start)
+}
+
+// Weekdays returns the seven days of the week
+// in order beginning with Start.
+func (c WeekConfig) Weekdays() []time.Weekday {
+	weekdays := make([]time.Weekday, 7)
+	for i := range weekdays {
+		weekdays[i] = (c.Start + time.Weekday(i)) % 7
+	}
+	return weekdays
+}
+
+// Week returns the year and week number in which the date occurs.
+// With WeekNumberingUS the year is always the calendar year of the date.
+// Returns zeros if the date is not valid.
+func (c WeekConfig) Week(date Date) (year, week int) {
+	if !date.Valid() {
+		return 0, 0
+	}
+	if c.Numbering == WeekNumberingUS {
+		year = date.Year()
+		firstStart := Of(year, time.January, 1).BeginningOfWeek(c.Start)
+		return year, int(date.Sub(firstStart)/(24*time.Hour))/7 + 1
+	}
+	// The middle day of a week decides to which year it belongs
+	middle := date.BeginningOfWeek(c.Start).AddDays(3).MidnightUTC()
+	return middle.Year(), (middle.YearDay()-1)/7 + 1
+}
+
+// WeekRange returns the first and last date of a week of a year.
+// With WeekNumberingUS the range of the first and last week
+// is limited to the calendar year.
+// Returns empty dates if the year has no such week.
+func (c WeekConfig) WeekRange(year, week int) (from, until Date) {
+	if week < 1 || week > 54 {
+		return "", ""
+	}
+	if c.Numbering == WeekNumberingUS {
+		jan1, dec31 := YearRange(year)
+		from = jan1.BeginningOfWeek(c.Start).AddDays((week - 1) * 7)
+		if from.After(dec31) {
+			return "", ""
+		}
+		return MaxDate(from, jan1), MinDate(from.AddDays(6), dec31)
+	}
+	// Week 1 always contains the 4th of January
+	from = Of(year, time.January, 4).BeginningOfWeek(c.Start).AddDays((week - 1) * 7)
+	if y, _ := c.Week(from); y != year {
+		return "", ""
+	}
+	return from, from.AddDays(6)
+}
+
+// FormatWeek formats the year and week number of the date
+// in the format "YYYY-Wnn" like "2024-W05".
+// Returns an empty string if the date is not valid.
+func (c WeekConfig) FormatWeek(date Date) string {
+	year, week := c.Week(date)
+	if week == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+var weekConfigCtxKey int
+
+// ContextWithWeekConfig returns a context with the passed WeekConfig
+// that is returned by WeekConfigFromContext.
+// This way services can use the week settings of the user per request.
+func ContextWithWeekConfig(ctx context.Context, config WeekConfig) context.Context {
+	return context.WithValue(ctx, &weekConfigCtxKey, config)
+}
+
+// WeekConfigFromContext returns the WeekConfig added
+// to the context with ContextWithWeekConfig or ISOWeekConfig.
+func WeekConfigFromContext(ctx context.Context) WeekConfig {
+	if config, ok := ctx.Value(&weekConfigCtxKey).(WeekConfig); ok {
+		return config
+	}
+	return ISOWeekConfig
+}
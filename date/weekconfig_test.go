@@ -0,0 +1,101 @@
+package date
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekConfig_Week(t *testing.T) {
+	tests := []struct {
+		config   WeekConfig
+		date     Date
+		wantYear int
+		wantWeek int
+	}{
+		// 2027-01-01 is a Friday
+		{ISOWeekConfig, "2027-01-01", 2026, 53},
+		{ISOWeekConfig, "2027-01-04", 2027, 1},
+		{USWeekConfig, "2027-01-01", 2027, 1},
+		{USWeekConfig, "2027-01-02", 2027, 1},
+		{USWeekConfig, "2027-01-03", 2027, 2},
+		// 2024-12-30 is a Monday
+		{ISOWeekConfig, "2024-12-30", 2025, 1},
+		{USWeekConfig, "2024-12-30", 2024, 53},
+		// 2000 is a leap year starting on Saturday with 54 US weeks
+		{USWeekConfig, "2000-12-31", 2000, 54},
+		{WeekConfig{Start: time.Monday, Numbering: WeekNumberingUS}, "2027-01-04", 2027, 2},
+		{WeekConfig{Start: time.Sunday, Numbering: WeekNumberingISO}, "2027-01-03", 2027, 1},
+		{ISOWeekConfig, "invalid", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.config.FormatWeek(tt.date), func(t *testing.T) {
+			year, week := tt.config.Week(tt.date)
+			assert.Equal(t, tt.wantYear, year, "year")
+			assert.Equal(t, tt.wantWeek, week, "week")
+		})
+	}
+
+	// ISO numbering with Monday start matches time.Time.ISOWeek
+	for date := Date("2020-12-20"); date.Before("2028-01-10"); date = date.AddDays(1) {
+		year, week := ISOWeekConfig.Week(date)
+		wantYear, wantWeek := date.ISOWeek()
+		require.Equal(t, wantYear, year, date)
+		require.Equal(t, wantWeek, week, date)
+	}
+}
+
+func TestWeekConfig_WeekRange(t *testing.T) {
+	configs := []WeekConfig{
+		ISOWeekConfig,
+		USWeekConfig,
+		{Start: time.Monday, Numbering: WeekNumberingUS},
+		{Start: time.Sunday, Numbering: WeekNumberingISO},
+	}
+	for _, config := range configs {
+		// Every date is within the range of its week
+		for date := Date("1999-12-01"); date.Before("2001-02-01"); date = date.AddDays(1) {
+			year, week := config.Week(date)
+			from, until := config.WeekRange(year, week)
+			require.True(t, date.WithinIncl(from, until), "%v %s not in %s - %s", config, date, from, until)
+		}
+	}
+
+	from, until := USWeekConfig.WeekRange(2027, 1)
+	assert.Equal(t, Date("2027-01-01"), from)
+	assert.Equal(t, Date("2027-01-02"), until)
+	from, until = ISOWeekConfig.WeekRange(2026, 53)
+	assert.Equal(t, Date("2026-12-28"), from)
+	assert.Equal(t, Date("2027-01-03"), until)
+
+	from, until = ISOWeekConfig.WeekRange(2027, 53)
+	assert.Empty(t, from, "2027 has only 52 ISO weeks")
+	assert.Empty(t, until)
+	from, until = USWeekConfig.WeekRange(2027, 54)
+	assert.Empty(t, from)
+	assert.Empty(t, until)
+}
+
+func TestWeekConfig_Validate(t *testing.T) {
+	assert.NoError(t, ISOWeekConfig.Validate())
+	assert.NoError(t, USWeekConfig.Validate())
+	assert.Error(t, WeekConfig{}.Validate())
+	assert.Error(t, WeekConfig{Start: 7, Numbering: WeekNumberingISO}.Validate())
+
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}, USWeekConfig.Weekdays())
+	assert.Equal(t, time.Monday, ISOWeekConfig.Weekdays()[0])
+	assert.Equal(t, time.Sunday, ISOWeekConfig.Weekdays()[6])
+	assert.Equal(t, Date("2027-01-03"), USWeekConfig.BeginningOfWeek("2027-01-09"))
+	assert.Equal(t, Date("2027-01-10"), ISOWeekConfig.EndOfWeek("2027-01-09"))
+}
+
+func TestWeekConfigFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ISOWeekConfig, WeekConfigFromContext(ctx))
+	ctx = ContextWithWeekConfig(ctx, USWeekConfig)
+	assert.Equal(t, USWeekConfig, WeekConfigFromContext(ctx))
+	assert.Equal(t, "2024-W53", WeekConfigFromContext(ctx).FormatWeek("2024-12-30"))
+}
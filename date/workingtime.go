@@ -0,0 +1,133 @@
+package date
+
+import (
+	"time"
+)
+
+// HolidayCalendar is implemented by types
+// that know which dates are public holidays.
+type HolidayCalendar interface {
+	IsHoliday(date Date) bool
+}
+
+// Holidays maps public holiday dates to their names
+// and implements HolidayCalendar.
+type Holidays map[Date]string
+
+// IsHoliday implements the HolidayCalendar interface.
+func (h Holidays) IsHoliday(date Date) bool {
+	_, ok := h[date]
+	return ok
+}
+
+// HolidayFunc implements HolidayCalendar with a function.
+type HolidayFunc func(date Date) bool
+
+// IsHoliday implements the HolidayCalendar interface.
+func (f HolidayFunc) IsHoliday(date Date) bool {
+	return f(date)
+}
+
+// IsWeekend returns if the date is a Saturday or Sunday.
+func (date Date) IsWeekend() bool {
+	weekday := date.Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
+}
+
+// BridgeDays returns the working days of a year that are
+// sandwiched between a public holiday of the calendar
+// and another holiday or weekend day, like the Friday
+// after a holiday on Thursday.
+// Saturday and Sunday are considered as weekend days.
+func BridgeDays(year int, calendar HolidayCalendar) []Date {
+	dayOff := func(date Date) bool {
+		return date.IsWeekend() || calendar.IsHoliday(date)
+	}
+	var bridgeDays []Date
+	from, until := YearRange(year)
+	for date := from; !date.After(until); date = date.AddDate(0, 0, 1) {
+		if dayOff(date) {
+			continue
+		}
+		before, after := date.AddDate(0, 0, -1), date.AddDate(0, 0, 1)
+		if dayOff(before) && dayOff(after) && (calendar.IsHoliday(before) || calendar.IsHoliday(after)) {
+			bridgeDays = append(bridgeDays, date)
+		}
+	}
+	return bridgeDays
+}
+
+// WorkingTime is a time range of a day
+// given as durations since midnight.
+type WorkingTime struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// WorkSchedule is a weekly schedule of working times
+// excluding public holidays.
+type WorkSchedule struct {
+	// Weekdays holds the working times indexed by time.Weekday.
+	Weekdays [7][]WorkingTime
+	// Holidays are days without working time, can be nil.
+	Holidays HolidayCalendar
+	// Location of the working times,
+	// the location of the start time is used if nil.
+	Location *time.Location
+}
+
+// MondayToFriday returns a WorkSchedule with the same
+// working time from start to end on Monday to Friday.
+func MondayToFriday(start, end time.Duration, holidays HolidayCalendar, location *time.Location) *WorkSchedule {
+	s := &WorkSchedule{Holidays: holidays, Location: location}
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		s.Weekdays[weekday] = []WorkingTime{{Start: start, End: end}}
+	}
+	return s
+}
+
+// IsWorkingDay returns if the date has working times
+// and is not a holiday.
+func (s *WorkSchedule) IsWorkingDay(date Date) bool {
+	if len(s.Weekdays[date.Weekday()]) == 0 {
+		return false
+	}
+	return s.Holidays == nil || !s.Holidays.IsHoliday(date)
+}
+
+// WorkingHoursBetween returns the working time of the schedule
+// between start and end, excluding days without working times
+// and holidays. Returns zero if end is not after start.
+func WorkingHoursBetween(start, end time.Time, schedule *WorkSchedule) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+	loc := schedule.Location
+	if loc == nil {
+		loc = start.Location()
+	}
+	var total time.Duration
+	lastDate := OfTime(end.In(loc))
+	for date := OfTime(start.In(loc)); !date.After(lastDate); date = date.AddDate(0, 0, 1) {
+		if !schedule.IsWorkingDay(date) {
+			continue
+		}
+		year, month, day := date.YearMonthDay()
+		for _, wt := range schedule.Weekdays[date.Weekday()] {
+			// time.Date normalizes the nanoseconds to wall clock time
+			// so that working times are correct on DST changes
+			from := time.Date(year, month, day, 0, 0, 0, int(wt.Start), loc)
+			until := time.Date(year, month, day, 0, 0, 0, int(wt.End), loc)
+			if from.Before(start) {
+				from = start
+			}
+			if until.After(end) {
+				until = end
+			}
+			if until.After(from) {
+				total += until.Sub(from)
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,50 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Public holidays in Austria and Germany in 2024
+var testHolidays2024 = Holidays{
+	"2024-05-01": "Labour Day",
+	"2024-05-09": "Ascension Day",
+	"2024-05-30": "Corpus Christi",
+	"2024-10-03": "German Unity Day",
+	"2024-12-25": "Christmas Day",
+	"2024-12-26": "St. Stephen's Day",
+}
+
+func TestBridgeDays(t *testing.T) {
+	expected := []Date{"2024-05-10", "2024-05-31", "2024-10-04", "2024-12-27"}
+	assert.Equal(t, expected, BridgeDays(2024, testHolidays2024))
+	assert.Empty(t, BridgeDays(2024, Holidays{}))
+}
+
+func TestWorkingHoursBetween(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		t.Skip(err)
+	}
+	schedule := MondayToFriday(9*time.Hour, 17*time.Hour, testHolidays2024, vienna)
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       time.Duration
+	}{
+		{name: "same day", start: time.Date(2024, 4, 30, 10, 0, 0, 0, vienna), end: time.Date(2024, 4, 30, 12, 30, 0, 0, vienna), want: 150 * time.Minute},
+		{name: "over holiday", start: time.Date(2024, 4, 30, 16, 0, 0, 0, vienna), end: time.Date(2024, 5, 2, 10, 0, 0, 0, vienna), want: 2 * time.Hour},
+		{name: "over weekend", start: time.Date(2024, 6, 7, 12, 0, 0, 0, vienna), end: time.Date(2024, 6, 10, 12, 0, 0, 0, vienna), want: 8 * time.Hour},
+		{name: "outside working time", start: time.Date(2024, 6, 7, 18, 0, 0, 0, vienna), end: time.Date(2024, 6, 10, 8, 0, 0, 0, vienna), want: 0},
+		{name: "UTC input", start: time.Date(2024, 6, 10, 7, 0, 0, 0, time.UTC), end: time.Date(2024, 6, 10, 8, 0, 0, 0, time.UTC), want: time.Hour},
+		{name: "end before start", start: time.Date(2024, 6, 10, 12, 0, 0, 0, vienna), end: time.Date(2024, 6, 10, 10, 0, 0, 0, vienna), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WorkingHoursBetween(tt.start, tt.end, schedule))
+		})
+	}
+}
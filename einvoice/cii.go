@@ -0,0 +1,730 @@
+package einvoice
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-types/bank"
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/vat"
+)
+
+// Specification identifiers (BT-24) of the ZUGFeRD/Factur-X profiles
+// in addition to SpecificationEN16931 used by the EN 16931 profile
+// that is also called COMFORT.
+const (
+	SpecificationFacturXMinimum  = "urn:factur-x.eu:1p0:minimum"
+	SpecificationFacturXBasicWL  = "urn:factur-x.eu:1p0:basicwl"
+	SpecificationFacturXBasic    = "urn:cen.eu:en16931:2017#compliant#urn:factur-x.eu:1p0:basic"
+	SpecificationFacturXExtended = "urn:cen.eu:en16931:2017#conformant#urn:factur-x.eu:1p0:extended"
+)
+
+// XML namespaces of the UN/CEFACT Cross Industry Invoice (CII)
+// syntax used by ZUGFeRD/Factur-X and XRechnung.
+const (
+	CIINamespaceRSM = "urn:un:unece:uncefact:data:standard:CrossIndustryInvoice:100"
+	CIINamespaceRAM = "urn:un:unece:uncefact:data:standard:ReusableAggregateBusinessInformationEntity:100"
+	CIINamespaceQDT = "urn:un:unece:uncefact:data:standard:QualifiedDataType:100"
+	CIINamespaceUDT = "urn:un:unece:uncefact:data:standard:UnqualifiedDataType:100"
+)
+
+// ciiPrefixes are the conventional prefixes of the CII namespaces
+// used as literal element name prefixes by the cii* structs.
+var ciiPrefixes = map[string]string{
+	CIINamespaceRSM: "rsm",
+	CIINamespaceRAM: "ram",
+	CIINamespaceQDT: "qdt",
+	CIINamespaceUDT: "udt",
+}
+
+// MarshalCII returns the invoice as UN/CEFACT Cross Industry Invoice
+// XML document as embedded in ZUGFeRD/Factur-X PDFs.
+// The profile is defined by the SpecificationID of the invoice.
+//
+// Amounts are formatted with two decimals, VAT rates as percentages,
+// and dates in the format "102" (YYYYMMDD).
+// The business rules are not checked, use Invoice.Validate for that.
+func (inv *Invoice) MarshalCII() ([]byte, error) {
+	if inv.SpecificationID == "" {
+		return nil, errors.New("missing invoice specification identifier")
+	}
+	issueDate, err := inv.IssueDate.Normalized()
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice issue date: %w", err)
+	}
+	currency, err := inv.Currency.Normalized()
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice currency: %w", err)
+	}
+
+	doc := ciiDocument{
+		XmlnsRSM:        CIINamespaceRSM,
+		XmlnsRAM:        CIINamespaceRAM,
+		XmlnsQDT:        CIINamespaceQDT,
+		XmlnsUDT:        CIINamespaceUDT,
+		SpecificationID: inv.SpecificationID,
+		Document: ciiExchangedDocument{
+			ID:        inv.Number,
+			TypeCode:  string(inv.TypeCode),
+			IssueDate: ciiDateOf(issueDate),
+		},
+	}
+	for _, note := range inv.Notes {
+		doc.Document.Notes = append(doc.Document.Notes, ciiNote{Content: note})
+	}
+
+	tx := &doc.Transaction
+	for i := range inv.Lines {
+		tx.Lines = append(tx.Lines, ciiLineOf(&inv.Lines[i]))
+	}
+
+	tx.Agreement.BuyerReference = inv.BuyerReference
+	tx.Agreement.Seller = ciiPartyOf(&inv.Seller, true)
+	tx.Agreement.Buyer = ciiPartyOf(&inv.Buyer, false)
+	if inv.OrderReference != "" {
+		tx.Agreement.BuyerOrder = &ciiReferencedDocument{IssuerAssignedID: inv.OrderReference}
+	}
+
+	if inv.DeliveryDate.IsNotNull() {
+		tx.Delivery.Event = &ciiDeliveryEvent{Occurrence: ciiDateOf(inv.DeliveryDate.Get())}
+	}
+
+	settlement := &tx.Settlement
+	settlement.Currency = string(currency)
+	var mandateReference string
+	for _, pm := range inv.PaymentMeans {
+		settlement.CreditorReferenceID = cmp.Or(settlement.CreditorReferenceID, string(pm.CreditorID))
+		settlement.PaymentReference = cmp.Or(settlement.PaymentReference, pm.RemittanceInformation)
+		mandateReference = cmp.Or(mandateReference, pm.MandateReference)
+		settlement.PaymentMeans = append(settlement.PaymentMeans, ciiPaymentMeansOf(&pm))
+	}
+	for _, vb := range inv.VATBreakdown {
+		settlement.Taxes = append(settlement.Taxes, ciiTradeTax{
+			CalculatedAmount:    formatAmount(vb.TaxAmount),
+			TypeCode:            "VAT",
+			ExemptionReason:     vb.ExemptionReason,
+			BasisAmount:         formatAmount(vb.TaxableAmount),
+			CategoryCode:        string(vb.Category),
+			ExemptionReasonCode: vb.ExemptionReasonCode,
+			RatePercent:         formatPercent(vb.Rate),
+		})
+	}
+	for _, ac := range inv.AllowancesCharges {
+		settlement.AllowancesCharges = append(settlement.AllowancesCharges, ciiAllowanceCharge{
+			ChargeIndicator: ciiIndicator{Value: ac.Charge},
+			ActualAmount:    formatAmount(ac.Amount),
+			Reason:          ac.Reason,
+			Tax: ciiTradeTax{
+				TypeCode:     "VAT",
+				CategoryCode: string(ac.VATCategory),
+				RatePercent:  formatPercent(ac.VATRate),
+			},
+		})
+	}
+	if inv.PaymentTerms != "" || inv.DueDate.IsNotNull() || mandateReference != "" {
+		terms := &ciiPaymentTerms{
+			Description: inv.PaymentTerms,
+			MandateID:   mandateReference,
+		}
+		if inv.DueDate.IsNotNull() {
+			dueDate := ciiDateOf(inv.DueDate.Get())
+			terms.DueDate = &dueDate
+		}
+		settlement.PaymentTerms = terms
+	}
+	t := &inv.Totals
+	settlement.Summation = ciiSummation{
+		LineTotal:          formatAmount(t.LineNetAmount),
+		ChargeTotal:        ciiOptionalAmountString(t.ChargeTotal),
+		AllowanceTotal:     ciiOptionalAmountString(t.AllowanceTotal),
+		TaxBasisTotal:      formatAmount(t.TaxExclusiveAmount),
+		TaxTotals:          []ciiAmount{{CurrencyID: string(currency), Value: formatAmount(t.TaxAmount)}},
+		RoundingAmount:     ciiOptionalAmountString(t.RoundingAmount),
+		GrandTotal:         formatAmount(t.TaxInclusiveAmount),
+		TotalPrepaidAmount: ciiOptionalAmountString(t.PrepaidAmount),
+		DuePayableAmount:   formatAmount(t.PayableAmount),
+	}
+
+	out, err := xml.MarshalIndent(&doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// ParseCII parses a UN/CEFACT Cross Industry Invoice XML document
+// of any ZUGFeRD/Factur-X profile or XRechnung as Invoice.
+//
+// The document level remittance information (BT-83),
+// creditor identifier (BT-90), and mandate reference (BT-89)
+// are assigned to the first PaymentMeans.
+func ParseCII(data []byte) (*Invoice, error) {
+	var doc ciiDocument
+	dec := xml.NewTokenDecoder(&prefixTokenReader{dec: xml.NewDecoder(bytes.NewReader(data)), prefixes: ciiPrefixes})
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("can't parse CII XML: %w", err)
+	}
+	if doc.XMLName.Local != "rsm:CrossIndustryInvoice" {
+		return nil, fmt.Errorf("root element %q is not a CII rsm:CrossIndustryInvoice", doc.XMLName.Local)
+	}
+
+	var (
+		p   = valueParser{syntax: "CII"}
+		inv = &Invoice{
+			Number:          doc.Document.ID,
+			TypeCode:        TypeCode(doc.Document.TypeCode),
+			SpecificationID: doc.SpecificationID,
+		}
+		tx         = &doc.Transaction
+		settlement = &tx.Settlement
+	)
+	inv.IssueDate = p.ciiDate(doc.Document.IssueDate, "issue date")
+	for _, note := range doc.Document.Notes {
+		inv.Notes = append(inv.Notes, note.Content)
+	}
+	inv.Currency = money.Currency(settlement.Currency)
+	inv.BuyerReference = tx.Agreement.BuyerReference
+	if tx.Agreement.BuyerOrder != nil {
+		inv.OrderReference = tx.Agreement.BuyerOrder.IssuerAssignedID
+	}
+	inv.Seller = p.ciiParty(&tx.Agreement.Seller)
+	inv.Buyer = p.ciiParty(&tx.Agreement.Buyer)
+	if tx.Delivery.Event != nil {
+		inv.DeliveryDate = p.ciiDate(tx.Delivery.Event.Occurrence, "delivery date").Nullable()
+	}
+
+	for _, pm := range settlement.PaymentMeans {
+		means := PaymentMeans{
+			Code: PaymentMeansCode(pm.TypeCode),
+			Text: pm.Information,
+		}
+		if pm.PayerAccount != nil {
+			means.DebitedIBAN = bank.NullableIBAN(pm.PayerAccount.IBAN)
+		}
+		if pm.PayeeAccount != nil {
+			means.IBAN = bank.NullableIBAN(pm.PayeeAccount.IBAN)
+			means.AccountName = pm.PayeeAccount.AccountName
+		}
+		if pm.PayeeInstitution != nil {
+			means.BIC = bank.NullableBIC(pm.PayeeInstitution.BIC)
+		}
+		inv.PaymentMeans = append(inv.PaymentMeans, means)
+	}
+	if len(inv.PaymentMeans) > 0 {
+		inv.PaymentMeans[0].RemittanceInformation = settlement.PaymentReference
+		inv.PaymentMeans[0].CreditorID = bank.CreditorID(settlement.CreditorReferenceID)
+		if settlement.PaymentTerms != nil {
+			inv.PaymentMeans[0].MandateReference = settlement.PaymentTerms.MandateID
+		}
+	}
+	if terms := settlement.PaymentTerms; terms != nil {
+		inv.PaymentTerms = terms.Description
+		if terms.DueDate != nil {
+			inv.DueDate = p.ciiDate(*terms.DueDate, "due date").Nullable()
+		}
+	}
+
+	for _, tax := range settlement.Taxes {
+		inv.VATBreakdown = append(inv.VATBreakdown, VATBreakdown{
+			TaxableAmount:       p.amount(tax.BasisAmount, "VAT basis amount"),
+			TaxAmount:           p.amount(tax.CalculatedAmount, "VAT amount"),
+			Category:            VATCategory(tax.CategoryCode),
+			Rate:                p.percent(tax.RatePercent),
+			ExemptionReason:     tax.ExemptionReason,
+			ExemptionReasonCode: tax.ExemptionReasonCode,
+		})
+	}
+	for _, ac := range settlement.AllowancesCharges {
+		inv.AllowancesCharges = append(inv.AllowancesCharges, AllowanceCharge{
+			Charge:      ac.ChargeIndicator.Value,
+			Amount:      p.amount(ac.ActualAmount, "allowance or charge amount"),
+			Reason:      ac.Reason,
+			VATCategory: VATCategory(ac.Tax.CategoryCode),
+			VATRate:     p.percent(ac.Tax.RatePercent),
+		})
+	}
+
+	sum := &settlement.Summation
+	inv.Totals = Totals{
+		LineNetAmount:      p.amount(sum.LineTotal, "line total amount"),
+		AllowanceTotal:     p.amount(sum.AllowanceTotal, "allowance total amount"),
+		ChargeTotal:        p.amount(sum.ChargeTotal, "charge total amount"),
+		TaxExclusiveAmount: p.amount(sum.TaxBasisTotal, "tax basis total amount"),
+		TaxInclusiveAmount: p.amount(sum.GrandTotal, "grand total amount"),
+		PrepaidAmount:      p.amount(sum.TotalPrepaidAmount, "prepaid amount"),
+		RoundingAmount:     p.amount(sum.RoundingAmount, "rounding amount"),
+		PayableAmount:      p.amount(sum.DuePayableAmount, "due payable amount"),
+	}
+	if taxTotal := ciiTaxTotal(sum.TaxTotals, settlement.Currency); taxTotal != nil {
+		inv.Totals.TaxAmount = p.amount(taxTotal.Value, "tax total amount")
+	}
+
+	for i := range tx.Lines {
+		l := &tx.Lines[i]
+		line := Line{
+			ID:          l.Document.LineID,
+			ItemName:    l.Product.Name,
+			NetPrice:    p.amount(l.Agreement.NetPrice.ChargeAmount, "net price"),
+			NetAmount:   p.amount(l.Settlement.Summation.LineTotal, "line total amount"),
+			VATCategory: VATCategory(l.Settlement.Tax.CategoryCode),
+			VATRate:     p.percent(l.Settlement.Tax.RatePercent),
+		}
+		if l.Document.Note != nil {
+			line.Note = l.Document.Note.Content
+		}
+		line.Quantity.Unit = l.Delivery.BilledQuantity.UnitCode
+		line.Quantity.Value = p.float(l.Delivery.BilledQuantity.Value, "billed quantity")
+		if base := l.Agreement.NetPrice.BasisQuantity; base != nil {
+			line.BaseQuantity = p.float(base.Value, "basis quantity")
+		}
+		inv.Lines = append(inv.Lines, line)
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	return inv, nil
+}
+
+// valueParser parses the values of an XML syntax
+// and keeps the first error.
+type valueParser struct {
+	syntax string
+	err    error
+}
+
+func (p *valueParser) float(s, field string) float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("can't parse %s %s %q: %w", p.syntax, field, s, err)
+	}
+	return f
+}
+
+func (p *valueParser) amount(s, field string) money.Amount {
+	return money.Amount(p.float(s, field))
+}
+
+func (p *valueParser) percent(s string) money.Rate {
+	return money.Rate(p.float(s, "VAT rate") / 100)
+}
+
+func (p *valueParser) ciiDate(d ciiDate, field string) date.Date {
+	if d.String.Format != "" && d.String.Format != "102" && p.err == nil {
+		p.err = fmt.Errorf("unsupported %s %s format %q", p.syntax, field, d.String.Format)
+		return ""
+	}
+	parsed, err := date.Parse("20060102", strings.TrimSpace(d.String.Value))
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("can't parse %s %s: %w", p.syntax, field, err)
+	}
+	return parsed
+}
+
+func (p *valueParser) ciiParty(cp *ciiParty) Party {
+	party := Party{
+		Name: cp.Name,
+		Address: Address{
+			Line1:       cp.Address.LineOne,
+			Line2:       cp.Address.LineTwo,
+			City:        cp.Address.City,
+			PostCode:    cp.Address.Postcode,
+			Subdivision: cp.Address.Subdivision,
+			Country:     country.Code(cp.Address.Country),
+		},
+	}
+	if cp.LegalOrganization != nil {
+		party.LegalRegistrationID = cp.LegalOrganization.ID
+		party.TradingName = cp.LegalOrganization.TradingName
+	}
+	if cp.Communication != nil {
+		party.ElectronicAddress = cp.Communication.URIID.Value
+		party.ElectronicAddressScheme = cp.Communication.URIID.SchemeID
+	}
+	for _, reg := range cp.TaxRegistrations {
+		switch reg.ID.SchemeID {
+		case "VA":
+			party.VATID = vat.NullableID(reg.ID.Value)
+		case "FC":
+			party.TaxRegistrationID = reg.ID.Value
+		}
+	}
+	return party
+}
+
+func ciiPartyOf(party *Party, seller bool) ciiParty {
+	cp := ciiParty{
+		Name: party.Name,
+		Address: ciiAddress{
+			Postcode:    party.Address.PostCode,
+			LineOne:     party.Address.Line1,
+			LineTwo:     party.Address.Line2,
+			City:        party.Address.City,
+			Country:     string(party.Address.Country),
+			Subdivision: party.Address.Subdivision,
+		},
+	}
+	if party.LegalRegistrationID != "" || party.TradingName != "" {
+		cp.LegalOrganization = &ciiLegalOrganization{
+			ID:          party.LegalRegistrationID,
+			TradingName: party.TradingName,
+		}
+	}
+	if party.ElectronicAddress != "" {
+		cp.Communication = &ciiCommunication{URIID: ciiID{
+			SchemeID: cmp.Or(party.ElectronicAddressScheme, "EM"),
+			Value:    party.ElectronicAddress,
+		}}
+	}
+	if party.VATID.IsNotNull() {
+		cp.TaxRegistrations = append(cp.TaxRegistrations, ciiTaxRegistration{ID: ciiID{SchemeID: "VA", Value: party.VATID.String()}})
+	}
+	if seller && party.TaxRegistrationID != "" {
+		cp.TaxRegistrations = append(cp.TaxRegistrations, ciiTaxRegistration{ID: ciiID{SchemeID: "FC", Value: party.TaxRegistrationID}})
+	}
+	return cp
+}
+
+func ciiPaymentMeansOf(pm *PaymentMeans) ciiPaymentMeans {
+	c := ciiPaymentMeans{
+		TypeCode:    string(pm.Code),
+		Information: pm.Text,
+	}
+	if pm.DebitedIBAN.IsNotNull() {
+		c.PayerAccount = &ciiFinancialAccount{IBAN: pm.DebitedIBAN.String()}
+	}
+	if pm.IBAN.IsNotNull() {
+		c.PayeeAccount = &ciiFinancialAccount{IBAN: pm.IBAN.String(), AccountName: pm.AccountName}
+	}
+	if pm.BIC.IsNotNull() {
+		c.PayeeInstitution = &ciiFinancialInstitution{BIC: pm.BIC.String()}
+	}
+	return c
+}
+
+func ciiLineOf(line *Line) ciiLineItem {
+	item := ciiLineItem{
+		Document: ciiLineDocument{LineID: line.ID},
+		Product:  ciiProduct{Name: line.ItemName},
+	}
+	if line.Note != "" {
+		item.Document.Note = &ciiNote{Content: line.Note}
+	}
+	item.Agreement.NetPrice.ChargeAmount = formatAmount(line.NetPrice)
+	if line.BaseQuantity != 0 {
+		item.Agreement.NetPrice.BasisQuantity = &ciiQuantity{
+			UnitCode: line.Quantity.Unit,
+			Value:    strconv.FormatFloat(line.BaseQuantity, 'f', -1, 64),
+		}
+	}
+	item.Delivery.BilledQuantity = ciiQuantity{
+		UnitCode: line.Quantity.Unit,
+		Value:    strconv.FormatFloat(line.Quantity.Value, 'f', -1, 64),
+	}
+	item.Settlement.Tax = ciiTradeTax{
+		TypeCode:     "VAT",
+		CategoryCode: string(line.VATCategory),
+		RatePercent:  formatPercent(line.VATRate),
+	}
+	item.Settlement.Summation.LineTotal = formatAmount(line.NetAmount)
+	return item
+}
+
+func ciiDateOf(d date.Date) ciiDate {
+	return ciiDate{String: ciiDateString{Format: "102", Value: d.Format("20060102")}}
+}
+
+func formatAmount(a money.Amount) string {
+	return a.Format(0, '.', 2)
+}
+
+func ciiOptionalAmountString(a money.Amount) string {
+	if a == 0 {
+		return ""
+	}
+	return formatAmount(a)
+}
+
+func formatPercent(rate money.Rate) string {
+	return money.Amount(rate*100).Format(0, '.', 2)
+}
+
+// prefixTokenReader renames the elements of the namespaces
+// in prefixes to their conventional prefixes like "ram:ID"
+// so that documents are decoded independently of the prefixes
+// they use into the structs with prefixed element names.
+// Namespace declarations are removed.
+type prefixTokenReader struct {
+	dec      *xml.Decoder
+	prefixes map[string]string
+}
+
+func (r *prefixTokenReader) Token() (xml.Token, error) {
+	tok, err := r.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case xml.StartElement:
+		t.Name = r.prefixedName(t.Name)
+		attrs := t.Attr[:0:0]
+		for _, attr := range t.Attr {
+			if attr.Name.Space != "xmlns" && attr.Name.Local != "xmlns" {
+				attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attr.Name.Local}, Value: attr.Value})
+			}
+		}
+		t.Attr = attrs
+		return t, nil
+	case xml.EndElement:
+		t.Name = r.prefixedName(t.Name)
+		return t, nil
+	}
+	return tok, nil
+}
+
+// prefixedName returns name without namespace
+// and with the prefix of its namespace if there is one.
+func (r *prefixTokenReader) prefixedName(name xml.Name) xml.Name {
+	if prefix := r.prefixes[name.Space]; prefix != "" {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+	return xml.Name{Local: name.Local}
+}
+
+var _ xml.TokenReader = (*prefixTokenReader)(nil)
+
+type ciiDocument struct {
+	XMLName         xml.Name             `xml:"rsm:CrossIndustryInvoice"`
+	XmlnsRSM        string               `xml:"xmlns:rsm,attr,omitempty"`
+	XmlnsRAM        string               `xml:"xmlns:ram,attr,omitempty"`
+	XmlnsQDT        string               `xml:"xmlns:qdt,attr,omitempty"`
+	XmlnsUDT        string               `xml:"xmlns:udt,attr,omitempty"`
+	SpecificationID string               `xml:"rsm:ExchangedDocumentContext>ram:GuidelineSpecifiedDocumentContextParameter>ram:ID"`
+	Document        ciiExchangedDocument `xml:"rsm:ExchangedDocument"`
+	Transaction     ciiTradeTransaction  `xml:"rsm:SupplyChainTradeTransaction"`
+}
+
+type ciiExchangedDocument struct {
+	ID        string    `xml:"ram:ID"`
+	TypeCode  string    `xml:"ram:TypeCode"`
+	IssueDate ciiDate   `xml:"ram:IssueDateTime"`
+	Notes     []ciiNote `xml:"ram:IncludedNote"`
+}
+
+type ciiNote struct {
+	Content string `xml:"ram:Content"`
+}
+
+type ciiDate struct {
+	String ciiDateString `xml:"udt:DateTimeString"`
+}
+
+type ciiDateString struct {
+	Format string `xml:"format,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type ciiID struct {
+	SchemeID string `xml:"schemeID,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ciiAmount struct {
+	CurrencyID string `xml:"currencyID,attr,omitempty"`
+	Value      string `xml:",chardata"`
+}
+
+type ciiQuantity struct {
+	UnitCode string `xml:"unitCode,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ciiIndicator struct {
+	Value bool `xml:"udt:Indicator"`
+}
+
+type ciiTradeTransaction struct {
+	Lines      []ciiLineItem `xml:"ram:IncludedSupplyChainTradeLineItem"`
+	Agreement  ciiAgreement  `xml:"ram:ApplicableHeaderTradeAgreement"`
+	Delivery   ciiDelivery   `xml:"ram:ApplicableHeaderTradeDelivery"`
+	Settlement ciiSettlement `xml:"ram:ApplicableHeaderTradeSettlement"`
+}
+
+type ciiLineItem struct {
+	Document   ciiLineDocument   `xml:"ram:AssociatedDocumentLineDocument"`
+	Product    ciiProduct        `xml:"ram:SpecifiedTradeProduct"`
+	Agreement  ciiLineAgreement  `xml:"ram:SpecifiedLineTradeAgreement"`
+	Delivery   ciiLineDelivery   `xml:"ram:SpecifiedLineTradeDelivery"`
+	Settlement ciiLineSettlement `xml:"ram:SpecifiedLineTradeSettlement"`
+}
+
+type ciiLineDocument struct {
+	LineID string   `xml:"ram:LineID"`
+	Note   *ciiNote `xml:"ram:IncludedNote,omitempty"`
+}
+
+type ciiProduct struct {
+	Name string `xml:"ram:Name"`
+}
+
+type ciiLineAgreement struct {
+	NetPrice ciiTradePrice `xml:"ram:NetPriceProductTradePrice"`
+}
+
+type ciiTradePrice struct {
+	ChargeAmount  string       `xml:"ram:ChargeAmount"`
+	BasisQuantity *ciiQuantity `xml:"ram:BasisQuantity,omitempty"`
+}
+
+type ciiLineDelivery struct {
+	BilledQuantity ciiQuantity `xml:"ram:BilledQuantity"`
+}
+
+type ciiLineSettlement struct {
+	Tax       ciiTradeTax `xml:"ram:ApplicableTradeTax"`
+	Summation struct {
+		LineTotal string `xml:"ram:LineTotalAmount"`
+	} `xml:"ram:SpecifiedTradeSettlementLineMonetarySummation"`
+}
+
+type ciiAgreement struct {
+	BuyerReference string                 `xml:"ram:BuyerReference,omitempty"`
+	Seller         ciiParty               `xml:"ram:SellerTradeParty"`
+	Buyer          ciiParty               `xml:"ram:BuyerTradeParty"`
+	BuyerOrder     *ciiReferencedDocument `xml:"ram:BuyerOrderReferencedDocument,omitempty"`
+}
+
+type ciiReferencedDocument struct {
+	IssuerAssignedID string `xml:"ram:IssuerAssignedID"`
+}
+
+type ciiParty struct {
+	Name              string                `xml:"ram:Name"`
+	LegalOrganization *ciiLegalOrganization `xml:"ram:SpecifiedLegalOrganization,omitempty"`
+	Address           ciiAddress            `xml:"ram:PostalTradeAddress"`
+	Communication     *ciiCommunication     `xml:"ram:URIUniversalCommunication,omitempty"`
+	TaxRegistrations  []ciiTaxRegistration  `xml:"ram:SpecifiedTaxRegistration"`
+}
+
+type ciiLegalOrganization struct {
+	ID          string `xml:"ram:ID,omitempty"`
+	TradingName string `xml:"ram:TradingBusinessName,omitempty"`
+}
+
+type ciiAddress struct {
+	Postcode    string `xml:"ram:PostcodeCode,omitempty"`
+	LineOne     string `xml:"ram:LineOne,omitempty"`
+	LineTwo     string `xml:"ram:LineTwo,omitempty"`
+	City        string `xml:"ram:CityName,omitempty"`
+	Country     string `xml:"ram:CountryID"`
+	Subdivision string `xml:"ram:CountrySubDivisionName,omitempty"`
+}
+
+type ciiCommunication struct {
+	URIID ciiID `xml:"ram:URIID"`
+}
+
+type ciiTaxRegistration struct {
+	ID ciiID `xml:"ram:ID"`
+}
+
+type ciiDelivery struct {
+	Event *ciiDeliveryEvent `xml:"ram:ActualDeliverySupplyChainEvent,omitempty"`
+}
+
+type ciiDeliveryEvent struct {
+	Occurrence ciiDate `xml:"ram:OccurrenceDateTime"`
+}
+
+type ciiSettlement struct {
+	CreditorReferenceID string               `xml:"ram:CreditorReferenceID,omitempty"`
+	PaymentReference    string               `xml:"ram:PaymentReference,omitempty"`
+	Currency            string               `xml:"ram:InvoiceCurrencyCode"`
+	PaymentMeans        []ciiPaymentMeans    `xml:"ram:SpecifiedTradeSettlementPaymentMeans"`
+	Taxes               []ciiTradeTax        `xml:"ram:ApplicableTradeTax"`
+	AllowancesCharges   []ciiAllowanceCharge `xml:"ram:SpecifiedTradeAllowanceCharge"`
+	PaymentTerms        *ciiPaymentTerms     `xml:"ram:SpecifiedTradePaymentTerms,omitempty"`
+	Summation           ciiSummation         `xml:"ram:SpecifiedTradeSettlementHeaderMonetarySummation"`
+}
+
+type ciiPaymentMeans struct {
+	TypeCode         string                   `xml:"ram:TypeCode"`
+	Information      string                   `xml:"ram:Information,omitempty"`
+	PayerAccount     *ciiFinancialAccount     `xml:"ram:PayerPartyDebtorFinancialAccount,omitempty"`
+	PayeeAccount     *ciiFinancialAccount     `xml:"ram:PayeePartyCreditorFinancialAccount,omitempty"`
+	PayeeInstitution *ciiFinancialInstitution `xml:"ram:PayeeSpecifiedCreditorFinancialInstitution,omitempty"`
+}
+
+type ciiFinancialAccount struct {
+	IBAN        string `xml:"ram:IBANID,omitempty"`
+	AccountName string `xml:"ram:AccountName,omitempty"`
+}
+
+type ciiFinancialInstitution struct {
+	BIC string `xml:"ram:BICID"`
+}
+
+// ciiTradeTax is used for the VAT breakdown
+// and the VAT of lines, allowances, and charges
+// in the element order of the CII schema.
+type ciiTradeTax struct {
+	CalculatedAmount    string `xml:"ram:CalculatedAmount,omitempty"`
+	TypeCode            string `xml:"ram:TypeCode"`
+	ExemptionReason     string `xml:"ram:ExemptionReason,omitempty"`
+	BasisAmount         string `xml:"ram:BasisAmount,omitempty"`
+	CategoryCode        string `xml:"ram:CategoryCode"`
+	ExemptionReasonCode string `xml:"ram:ExemptionReasonCode,omitempty"`
+	RatePercent         string `xml:"ram:RateApplicablePercent,omitempty"`
+}
+
+type ciiAllowanceCharge struct {
+	ChargeIndicator ciiIndicator `xml:"ram:ChargeIndicator"`
+	ActualAmount    string       `xml:"ram:ActualAmount"`
+	Reason          string       `xml:"ram:Reason,omitempty"`
+	Tax             ciiTradeTax  `xml:"ram:CategoryTradeTax"`
+}
+
+type ciiPaymentTerms struct {
+	Description string   `xml:"ram:Description,omitempty"`
+	DueDate     *ciiDate `xml:"ram:DueDateDateTime,omitempty"`
+	MandateID   string   `xml:"ram:DirectDebitMandateID,omitempty"`
+}
+
+type ciiSummation struct {
+	LineTotal          string      `xml:"ram:LineTotalAmount"`
+	ChargeTotal        string      `xml:"ram:ChargeTotalAmount,omitempty"`
+	AllowanceTotal     string      `xml:"ram:AllowanceTotalAmount,omitempty"`
+	TaxBasisTotal      string      `xml:"ram:TaxBasisTotalAmount"`
+	TaxTotals          []ciiAmount `xml:"ram:TaxTotalAmount,omitempty"`
+	RoundingAmount     string      `xml:"ram:RoundingAmount,omitempty"`
+	GrandTotal         string      `xml:"ram:GrandTotalAmount"`
+	TotalPrepaidAmount string      `xml:"ram:TotalPrepaidAmount,omitempty"`
+	DuePayableAmount   string      `xml:"ram:DuePayableAmount"`
+}
+
+// ciiTaxTotal returns the tax total amount in the invoice currency.
+// A second tax total amount in the tax accounting currency (BT-111)
+// is ignored. A single total without currency ID is
+// assumed to be in the invoice currency.
+func ciiTaxTotal(totals []ciiAmount, currency string) *ciiAmount {
+	for i := range totals {
+		if totals[i].CurrencyID == currency {
+			return &totals[i]
+		}
+	}
+	if len(totals) == 1 && totals[0].CurrencyID == "" {
+		return &totals[0]
+	}
+	return nil
+}
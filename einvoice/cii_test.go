@@ -0,0 +1,91 @@
+package einvoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoice_MarshalCII(t *testing.T) {
+	inv := exampleInvoice()
+	inv.Notes = []string{"Thank you"}
+	inv.OrderReference = "PO-4711"
+	inv.DeliveryDate = "2024-03-10"
+	inv.Buyer.ElectronicAddress = "04011000-12345-03"
+	inv.Buyer.ElectronicAddressScheme = "0204"
+	inv.Seller.ElectronicAddress = "invoice@seller.example.com"
+	inv.Seller.TaxRegistrationID = "123/456/78901"
+	inv.PaymentMeans[0].RemittanceInformation = "R-2024-0001"
+	inv.PaymentMeans[0].BIC = "COBADEFFXXX"
+	inv.Lines[0].Note = "March"
+	inv.Lines[0].BaseQuantity = 1
+
+	data, err := inv.MarshalCII()
+	require.NoError(t, err)
+	xml := string(data)
+	for _, want := range []string{
+		`<rsm:CrossIndustryInvoice xmlns:rsm="urn:un:unece:uncefact:data:standard:CrossIndustryInvoice:100"`,
+		`<ram:ID>` + SpecificationXRechnung + `</ram:ID>`,
+		`<udt:DateTimeString format="102">20240315</udt:DateTimeString>`,
+		`<ram:BilledQuantity unitCode="HUR">2.5</ram:BilledQuantity>`,
+		`<ram:RateApplicablePercent>19.00</ram:RateApplicablePercent>`,
+		`<ram:ID schemeID="VA">ATU10223006</ram:ID>`,
+		`<ram:URIID schemeID="EM">invoice@seller.example.com</ram:URIID>`,
+		`<ram:URIID schemeID="0204">04011000-12345-03</ram:URIID>`,
+		`<ram:PaymentReference>R-2024-0001</ram:PaymentReference>`,
+		`<ram:TaxTotalAmount currencyID="EUR">38.45</ram:TaxTotalAmount>`,
+		`<ram:DuePayableAmount>203.45</ram:DuePayableAmount>`,
+	} {
+		assert.Contains(t, xml, want)
+	}
+
+	parsed, err := ParseCII(data)
+	require.NoError(t, err)
+	inv.Seller.ElectronicAddressScheme = "EM" // Default scheme
+	assert.Equal(t, inv, parsed)
+	assert.NoError(t, parsed.Validate())
+
+	_, err = (&Invoice{}).MarshalCII()
+	assert.Error(t, err, "missing specification identifier")
+}
+
+func TestParseCII(t *testing.T) {
+	data, err := exampleInvoice().MarshalCII()
+	require.NoError(t, err)
+
+	// Other namespace prefixes than the conventional ones
+	renamed := strings.NewReplacer(
+		"rsm:", "inv:", "xmlns:rsm", "xmlns:inv",
+		"ram:", "r:", "xmlns:ram", "xmlns:r",
+		"udt:", "u:", "xmlns:udt", "xmlns:u",
+	).Replace(string(data))
+	parsed, err := ParseCII([]byte(renamed))
+	require.NoError(t, err)
+	assert.Equal(t, exampleInvoice(), parsed)
+
+	_, err = ParseCII([]byte(`<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"/>`))
+	assert.Error(t, err, "UBL root element")
+
+	_, err = ParseCII([]byte(`<rsm:CrossIndustryInvoice`))
+	assert.Error(t, err, "invalid XML")
+
+	// Additional tax total in the tax accounting currency (BT-111)
+	taxCurrencyTotal := strings.Replace(string(data),
+		`<ram:TaxTotalAmount currencyID="EUR">38.45</ram:TaxTotalAmount>`,
+		`<ram:TaxTotalAmount currencyID="EUR">38.45</ram:TaxTotalAmount><ram:TaxTotalAmount currencyID="SEK">210.00</ram:TaxTotalAmount>`, 1)
+	require.Contains(t, taxCurrencyTotal, `currencyID="SEK"`)
+	parsed, err = ParseCII([]byte(taxCurrencyTotal))
+	require.NoError(t, err)
+	assert.Equal(t, exampleInvoice().Totals, parsed.Totals)
+	assert.NoError(t, parsed.Validate())
+
+	invalidAmount := strings.Replace(string(data), "<ram:DuePayableAmount>203.45<", "<ram:DuePayableAmount>203,45 EUR<", 1)
+	_, err = ParseCII([]byte(invalidAmount))
+	assert.Error(t, err, "invalid amount")
+
+	invalidDate := strings.Replace(string(data), `format="102">20240315<`, `format="102">2024-03-15<`, 1)
+	_, err = ParseCII([]byte(invalidDate))
+	assert.Error(t, err, "invalid date")
+}
@@ -0,0 +1,301 @@
+// Package einvoice provides the core semantic data model
+// of electronic invoices according to EN 16931
+// as used by XRechnung, ZUGFeRD/Factur-X, and Peppol BIS,
+// composed from the types of this module.
+//
+// The model is independent of a syntax like UBL or CII.
+// Field documentation refers to the business terms (BT-*)
+// and business groups (BG-*) of EN 16931-1.
+// Invoice.Validate checks a subset of the business rules (BR-*).
+package einvoice
+
+import (
+	"github.com/domonda/go-types/bank"
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/vat"
+)
+
+// Specification identifiers (BT-24) of common EN 16931 specifications.
+const (
+	SpecificationEN16931   = "urn:cen.eu:en16931:2017"
+	SpecificationXRechnung = "urn:cen.eu:en16931:2017#compliant#urn:xeinkauf.de:kosit:xrechnung_3.0"
+	SpecificationPeppolBIS = "urn:cen.eu:en16931:2017#compliant#urn:fdc:peppol.eu:2017:poacc:billing:3.0"
+)
+
+// TypeCode is an invoice type code (BT-3)
+// from the UNTDID 1001 code list.
+type TypeCode string
+
+const (
+	TypeCodePartialInvoice    TypeCode = "326"
+	TypeCodeCommercialInvoice TypeCode = "380"
+	TypeCodeCreditNote        TypeCode = "381"
+	TypeCodeCorrectedInvoice  TypeCode = "384"
+	TypeCodeSelfBilledInvoice TypeCode = "389"
+	TypeCodePrepaymentInvoice TypeCode = "386"
+)
+
+// IsCreditNote returns true for the credit note type code "381"
+// that is represented by a CreditNote document in UBL.
+func (c TypeCode) IsCreditNote() bool {
+	return c == TypeCodeCreditNote
+}
+
+// VATCategory is a VAT category code (BT-95, BT-102, BT-118, BT-151)
+// from the UNTDID 5305 code list as restricted by EN 16931.
+type VATCategory string
+
+const (
+	// VATCategoryStandard is the standard or reduced rate.
+	VATCategoryStandard VATCategory = "S"
+	// VATCategoryZeroRated is for zero rated goods.
+	VATCategoryZeroRated VATCategory = "Z"
+	// VATCategoryExempt is for supplies exempt from VAT.
+	VATCategoryExempt VATCategory = "E"
+	// VATCategoryReverseCharge is for supplies
+	// where the buyer is liable for the VAT.
+	VATCategoryReverseCharge VATCategory = "AE"
+	// VATCategoryIntraCommunity is for VAT exempt
+	// intra-community supplies of goods and services.
+	VATCategoryIntraCommunity VATCategory = "K"
+	// VATCategoryExport is for free export outside the EU.
+	VATCategoryExport VATCategory = "G"
+	// VATCategoryNotSubject is for services outside
+	// the scope of VAT.
+	VATCategoryNotSubject VATCategory = "O"
+	// VATCategoryCanaryIslands is the Canary Islands general indirect tax.
+	VATCategoryCanaryIslands VATCategory = "L"
+	// VATCategoryCeutaMelilla is the tax for production,
+	// services and importation in Ceuta and Melilla.
+	VATCategoryCeutaMelilla VATCategory = "M"
+)
+
+// Valid returns true if the category is one of the defined constants.
+func (c VATCategory) Valid() bool {
+	switch c {
+	case VATCategoryStandard, VATCategoryZeroRated, VATCategoryExempt,
+		VATCategoryReverseCharge, VATCategoryIntraCommunity, VATCategoryExport,
+		VATCategoryNotSubject, VATCategoryCanaryIslands, VATCategoryCeutaMelilla:
+		return true
+	}
+	return false
+}
+
+// PaymentMeansCode is a payment means type code (BT-81)
+// from the UNTDID 4461 code list.
+type PaymentMeansCode string
+
+const (
+	PaymentMeansCreditTransfer     PaymentMeansCode = "30"
+	PaymentMeansDebitTransfer      PaymentMeansCode = "31"
+	PaymentMeansPaymentCard        PaymentMeansCode = "48"
+	PaymentMeansDirectDebit        PaymentMeansCode = "49"
+	PaymentMeansSEPACreditTransfer PaymentMeansCode = "58"
+	PaymentMeansSEPADirectDebit    PaymentMeansCode = "59"
+)
+
+// IsCreditTransfer returns true for the
+// credit transfer codes "30" and "58".
+func (c PaymentMeansCode) IsCreditTransfer() bool {
+	return c == PaymentMeansCreditTransfer || c == PaymentMeansSEPACreditTransfer
+}
+
+// Invoice is the semantic data model of an EN 16931 invoice.
+type Invoice struct {
+	// Number is the invoice number (BT-1).
+	Number string `json:"number"`
+	// IssueDate is the invoice issue date (BT-2).
+	IssueDate date.Date `json:"issueDate"`
+	// TypeCode is the invoice type code (BT-3).
+	TypeCode TypeCode `json:"typeCode"`
+	// Currency is the invoice currency code (BT-5).
+	Currency money.Currency `json:"currency"`
+	// DueDate is the optional payment due date (BT-9).
+	DueDate date.NullableDate `json:"dueDate,omitempty"`
+	// BuyerReference is the buyer reference (BT-10)
+	// which is the Leitweg-ID for XRechnung.
+	BuyerReference string `json:"buyerReference,omitempty"`
+	// OrderReference is the purchase order reference (BT-13).
+	OrderReference string `json:"orderReference,omitempty"`
+	// PaymentTerms is the payment terms text (BT-20).
+	PaymentTerms string `json:"paymentTerms,omitempty"`
+	// Notes are the invoice notes (BT-22).
+	Notes []string `json:"notes,omitempty"`
+	// SpecificationID is the specification identifier (BT-24)
+	// like SpecificationEN16931 or SpecificationXRechnung.
+	SpecificationID string `json:"specificationID"`
+	// DeliveryDate is the optional actual delivery date (BT-72).
+	DeliveryDate date.NullableDate `json:"deliveryDate,omitempty"`
+
+	// Seller is the seller (BG-4).
+	Seller Party `json:"seller"`
+	// Buyer is the buyer (BG-7).
+	Buyer Party `json:"buyer"`
+
+	// PaymentMeans are the payment instructions (BG-16).
+	PaymentMeans []PaymentMeans `json:"paymentMeans,omitempty"`
+	// AllowancesCharges are the document level
+	// allowances (BG-20) and charges (BG-21).
+	AllowancesCharges []AllowanceCharge `json:"allowancesCharges,omitempty"`
+	// Totals are the document totals (BG-22).
+	Totals Totals `json:"totals"`
+	// VATBreakdown is the VAT breakdown (BG-23)
+	// with one entry per VAT category and rate.
+	VATBreakdown []VATBreakdown `json:"vatBreakdown"`
+	// Lines are the invoice lines (BG-25).
+	Lines []Line `json:"lines"`
+}
+
+// Party is the seller (BG-4) or buyer (BG-7) of an invoice.
+type Party struct {
+	// Name is the legal name (BT-27, BT-44).
+	Name string `json:"name"`
+	// TradingName is the optional trading name (BT-28, BT-45).
+	TradingName string `json:"tradingName,omitempty"`
+	// LegalRegistrationID is the optional legal registration
+	// identifier like a commercial register number (BT-30, BT-47).
+	LegalRegistrationID string `json:"legalRegistrationID,omitempty"`
+	// VATID is the optional VAT identifier (BT-31, BT-48).
+	VATID vat.NullableID `json:"vatID,omitempty"`
+	// TaxRegistrationID is the optional local tax registration
+	// identifier like the German Steuernummer (BT-32),
+	// only defined for the seller.
+	TaxRegistrationID string `json:"taxRegistrationID,omitempty"`
+	// ElectronicAddress is the optional electronic address
+	// like an email address or Peppol ID (BT-34, BT-49).
+	ElectronicAddress string `json:"electronicAddress,omitempty"`
+	// ElectronicAddressScheme is the scheme identifier
+	// of the ElectronicAddress from the EAS code list
+	// like "EM" for email or "0204" for a Leitweg-ID.
+	ElectronicAddressScheme string `json:"electronicAddressScheme,omitempty"`
+	// Address is the postal address (BG-5, BG-8).
+	Address Address `json:"address"`
+}
+
+// Address is a postal address (BG-5, BG-8).
+type Address struct {
+	Line1       string       `json:"line1,omitempty"`
+	Line2       string       `json:"line2,omitempty"`
+	City        string       `json:"city,omitempty"`
+	PostCode    string       `json:"postCode,omitempty"`
+	Subdivision string       `json:"subdivision,omitempty"`
+	Country     country.Code `json:"country"`
+}
+
+// PaymentMeans is a payment instruction (BG-16).
+type PaymentMeans struct {
+	// Code is the payment means type code (BT-81).
+	Code PaymentMeansCode `json:"code"`
+	// Text is the optional payment means text (BT-82).
+	Text string `json:"text,omitempty"`
+	// RemittanceInformation is the optional
+	// remittance information (BT-83).
+	RemittanceInformation string `json:"remittanceInformation,omitempty"`
+	// IBAN is the payment account identifier
+	// for credit transfers (BT-84).
+	IBAN bank.NullableIBAN `json:"iban,omitempty"`
+	// AccountName is the optional payment account name (BT-85).
+	AccountName string `json:"accountName,omitempty"`
+	// BIC is the optional payment service provider identifier (BT-86).
+	BIC bank.NullableBIC `json:"bic,omitempty"`
+	// MandateReference is the mandate reference
+	// identifier for direct debits (BT-89).
+	MandateReference string `json:"mandateReference,omitempty"`
+	// CreditorID is the bank assigned creditor identifier
+	// for direct debits (BT-90).
+	CreditorID bank.CreditorID `json:"creditorID,omitempty"`
+	// DebitedIBAN is the debited account identifier
+	// for direct debits (BT-91).
+	DebitedIBAN bank.NullableIBAN `json:"debitedIBAN,omitempty"`
+}
+
+// AllowanceCharge is a document level allowance (BG-20)
+// or charge (BG-21).
+type AllowanceCharge struct {
+	// Charge is true for a charge and false for an allowance.
+	Charge bool `json:"charge"`
+	// Amount is the amount without VAT (BT-92, BT-99).
+	Amount money.Amount `json:"amount"`
+	// Reason is the optional reason text (BT-97, BT-104).
+	Reason string `json:"reason,omitempty"`
+	// VATCategory is the VAT category (BT-95, BT-102).
+	VATCategory VATCategory `json:"vatCategory"`
+	// VATRate is the VAT rate like 0.19 for 19% (BT-96, BT-103).
+	VATRate money.Rate `json:"vatRate"`
+}
+
+// Totals are the document totals (BG-22).
+type Totals struct {
+	// LineNetAmount is the sum of the invoice line net amounts (BT-106).
+	LineNetAmount money.Amount `json:"lineNetAmount"`
+	// AllowanceTotal is the sum of the document level allowances (BT-107).
+	AllowanceTotal money.Amount `json:"allowanceTotal,omitempty"`
+	// ChargeTotal is the sum of the document level charges (BT-108).
+	ChargeTotal money.Amount `json:"chargeTotal,omitempty"`
+	// TaxExclusiveAmount is the invoice total without VAT (BT-109).
+	TaxExclusiveAmount money.Amount `json:"taxExclusiveAmount"`
+	// TaxAmount is the invoice total VAT amount (BT-110).
+	TaxAmount money.Amount `json:"taxAmount"`
+	// TaxInclusiveAmount is the invoice total with VAT (BT-112).
+	TaxInclusiveAmount money.Amount `json:"taxInclusiveAmount"`
+	// PrepaidAmount is the already paid amount (BT-113).
+	PrepaidAmount money.Amount `json:"prepaidAmount,omitempty"`
+	// RoundingAmount is the rounding amount (BT-114).
+	RoundingAmount money.Amount `json:"roundingAmount,omitempty"`
+	// PayableAmount is the amount due for payment (BT-115).
+	PayableAmount money.Amount `json:"payableAmount"`
+}
+
+// VATBreakdown is the VAT breakdown (BG-23)
+// of one VAT category and rate.
+type VATBreakdown struct {
+	// TaxableAmount is the VAT category taxable amount (BT-116).
+	TaxableAmount money.Amount `json:"taxableAmount"`
+	// TaxAmount is the VAT category tax amount (BT-117).
+	TaxAmount money.Amount `json:"taxAmount"`
+	// Category is the VAT category code (BT-118).
+	Category VATCategory `json:"category"`
+	// Rate is the VAT category rate like 0.19 for 19% (BT-119).
+	Rate money.Rate `json:"rate"`
+	// ExemptionReason is the VAT exemption reason text (BT-120).
+	ExemptionReason string `json:"exemptionReason,omitempty"`
+	// ExemptionReasonCode is the VAT exemption reason code (BT-121).
+	ExemptionReasonCode string `json:"exemptionReasonCode,omitempty"`
+}
+
+// Line is an invoice line (BG-25).
+type Line struct {
+	// ID is the invoice line identifier (BT-126).
+	ID string `json:"id"`
+	// Note is the optional invoice line note (BT-127).
+	Note string `json:"note,omitempty"`
+	// Quantity is the invoiced quantity (BT-129)
+	// with its unit of measure code (BT-130).
+	Quantity money.Quantity `json:"quantity"`
+	// NetAmount is the invoice line net amount (BT-131).
+	NetAmount money.Amount `json:"netAmount"`
+	// ItemName is the item name (BT-153).
+	ItemName string `json:"itemName"`
+	// NetPrice is the item net price (BT-146).
+	NetPrice money.Amount `json:"netPrice"`
+	// BaseQuantity is the optional item price
+	// base quantity (BT-149), zero means one unit.
+	BaseQuantity float64 `json:"baseQuantity,omitempty"`
+	// VATCategory is the invoiced item VAT category code (BT-151).
+	VATCategory VATCategory `json:"vatCategory"`
+	// VATRate is the invoiced item VAT rate
+	// like 0.19 for 19% (BT-152).
+	VATRate money.Rate `json:"vatRate"`
+}
+
+// QuantityLine returns the price and quantity of the line
+// for calculating the net amount with money.SumQuantityLines.
+func (l *Line) QuantityLine() money.QuantityLine {
+	return money.QuantityLine{
+		UnitPrice:    l.NetPrice,
+		Quantity:     l.Quantity,
+		BaseQuantity: l.BaseQuantity,
+	}
+}
@@ -0,0 +1,147 @@
+package einvoice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types"
+	"github.com/domonda/go-types/money"
+)
+
+func exampleInvoice() *Invoice {
+	return &Invoice{
+		Number:          "R-2024-0001",
+		IssueDate:       "2024-03-15",
+		TypeCode:        TypeCodeCommercialInvoice,
+		Currency:        money.EUR,
+		DueDate:         "2024-04-14",
+		BuyerReference:  "04011000-12345-03",
+		SpecificationID: SpecificationXRechnung,
+		Seller: Party{
+			Name:  "Seller GmbH",
+			VATID: "ATU10223006",
+			Address: Address{
+				Line1:    "Hauptstraße 1",
+				City:     "Wien",
+				PostCode: "1010",
+				Country:  "AT",
+			},
+		},
+		Buyer: Party{
+			Name: "Buyer AG",
+			Address: Address{
+				City:    "Berlin",
+				Country: "DE",
+			},
+		},
+		PaymentMeans: []PaymentMeans{
+			{Code: PaymentMeansSEPACreditTransfer, IBAN: "DE89370400440532013000"},
+		},
+		AllowancesCharges: []AllowanceCharge{
+			{Amount: 10, Reason: "Discount", VATCategory: VATCategoryStandard, VATRate: 0.19},
+			{Charge: true, Amount: 5, Reason: "Shipping", VATCategory: VATCategoryStandard, VATRate: 0.19},
+		},
+		Lines: []Line{
+			{
+				ID:          "1",
+				Quantity:    money.Quantity{Value: 2.5, Unit: "HUR"},
+				NetAmount:   200,
+				ItemName:    "Consulting",
+				NetPrice:    80,
+				VATCategory: VATCategoryStandard,
+				VATRate:     0.19,
+			},
+			{
+				ID:          "2",
+				Quantity:    money.Quantity{Value: 1, Unit: "C62"},
+				NetAmount:   20,
+				ItemName:    "Book",
+				NetPrice:    20,
+				VATCategory: VATCategoryStandard,
+				VATRate:     0.07,
+			},
+		},
+		VATBreakdown: []VATBreakdown{
+			{TaxableAmount: 195, TaxAmount: 37.05, Category: VATCategoryStandard, Rate: 0.19},
+			{TaxableAmount: 20, TaxAmount: 1.4, Category: VATCategoryStandard, Rate: 0.07},
+		},
+		Totals: Totals{
+			LineNetAmount:      220,
+			AllowanceTotal:     10,
+			ChargeTotal:        5,
+			TaxExclusiveAmount: 215,
+			TaxAmount:          38.45,
+			TaxInclusiveAmount: 253.45,
+			PrepaidAmount:      50,
+			PayableAmount:      203.45,
+		},
+	}
+}
+
+func violatedRules(inv *Invoice) []string {
+	var rules []string
+	for _, v := range inv.Violations() {
+		rules = append(rules, v.Params["rule"].(string))
+	}
+	return rules
+}
+
+func TestInvoice_Validate(t *testing.T) {
+	inv := exampleInvoice()
+	require.NoError(t, inv.Validate())
+
+	lines, total, err := money.SumQuantityLines(
+		[]money.QuantityLine{inv.Lines[0].QuantityLine(), inv.Lines[1].QuantityLine()},
+		money.RoundAtLine,
+		money.DefaultRounding,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []money.Amount{inv.Lines[0].NetAmount, inv.Lines[1].NetAmount}, lines)
+	require.Equal(t, inv.Totals.LineNetAmount, total)
+
+	inv.Totals.LineNetAmount = 221
+	err = inv.Validate()
+	require.Error(t, err)
+	code, params, ok := types.ErrorCodeOf(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCode("BR-CO-10"), code)
+	require.Equal(t, "BR-CO-10", params["rule"])
+	require.Equal(t, []string{"BR-CO-10", "BR-CO-13"}, violatedRules(inv))
+}
+
+func TestInvoice_Violations(t *testing.T) {
+	require.Equal(t,
+		[]string{"BR-01", "BR-02", "BR-03", "BR-04", "BR-05", "BR-06", "BR-07", "BR-08", "BR-09", "BR-10", "BR-11", "BR-16", "BR-CO-18"},
+		violatedRules(&Invoice{}),
+	)
+
+	inv := exampleInvoice()
+	inv.DueDate = ""
+	inv.Seller.VATID = "ATU12345678"
+	inv.PaymentMeans[0].IBAN = ""
+	inv.Lines[0].Quantity.Unit = ""
+	inv.Lines[1].VATRate = 0.19
+	inv.VATBreakdown[0].TaxAmount = 37.06
+	inv.Totals.TaxAmount = 38.46
+	inv.Totals.TaxInclusiveAmount = 253.46
+	inv.Totals.PayableAmount = 203.46
+	require.Equal(t,
+		[]string{"BR-23", "BR-61", "BR-CO-9", "BR-CO-25", "BR-S-08", "BR-CO-17", "BR-S-08"},
+		violatedRules(inv),
+	)
+
+	inv = exampleInvoice()
+	inv.Lines = append(inv.Lines, Line{
+		ID:          "3",
+		Quantity:    money.Quantity{Value: 1, Unit: "C62"},
+		ItemName:    "Export",
+		NetPrice:    0,
+		VATCategory: VATCategoryReverseCharge,
+	})
+	inv.VATBreakdown = append(inv.VATBreakdown, VATBreakdown{Category: VATCategoryExempt})
+	require.Equal(t,
+		[]string{"BR-E-10", "BR-AE-01", "BR-AE-02"},
+		violatedRules(inv),
+	)
+}
@@ -0,0 +1,321 @@
+package einvoice
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/domonda/go-types"
+	"github.com/domonda/go-types/money"
+)
+
+// ErrorCode returns the types.ErrorCode of a business rule
+// like "einvoice.br-co-10" for "BR-CO-10".
+func ErrorCode(rule string) types.ErrorCode {
+	return types.ErrorCode("einvoice." + strings.ToLower(rule))
+}
+
+func violation(rule, format string, args ...any) *types.CodedError {
+	return types.NewCodedError(ErrorCode(rule), rule+": "+fmt.Sprintf(format, args...), "rule", rule)
+}
+
+// Validate returns the business rule violations
+// of Violations joined with errors.Join
+// or nil if the invoice is valid.
+func (inv *Invoice) Validate() error {
+	violations := inv.Violations()
+	if len(violations) == 0 {
+		return nil
+	}
+	errs := make([]error, len(violations))
+	for i, v := range violations {
+		errs[i] = v
+	}
+	return errors.Join(errs...)
+}
+
+// codeListRules are the business rules checking
+// codes against the code lists of the currency,
+// country, and VAT category types.
+var codeListRules = map[string]bool{
+	"BR-05":   true,
+	"BR-09":   true,
+	"BR-11":   true,
+	"BR-CO-4": true,
+	"BR-32":   true,
+	"BR-37":   true,
+	"BR-47":   true,
+}
+
+// ValidateCodeLists returns the violations of the business rules
+// checking the currency (BR-05), country (BR-09, BR-11),
+// and VAT category codes (BR-CO-4, BR-32, BR-37, BR-47)
+// against their code lists joined with errors.Join
+// or nil if all codes are valid.
+func (inv *Invoice) ValidateCodeLists() error {
+	var errs []error
+	for _, v := range inv.Violations() {
+		if codeListRules[v.Params["rule"].(string)] {
+			errs = append(errs, v)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Violations returns the violated business rules of EN 16931
+// in the order of their checks as CodedError with the
+// ErrorCode of the rule and the rule identifier as "rule" parameter.
+//
+// Checked are the mandatory fields (BR-01 to BR-11, BR-16,
+// BR-21 to BR-27, BR-31 to BR-33, BR-36 to BR-38, BR-45 to BR-49),
+// the VAT categories of lines (BR-CO-4), the VAT identifiers (BR-CO-9),
+// the calculation of the totals (BR-CO-10 to BR-CO-18),
+// a payment due date or terms for a positive amount due (BR-CO-25),
+// the IBAN of credit transfers (BR-61), and per VAT category
+// the presence in the VAT breakdown (BR-*-01), the taxable amounts (BR-*-08),
+// the VAT identifiers (BR-S-02, BR-AE-02), and the exemption reasons
+// (BR-E-10, BR-AE-10).
+//
+// Amounts are compared rounded to cents.
+func (inv *Invoice) Violations() (violations []*types.CodedError) {
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, violation(rule, format, args...))
+	}
+
+	if inv.SpecificationID == "" {
+		add("BR-01", "specification identifier missing")
+	}
+	if inv.Number == "" {
+		add("BR-02", "invoice number missing")
+	}
+	if !inv.IssueDate.Valid() {
+		add("BR-03", "invalid invoice issue date %q", inv.IssueDate)
+	}
+	if inv.TypeCode == "" {
+		add("BR-04", "invoice type code missing")
+	}
+	if !inv.Currency.Valid() {
+		add("BR-05", "invalid invoice currency code %q", inv.Currency)
+	}
+	if inv.Seller.Name == "" {
+		add("BR-06", "seller name missing")
+	}
+	if inv.Buyer.Name == "" {
+		add("BR-07", "buyer name missing")
+	}
+	if inv.Seller.Address == (Address{}) {
+		add("BR-08", "seller postal address missing")
+	}
+	if !inv.Seller.Address.Country.Valid() {
+		add("BR-09", "invalid seller country code %q", inv.Seller.Address.Country)
+	}
+	if inv.Buyer.Address == (Address{}) {
+		add("BR-10", "buyer postal address missing")
+	}
+	if !inv.Buyer.Address.Country.Valid() {
+		add("BR-11", "invalid buyer country code %q", inv.Buyer.Address.Country)
+	}
+	if len(inv.Lines) == 0 {
+		add("BR-16", "no invoice lines")
+	}
+	for i := range inv.Lines {
+		line := &inv.Lines[i]
+		if line.ID == "" {
+			add("BR-21", "identifier of line %d missing", i+1)
+		}
+		if !line.Quantity.Valid() {
+			add("BR-22", "invalid quantity of line %d", i+1)
+		}
+		if line.Quantity.Unit == "" {
+			add("BR-23", "unit of measure of line %d missing", i+1)
+		}
+		if !line.NetAmount.Valid() {
+			add("BR-24", "invalid net amount of line %d", i+1)
+		}
+		if line.ItemName == "" {
+			add("BR-25", "item name of line %d missing", i+1)
+		}
+		if !line.NetPrice.Valid() {
+			add("BR-26", "invalid net price of line %d", i+1)
+		}
+		if line.NetPrice < 0 {
+			add("BR-27", "negative net price %s of line %d", line.NetPrice, i+1)
+		}
+		if !line.VATCategory.Valid() {
+			add("BR-CO-4", "invalid VAT category %q of line %d", line.VATCategory, i+1)
+		}
+	}
+	for i, ac := range inv.AllowancesCharges {
+		if ac.Charge {
+			if !ac.Amount.Valid() {
+				add("BR-36", "invalid amount of charge %d", i+1)
+			}
+			if !ac.VATCategory.Valid() {
+				add("BR-37", "invalid VAT category %q of charge %d", ac.VATCategory, i+1)
+			}
+			if ac.Reason == "" {
+				add("BR-38", "reason of charge %d missing", i+1)
+			}
+		} else {
+			if !ac.Amount.Valid() {
+				add("BR-31", "invalid amount of allowance %d", i+1)
+			}
+			if !ac.VATCategory.Valid() {
+				add("BR-32", "invalid VAT category %q of allowance %d", ac.VATCategory, i+1)
+			}
+			if ac.Reason == "" {
+				add("BR-33", "reason of allowance %d missing", i+1)
+			}
+		}
+	}
+	for i, vb := range inv.VATBreakdown {
+		if !vb.TaxableAmount.Valid() {
+			add("BR-45", "invalid taxable amount of VAT breakdown %d", i+1)
+		}
+		if !vb.TaxAmount.Valid() {
+			add("BR-46", "invalid tax amount of VAT breakdown %d", i+1)
+		}
+		if !vb.Category.Valid() {
+			add("BR-47", "invalid VAT category %q of VAT breakdown %d", vb.Category, i+1)
+		}
+		if !vb.Rate.Valid() {
+			add("BR-48", "invalid VAT rate of VAT breakdown %d", i+1)
+		}
+	}
+	for i, pm := range inv.PaymentMeans {
+		if pm.Code == "" {
+			add("BR-49", "type code of payment means %d missing", i+1)
+		}
+		if pm.Code.IsCreditTransfer() && pm.IBAN.IsNull() {
+			add("BR-61", "IBAN of credit transfer payment means %d missing", i+1)
+		}
+	}
+
+	if inv.Seller.VATID.IsNotNull() && !inv.Seller.VATID.Valid() {
+		add("BR-CO-9", "invalid seller VAT identifier %q", inv.Seller.VATID)
+	}
+	if inv.Buyer.VATID.IsNotNull() && !inv.Buyer.VATID.Valid() {
+		add("BR-CO-9", "invalid buyer VAT identifier %q", inv.Buyer.VATID)
+	}
+
+	inv.checkTotals(add)
+	inv.checkVATBreakdown(add)
+	return violations
+}
+
+func (inv *Invoice) checkTotals(add func(rule, format string, args ...any)) {
+	t := &inv.Totals
+	var lineNet, allowances, charges, tax money.Amount
+	for _, line := range inv.Lines {
+		lineNet += line.NetAmount
+	}
+	for _, ac := range inv.AllowancesCharges {
+		if ac.Charge {
+			charges += ac.Amount
+		} else {
+			allowances += ac.Amount
+		}
+	}
+	for _, vb := range inv.VATBreakdown {
+		tax += vb.TaxAmount
+	}
+
+	if !equalCents(t.LineNetAmount, lineNet) {
+		add("BR-CO-10", "sum of line net amounts %s is not %s", t.LineNetAmount, lineNet.RoundToCents())
+	}
+	if !equalCents(t.AllowanceTotal, allowances) {
+		add("BR-CO-11", "sum of allowances %s is not %s", t.AllowanceTotal, allowances.RoundToCents())
+	}
+	if !equalCents(t.ChargeTotal, charges) {
+		add("BR-CO-12", "sum of charges %s is not %s", t.ChargeTotal, charges.RoundToCents())
+	}
+	if want := t.LineNetAmount - t.AllowanceTotal + t.ChargeTotal; !equalCents(t.TaxExclusiveAmount, want) {
+		add("BR-CO-13", "invoice total without VAT %s is not %s", t.TaxExclusiveAmount, want.RoundToCents())
+	}
+	if !equalCents(t.TaxAmount, tax) {
+		add("BR-CO-14", "invoice total VAT amount %s is not %s", t.TaxAmount, tax.RoundToCents())
+	}
+	if want := t.TaxExclusiveAmount + t.TaxAmount; !equalCents(t.TaxInclusiveAmount, want) {
+		add("BR-CO-15", "invoice total with VAT %s is not %s", t.TaxInclusiveAmount, want.RoundToCents())
+	}
+	if want := t.TaxInclusiveAmount - t.PrepaidAmount + t.RoundingAmount; !equalCents(t.PayableAmount, want) {
+		add("BR-CO-16", "amount due for payment %s is not %s", t.PayableAmount, want.RoundToCents())
+	}
+	if t.PayableAmount.Cents() > 0 && inv.DueDate.IsNull() && inv.PaymentTerms == "" {
+		add("BR-CO-25", "payment due date or payment terms missing for positive amount due")
+	}
+}
+
+func (inv *Invoice) checkVATBreakdown(add func(rule, format string, args ...any)) {
+	if len(inv.VATBreakdown) == 0 {
+		add("BR-CO-18", "no VAT breakdown")
+	}
+
+	type categoryRate struct {
+		category VATCategory
+		rate     int64 // in 1/100 of a percent
+	}
+	keyOf := func(category VATCategory, rate money.Rate) categoryRate {
+		return categoryRate{category, int64(math.Round(float64(rate) * 10000))}
+	}
+	taxable := make(map[categoryRate]money.Amount)
+	for _, line := range inv.Lines {
+		taxable[keyOf(line.VATCategory, line.VATRate)] += line.NetAmount
+	}
+	for _, ac := range inv.AllowancesCharges {
+		if ac.Charge {
+			taxable[keyOf(ac.VATCategory, ac.VATRate)] += ac.Amount
+		} else {
+			taxable[keyOf(ac.VATCategory, ac.VATRate)] -= ac.Amount
+		}
+	}
+
+	categories := make(map[VATCategory]bool)
+	for key := range taxable {
+		categories[key.category] = true
+	}
+	for i, vb := range inv.VATBreakdown {
+		categories[vb.Category] = true
+		key := keyOf(vb.Category, vb.Rate)
+		if want := taxable[key]; !equalCents(vb.TaxableAmount, want) {
+			add("BR-"+string(vb.Category)+"-08", "taxable amount %s of VAT breakdown %d is not %s", vb.TaxableAmount, i+1, want.RoundToCents())
+		}
+		delete(taxable, key)
+		if want := (vb.TaxableAmount * money.Amount(vb.Rate)).RoundToCents(); !equalCents(vb.TaxAmount, want) {
+			add("BR-CO-17", "tax amount %s of VAT breakdown %d is not %s", vb.TaxAmount, i+1, want)
+		}
+		switch vb.Category {
+		case VATCategoryExempt, VATCategoryReverseCharge:
+			if vb.ExemptionReason == "" && vb.ExemptionReasonCode == "" {
+				add("BR-"+string(vb.Category)+"-10", "exemption reason of VAT breakdown %d missing", i+1)
+			}
+		}
+	}
+	missing := slices.SortedFunc(maps.Keys(taxable), func(a, b categoryRate) int {
+		return cmp.Or(cmp.Compare(a.category, b.category), cmp.Compare(a.rate, b.rate))
+	})
+	for _, key := range missing {
+		if key.category.Valid() {
+			add("BR-"+string(key.category)+"-01", "VAT breakdown for category %s with rate %v%% missing", key.category, float64(key.rate)/100)
+		}
+	}
+
+	if categories[VATCategoryStandard] && inv.Seller.VATID.IsNull() && inv.Seller.TaxRegistrationID == "" {
+		add("BR-S-02", "seller VAT identifier or tax registration identifier missing for standard rated VAT")
+	}
+	if categories[VATCategoryReverseCharge] {
+		sellerID := inv.Seller.VATID.IsNotNull() || inv.Seller.TaxRegistrationID != ""
+		buyerID := inv.Buyer.VATID.IsNotNull() || inv.Buyer.LegalRegistrationID != ""
+		if !sellerID || !buyerID {
+			add("BR-AE-02", "seller and buyer VAT identifiers required for reverse charge")
+		}
+	}
+}
+
+func equalCents(a, b money.Amount) bool {
+	return a.Cents() == b.Cents()
+}
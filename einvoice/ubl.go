@@ -0,0 +1,648 @@
+package einvoice
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-types/bank"
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/vat"
+)
+
+// ProfilePeppolBilling is the business process type (BT-23)
+// written as ProfileID for the SpecificationPeppolBIS.
+const ProfilePeppolBilling = "urn:fdc:peppol.eu:2017:poacc:billing:01:1.0"
+
+// XML namespaces of OASIS UBL 2.1 documents
+// used by Peppol BIS Billing 3.0 and XRechnung.
+const (
+	UBLNamespaceInvoice    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	UBLNamespaceCreditNote = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	UBLNamespaceCAC        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	UBLNamespaceCBC        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// ublPrefixes are the conventional prefixes of the UBL namespaces
+// used as literal element name prefixes by the ubl* structs.
+// The document namespaces are the default namespace.
+var ublPrefixes = map[string]string{
+	UBLNamespaceCAC: "cac",
+	UBLNamespaceCBC: "cbc",
+}
+
+// MarshalUBL returns the invoice as OASIS UBL 2.1 XML document
+// as used by Peppol BIS Billing 3.0.
+// A credit note type code results in a CreditNote document,
+// all other type codes in an Invoice document.
+//
+// CreditNote documents have no DueDate element, so the due date
+// of a credit note is written as PaymentDueDate of the first PaymentMeans
+// and an error is returned if a credit note has a due date but no PaymentMeans.
+//
+// An error is returned if the currency, country, or VAT category codes
+// are not valid according to ValidateCodeLists.
+// The other business rules are not checked, use Invoice.Validate for that.
+func (inv *Invoice) MarshalUBL() ([]byte, error) {
+	if inv.SpecificationID == "" {
+		return nil, errors.New("missing invoice specification identifier")
+	}
+	issueDate, err := inv.IssueDate.Normalized()
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice issue date: %w", err)
+	}
+	if err := inv.ValidateCodeLists(); err != nil {
+		return nil, err
+	}
+
+	currency := string(inv.Currency)
+	amount := func(a money.Amount) ublAmount {
+		return ublAmount{CurrencyID: currency, Value: formatAmount(a)}
+	}
+	optionalAmount := func(a money.Amount) *ublAmount {
+		if a == 0 {
+			return nil
+		}
+		value := amount(a)
+		return &value
+	}
+
+	creditNote := inv.TypeCode.IsCreditNote()
+	if creditNote && inv.DueDate.IsNotNull() && len(inv.PaymentMeans) == 0 {
+		return nil, errors.New("credit note due date requires payment means")
+	}
+	doc := ublDocument{
+		XMLName:         xml.Name{Local: "Invoice"},
+		Xmlns:           UBLNamespaceInvoice,
+		XmlnsCAC:        UBLNamespaceCAC,
+		XmlnsCBC:        UBLNamespaceCBC,
+		CustomizationID: inv.SpecificationID,
+		ID:              inv.Number,
+		IssueDate:       string(issueDate),
+		Notes:           inv.Notes,
+		Currency:        currency,
+		BuyerReference:  inv.BuyerReference,
+		Seller:          ublPartyOf(&inv.Seller),
+		Buyer:           ublPartyOf(&inv.Buyer),
+	}
+	if inv.SpecificationID == SpecificationPeppolBIS {
+		doc.ProfileID = ProfilePeppolBilling
+	}
+	if creditNote {
+		doc.XMLName.Local = "CreditNote"
+		doc.Xmlns = UBLNamespaceCreditNote
+		doc.CreditNoteTypeCode = string(inv.TypeCode)
+	} else {
+		doc.InvoiceTypeCode = string(inv.TypeCode)
+		if inv.DueDate.IsNotNull() {
+			doc.DueDate = string(inv.DueDate.Get())
+		}
+	}
+	if inv.OrderReference != "" {
+		doc.OrderReference = &ublReference{ID: inv.OrderReference}
+	}
+	if inv.DeliveryDate.IsNotNull() {
+		doc.Delivery = &ublDelivery{ActualDeliveryDate: string(inv.DeliveryDate.Get())}
+	}
+
+	for i, pm := range inv.PaymentMeans {
+		if pm.CreditorID != "" && doc.Seller.Party.Identification == nil {
+			doc.Seller.Party.Identification = &ublPartyIdentification{ID: ublID{SchemeID: "SEPA", Value: string(pm.CreditorID)}}
+		}
+		means := ublPaymentMeans{
+			Code:      ublCode{Name: pm.Text, Value: string(pm.Code)},
+			PaymentID: pm.RemittanceInformation,
+		}
+		if creditNote && i == 0 && inv.DueDate.IsNotNull() {
+			// CreditNote documents have no DueDate
+			means.PaymentDueDate = string(inv.DueDate.Get())
+		}
+		if pm.IBAN.IsNotNull() {
+			means.PayeeAccount = &ublFinancialAccount{ID: pm.IBAN.String(), Name: pm.AccountName}
+			if pm.BIC.IsNotNull() {
+				means.PayeeAccount.Branch = &ublBranch{ID: pm.BIC.String()}
+			}
+		}
+		if pm.MandateReference != "" || pm.DebitedIBAN.IsNotNull() {
+			means.Mandate = &ublMandate{ID: pm.MandateReference}
+			if pm.DebitedIBAN.IsNotNull() {
+				means.Mandate.PayerAccount = &ublFinancialAccount{ID: pm.DebitedIBAN.String()}
+			}
+		}
+		doc.PaymentMeans = append(doc.PaymentMeans, means)
+	}
+	if inv.PaymentTerms != "" {
+		doc.PaymentTerms = &ublPaymentTerms{Note: inv.PaymentTerms}
+	}
+
+	for _, ac := range inv.AllowancesCharges {
+		doc.AllowancesCharges = append(doc.AllowancesCharges, ublAllowanceCharge{
+			ChargeIndicator: ac.Charge,
+			Reason:          ac.Reason,
+			Amount:          amount(ac.Amount),
+			TaxCategory:     ublTaxCategoryOf(ac.VATCategory, ac.VATRate),
+		})
+	}
+
+	taxTotal := ublTaxTotal{TaxAmount: amount(inv.Totals.TaxAmount)}
+	for _, vb := range inv.VATBreakdown {
+		category := ublTaxCategoryOf(vb.Category, vb.Rate)
+		category.ExemptionReasonCode = vb.ExemptionReasonCode
+		category.ExemptionReason = vb.ExemptionReason
+		taxTotal.Subtotals = append(taxTotal.Subtotals, ublTaxSubtotal{
+			TaxableAmount: amount(vb.TaxableAmount),
+			TaxAmount:     amount(vb.TaxAmount),
+			TaxCategory:   category,
+		})
+	}
+	doc.TaxTotals = []ublTaxTotal{taxTotal}
+
+	t := &inv.Totals
+	doc.MonetaryTotal = ublMonetaryTotal{
+		LineExtensionAmount: amount(t.LineNetAmount),
+		TaxExclusiveAmount:  amount(t.TaxExclusiveAmount),
+		TaxInclusiveAmount:  amount(t.TaxInclusiveAmount),
+		AllowanceTotal:      optionalAmount(t.AllowanceTotal),
+		ChargeTotal:         optionalAmount(t.ChargeTotal),
+		PrepaidAmount:       optionalAmount(t.PrepaidAmount),
+		RoundingAmount:      optionalAmount(t.RoundingAmount),
+		PayableAmount:       amount(t.PayableAmount),
+	}
+
+	for i := range inv.Lines {
+		l := &inv.Lines[i]
+		quantity := &ublQuantity{
+			UnitCode: l.Quantity.Unit,
+			Value:    strconv.FormatFloat(l.Quantity.Value, 'f', -1, 64),
+		}
+		line := ublLine{
+			ID:                  l.ID,
+			Note:                l.Note,
+			LineExtensionAmount: amount(l.NetAmount),
+			Item: ublItem{
+				Name:        l.ItemName,
+				TaxCategory: ublTaxCategoryOf(l.VATCategory, l.VATRate),
+			},
+			Price: ublPrice{PriceAmount: amount(l.NetPrice)},
+		}
+		if l.BaseQuantity != 0 {
+			line.Price.BaseQuantity = &ublQuantity{
+				UnitCode: l.Quantity.Unit,
+				Value:    strconv.FormatFloat(l.BaseQuantity, 'f', -1, 64),
+			}
+		}
+		if creditNote {
+			line.CreditedQuantity = quantity
+			doc.CreditNoteLines = append(doc.CreditNoteLines, line)
+		} else {
+			line.InvoicedQuantity = quantity
+			doc.InvoiceLines = append(doc.InvoiceLines, line)
+		}
+	}
+
+	out, err := xml.MarshalIndent(&doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// ParseUBL parses an OASIS UBL 2.1 Invoice or CreditNote
+// XML document as Invoice.
+//
+// An error is returned if the currency, country, or VAT category codes
+// are not valid according to ValidateCodeLists.
+// The SEPA creditor identifier (BT-90) is assigned to the first PaymentMeans.
+func ParseUBL(data []byte) (*Invoice, error) {
+	var doc ublDocument
+	dec := xml.NewTokenDecoder(&prefixTokenReader{dec: xml.NewDecoder(bytes.NewReader(data)), prefixes: ublPrefixes})
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("can't parse UBL XML: %w", err)
+	}
+	if doc.XMLName.Local != "Invoice" && doc.XMLName.Local != "CreditNote" {
+		return nil, fmt.Errorf("root element %q is not a UBL Invoice or CreditNote", doc.XMLName.Local)
+	}
+
+	p := valueParser{syntax: "UBL"}
+	inv := &Invoice{
+		Number:          doc.ID,
+		IssueDate:       p.ublDate(doc.IssueDate, "issue date"),
+		TypeCode:        TypeCode(cmp.Or(doc.InvoiceTypeCode, doc.CreditNoteTypeCode)),
+		Currency:        money.Currency(doc.Currency),
+		BuyerReference:  doc.BuyerReference,
+		Notes:           doc.Notes,
+		SpecificationID: doc.CustomizationID,
+		Seller:          ublPartyFrom(&doc.Seller.Party),
+		Buyer:           ublPartyFrom(&doc.Buyer.Party),
+	}
+	if doc.DueDate != "" {
+		inv.DueDate = p.ublDate(doc.DueDate, "due date").Nullable()
+	}
+	if doc.OrderReference != nil {
+		inv.OrderReference = doc.OrderReference.ID
+	}
+	if doc.Delivery != nil && doc.Delivery.ActualDeliveryDate != "" {
+		inv.DeliveryDate = p.ublDate(doc.Delivery.ActualDeliveryDate, "delivery date").Nullable()
+	}
+
+	for _, pm := range doc.PaymentMeans {
+		means := PaymentMeans{
+			Code:                  PaymentMeansCode(pm.Code.Value),
+			Text:                  pm.Code.Name,
+			RemittanceInformation: pm.PaymentID,
+		}
+		if pm.PaymentDueDate != "" && inv.DueDate.IsNull() {
+			inv.DueDate = p.ublDate(pm.PaymentDueDate, "payment due date").Nullable()
+		}
+		if pm.PayeeAccount != nil {
+			means.IBAN = bank.NullableIBAN(pm.PayeeAccount.ID)
+			means.AccountName = pm.PayeeAccount.Name
+			if pm.PayeeAccount.Branch != nil {
+				means.BIC = bank.NullableBIC(pm.PayeeAccount.Branch.ID)
+			}
+		}
+		if pm.Mandate != nil {
+			means.MandateReference = pm.Mandate.ID
+			if pm.Mandate.PayerAccount != nil {
+				means.DebitedIBAN = bank.NullableIBAN(pm.Mandate.PayerAccount.ID)
+			}
+		}
+		inv.PaymentMeans = append(inv.PaymentMeans, means)
+	}
+	if id := doc.Seller.Party.Identification; id != nil && id.ID.SchemeID == "SEPA" && len(inv.PaymentMeans) > 0 {
+		inv.PaymentMeans[0].CreditorID = bank.CreditorID(id.ID.Value)
+	}
+	if doc.PaymentTerms != nil {
+		inv.PaymentTerms = doc.PaymentTerms.Note
+	}
+
+	for _, ac := range doc.AllowancesCharges {
+		inv.AllowancesCharges = append(inv.AllowancesCharges, AllowanceCharge{
+			Charge:      ac.ChargeIndicator,
+			Amount:      p.amount(ac.Amount.Value, "allowance or charge amount"),
+			Reason:      ac.Reason,
+			VATCategory: VATCategory(ac.TaxCategory.ID),
+			VATRate:     p.percent(ac.TaxCategory.Percent),
+		})
+	}
+
+	taxTotal := ublDocumentTaxTotal(doc.TaxTotals, doc.Currency)
+	for _, sub := range taxTotal.Subtotals {
+		inv.VATBreakdown = append(inv.VATBreakdown, VATBreakdown{
+			TaxableAmount:       p.amount(sub.TaxableAmount.Value, "taxable amount"),
+			TaxAmount:           p.amount(sub.TaxAmount.Value, "VAT amount"),
+			Category:            VATCategory(sub.TaxCategory.ID),
+			Rate:                p.percent(sub.TaxCategory.Percent),
+			ExemptionReason:     sub.TaxCategory.ExemptionReason,
+			ExemptionReasonCode: sub.TaxCategory.ExemptionReasonCode,
+		})
+	}
+
+	total := &doc.MonetaryTotal
+	inv.Totals = Totals{
+		LineNetAmount:      p.amount(total.LineExtensionAmount.Value, "line extension amount"),
+		AllowanceTotal:     p.optionalAmount(total.AllowanceTotal, "allowance total amount"),
+		ChargeTotal:        p.optionalAmount(total.ChargeTotal, "charge total amount"),
+		TaxExclusiveAmount: p.amount(total.TaxExclusiveAmount.Value, "tax exclusive amount"),
+		TaxAmount:          p.amount(taxTotal.TaxAmount.Value, "tax amount"),
+		TaxInclusiveAmount: p.amount(total.TaxInclusiveAmount.Value, "tax inclusive amount"),
+		PrepaidAmount:      p.optionalAmount(total.PrepaidAmount, "prepaid amount"),
+		RoundingAmount:     p.optionalAmount(total.RoundingAmount, "payable rounding amount"),
+		PayableAmount:      p.amount(total.PayableAmount.Value, "payable amount"),
+	}
+
+	for _, l := range append(doc.InvoiceLines, doc.CreditNoteLines...) {
+		line := Line{
+			ID:          l.ID,
+			Note:        l.Note,
+			NetAmount:   p.amount(l.LineExtensionAmount.Value, "line extension amount"),
+			ItemName:    l.Item.Name,
+			NetPrice:    p.amount(l.Price.PriceAmount.Value, "price amount"),
+			VATCategory: VATCategory(l.Item.TaxCategory.ID),
+			VATRate:     p.percent(l.Item.TaxCategory.Percent),
+		}
+		if quantity := cmp.Or(l.InvoicedQuantity, l.CreditedQuantity); quantity != nil {
+			line.Quantity.Unit = quantity.UnitCode
+			line.Quantity.Value = p.float(quantity.Value, "quantity")
+		}
+		if l.Price.BaseQuantity != nil {
+			line.BaseQuantity = p.float(l.Price.BaseQuantity.Value, "base quantity")
+		}
+		inv.Lines = append(inv.Lines, line)
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := inv.ValidateCodeLists(); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (p *valueParser) optionalAmount(a *ublAmount, field string) money.Amount {
+	if a == nil {
+		return 0
+	}
+	return p.amount(a.Value, field)
+}
+
+func (p *valueParser) ublDate(s, field string) date.Date {
+	d, err := date.Parse("2006-01-02", strings.TrimSpace(s))
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("can't parse %s %s: %w", p.syntax, field, err)
+	}
+	return d
+}
+
+func ublPartyOf(party *Party) ublPartyRole {
+	up := ublParty{
+		Address: ublAddress{
+			StreetName:           party.Address.Line1,
+			AdditionalStreetName: party.Address.Line2,
+			CityName:             party.Address.City,
+			PostalZone:           party.Address.PostCode,
+			CountrySubentity:     party.Address.Subdivision,
+			Country:              ublCountry{IdentificationCode: string(party.Address.Country)},
+		},
+		LegalEntity: ublLegalEntity{
+			RegistrationName: party.Name,
+			CompanyID:        party.LegalRegistrationID,
+		},
+	}
+	if party.ElectronicAddress != "" {
+		up.EndpointID = &ublID{
+			SchemeID: cmp.Or(party.ElectronicAddressScheme, "EM"),
+			Value:    party.ElectronicAddress,
+		}
+	}
+	if party.TradingName != "" {
+		up.PartyName = &ublPartyName{Name: party.TradingName}
+	}
+	if party.VATID.IsNotNull() {
+		up.TaxSchemes = append(up.TaxSchemes, ublPartyTaxScheme{CompanyID: party.VATID.String(), TaxScheme: ublTaxScheme{ID: "VAT"}})
+	}
+	if party.TaxRegistrationID != "" {
+		up.TaxSchemes = append(up.TaxSchemes, ublPartyTaxScheme{CompanyID: party.TaxRegistrationID, TaxScheme: ublTaxScheme{ID: "TAX"}})
+	}
+	return ublPartyRole{Party: up}
+}
+
+func ublPartyFrom(up *ublParty) Party {
+	party := Party{
+		Name:                up.LegalEntity.RegistrationName,
+		LegalRegistrationID: up.LegalEntity.CompanyID,
+		Address: Address{
+			Line1:       up.Address.StreetName,
+			Line2:       up.Address.AdditionalStreetName,
+			City:        up.Address.CityName,
+			PostCode:    up.Address.PostalZone,
+			Subdivision: up.Address.CountrySubentity,
+			Country:     country.Code(up.Address.Country.IdentificationCode),
+		},
+	}
+	if up.EndpointID != nil {
+		party.ElectronicAddress = up.EndpointID.Value
+		party.ElectronicAddressScheme = up.EndpointID.SchemeID
+	}
+	if up.PartyName != nil {
+		party.TradingName = up.PartyName.Name
+	}
+	for _, ts := range up.TaxSchemes {
+		if ts.TaxScheme.ID == "VAT" {
+			party.VATID = vat.NullableID(ts.CompanyID)
+		} else {
+			party.TaxRegistrationID = ts.CompanyID
+		}
+	}
+	return party
+}
+
+func ublTaxCategoryOf(category VATCategory, rate money.Rate) ublTaxCategory {
+	c := ublTaxCategory{
+		ID:        string(category),
+		TaxScheme: ublTaxScheme{ID: "VAT"},
+	}
+	// No rate for categories not subject to VAT
+	if category != VATCategoryNotSubject {
+		c.Percent = formatPercent(rate)
+	}
+	return c
+}
+
+type ublDocument struct {
+	XMLName            xml.Name
+	Xmlns              string               `xml:"xmlns,attr,omitempty"`
+	XmlnsCAC           string               `xml:"xmlns:cac,attr,omitempty"`
+	XmlnsCBC           string               `xml:"xmlns:cbc,attr,omitempty"`
+	CustomizationID    string               `xml:"cbc:CustomizationID"`
+	ProfileID          string               `xml:"cbc:ProfileID,omitempty"`
+	ID                 string               `xml:"cbc:ID"`
+	IssueDate          string               `xml:"cbc:IssueDate"`
+	DueDate            string               `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode    string               `xml:"cbc:InvoiceTypeCode,omitempty"`
+	CreditNoteTypeCode string               `xml:"cbc:CreditNoteTypeCode,omitempty"`
+	Notes              []string             `xml:"cbc:Note"`
+	Currency           string               `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference     string               `xml:"cbc:BuyerReference,omitempty"`
+	OrderReference     *ublReference        `xml:"cac:OrderReference,omitempty"`
+	Seller             ublPartyRole         `xml:"cac:AccountingSupplierParty"`
+	Buyer              ublPartyRole         `xml:"cac:AccountingCustomerParty"`
+	Delivery           *ublDelivery         `xml:"cac:Delivery,omitempty"`
+	PaymentMeans       []ublPaymentMeans    `xml:"cac:PaymentMeans"`
+	PaymentTerms       *ublPaymentTerms     `xml:"cac:PaymentTerms,omitempty"`
+	AllowancesCharges  []ublAllowanceCharge `xml:"cac:AllowanceCharge"`
+	TaxTotals          []ublTaxTotal        `xml:"cac:TaxTotal"`
+	MonetaryTotal      ublMonetaryTotal     `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines       []ublLine            `xml:"cac:InvoiceLine"`
+	CreditNoteLines    []ublLine            `xml:"cac:CreditNoteLine"`
+}
+
+type ublID struct {
+	SchemeID string `xml:"schemeID,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ublCode struct {
+	Name  string `xml:"name,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type ublAmount struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ublQuantity struct {
+	UnitCode string `xml:"unitCode,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ublReference struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublPartyRole struct {
+	Party ublParty `xml:"cac:Party"`
+}
+
+type ublParty struct {
+	EndpointID     *ublID                  `xml:"cbc:EndpointID,omitempty"`
+	Identification *ublPartyIdentification `xml:"cac:PartyIdentification,omitempty"`
+	PartyName      *ublPartyName           `xml:"cac:PartyName,omitempty"`
+	Address        ublAddress              `xml:"cac:PostalAddress"`
+	TaxSchemes     []ublPartyTaxScheme     `xml:"cac:PartyTaxScheme"`
+	LegalEntity    ublLegalEntity          `xml:"cac:PartyLegalEntity"`
+}
+
+type ublPartyIdentification struct {
+	ID ublID `xml:"cbc:ID"`
+}
+
+type ublPartyName struct {
+	Name string `xml:"cbc:Name"`
+}
+
+type ublAddress struct {
+	StreetName           string     `xml:"cbc:StreetName,omitempty"`
+	AdditionalStreetName string     `xml:"cbc:AdditionalStreetName,omitempty"`
+	CityName             string     `xml:"cbc:CityName,omitempty"`
+	PostalZone           string     `xml:"cbc:PostalZone,omitempty"`
+	CountrySubentity     string     `xml:"cbc:CountrySubentity,omitempty"`
+	Country              ublCountry `xml:"cac:Country"`
+}
+
+type ublCountry struct {
+	IdentificationCode string `xml:"cbc:IdentificationCode"`
+}
+
+type ublPartyTaxScheme struct {
+	CompanyID string       `xml:"cbc:CompanyID"`
+	TaxScheme ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublTaxScheme struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublLegalEntity struct {
+	RegistrationName string `xml:"cbc:RegistrationName"`
+	CompanyID        string `xml:"cbc:CompanyID,omitempty"`
+}
+
+type ublDelivery struct {
+	ActualDeliveryDate string `xml:"cbc:ActualDeliveryDate,omitempty"`
+}
+
+type ublPaymentMeans struct {
+	Code           ublCode              `xml:"cbc:PaymentMeansCode"`
+	PaymentDueDate string               `xml:"cbc:PaymentDueDate,omitempty"`
+	PaymentID      string               `xml:"cbc:PaymentID,omitempty"`
+	PayeeAccount   *ublFinancialAccount `xml:"cac:PayeeFinancialAccount,omitempty"`
+	Mandate        *ublMandate          `xml:"cac:PaymentMandate,omitempty"`
+}
+
+type ublFinancialAccount struct {
+	ID     string     `xml:"cbc:ID"`
+	Name   string     `xml:"cbc:Name,omitempty"`
+	Branch *ublBranch `xml:"cac:FinancialInstitutionBranch,omitempty"`
+}
+
+type ublBranch struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ublMandate struct {
+	ID           string               `xml:"cbc:ID,omitempty"`
+	PayerAccount *ublFinancialAccount `xml:"cac:PayerFinancialAccount,omitempty"`
+}
+
+type ublPaymentTerms struct {
+	Note string `xml:"cbc:Note"`
+}
+
+type ublAllowanceCharge struct {
+	ChargeIndicator bool           `xml:"cbc:ChargeIndicator"`
+	Reason          string         `xml:"cbc:AllowanceChargeReason,omitempty"`
+	Amount          ublAmount      `xml:"cbc:Amount"`
+	TaxCategory     ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+// ublTaxCategory is used for the VAT breakdown
+// and the VAT of lines, allowances, and charges
+// in the element order of the UBL schema.
+type ublTaxCategory struct {
+	ID                  string       `xml:"cbc:ID"`
+	Percent             string       `xml:"cbc:Percent,omitempty"`
+	ExemptionReasonCode string       `xml:"cbc:TaxExemptionReasonCode,omitempty"`
+	ExemptionReason     string       `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme           ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount ublAmount        `xml:"cbc:TaxAmount"`
+	Subtotals []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+// ublDocumentTaxTotal returns the tax total in the document currency
+// which is the one with subtotals. A second tax total in the
+// tax currency (BT-111) has no subtotals and is ignored.
+func ublDocumentTaxTotal(totals []ublTaxTotal, currency string) *ublTaxTotal {
+	for i := range totals {
+		if totals[i].TaxAmount.CurrencyID == currency {
+			return &totals[i]
+		}
+	}
+	for i := range totals {
+		if len(totals[i].Subtotals) > 0 {
+			return &totals[i]
+		}
+	}
+	if len(totals) > 0 {
+		return &totals[0]
+	}
+	return &ublTaxTotal{}
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublMonetaryTotal struct {
+	LineExtensionAmount ublAmount  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  ublAmount  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  ublAmount  `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotal      *ublAmount `xml:"cbc:AllowanceTotalAmount,omitempty"`
+	ChargeTotal         *ublAmount `xml:"cbc:ChargeTotalAmount,omitempty"`
+	PrepaidAmount       *ublAmount `xml:"cbc:PrepaidAmount,omitempty"`
+	RoundingAmount      *ublAmount `xml:"cbc:PayableRoundingAmount,omitempty"`
+	PayableAmount       ublAmount  `xml:"cbc:PayableAmount"`
+}
+
+type ublLine struct {
+	ID                  string       `xml:"cbc:ID"`
+	Note                string       `xml:"cbc:Note,omitempty"`
+	InvoicedQuantity    *ublQuantity `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *ublQuantity `xml:"cbc:CreditedQuantity,omitempty"`
+	LineExtensionAmount ublAmount    `xml:"cbc:LineExtensionAmount"`
+	Item                ublItem      `xml:"cac:Item"`
+	Price               ublPrice     `xml:"cac:Price"`
+}
+
+type ublItem struct {
+	Name        string         `xml:"cbc:Name"`
+	TaxCategory ublTaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+type ublPrice struct {
+	PriceAmount  ublAmount    `xml:"cbc:PriceAmount"`
+	BaseQuantity *ublQuantity `xml:"cbc:BaseQuantity,omitempty"`
+}
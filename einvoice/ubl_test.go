@@ -0,0 +1,120 @@
+package einvoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoice_MarshalUBL(t *testing.T) {
+	inv := exampleInvoice()
+	inv.SpecificationID = SpecificationPeppolBIS
+	inv.Notes = []string{"Thank you"}
+	inv.OrderReference = "PO-4711"
+	inv.DeliveryDate = "2024-03-10"
+	inv.Seller.TradingName = "Seller"
+	inv.Seller.ElectronicAddress = "invoice@seller.example.com"
+	inv.Seller.ElectronicAddressScheme = "EM"
+	inv.Buyer.ElectronicAddress = "04011000-12345-03"
+	inv.Buyer.ElectronicAddressScheme = "0204"
+	inv.PaymentMeans[0].RemittanceInformation = "R-2024-0001"
+	inv.PaymentMeans[0].BIC = "COBADEFFXXX"
+	inv.Lines[0].Note = "March"
+	inv.Lines[0].BaseQuantity = 1
+
+	data, err := inv.MarshalUBL()
+	require.NoError(t, err)
+	xml := string(data)
+	for _, want := range []string{
+		`<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`,
+		`<cbc:CustomizationID>` + SpecificationPeppolBIS + `</cbc:CustomizationID>`,
+		`<cbc:ProfileID>` + ProfilePeppolBilling + `</cbc:ProfileID>`,
+		`<cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>`,
+		`<cbc:DueDate>2024-04-14</cbc:DueDate>`,
+		`<cbc:EndpointID schemeID="0204">04011000-12345-03</cbc:EndpointID>`,
+		`<cbc:InvoicedQuantity unitCode="HUR">2.5</cbc:InvoicedQuantity>`,
+		`<cbc:Percent>19.00</cbc:Percent>`,
+		`<cbc:TaxAmount currencyID="EUR">38.45</cbc:TaxAmount>`,
+		`<cbc:PayableAmount currencyID="EUR">203.45</cbc:PayableAmount>`,
+	} {
+		assert.Contains(t, xml, want)
+	}
+
+	parsed, err := ParseUBL(data)
+	require.NoError(t, err)
+	assert.Equal(t, inv, parsed)
+	assert.NoError(t, parsed.Validate())
+
+	inv.Currency = "XYZ"
+	inv.Lines[1].VATCategory = "X"
+	_, err = inv.MarshalUBL()
+	require.Error(t, err, "invalid code lists")
+	assert.Contains(t, err.Error(), "BR-05")
+	assert.Contains(t, err.Error(), "BR-CO-4")
+}
+
+func TestInvoice_MarshalUBL_CreditNote(t *testing.T) {
+	inv := exampleInvoice()
+	inv.TypeCode = TypeCodeCreditNote
+	inv.PaymentMeans[0].CreditorID = "DE98ZZZ09999999999"
+
+	data, err := inv.MarshalUBL()
+	require.NoError(t, err)
+	xml := string(data)
+	assert.Contains(t, xml, `<CreditNote xmlns="urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"`)
+	assert.Contains(t, xml, `<cbc:CreditNoteTypeCode>381</cbc:CreditNoteTypeCode>`)
+	assert.Contains(t, xml, `<cbc:PaymentDueDate>2024-04-14</cbc:PaymentDueDate>`)
+	assert.Contains(t, xml, `<cbc:CreditedQuantity unitCode="C62">1</cbc:CreditedQuantity>`)
+	assert.NotContains(t, xml, `<cbc:DueDate>`)
+	assert.NotContains(t, xml, `InvoiceLine`)
+
+	parsed, err := ParseUBL(data)
+	require.NoError(t, err)
+	assert.Equal(t, inv, parsed)
+
+	// Due date can't be written without PaymentMeans
+	inv.PaymentMeans = nil
+	_, err = inv.MarshalUBL()
+	assert.Error(t, err, "credit note due date without payment means")
+	inv.DueDate.SetNull()
+	_, err = inv.MarshalUBL()
+	assert.NoError(t, err, "credit note without due date and payment means")
+}
+
+func TestParseUBL(t *testing.T) {
+	data, err := exampleInvoice().MarshalUBL()
+	require.NoError(t, err)
+
+	// Other namespace prefixes than the conventional ones
+	renamed := strings.NewReplacer(
+		"cac:", "a:", "xmlns:cac", "xmlns:a",
+		"cbc:", "b:", "xmlns:cbc", "xmlns:b",
+	).Replace(string(data))
+	parsed, err := ParseUBL([]byte(renamed))
+	require.NoError(t, err)
+	assert.Equal(t, exampleInvoice(), parsed)
+
+	_, err = ParseUBL([]byte(`<rsm:CrossIndustryInvoice xmlns:rsm="` + CIINamespaceRSM + `"/>`))
+	assert.Error(t, err, "CII root element")
+
+	invalidCountry := strings.Replace(string(data), "<cbc:IdentificationCode>DE<", "<cbc:IdentificationCode>XX<", 1)
+	_, err = ParseUBL([]byte(invalidCountry))
+	require.Error(t, err, "invalid country code")
+	assert.Contains(t, err.Error(), "BR-11")
+
+	invalidDate := strings.Replace(string(data), "<cbc:IssueDate>2024-03-15<", "<cbc:IssueDate>15.03.2024<", 1)
+	_, err = ParseUBL([]byte(invalidDate))
+	assert.Error(t, err, "invalid date")
+
+	// Additional tax total in the tax currency (BT-111) without subtotals
+	taxCurrencyTotal := strings.Replace(string(data),
+		"</cac:TaxTotal>",
+		`</cac:TaxTotal><cac:TaxTotal><cbc:TaxAmount currencyID="SEK">210.00</cbc:TaxAmount></cac:TaxTotal>`, 1)
+	require.Contains(t, taxCurrencyTotal, `currencyID="SEK"`)
+	parsed, err = ParseUBL([]byte(taxCurrencyTotal))
+	require.NoError(t, err)
+	assert.Equal(t, exampleInvoice(), parsed)
+	assert.NoError(t, parsed.Validate())
+}
@@ -37,6 +37,48 @@ func NormalizeAddressList(list string) (normalized []string, err error) {
 	return normalized, nil
 }
 
+// MergeAddressLists merges the passed address lists into one list
+// of normalized addresses without duplicates, for example to build
+// a combined recipient list from the messages of a thread.
+//
+// Addresses are deduplicated by their normalized address part
+// and returned in the order they were first seen.
+// The first non-empty display name found for an address
+// is used, so an address without name is replaced by
+// a later occurrence of the same address with a name.
+// Invalid addresses are skipped.
+func MergeAddressLists(lists ...[]Address) []Address {
+	var (
+		merged []*mail.Address
+		index  = make(map[string]int)
+	)
+	for _, list := range lists {
+		for _, addr := range list {
+			parsed, err := addr.Parse()
+			if err != nil {
+				continue
+			}
+			i, exists := index[parsed.Address]
+			if !exists {
+				index[parsed.Address] = len(merged)
+				merged = append(merged, parsed)
+				continue
+			}
+			if merged[i].Name == "" && parsed.Name != "" {
+				merged[i].Name = parsed.Name
+			}
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	result := make([]Address, len(merged))
+	for i, addr := range merged {
+		result[i] = AddressFrom(addr)
+	}
+	return result
+}
+
 // AddressList represents a comma-separated list of email addresses.
 // The list must contain at least one valid email address.
 // Use NullableAddressList for lists that can be empty.
@@ -55,3 +55,19 @@ func TestAddressList_Split(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeAddressLists(t *testing.T) {
+	merged := MergeAddressLists(
+		[]Address{"erik@example.com", "Jane Doe <JANE@example.com>", "invalid"},
+		nil,
+		[]Address{`"Unger, Erik" <Erik@Example.com>`, "jane@example.com", "Other Name <jane@example.com>", "bob@example.com"},
+	)
+	assert.Equal(t, []Address{
+		`"Unger, Erik" <erik@example.com>`,
+		`"Jane Doe" <jane@example.com>`,
+		"bob@example.com",
+	}, merged)
+
+	assert.Nil(t, MergeAddressLists())
+	assert.Nil(t, MergeAddressLists([]Address{"invalid"}))
+}
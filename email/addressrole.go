@@ -0,0 +1,151 @@
+package email
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// AddressRole is the function of an email address
+// derived from its local part.
+type AddressRole string
+
+const (
+	// AddressRoleInvoice is an address for receiving
+	// or sending invoices like "invoice@" or "rechnung@".
+	AddressRoleInvoice AddressRole = "INVOICE"
+	// AddressRoleBilling is an address of a billing or accounting
+	// department like "billing@" or "buchhaltung@".
+	AddressRoleBilling AddressRole = "BILLING"
+	// AddressRoleNoReply is an address that does not accept
+	// replies like "noreply@" or "do-not-reply@".
+	AddressRoleNoReply AddressRole = "NO_REPLY"
+	// AddressRolePersonal is the role of all addresses
+	// not matching any other role.
+	AddressRolePersonal AddressRole = "PERSONAL"
+)
+
+// Valid returns true if the role is one of the defined constants.
+func (r AddressRole) Valid() bool {
+	switch r {
+	case AddressRoleInvoice, AddressRoleBilling, AddressRoleNoReply, AddressRolePersonal:
+		return true
+	}
+	return false
+}
+
+// AddressRoleRule assigns a role to addresses
+// with a local part matching a pattern.
+type AddressRoleRule struct {
+	// Pattern is a path.Match pattern like "invoice*"
+	// that is matched against the lower case local part
+	// of an address without a "+" sub-address tag.
+	Pattern string `json:"pattern"`
+	// Role of matching addresses.
+	Role AddressRole `json:"role"`
+}
+
+// AddressRoleRules is an ordered registry of rules
+// where the first matching rule determines the role of an address.
+type AddressRoleRules []AddressRoleRule
+
+// DefaultAddressRoleRules are the English and German
+// rules used by Address.Role.
+// Append to a clone of it for custom rules.
+var DefaultAddressRoleRules = AddressRoleRules{
+	{Pattern: "noreply*", Role: AddressRoleNoReply},
+	{Pattern: "no-reply*", Role: AddressRoleNoReply},
+	{Pattern: "no_reply*", Role: AddressRoleNoReply},
+	{Pattern: "donotreply*", Role: AddressRoleNoReply},
+	{Pattern: "do-not-reply*", Role: AddressRoleNoReply},
+	{Pattern: "do_not_reply*", Role: AddressRoleNoReply},
+	{Pattern: "mailer-daemon", Role: AddressRoleNoReply},
+	{Pattern: "postmaster", Role: AddressRoleNoReply},
+	{Pattern: "bounce*", Role: AddressRoleNoReply},
+	{Pattern: "invoice*", Role: AddressRoleInvoice},
+	{Pattern: "invoicing*", Role: AddressRoleInvoice},
+	{Pattern: "e-invoice*", Role: AddressRoleInvoice},
+	{Pattern: "einvoice*", Role: AddressRoleInvoice},
+	{Pattern: "rechnung*", Role: AddressRoleInvoice},
+	{Pattern: "e-rechnung*", Role: AddressRoleInvoice},
+	{Pattern: "erechnung*", Role: AddressRoleInvoice},
+	{Pattern: "billing*", Role: AddressRoleBilling},
+	{Pattern: "accounting*", Role: AddressRoleBilling},
+	{Pattern: "accounts*", Role: AddressRoleBilling},
+	{Pattern: "payable*", Role: AddressRoleBilling},
+	{Pattern: "receivable*", Role: AddressRoleBilling},
+	{Pattern: "finance*", Role: AddressRoleBilling},
+	{Pattern: "buchhaltung*", Role: AddressRoleBilling},
+	{Pattern: "fibu*", Role: AddressRoleBilling},
+	{Pattern: "kreditoren*", Role: AddressRoleBilling},
+	{Pattern: "debitoren*", Role: AddressRoleBilling},
+}
+
+// Validate returns an error if a rule has
+// a malformed pattern or an invalid role.
+func (rules AddressRoleRules) Validate() error {
+	for i, rule := range rules {
+		if _, err := path.Match(rule.Pattern, ""); err != nil || rule.Pattern == "" {
+			return fmt.Errorf("invalid address role pattern %q at index %d", rule.Pattern, i)
+		}
+		if !rule.Role.Valid() {
+			return fmt.Errorf("invalid address role %q at index %d", rule.Role, i)
+		}
+	}
+	return nil
+}
+
+// Classify returns the role of the first rule matching the address
+// or AddressRolePersonal if no rule matches.
+// Returns an empty string if the address can't be parsed.
+func (rules AddressRoleRules) Classify(addr Address) AddressRole {
+	local, err := addr.LocalPart()
+	if err != nil {
+		return ""
+	}
+	local = strings.ToLower(local)
+	if tag := strings.IndexByte(local, '+'); tag > 0 {
+		local = local[:tag]
+	}
+	for _, rule := range rules {
+		if ok, _ := path.Match(rule.Pattern, local); ok {
+			return rule.Role
+		}
+	}
+	return AddressRolePersonal
+}
+
+// ReplyTarget returns the first of the candidate addresses
+// that can be parsed and is not classified as AddressRoleNoReply.
+// Returns false if there is no such address.
+func (rules AddressRoleRules) ReplyTarget(candidates ...Address) (Address, bool) {
+	for _, addr := range candidates {
+		if role := rules.Classify(addr); role != "" && role != AddressRoleNoReply {
+			return addr, true
+		}
+	}
+	return "", false
+}
+
+// Role returns the role of the address
+// using the DefaultAddressRoleRules.
+// Returns an empty string if the address can't be parsed.
+func (a Address) Role() AddressRole {
+	return DefaultAddressRoleRules.Classify(a)
+}
+
+// ReplyTarget returns the address a reply to the message
+// should be sent to by choosing the "Reply-To" address
+// over the "From" address unless it is classified
+// as AddressRoleNoReply by the rules.
+// Returns false if none of them accepts replies.
+func (msg *Message) ReplyTarget(rules AddressRoleRules) (Address, bool) {
+	candidates := make([]Address, 0, 2)
+	if msg.ReplyTo.IsNotNull() {
+		candidates = append(candidates, msg.ReplyTo.Get())
+	}
+	if msg.From != "" {
+		candidates = append(candidates, msg.From)
+	}
+	return rules.ReplyTarget(candidates...)
+}
@@ -0,0 +1,64 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddress_Role(t *testing.T) {
+	tests := []struct {
+		addr Address
+		want AddressRole
+	}{
+		{addr: "invoice@example.com", want: AddressRoleInvoice},
+		{addr: "Invoices+2024@example.com", want: AddressRoleInvoice},
+		{addr: "Rechnung <rechnungseingang@example.de>", want: AddressRoleInvoice},
+		{addr: "billing@example.com", want: AddressRoleBilling},
+		{addr: "buchhaltung@example.de", want: AddressRoleBilling},
+		{addr: "noreply@example.com", want: AddressRoleNoReply},
+		{addr: "No-Reply@example.com", want: AddressRoleNoReply},
+		{addr: "do-not-reply@example.com", want: AddressRoleNoReply},
+		{addr: "Jane Doe <jane.doe@example.com>", want: AddressRolePersonal},
+		{addr: "invalid", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.addr), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.addr.Role())
+		})
+	}
+}
+
+func TestAddressRoleRules(t *testing.T) {
+	assert.NoError(t, DefaultAddressRoleRules.Validate())
+	assert.Error(t, AddressRoleRules{{Pattern: "[", Role: AddressRoleInvoice}}.Validate())
+	assert.Error(t, AddressRoleRules{{Pattern: "", Role: AddressRoleInvoice}}.Validate())
+	assert.Error(t, AddressRoleRules{{Pattern: "x", Role: "OTHER"}}.Validate())
+
+	custom := append(AddressRoleRules{{Pattern: "ap", Role: AddressRoleBilling}}, DefaultAddressRoleRules...)
+	assert.Equal(t, AddressRoleBilling, custom.Classify("ap@example.com"))
+	assert.Equal(t, AddressRolePersonal, DefaultAddressRoleRules.Classify("ap@example.com"))
+	assert.Equal(t, AddressRolePersonal, AddressRoleRules(nil).Classify("noreply@example.com"))
+
+	target, ok := DefaultAddressRoleRules.ReplyTarget("noreply@example.com", "invalid", "billing@example.com")
+	assert.True(t, ok)
+	assert.Equal(t, Address("billing@example.com"), target)
+	_, ok = DefaultAddressRoleRules.ReplyTarget("noreply@example.com")
+	assert.False(t, ok)
+}
+
+func TestMessage_ReplyTarget(t *testing.T) {
+	msg := &Message{From: "noreply@shop.example.com"}
+	_, ok := msg.ReplyTarget(DefaultAddressRoleRules)
+	assert.False(t, ok)
+
+	msg.ReplyTo = "support@shop.example.com"
+	target, ok := msg.ReplyTarget(DefaultAddressRoleRules)
+	assert.True(t, ok)
+	assert.Equal(t, Address("support@shop.example.com"), target)
+
+	msg = &Message{From: "jane@example.com", ReplyTo: "do-not-reply@example.com"}
+	target, ok = msg.ReplyTarget(DefaultAddressRoleRules)
+	assert.True(t, ok)
+	assert.Equal(t, Address("jane@example.com"), target)
+}
@@ -67,6 +67,13 @@ func (set AddressSet) IsNull() bool {
 	return set == nil
 }
 
+// IsZero returns true if the set is nil or empty
+// so that encoding/json omits it with the omitzero tag option
+// like with omitempty.
+func (set AddressSet) IsZero() bool {
+	return len(set) == 0
+}
+
 func (set AddressSet) Contains(addr Address) bool {
 	_, ok := set[addr]
 	return ok
@@ -16,6 +16,19 @@ type Attachment struct {
 	Inline      bool   `json:",omitempty"`
 	Filename    string
 	Content     []byte
+
+	// ContentSHA256 is the hex encoded SHA-256 hash
+	// of the content of a redacted attachment without Content.
+	ContentSHA256 string `json:",omitempty"`
+	// ContentSize is the size of the content
+	// of a redacted attachment without Content.
+	ContentSize int `json:",omitempty"`
+
+	// ArchivePath is the path declared in an archive
+	// for attachments expanded from a ZIP attachment.
+	// The path is untrusted input from the archive.
+	// See Attachment.ExpandZip.
+	ArchivePath string `json:",omitempty"`
 }
 
 func NewAttachment(partID, filename string, content []byte) *Attachment {
@@ -1,7 +1,11 @@
 package email
 
 import (
+	"bytes"
 	"encoding/json"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -18,3 +22,35 @@ func TestAttachment_MarshalJSON(t *testing.T) {
 	require.NoError(t, err, "json.Marshal")
 	require.Equal(t, `{"PartID":"PartID","ContentID":"ContentID","ContentType":"ContentType","Filename":"FileName","Content":"RmlsZURhdGE="}`, string(j))
 }
+
+func TestAttachment_ImageInfo(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, img))
+	info, err := NewAttachment("1", "image.png", pngData.Bytes()).ImageInfo()
+	require.NoError(t, err)
+	require.Equal(t, &ImageInfo{Format: "png", Width: 40, Height: 30, Orientation: 1}, info)
+
+	var jpegData bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpegData, img, nil))
+	// Insert a big endian EXIF APP1 segment with orientation 6 (rotate 90° CW)
+	exif := []byte{
+		0xFF, 0xE1, 0x00, 0x22,
+		'E', 'x', 'i', 'f', 0, 0,
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08, // TIFF header
+		0x00, 0x01, // 1 IFD entry
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x06, 0x00, 0x00, // orientation
+		0x00, 0x00, 0x00, 0x00, // no next IFD
+	}
+	withEXIF := append(append([]byte{0xFF, 0xD8}, exif...), jpegData.Bytes()[2:]...)
+	info, err = NewAttachment("2", "photo.jpg", withEXIF).ImageInfo()
+	require.NoError(t, err)
+	require.Equal(t, &ImageInfo{Format: "jpeg", Width: 40, Height: 30, Orientation: 6}, info)
+	width, height := info.DisplaySize()
+	require.Equal(t, 30, width)
+	require.Equal(t, 40, height)
+
+	_, err = NewAttachment("3", "text.txt", []byte("Hello World")).ImageInfo()
+	require.ErrorIs(t, err, image.ErrFormat)
+}
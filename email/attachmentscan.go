@@ -0,0 +1,184 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ScanVerdict is the result of a virus scan of an attachment.
+type ScanVerdict string
+
+const (
+	// ScanClean means that no threat was found.
+	ScanClean ScanVerdict = "clean"
+	// ScanSkipped means that the attachment was not scanned
+	// because it is larger than ScanOptions.MaxSize
+	// or a redacted attachment without content.
+	ScanSkipped ScanVerdict = "skipped"
+	// ScanFailed means that the scanner returned an error,
+	// an invalid verdict, or panicked.
+	ScanFailed ScanVerdict = "failed"
+	// ScanInfected means that a threat was found.
+	ScanInfected ScanVerdict = "infected"
+)
+
+// severity orders the verdicts for aggregation
+// where a higher severity wins.
+func (v ScanVerdict) severity() int {
+	switch v {
+	case ScanClean:
+		return 0
+	case ScanSkipped:
+		return 1
+	case ScanFailed:
+		return 2
+	case ScanInfected:
+		return 3
+	}
+	return -1
+}
+
+// AttachmentScanner is implemented by virus scanner integrations
+// like ICAP or ClamAV clients.
+//
+// ScanAttachment returns ScanClean or ScanInfected with the name
+// of the found threat, or an error if the attachment could not be scanned.
+// Implementations must be safe for concurrent use.
+type AttachmentScanner interface {
+	ScanAttachment(ctx context.Context, attachment *Attachment) (verdict ScanVerdict, threat string, err error)
+}
+
+// AttachmentScannerFunc implements AttachmentScanner with a function.
+type AttachmentScannerFunc func(ctx context.Context, attachment *Attachment) (verdict ScanVerdict, threat string, err error)
+
+// ScanAttachment implements the AttachmentScanner interface.
+func (f AttachmentScannerFunc) ScanAttachment(ctx context.Context, attachment *Attachment) (verdict ScanVerdict, threat string, err error) {
+	return f(ctx, attachment)
+}
+
+// ScanOptions configure Message.ScanAttachments.
+type ScanOptions struct {
+	// MaxConcurrency is the maximum number of attachments
+	// scanned in parallel. Values below 1 mean 1.
+	MaxConcurrency int
+	// MaxSize is the maximum content size of a scanned attachment.
+	// Larger attachments get the verdict ScanSkipped.
+	// Zero means no limit.
+	MaxSize int
+}
+
+// DefaultScanOptions returns ScanOptions with
+// up to 4 parallel scans of attachments up to 50 MiB.
+func DefaultScanOptions() ScanOptions {
+	return ScanOptions{
+		MaxConcurrency: 4,
+		MaxSize:        50 << 20,
+	}
+}
+
+// AttachmentScanResult is the scan result of one attachment.
+type AttachmentScanResult struct {
+	Attachment *Attachment `json:"-"`
+	Filename   string      `json:"filename"`
+	Verdict    ScanVerdict `json:"verdict"`
+	Threat     string      `json:"threat,omitempty"`
+	Err        error       `json:"-"`
+}
+
+// AttachmentsScanReport is the result of Message.ScanAttachments.
+type AttachmentsScanReport struct {
+	// Verdict is the most severe verdict of all Results
+	// in the order ScanInfected, ScanFailed, ScanSkipped, ScanClean.
+	// Messages without attachments are ScanClean.
+	Verdict ScanVerdict `json:"verdict"`
+	// Results in the order of the message attachments.
+	Results []AttachmentScanResult `json:"results,omitempty"`
+}
+
+// Infected returns the results with the verdict ScanInfected.
+func (r *AttachmentsScanReport) Infected() []AttachmentScanResult {
+	var infected []AttachmentScanResult
+	for _, result := range r.Results {
+		if result.Verdict == ScanInfected {
+			infected = append(infected, result)
+		}
+	}
+	return infected
+}
+
+// ScanAttachments scans all attachments of the message with the scanner
+// in parallel and returns the results with an aggregated verdict.
+//
+// Errors of the scanner are reported as ScanFailed results
+// of the affected attachments and don't stop scanning the others.
+// Unknown verdicts returned by the scanner are reported as ScanFailed.
+// An error is only returned if the context is canceled.
+func (msg *Message) ScanAttachments(ctx context.Context, scanner AttachmentScanner, options ScanOptions) (*AttachmentsScanReport, error) {
+	var attachments []*Attachment
+	for _, a := range msg.Attachments {
+		if a != nil {
+			attachments = append(attachments, a)
+		}
+	}
+	report := &AttachmentsScanReport{
+		Verdict: ScanClean,
+		Results: make([]AttachmentScanResult, len(attachments)),
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, max(options.MaxConcurrency, 1))
+	)
+	for i, a := range attachments {
+		result := &report.Results[i]
+		result.Attachment = a
+		result.Filename = a.Filename
+		if a.Content == nil && a.ContentSize > 0 || options.MaxSize > 0 && len(a.Content) > options.MaxSize {
+			result.Verdict = ScanSkipped
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.Verdict, result.Threat, result.Err = scanAttachment(ctx, scanner, a)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, result := range report.Results {
+		if result.Verdict.severity() > report.Verdict.severity() {
+			report.Verdict = result.Verdict
+		}
+	}
+	return report, nil
+}
+
+func scanAttachment(ctx context.Context, scanner AttachmentScanner, a *Attachment) (verdict ScanVerdict, threat string, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			verdict, threat, err = ScanFailed, "", fmt.Errorf("panic scanning attachment %q: %v", a.Filename, p)
+		}
+	}()
+
+	verdict, threat, err = scanner.ScanAttachment(ctx, a)
+	if err != nil {
+		return ScanFailed, "", err
+	}
+	if verdict != ScanClean && verdict != ScanInfected {
+		return ScanFailed, "", fmt.Errorf("scanner returned invalid verdict %q for attachment %q", verdict, a.Filename)
+	}
+	return verdict, threat, nil
+}
@@ -0,0 +1,73 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_ScanAttachments(t *testing.T) {
+	var (
+		running    atomic.Int32
+		maxRunning atomic.Int32
+	)
+	scanner := AttachmentScannerFunc(func(ctx context.Context, a *Attachment) (ScanVerdict, string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		switch {
+		case bytes.Contains(a.Content, []byte("EICAR")):
+			return ScanInfected, "Eicar-Test-Signature", nil
+		case a.Filename == "error.pdf":
+			return "", "", errors.New("scanner unavailable")
+		case a.Filename == "panic.pdf":
+			panic("boom")
+		}
+		return ScanClean, "", nil
+	})
+
+	msg := &Message{Attachments: []*Attachment{
+		{Filename: "clean.pdf", Content: []byte("%PDF")},
+		nil,
+		{Filename: "large.pdf", Content: make([]byte, 101)},
+		{Filename: "redacted.pdf", ContentSize: 10},
+		{Filename: "error.pdf", Content: []byte("%PDF")},
+		{Filename: "panic.pdf", Content: []byte("%PDF")},
+	}}
+	report, err := msg.ScanAttachments(t.Context(), scanner, ScanOptions{MaxConcurrency: 2, MaxSize: 100})
+	require.NoError(t, err)
+	require.Equal(t, ScanFailed, report.Verdict)
+	require.Len(t, report.Results, 5)
+	require.Equal(t, ScanClean, report.Results[0].Verdict)
+	require.Equal(t, ScanSkipped, report.Results[1].Verdict)
+	require.Equal(t, ScanSkipped, report.Results[2].Verdict)
+	require.Equal(t, ScanFailed, report.Results[3].Verdict)
+	require.Error(t, report.Results[3].Err)
+	require.Equal(t, ScanFailed, report.Results[4].Verdict)
+	require.ErrorContains(t, report.Results[4].Err, "boom")
+	require.Empty(t, report.Infected())
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+
+	msg.AddAttachment("3", "virus.com", []byte("X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR"))
+	report, err = msg.ScanAttachments(t.Context(), scanner, DefaultScanOptions())
+	require.NoError(t, err)
+	require.Equal(t, ScanInfected, report.Verdict)
+	require.Len(t, report.Infected(), 1)
+	require.Equal(t, "Eicar-Test-Signature", report.Infected()[0].Threat)
+	require.Equal(t, "virus.com", report.Infected()[0].Filename)
+
+	report, err = (&Message{}).ScanAttachments(t.Context(), scanner, DefaultScanOptions())
+	require.NoError(t, err)
+	require.Equal(t, ScanClean, report.Verdict)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = msg.ScanAttachments(ctx, scanner, DefaultScanOptions())
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,193 @@
+package email
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/domonda/go-errs"
+)
+
+// ErrZipLimitExceeded is returned when expanding
+// a ZIP attachment exceeds a ZipLimits limit.
+const ErrZipLimitExceeded errs.Sentinel = "ZIP attachment exceeds expansion limit"
+
+// ZipLimits protect against zip bombs
+// when expanding ZIP attachments.
+type ZipLimits struct {
+	// MaxFiles is the maximum number of expanded files
+	// including the files of nested ZIP archives.
+	MaxFiles int
+	// MaxFileSize is the maximum uncompressed size of a file.
+	MaxFileSize int64
+	// MaxTotalSize is the maximum uncompressed size of all files.
+	MaxTotalSize int64
+	// MaxCompressionRatio is the maximum ratio
+	// of uncompressed to compressed size of a file.
+	// Only checked for files larger than 1 MiB
+	// because small files can have high ratios.
+	MaxCompressionRatio float64
+	// MaxDepth is the maximum nesting depth of ZIP archives
+	// where 1 means that nested ZIP files are not expanded.
+	MaxDepth int
+}
+
+// DefaultZipLimits returns ZipLimits that are
+// generous enough for invoice archives sent by email.
+func DefaultZipLimits() ZipLimits {
+	return ZipLimits{
+		MaxFiles:            1000,
+		MaxFileSize:         100 << 20,
+		MaxTotalSize:        500 << 20,
+		MaxCompressionRatio: 200,
+		MaxDepth:            2,
+	}
+}
+
+const zipRatioCheckMinSize = 1 << 20
+
+var zipContentTypes = map[string]struct{}{
+	"application/zip":              {},
+	"application/x-zip":            {},
+	"application/x-zip-compressed": {},
+	"multipart/x-zip":              {},
+}
+
+// IsZip returns if the attachment is declared as ZIP archive
+// by its content type or filename extension and the content
+// starts with a ZIP file signature.
+//
+// Office documents like .docx or .xlsx which are
+// ZIP archives internally are not reported as ZIP.
+func (a *Attachment) IsZip() bool {
+	if !bytes.HasPrefix(a.Content, []byte("PK\x03\x04")) && !bytes.HasPrefix(a.Content, []byte("PK\x05\x06")) {
+		return false
+	}
+	contentType, _, _ := strings.Cut(strings.ToLower(a.ContentType), ";")
+	if _, ok := zipContentTypes[strings.TrimSpace(contentType)]; ok {
+		return true
+	}
+	return strings.EqualFold(path.Ext(a.Filename), ".zip")
+}
+
+// ExpandZip returns the files of a ZIP attachment
+// as virtual child attachments with the declared path
+// within the archive as ArchivePath, the base name of the path
+// as Filename, and the PartID of the ZIP attachment
+// followed by a slash and the file index as PartID.
+//
+// Nested ZIP files are expanded up to limits.MaxDepth
+// and replaced by their files.
+// Directories, encrypted files, and macOS metadata files are skipped.
+//
+// Returns a wrapped ErrZipLimitExceeded if any of the limits is exceeded
+// or an error if the attachment is not a valid ZIP archive.
+func (a *Attachment) ExpandZip(limits ZipLimits) ([]*Attachment, error) {
+	e := zipExpander{limits: limits}
+	return e.expand(a, 1)
+}
+
+// ExpandZipAttachments returns the attachments of the message
+// with every ZIP attachment as reported by Attachment.IsZip
+// replaced by its expanded files.
+// The message is not modified.
+// See Attachment.ExpandZip.
+func (msg *Message) ExpandZipAttachments(limits ZipLimits) ([]*Attachment, error) {
+	e := zipExpander{limits: limits}
+	expanded := make([]*Attachment, 0, len(msg.Attachments))
+	for _, a := range msg.Attachments {
+		if a == nil || !a.IsZip() {
+			expanded = append(expanded, a)
+			continue
+		}
+		files, err := e.expand(a, 1)
+		if err != nil {
+			return nil, fmt.Errorf("expanding ZIP attachment %q: %w", a.Filename, err)
+		}
+		expanded = append(expanded, files...)
+	}
+	return expanded, nil
+}
+
+// zipExpander counts the expanded files and bytes
+// across nested archives and multiple attachments.
+type zipExpander struct {
+	limits    ZipLimits
+	files     int
+	totalSize int64
+}
+
+func (e *zipExpander) expand(archive *Attachment, depth int) ([]*Attachment, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive.Content), int64(len(archive.Content)))
+	if err != nil {
+		return nil, err
+	}
+	var files []*Attachment
+	for i, f := range r.File {
+		if skipZipFile(f) {
+			continue
+		}
+		e.files++
+		if e.files > e.limits.MaxFiles {
+			return nil, fmt.Errorf("%w: more than %d files", ErrZipLimitExceeded, e.limits.MaxFiles)
+		}
+		content, err := e.readFile(f)
+		if err != nil {
+			return nil, err
+		}
+		child := NewAttachment(fmt.Sprintf("%s/%d", archive.PartID, i), path.Base(f.Name), content)
+		child.ArchivePath = f.Name
+		if archive.ArchivePath != "" {
+			child.ArchivePath = archive.ArchivePath + "/" + f.Name
+		}
+		if depth < e.limits.MaxDepth && child.IsZip() {
+			nested, err := e.expand(child, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("expanding nested ZIP file %q: %w", f.Name, err)
+			}
+			files = append(files, nested...)
+			continue
+		}
+		files = append(files, child)
+	}
+	return files, nil
+}
+
+func (e *zipExpander) readFile(f *zip.File) ([]byte, error) {
+	// Check declared sizes first, but don't trust them when reading
+	if f.UncompressedSize64 > uint64(e.limits.MaxFileSize) { //#nosec G115 -- integer conversion OK
+		return nil, fmt.Errorf("%w: file %q larger than %d bytes", ErrZipLimitExceeded, f.Name, e.limits.MaxFileSize)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	maxSize := min(e.limits.MaxFileSize, e.limits.MaxTotalSize-e.totalSize)
+	content, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	size := int64(len(content))
+	if size > maxSize {
+		return nil, fmt.Errorf("%w: file %q exceeds size limit", ErrZipLimitExceeded, f.Name)
+	}
+	e.totalSize += size
+	if compressed := max(f.CompressedSize64, 1); size > zipRatioCheckMinSize && float64(size)/float64(compressed) > e.limits.MaxCompressionRatio {
+		return nil, fmt.Errorf("%w: file %q compression ratio above %g", ErrZipLimitExceeded, f.Name, e.limits.MaxCompressionRatio)
+	}
+	return content, nil
+}
+
+func skipZipFile(f *zip.File) bool {
+	const encryptedFlag = 0x1
+	name := f.Name
+	return f.FileInfo().IsDir() ||
+		f.Flags&encryptedFlag != 0 ||
+		strings.HasPrefix(name, "__MACOSX/") ||
+		path.Base(name) == ".DS_Store"
+}
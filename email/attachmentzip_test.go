@@ -0,0 +1,93 @@
+package email
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestZip(t *testing.T, files map[string][]byte, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(files[name])
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestAttachment_ExpandZip(t *testing.T) {
+	pdf := []byte("%PDF-1.4 invoice")
+	nested := makeTestZip(t, map[string][]byte{"inner.pdf": pdf}, "inner.pdf")
+	files := map[string][]byte{
+		"invoices/":              nil,
+		"invoices/RE-2024-1.pdf": pdf,
+		"__MACOSX/._RE.pdf":      []byte("meta"),
+		"more.zip":               nested,
+	}
+	archive := &Attachment{
+		PartID:      "2",
+		ContentType: "application/zip",
+		Filename:    "invoices.zip",
+		Content:     makeTestZip(t, files, "invoices/", "invoices/RE-2024-1.pdf", "__MACOSX/._RE.pdf", "more.zip"),
+	}
+	require.True(t, archive.IsZip())
+
+	expanded, err := archive.ExpandZip(DefaultZipLimits())
+	require.NoError(t, err)
+	require.Len(t, expanded, 2)
+	require.Equal(t, "2/1", expanded[0].PartID)
+	require.Equal(t, "RE-2024-1.pdf", expanded[0].Filename)
+	require.Equal(t, "invoices/RE-2024-1.pdf", expanded[0].ArchivePath)
+	require.Equal(t, "application/pdf", expanded[0].ContentType)
+	require.Equal(t, pdf, expanded[0].Content)
+	require.Equal(t, "2/3/0", expanded[1].PartID)
+	require.Equal(t, "inner.pdf", expanded[1].Filename)
+	require.Equal(t, "more.zip/inner.pdf", expanded[1].ArchivePath)
+
+	limits := DefaultZipLimits()
+	limits.MaxDepth = 1
+	expanded, err = archive.ExpandZip(limits)
+	require.NoError(t, err)
+	require.Len(t, expanded, 2)
+	require.Equal(t, "more.zip", expanded[1].Filename, "nested ZIP not expanded")
+
+	limits = DefaultZipLimits()
+	limits.MaxFiles = 1
+	_, err = archive.ExpandZip(limits)
+	require.ErrorIs(t, err, ErrZipLimitExceeded)
+
+	limits = DefaultZipLimits()
+	limits.MaxTotalSize = int64(len(pdf)) + 1
+	_, err = archive.ExpandZip(limits)
+	require.ErrorIs(t, err, ErrZipLimitExceeded)
+
+	bomb := &Attachment{
+		Filename: "bomb.zip",
+		Content:  makeTestZip(t, map[string][]byte{"zeros": make([]byte, 10<<20)}, "zeros"),
+	}
+	require.True(t, bomb.IsZip())
+	_, err = bomb.ExpandZip(DefaultZipLimits())
+	require.ErrorIs(t, err, ErrZipLimitExceeded, "compression ratio")
+
+	docx := &Attachment{
+		ContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		Filename:    "letter.docx",
+		Content:     makeTestZip(t, map[string][]byte{"word/document.xml": nil}, "word/document.xml"),
+	}
+	require.False(t, docx.IsZip(), "Office document")
+	require.False(t, (&Attachment{Filename: "fake.zip", Content: pdf}).IsZip(), "no ZIP signature")
+
+	msg := &Message{Attachments: []*Attachment{docx, archive}}
+	all, err := msg.ExpandZipAttachments(DefaultZipLimits())
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Same(t, docx, all[0])
+	require.Len(t, msg.Attachments, 2, "message not modified")
+}
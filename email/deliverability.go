@@ -0,0 +1,329 @@
+package email
+
+import (
+	"fmt"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MessageOption configures headers or the delivery of a message
+// and returns an error if its arguments are not valid.
+// Use Message.Apply to apply options.
+type MessageOption func(msg *Message) error
+
+// Apply applies the options to the message in the passed order
+// and returns the first error. Options before the failing one
+// have already been applied.
+func (msg *Message) Apply(options ...MessageOption) error {
+	for _, option := range options {
+		if err := option(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (msg *Message) setExtraHeader(key, value string) {
+	if msg.ExtraHeader == nil {
+		msg.ExtraHeader = make(Header)
+	}
+	msg.ExtraHeader.Set(key, value)
+}
+
+// DSNNotify is a condition for which a delivery status
+// notification is requested according to RFC 3461.
+type DSNNotify string
+
+const (
+	DSNNotifySuccess DSNNotify = "SUCCESS"
+	DSNNotifyFailure DSNNotify = "FAILURE"
+	DSNNotifyDelay   DSNNotify = "DELAY"
+	// DSNNotifyNever must not be combined with other conditions.
+	DSNNotifyNever DSNNotify = "NEVER"
+)
+
+// Valid returns true if the condition is one of the defined constants.
+func (n DSNNotify) Valid() bool {
+	switch n {
+	case DSNNotifySuccess, DSNNotifyFailure, DSNNotifyDelay, DSNNotifyNever:
+		return true
+	}
+	return false
+}
+
+// DSNReturn defines how much of the message is returned
+// with a failure delivery status notification.
+type DSNReturn string
+
+const (
+	DSNReturnFull    DSNReturn = "FULL"
+	DSNReturnHeaders DSNReturn = "HDRS"
+)
+
+// Valid returns true if the value is one of the defined constants.
+func (r DSNReturn) Valid() bool {
+	return r == DSNReturnFull || r == DSNReturnHeaders
+}
+
+// DSNRequest requests delivery status notifications
+// with the SMTP envelope parameters of RFC 3461.
+// It is not part of the message header but used
+// by the transports of the email/send package.
+type DSNRequest struct {
+	// Notify are the conditions for the NOTIFY parameter
+	// of every recipient, the server default is used if empty.
+	Notify []DSNNotify `json:"notify,omitempty"`
+	// Return is the optional RET parameter.
+	Return DSNReturn `json:"return,omitempty"`
+	// EnvelopeID is the optional ENVID parameter
+	// that is included in the notifications to
+	// identify the sent message. Must be printable ASCII.
+	EnvelopeID string `json:"envelopeID,omitempty"`
+}
+
+// Validate returns an error if the request has invalid
+// or invalid combined values.
+func (r *DSNRequest) Validate() error {
+	for _, n := range r.Notify {
+		if !n.Valid() {
+			return fmt.Errorf("invalid DSN notify condition %q", n)
+		}
+	}
+	if slices.Contains(r.Notify, DSNNotifyNever) && len(r.Notify) > 1 {
+		return fmt.Errorf("DSN notify condition %s can't be combined with other conditions", DSNNotifyNever)
+	}
+	if r.Return != "" && !r.Return.Valid() {
+		return fmt.Errorf("invalid DSN return value %q", r.Return)
+	}
+	if len(r.EnvelopeID) > 100 {
+		return fmt.Errorf("DSN envelope ID longer than 100 characters: %q", r.EnvelopeID)
+	}
+	for _, c := range []byte(r.EnvelopeID) {
+		if c < '!' || c > '~' {
+			return fmt.Errorf("DSN envelope ID must be printable ASCII without spaces: %q", r.EnvelopeID)
+		}
+	}
+	return nil
+}
+
+// MailParameters returns the RET and ENVID parameters
+// for the SMTP MAIL FROM command.
+func (r *DSNRequest) MailParameters() []string {
+	var params []string
+	if r.Return != "" {
+		params = append(params, "RET="+string(r.Return))
+	}
+	if r.EnvelopeID != "" {
+		params = append(params, "ENVID="+xtext(r.EnvelopeID))
+	}
+	return params
+}
+
+// RcptParameters returns the NOTIFY parameter
+// for the SMTP RCPT TO command or nil.
+func (r *DSNRequest) RcptParameters() []string {
+	if len(r.Notify) == 0 {
+		return nil
+	}
+	notify := make([]string, len(r.Notify))
+	for i, n := range r.Notify {
+		notify[i] = string(n)
+	}
+	return []string{"NOTIFY=" + strings.Join(notify, ",")}
+}
+
+// xtext encodes s according to RFC 3461 section 4.
+func xtext(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if c >= '!' && c <= '~' && c != '+' && c != '=' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "+%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// WithDSNRequest returns a MessageOption that requests
+// delivery status notifications for the message.
+func WithDSNRequest(request DSNRequest) MessageOption {
+	return func(msg *Message) error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		request.Notify = slices.Clone(request.Notify)
+		msg.DSN = &request
+		return nil
+	}
+}
+
+// WithListUnsubscribe returns a MessageOption that sets the
+// "List-Unsubscribe" header of RFC 2369 with the passed
+// mailto, http, or https URIs.
+//
+// If oneClick is true, then the "List-Unsubscribe-Post" header
+// for one-click unsubscription according to RFC 8058 is set
+// as required by large mailbox providers for bulk senders.
+// One-click unsubscription requires an https URI.
+func WithListUnsubscribe(oneClick bool, uris ...string) MessageOption {
+	return func(msg *Message) error {
+		if len(uris) == 0 {
+			return fmt.Errorf("no List-Unsubscribe URI")
+		}
+		hasHTTPS := false
+		values := make([]string, len(uris))
+		for i, uri := range uris {
+			u, err := url.Parse(uri)
+			if err != nil {
+				return fmt.Errorf("invalid List-Unsubscribe URI %q: %w", uri, err)
+			}
+			switch u.Scheme {
+			case "mailto":
+				if u.Opaque == "" {
+					return fmt.Errorf("List-Unsubscribe mailto URI %q without address", uri)
+				}
+			case "https":
+				hasHTTPS = true
+				fallthrough
+			case "http":
+				if u.Host == "" {
+					return fmt.Errorf("List-Unsubscribe URI %q without host", uri)
+				}
+			default:
+				return fmt.Errorf("List-Unsubscribe URI %q must use mailto, http, or https scheme", uri)
+			}
+			if strings.ContainsAny(uri, "<>, \t\r\n") {
+				return fmt.Errorf("List-Unsubscribe URI %q contains invalid characters", uri)
+			}
+			values[i] = "<" + uri + ">"
+		}
+		if oneClick && !hasHTTPS {
+			return fmt.Errorf("one-click List-Unsubscribe requires an https URI")
+		}
+		msg.setExtraHeader("List-Unsubscribe", strings.Join(values, ", "))
+		if oneClick {
+			msg.setExtraHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+		} else {
+			msg.ExtraHeader.Del("List-Unsubscribe-Post")
+		}
+		return nil
+	}
+}
+
+// AutoSubmitted is a value of the "Auto-Submitted" header
+// according to RFC 3834.
+type AutoSubmitted string
+
+const (
+	AutoSubmittedNo        AutoSubmitted = "no"
+	AutoSubmittedGenerated AutoSubmitted = "auto-generated"
+	AutoSubmittedReplied   AutoSubmitted = "auto-replied"
+	// AutoSubmittedNotified is used for notifications
+	// of Sieve scripts, see RFC 5436.
+	AutoSubmittedNotified AutoSubmitted = "auto-notified"
+)
+
+// Valid returns true if the value is one of the defined constants.
+func (a AutoSubmitted) Valid() bool {
+	switch a {
+	case AutoSubmittedNo, AutoSubmittedGenerated, AutoSubmittedReplied, AutoSubmittedNotified:
+		return true
+	}
+	return false
+}
+
+// WithAutoSubmitted returns a MessageOption that sets the
+// "Auto-Submitted" header so that receivers don't send
+// automatic replies like out of office notices to the message.
+// For values other than AutoSubmittedNo the "X-Auto-Response-Suppress"
+// header is also set to "All" for Microsoft Exchange.
+//
+// See Message.IsAutoSubmitted and Message.AutoResponseSuppress.
+func WithAutoSubmitted(value AutoSubmitted) MessageOption {
+	return func(msg *Message) error {
+		if !value.Valid() {
+			return fmt.Errorf("invalid Auto-Submitted value %q", value)
+		}
+		msg.setExtraHeader("Auto-Submitted", string(value))
+		if value == AutoSubmittedNo {
+			msg.ExtraHeader.Del("X-Auto-Response-Suppress")
+		} else {
+			msg.setExtraHeader("X-Auto-Response-Suppress", "All")
+		}
+		return nil
+	}
+}
+
+// WithFeedbackID returns a MessageOption that sets the
+// "Feedback-Id" header used by the feedback loops of
+// mailbox providers like Gmail in the format
+// "campaignID:customerID:mailType:senderID".
+// Only senderID is required, the parts must not contain colons.
+//
+// See Message.FeedbackID.
+func WithFeedbackID(campaignID, customerID, mailType, senderID string) MessageOption {
+	return func(msg *Message) error {
+		if senderID == "" {
+			return fmt.Errorf("missing Feedback-Id sender ID")
+		}
+		parts := []string{campaignID, customerID, mailType, senderID}
+		for _, part := range parts {
+			if strings.Contains(part, ":") || !isValidHeaderValue(part) {
+				return fmt.Errorf("invalid Feedback-Id part %q", part)
+			}
+		}
+		msg.setExtraHeader("Feedback-Id", strings.Join(parts, ":"))
+		return nil
+	}
+}
+
+// WithTrackingHeader returns a MessageOption that sets a custom
+// header like "X-Campaign-ID" for tracking the message.
+//
+// The key must be a valid header field name that is not covered
+// by the parsed Message fields (see IsParsedHeader),
+// and the value must not contain line breaks or control characters
+// to prevent header injection.
+func WithTrackingHeader(key, value string) MessageOption {
+	return func(msg *Message) error {
+		if !isValidHeaderKey(key) {
+			return fmt.Errorf("invalid header name %q", key)
+		}
+		if IsParsedHeader(key) {
+			return fmt.Errorf("header %q can't be used as tracking header", textproto.CanonicalMIMEHeaderKey(key))
+		}
+		if !isValidHeaderValue(value) {
+			return fmt.Errorf("invalid value for header %q: %q", key, value)
+		}
+		msg.setExtraHeader(key, value)
+		return nil
+	}
+}
+
+// isValidHeaderKey returns true if key is a valid
+// header field name according to RFC 5322 section 3.6.8.
+func isValidHeaderKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range []byte(key) {
+		if c < '!' || c > '~' || c == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidHeaderValue returns true if value contains
+// no control characters other than horizontal tabs.
+func isValidHeaderValue(value string) bool {
+	for _, r := range value {
+		if (r < ' ' && r != '\t') || r == 0x7F {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Apply(t *testing.T) {
+	msg := NewMessage("sender@example.com", "to@example.org", "Invoice", "Body", "")
+	err := msg.Apply(
+		WithDSNRequest(DSNRequest{
+			Notify:     []DSNNotify{DSNNotifyFailure, DSNNotifyDelay},
+			Return:     DSNReturnHeaders,
+			EnvelopeID: "inv+123=a",
+		}),
+		WithListUnsubscribe(true, "mailto:unsubscribe@example.com?subject=stop", "https://example.com/unsubscribe/123"),
+		WithAutoSubmitted(AutoSubmittedGenerated),
+		WithFeedbackID("invoices", "customer1", "transactional", "domonda"),
+		WithTrackingHeader("x-campaign-id", "2024-spring"),
+	)
+	require.NoError(t, err)
+
+	require.NotNil(t, msg.DSN)
+	assert.Equal(t, []string{"RET=HDRS", "ENVID=inv+2B123+3Da"}, msg.DSN.MailParameters())
+	assert.Equal(t, []string{"NOTIFY=FAILURE,DELAY"}, msg.DSN.RcptParameters())
+
+	assert.Equal(t, "<mailto:unsubscribe@example.com?subject=stop>, <https://example.com/unsubscribe/123>", msg.ExtraHeader.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", msg.ExtraHeader.Get("List-Unsubscribe-Post"))
+	assert.True(t, msg.IsAutoSubmitted())
+	assert.True(t, msg.AutoResponseSuppress())
+	assert.Equal(t, "invoices:customer1:transactional:domonda", msg.FeedbackID())
+	assert.Equal(t, "2024-spring", msg.ExtraHeader.Get("X-Campaign-Id"))
+
+	raw, err := msg.BuildRawMessage()
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "Auto-Submitted: auto-generated")
+	assert.NotContains(t, string(raw), "NOTIFY", "DSN is not a header")
+
+	require.NoError(t, msg.Apply(WithAutoSubmitted(AutoSubmittedNo)))
+	assert.False(t, msg.IsAutoSubmitted())
+	assert.False(t, msg.AutoResponseSuppress())
+}
+
+func TestMessageOption_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		option MessageOption
+	}{
+		{name: "invalid notify", option: WithDSNRequest(DSNRequest{Notify: []DSNNotify{"ALWAYS"}})},
+		{name: "never combined", option: WithDSNRequest(DSNRequest{Notify: []DSNNotify{DSNNotifyNever, DSNNotifyFailure}})},
+		{name: "invalid return", option: WithDSNRequest(DSNRequest{Return: "BODY"})},
+		{name: "envelope ID with space", option: WithDSNRequest(DSNRequest{EnvelopeID: "a b"})},
+		{name: "no unsubscribe URI", option: WithListUnsubscribe(false)},
+		{name: "unsubscribe scheme", option: WithListUnsubscribe(false, "ftp://example.com")},
+		{name: "unsubscribe mailto", option: WithListUnsubscribe(false, "mailto:")},
+		{name: "one-click without https", option: WithListUnsubscribe(true, "mailto:stop@example.com")},
+		{name: "unsubscribe injection", option: WithListUnsubscribe(false, "https://example.com/>\r\nBcc: x")},
+		{name: "auto submitted", option: WithAutoSubmitted("yes")},
+		{name: "feedback without sender", option: WithFeedbackID("a", "b", "c", "")},
+		{name: "feedback colon", option: WithFeedbackID("a:b", "", "", "sender")},
+		{name: "tracking parsed header", option: WithTrackingHeader("bcc", "x@example.com")},
+		{name: "tracking header name", option: WithTrackingHeader("X Campaign", "1")},
+		{name: "tracking injection", option: WithTrackingHeader("X-Campaign", "1\r\nBcc: x@example.com")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := new(Message)
+			assert.Error(t, msg.Apply(tt.option))
+			assert.Nil(t, msg.DSN)
+			assert.Empty(t, msg.ExtraHeader)
+		})
+	}
+}
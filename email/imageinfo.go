@@ -0,0 +1,126 @@
+package email
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder for image.DecodeConfig
+	_ "image/jpeg" // register JPEG decoder for image.DecodeConfig
+	_ "image/png"  // register PNG decoder for image.DecodeConfig
+)
+
+// ImageInfo holds metadata of an image attachment
+// that is available without decoding the whole image.
+type ImageInfo struct {
+	// Format is the image format name like "jpeg", "png" or "gif".
+	Format string
+	// Width and Height are the pixel dimensions as stored in the file,
+	// without applying the Orientation.
+	Width, Height int
+	// Orientation is the EXIF orientation from 1 to 8,
+	// 1 means no transformation or that no EXIF data was found.
+	Orientation int
+}
+
+// DisplaySize returns the width and height of the image
+// after applying the EXIF Orientation, which swaps the dimensions
+// for the orientations 5 to 8 that rotate the image by 90 degrees.
+func (info *ImageInfo) DisplaySize() (width, height int) {
+	if info.Orientation >= 5 && info.Orientation <= 8 {
+		return info.Height, info.Width
+	}
+	return info.Width, info.Height
+}
+
+// Pixels returns the number of pixels of the image.
+func (info *ImageInfo) Pixels() int {
+	return info.Width * info.Height
+}
+
+// ImageInfo returns the format, dimensions and EXIF orientation
+// of a JPEG, PNG or GIF image attachment by parsing only
+// the image header, so that oversized images can be rejected
+// before the content is decoded.
+func (a *Attachment) ImageInfo() (*ImageInfo, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(a.Content))
+	if err != nil {
+		return nil, fmt.Errorf("attachment %q is not a supported image: %w", a.Filename, err)
+	}
+	info := &ImageInfo{
+		Format:      format,
+		Width:       config.Width,
+		Height:      config.Height,
+		Orientation: 1,
+	}
+	if format == "jpeg" {
+		if o := jpegEXIFOrientation(a.Content); o >= 1 && o <= 8 {
+			info.Orientation = o
+		}
+	}
+	return info, nil
+}
+
+// jpegEXIFOrientation returns the orientation tag value
+// of the EXIF APP1 segment of a JPEG file or zero if not found.
+func jpegEXIFOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0
+	}
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return 0
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			// Start of scan or end of image, no more metadata
+			return 0
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 0
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 0
+}
+
+// tiffOrientation returns the orientation tag 0x0112
+// of the first IFD of TIFF formatted EXIF data or zero if not found.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	numEntries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < numEntries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		const orientationTag, shortType = 0x0112, 3
+		if order.Uint16(tiff[entry:]) == orientationTag && order.Uint16(tiff[entry+2:]) == shortType {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+package email
+
+import (
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+
+	"github.com/domonda/go-types/language"
+)
+
+// Weights of the language hints combined by Message.DetectLanguage
+const (
+	languageHeaderWeight = 1.0
+	languageHTMLWeight   = 1.0
+	languageTextWeight   = 2.0
+)
+
+// DetectLanguage returns the languages of the message
+// ranked by descending confidence, or nil if no language
+// could be detected.
+//
+// The result combines the codes of the "Content-Language" header,
+// the lang attributes of the HTML body, and language.DetectText
+// over the subject and the plaintext body, where the text detection
+// is weighted twice as strong as each of the hints.
+func (msg *Message) DetectLanguage() []language.Detection {
+	scores := make(map[language.Code]float64)
+	addHints := func(detections []language.Detection, weight float64) {
+		for _, d := range detections {
+			scores[d.Code] += d.Confidence * weight
+		}
+	}
+
+	addHints(languageHints(msg.ExtraHeader.Values("Content-Language")), languageHeaderWeight)
+	if msg.BodyHTML.IsNotNull() {
+		addHints(languageHints(htmlLangAttributes(msg.BodyHTML.String())), languageHTMLWeight)
+	}
+
+	body := msg.Body
+	if strings.TrimSpace(body) == "" && msg.BodyHTML.IsNotNull() {
+		body, _ = HTMLToPlaintext([]byte(msg.BodyHTML.String()), "\n")
+	}
+	addHints(language.DetectText(msg.Subject+"\n"+body), languageTextWeight)
+
+	return language.RankDetections(scores)
+}
+
+// languageHints returns the equally weighted valid languages
+// of comma separated language tags like "de-AT, en".
+func languageHints(values []string) []language.Detection {
+	scores := make(map[language.Code]float64)
+	for _, value := range values {
+		for tag := range strings.SplitSeq(value, ",") {
+			primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+			primary, _, _ = strings.Cut(primary, "_")
+			code, err := language.Code(primary).Normalized()
+			if err == nil {
+				scores[code]++
+			}
+		}
+	}
+	return language.RankDetections(scores)
+}
+
+// htmlLangAttributes returns the values of all lang
+// and xml:lang attributes of the HTML elements.
+func htmlLangAttributes(html string) (langs []string) {
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(html))
+	for tt := tokenizer.Next(); tt != xhtml.ErrorToken; tt = tokenizer.Next() {
+		if tt != xhtml.StartTagToken && tt != xhtml.SelfClosingTagToken {
+			continue
+		}
+		for {
+			key, val, more := tokenizer.TagAttr()
+			if k := string(key); k == "lang" || k == "xml:lang" {
+				langs = append(langs, string(val))
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return langs
+}
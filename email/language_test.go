@@ -0,0 +1,46 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/language"
+	"github.com/domonda/go-types/nullable"
+)
+
+func TestMessageDetectLanguage(t *testing.T) {
+	t.Run("text only", func(t *testing.T) {
+		msg := NewMessage("", "", "Rechnung März", "Sehr geehrte Damen und Herren, anbei die Rechnung. Mit freundlichen Grüßen", "")
+		detections := msg.DetectLanguage()
+		require.NotEmpty(t, detections)
+		assert.Equal(t, language.DE, detections[0].Code)
+	})
+
+	t.Run("header and HTML hints", func(t *testing.T) {
+		msg := NewMessage("", "", "Invoice", "", nullable.TrimmedString(`<html lang="fr-FR"><body><p>Veuillez trouver la facture pour le mois de mars, merci</p></body></html>`))
+		msg.ExtraHeader.Set("Content-Language", "fr, en-GB")
+		detections := msg.DetectLanguage()
+		require.NotEmpty(t, detections)
+		assert.Equal(t, language.FR, detections[0].Code)
+		assert.Equal(t, language.EN, detections[1].Code)
+	})
+
+	t.Run("hints without text", func(t *testing.T) {
+		msg := NewMessage("", "", "", "", "")
+		msg.ExtraHeader.Set("Content-Language", "de-AT, invalid")
+		assert.Equal(t, []language.Detection{{Code: language.DE, Confidence: 1}}, msg.DetectLanguage())
+	})
+
+	t.Run("nothing detected", func(t *testing.T) {
+		msg := NewMessage("", "", "", "123", "")
+		assert.Nil(t, msg.DetectLanguage())
+	})
+}
+
+func TestHTMLLangAttributes(t *testing.T) {
+	html := `<html xml:lang="de"><body><p lang="en">Hello</p><br lang="fr"/></body></html>`
+	assert.Equal(t, []string{"de", "en", "fr"}, htmlLangAttributes(html))
+	assert.Nil(t, htmlLangAttributes(`<p>no lang</p>`))
+}
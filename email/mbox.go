@@ -0,0 +1,159 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// MboxReader reads messages from an mbox archive
+// in the mboxrd format where lines starting with "From "
+// or any number of '>' characters followed by "From "
+// are escaped with an additional '>' character.
+// The mboxo format is read the same way.
+type MboxReader struct {
+	r        *bufio.Reader
+	fromLine []byte // From line of the next message
+	done     bool
+}
+
+// NewMboxReader returns a MboxReader for r.
+func NewMboxReader(r io.Reader) *MboxReader {
+	return &MboxReader{r: bufio.NewReader(r)}
+}
+
+// NextRaw returns the RFC 5322 bytes of the next message
+// without the mbox From line and with unescaped From lines.
+// Returns io.EOF if there are no more messages.
+func (m *MboxReader) NextRaw() (raw []byte, err error) {
+	if m.done {
+		return nil, io.EOF
+	}
+	if m.fromLine == nil {
+		// Start of the archive, skip empty lines before the first From line
+		for {
+			line, err := m.r.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				if !bytes.HasPrefix(line, []byte("From ")) {
+					return nil, errors.New("mbox archive does not start with a From line")
+				}
+				m.fromLine = line
+				break
+			}
+			if err == io.EOF {
+				m.done = true
+				return nil, io.EOF
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	for {
+		line, err := m.r.ReadBytes('\n')
+		if bytes.HasPrefix(line, []byte("From ")) {
+			m.fromLine = line
+			break
+		}
+		if isMboxFromLine(line) {
+			line = line[1:]
+		}
+		buf.Write(line)
+		if err == io.EOF {
+			m.done = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Remove the empty line separating messages
+	raw = buf.Bytes()
+	if bytes.HasSuffix(raw, []byte("\n\n")) || bytes.HasSuffix(raw, []byte("\r\n\r\n")) {
+		raw = bytes.TrimSuffix(raw, []byte("\r\n"))
+		raw = bytes.TrimSuffix(raw, []byte("\n"))
+	}
+	return raw, nil
+}
+
+// Next parses the next message with ParseMIMEMessageBytes.
+// Returns io.EOF if there are no more messages.
+func (m *MboxReader) Next() (*Message, error) {
+	raw, err := m.NextRaw()
+	if err != nil {
+		return nil, err
+	}
+	return ParseMIMEMessageBytes(raw)
+}
+
+// MboxWriter writes messages to an mbox archive
+// in the mboxrd format, see MboxReader.
+type MboxWriter struct {
+	w io.Writer
+}
+
+// NewMboxWriter returns a MboxWriter for w.
+func NewMboxWriter(w io.Writer) *MboxWriter {
+	return &MboxWriter{w: w}
+}
+
+// Write writes the message built with Message.BuildRawMessage
+// using the address of the From header as envelope sender
+// and the Date header or the current time as envelope date.
+func (m *MboxWriter) Write(msg *Message) error {
+	raw, err := msg.BuildRawMessage()
+	if err != nil {
+		return err
+	}
+	sender, err := msg.From.AddressPartString()
+	if err != nil || sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+	date := time.Now()
+	if msg.Date != nil {
+		date = *msg.Date
+	}
+	return m.WriteRaw(sender, date, raw)
+}
+
+// WriteRaw writes the RFC 5322 bytes of a message
+// with a From line for the passed envelope sender and date.
+// Lines of raw that would be read as From line are escaped.
+func (m *MboxWriter) WriteRaw(sender string, date time.Time, raw []byte) error {
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(raw) + 64)
+	fmt.Fprintf(&buf, "From %s %s\n", sender, date.UTC().Format(time.ANSIC))
+	for len(raw) > 0 {
+		line := raw
+		if i := bytes.IndexByte(raw, '\n'); i >= 0 {
+			line = raw[:i+1]
+		}
+		raw = raw[len(line):]
+		if bytes.HasPrefix(line, []byte("From ")) || isMboxFromLine(line) {
+			buf.WriteByte('>')
+		}
+		buf.Write(line)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := m.w.Write(buf.Bytes())
+	return err
+}
+
+// isMboxFromLine returns if line consists of one or more
+// '>' characters followed by "From ".
+func isMboxFromLine(line []byte) bool {
+	trimmed := bytes.TrimLeft(line, ">")
+	return len(trimmed) < len(line) && bytes.HasPrefix(trimmed, []byte("From "))
+}
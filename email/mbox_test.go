@@ -0,0 +1,75 @@
+package email
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMboxWriteRaw(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewMboxWriter(&buf)
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	err := w.WriteRaw("sender@example.com", date, []byte("Subject: One\n\nFrom here\n>From there\nFromage\n"))
+	require.NoError(t, err)
+	err = w.WriteRaw("", date, []byte("Subject: Two\r\n\r\nNo newline at end"))
+	require.NoError(t, err)
+
+	require.Equal(t, ""+
+		"From sender@example.com Fri Mar  1 12:00:00 2024\n"+
+		"Subject: One\n\n>From here\n>>From there\nFromage\n\n"+
+		"From MAILER-DAEMON Fri Mar  1 12:00:00 2024\n"+
+		"Subject: Two\r\n\r\nNo newline at end\n\n",
+		buf.String(),
+	)
+
+	r := NewMboxReader(&buf)
+	raw, err := r.NextRaw()
+	require.NoError(t, err)
+	require.Equal(t, "Subject: One\n\nFrom here\n>From there\nFromage\n", string(raw))
+	raw, err = r.NextRaw()
+	require.NoError(t, err)
+	require.Equal(t, "Subject: Two\r\n\r\nNo newline at end\n", string(raw))
+	_, err = r.NextRaw()
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = NewMboxReader(strings.NewReader("\n\n")).NextRaw()
+	require.ErrorIs(t, err, io.EOF)
+	_, err = NewMboxReader(strings.NewReader("Subject: No mbox\n")).NextRaw()
+	require.Error(t, err)
+}
+
+func TestMboxMessages(t *testing.T) {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	messages := []*Message{
+		NewMessage("Sender <sender@example.com>", "receiver@example.com", "First", "Hello\nFrom the first message\n", ""),
+		NewMessage("sender@example.com", "receiver@example.com", "Second", "Second body", "<p>Second body</p>"),
+	}
+	for _, msg := range messages {
+		msg.Date = &date
+	}
+
+	var buf bytes.Buffer
+	w := NewMboxWriter(&buf)
+	for _, msg := range messages {
+		require.NoError(t, w.Write(msg))
+	}
+	require.True(t, strings.HasPrefix(buf.String(), "From sender@example.com Fri Mar  1 12:00:00 2024\n"))
+
+	r := NewMboxReader(&buf)
+	for _, want := range messages {
+		msg, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, want.Subject, msg.Subject)
+		require.Equal(t, strings.TrimSpace(want.Body), strings.TrimSpace(msg.Body))
+		require.Equal(t, want.BodyHTML, msg.BodyHTML)
+		require.True(t, date.Equal(*msg.Date))
+	}
+	_, err := r.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -89,6 +89,10 @@ type Message struct {
 	BodyHTML nullable.TrimmedString `json:"bodyHTML,omitempty"`
 
 	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// DSN optionally requests delivery status notifications
+	// via the SMTP envelope, see WithDSNRequest.
+	DSN *DSNRequest `json:"dsn,omitempty"`
 }
 
 // NewMessage returns a new message using the passed from, to, subject, body, and bodyHTML arguments.
@@ -0,0 +1,190 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"slices"
+	"strings"
+
+	"github.com/domonda/go-types/nullable"
+)
+
+// RedactionMode determines how a part of a message is redacted.
+type RedactionMode int
+
+const (
+	// RedactKeep keeps the data unchanged.
+	RedactKeep RedactionMode = iota
+	// RedactMask replaces the data with a pseudonym
+	// or a marker containing a hash of the data,
+	// so that equal data can still be correlated.
+	RedactMask
+	// RedactRemove removes the data.
+	RedactRemove
+)
+
+// RedactionPolicy configures how Message.Redacted redacts a message.
+// The zero value keeps everything.
+type RedactionPolicy struct {
+	// Addresses of the From, ReplyTo, To, DeliveredTo, Cc, and Bcc fields.
+	// Masked addresses lose their name part and get a hash
+	// as local part but keep the domain,
+	// like "3f2a9b1c5d7e8f01@example.com".
+	Addresses RedactionMode
+	// Subject of the message.
+	Subject RedactionMode
+	// Body and BodyHTML of the message.
+	Body RedactionMode
+	// ExtraHeader values of the message.
+	ExtraHeader RedactionMode
+	// Attachments of the message.
+	// Masked attachments keep their metadata but their content
+	// is replaced by its SHA-256 hash and size.
+	Attachments RedactionMode
+	// HashKey is used as HMAC key for the hashes of masked data
+	// if not empty, to prevent guessing low entropy data
+	// like email addresses from their hashes.
+	// Hashes of attachment contents are always unkeyed SHA-256
+	// so they can be compared to hashes of files.
+	HashKey []byte
+}
+
+// Redacted returns a copy of the message for data exports
+// or long-term audit storage with the addresses, subject,
+// body text, headers, and attachment contents
+// removed or masked as configured by the policy.
+// IDs, dates, and labels are always kept.
+//
+// Note that masked data is pseudonymized,
+// not anonymized, as long as the HashKey is known.
+func (msg *Message) Redacted(policy RedactionPolicy) *Message {
+	r := *msg
+	r.ProviderLabels = slices.Clone(msg.ProviderLabels)
+	if msg.Date != nil {
+		date := *msg.Date
+		r.Date = &date
+	}
+	if msg.DSN != nil {
+		dsn := *msg.DSN
+		dsn.Notify = slices.Clone(msg.DSN.Notify)
+		r.DSN = &dsn
+	}
+
+	switch policy.Addresses {
+	case RedactMask:
+		r.From = Address(policy.maskAddressList(string(msg.From)))
+		r.ReplyTo = NullableAddress(policy.maskAddressList(string(msg.ReplyTo)))
+		r.To = AddressList(policy.maskAddressList(string(msg.To)))
+		r.DeliveredTo = NullableAddress(policy.maskAddressList(string(msg.DeliveredTo)))
+		r.Cc = NullableAddressList(policy.maskAddressList(string(msg.Cc)))
+		r.Bcc = NullableAddressList(policy.maskAddressList(string(msg.Bcc)))
+	case RedactRemove:
+		r.From = ""
+		r.ReplyTo = ""
+		r.To = ""
+		r.DeliveredTo = ""
+		r.Cc = ""
+		r.Bcc = ""
+	}
+
+	switch policy.Subject {
+	case RedactMask:
+		r.Subject = policy.maskText(msg.Subject)
+	case RedactRemove:
+		r.Subject = ""
+	}
+
+	switch policy.Body {
+	case RedactMask:
+		r.Body = policy.maskText(msg.Body)
+		if msg.BodyHTML.IsNotNull() {
+			r.BodyHTML = nullable.TrimmedString(policy.maskText(msg.BodyHTML.String()))
+		}
+	case RedactRemove:
+		r.Body = ""
+		r.BodyHTML = nullable.TrimmedStringNull
+	}
+
+	switch policy.ExtraHeader {
+	case RedactKeep, RedactMask:
+		if msg.ExtraHeader != nil {
+			r.ExtraHeader = make(Header, len(msg.ExtraHeader))
+			for key, values := range msg.ExtraHeader {
+				values = slices.Clone(values)
+				if policy.ExtraHeader == RedactMask {
+					for i, value := range values {
+						values[i] = policy.maskText(value)
+					}
+				}
+				r.ExtraHeader[key] = values
+			}
+		}
+	case RedactRemove:
+		r.ExtraHeader = nil
+	}
+
+	r.Attachments = nil
+	if policy.Attachments != RedactRemove {
+		for _, a := range msg.Attachments {
+			redacted := *a
+			if policy.Attachments == RedactMask && a.Content != nil {
+				hash := sha256.Sum256(a.Content)
+				redacted.ContentSHA256 = hex.EncodeToString(hash[:])
+				redacted.ContentSize = len(a.Content)
+				redacted.Content = nil
+			}
+			r.Attachments = append(r.Attachments, &redacted)
+		}
+	}
+
+	return &r
+}
+
+// hash returns the hex encoded SHA-256 or HMAC-SHA256 hash of data.
+func (p *RedactionPolicy) hash(data string) string {
+	var h hash.Hash
+	if len(p.HashKey) > 0 {
+		h = hmac.New(sha256.New, p.HashKey)
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(data)) //#nosec G104 -- hash.Hash.Write never returns an error
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// maskText returns a marker with the hash of a non empty text.
+func (p *RedactionPolicy) maskText(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "[REDACTED " + p.hash(text) + "]"
+}
+
+// maskAddressList masks every address of a comma separated address list.
+func (p *RedactionPolicy) maskAddressList(list string) string {
+	if strings.TrimSpace(list) == "" {
+		return ""
+	}
+	addrs, err := AddressList(list).Split()
+	if err != nil {
+		return p.maskAddress(Address(list))
+	}
+	masked := make([]Address, len(addrs))
+	for i, addr := range addrs {
+		masked[i] = Address(p.maskAddress(addr))
+	}
+	return string(AddressListJoin(masked...))
+}
+
+// maskAddress returns the hash of the normalized address part
+// as local part with the domain of the address.
+// Invalid addresses get the domain "redacted.invalid".
+func (p *RedactionPolicy) maskAddress(addr Address) string {
+	addrPart, err := addr.AddressPart()
+	if err != nil {
+		return p.hash(string(addr))[:16] + "@redacted.invalid"
+	}
+	return p.hash(string(addrPart))[:16] + "@" + addrPart.DomainPart()
+}
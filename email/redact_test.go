@@ -0,0 +1,107 @@
+package email
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/nullable"
+)
+
+func newRedactionTestMessage() *Message {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := NewMessage(
+		"Erika Mustermann <Erika@Example.com>",
+		"a@example.org, Max <max@example.net>",
+		"Invoice 2024-001",
+		"Dear Max, please find the invoice attached.",
+		"<p>Dear Max</p>",
+	)
+	msg.MessageID = "<123@example.com>"
+	msg.Date = &date
+	msg.Cc = "cc@example.org"
+	msg.ExtraHeader.Set("X-Mailer", "Test")
+	msg.AddAttachment("1", "invoice.pdf", []byte("%PDF-1.7 content"))
+	return msg
+}
+
+func TestMessageRedactedKeep(t *testing.T) {
+	msg := newRedactionTestMessage()
+	redacted := msg.Redacted(RedactionPolicy{})
+	assert.Equal(t, msg, redacted)
+
+	// Deep copy
+	redacted.ExtraHeader.Set("X-Mailer", "Changed")
+	redacted.Attachments[0].Filename = "changed.pdf"
+	*redacted.Date = time.Time{}
+	assert.Equal(t, "Test", msg.ExtraHeader.Get("X-Mailer"))
+	assert.Equal(t, "invoice.pdf", msg.Attachments[0].Filename)
+	assert.False(t, msg.Date.IsZero())
+}
+
+func TestMessageRedactedMask(t *testing.T) {
+	msg := newRedactionTestMessage()
+	policy := RedactionPolicy{
+		Addresses:   RedactMask,
+		Subject:     RedactMask,
+		Body:        RedactMask,
+		ExtraHeader: RedactMask,
+		Attachments: RedactMask,
+	}
+	redacted := msg.Redacted(policy)
+
+	assert.Equal(t, msg.MessageID, redacted.MessageID)
+	assert.Equal(t, msg.Date, redacted.Date)
+
+	assert.NotContains(t, string(redacted.From), "Erika")
+	assert.True(t, strings.HasSuffix(string(redacted.From), "@example.com"), redacted.From)
+	assert.True(t, redacted.From.Valid())
+	to, err := redacted.To.Split()
+	require.NoError(t, err)
+	require.Len(t, to, 2)
+	assert.True(t, strings.HasSuffix(string(to[1]), "@example.net"))
+	assert.NotContains(t, string(redacted.To), "max")
+	assert.Equal(t, NullableAddress(""), redacted.ReplyTo)
+
+	// Same address results in the same pseudonym
+	assert.Equal(t, redacted.From, msg.Redacted(policy).From)
+	assert.Equal(t, Address(policy.maskAddress("erika@example.com")), redacted.From)
+	keyed := policy
+	keyed.HashKey = []byte("secret")
+	assert.NotEqual(t, redacted.From, msg.Redacted(keyed).From)
+
+	assert.True(t, strings.HasPrefix(redacted.Subject, "[REDACTED "), redacted.Subject)
+	assert.NotContains(t, redacted.Body, "Max")
+	assert.NotContains(t, redacted.BodyHTML.String(), "Max")
+	assert.NotEqual(t, "Test", redacted.ExtraHeader.Get("X-Mailer"))
+
+	hash := sha256.Sum256([]byte("%PDF-1.7 content"))
+	a := redacted.Attachments[0]
+	assert.Nil(t, a.Content)
+	assert.Equal(t, "invoice.pdf", a.Filename)
+	assert.Equal(t, msg.Attachments[0].ContentType, a.ContentType)
+	assert.Equal(t, hex.EncodeToString(hash[:]), a.ContentSHA256)
+	assert.Equal(t, len("%PDF-1.7 content"), a.ContentSize)
+	assert.NotNil(t, msg.Attachments[0].Content)
+}
+
+func TestMessageRedactedRemove(t *testing.T) {
+	msg := newRedactionTestMessage()
+	redacted := msg.Redacted(RedactionPolicy{
+		Addresses:   RedactRemove,
+		Subject:     RedactRemove,
+		Body:        RedactRemove,
+		ExtraHeader: RedactRemove,
+		Attachments: RedactRemove,
+	})
+	assert.Equal(t, &Message{
+		MessageID: msg.MessageID,
+		Date:      msg.Date,
+		BodyHTML:  nullable.TrimmedStringNull,
+	}, redacted)
+}
@@ -0,0 +1,279 @@
+package send
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/domonda/go-errs"
+)
+
+// DefaultDKIMHeaders are the header fields signed by a DKIMSigner
+// if no DKIMSigner.Headers are configured.
+// Only header fields present in the message will be signed.
+var DefaultDKIMHeaders = []string{
+	"From",
+	"Reply-To",
+	"Subject",
+	"Date",
+	"To",
+	"Cc",
+	"Message-Id",
+	"In-Reply-To",
+	"References",
+	"MIME-Version",
+	"Content-Type",
+	"Content-Transfer-Encoding",
+}
+
+// DKIMSigner adds a DKIM-Signature header according to RFC 6376
+// to raw messages using the relaxed/relaxed canonicalization.
+//
+// Supported keys are *rsa.PrivateKey for the rsa-sha256 algorithm
+// and ed25519.PrivateKey for the ed25519-sha256 algorithm of RFC 8463.
+type DKIMSigner struct {
+	// Domain is the signing domain (d= tag).
+	Domain string
+	// Selector is the DNS selector of the public key (s= tag).
+	Selector string
+	// Key is the private key used for signing.
+	Key crypto.Signer
+	// Headers are the header fields to sign,
+	// DefaultDKIMHeaders will be used if empty.
+	Headers []string
+	// Expiration adds an expiration (x= tag)
+	// relative to the signing time if not zero.
+	Expiration time.Duration
+	// Now returns the signing time, time.Now is used if nil.
+	Now func() time.Time
+}
+
+// NewDKIMSigner returns a DKIMSigner for the passed domain,
+// selector, and private key using DefaultDKIMHeaders.
+func NewDKIMSigner(domain, selector string, key crypto.Signer) (*DKIMSigner, error) {
+	s := &DKIMSigner{Domain: domain, Selector: selector, Key: key}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate returns an error if the signer is not properly configured.
+func (s *DKIMSigner) Validate() error {
+	if s.Domain == "" {
+		return errs.New("missing DKIM domain")
+	}
+	if s.Selector == "" {
+		return errs.New("missing DKIM selector")
+	}
+	_, err := s.algorithm()
+	return err
+}
+
+func (s *DKIMSigner) algorithm() (string, error) {
+	switch s.Key.(type) {
+	case *rsa.PrivateKey:
+		return "rsa-sha256", nil
+	case ed25519.PrivateKey:
+		return "ed25519-sha256", nil
+	case nil:
+		return "", errs.New("missing DKIM private key")
+	default:
+		return "", fmt.Errorf("unsupported DKIM private key type %T", s.Key)
+	}
+}
+
+// Sign returns the raw message with a DKIM-Signature header prepended.
+// The message must use CRLF line endings because
+// the signature covers the message as transmitted.
+func (s *DKIMSigner) Sign(raw []byte) (signed []byte, err error) {
+	defer errs.WrapWithFuncParams(&err, len(raw)) // Only the length of the raw message to keep its content out of errors
+
+	algo, err := s.algorithm()
+	if err != nil {
+		return nil, err
+	}
+
+	header, body := splitHeaderBody(raw)
+	fields := parseHeaderFields(header)
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+
+	headerNames := s.Headers
+	if len(headerNames) == 0 {
+		headerNames = DefaultDKIMHeaders
+	}
+	var (
+		signedNames []string
+		hashInput   bytes.Buffer
+		used        = make(map[int]bool)
+	)
+	for _, name := range headerNames {
+		// Multiple instances are signed bottom-up as described
+		// in RFC 6376 section 5.4.2, so every header name
+		// is added again for every instance present
+		for i := len(fields) - 1; i >= 0; i-- {
+			if used[i] || !strings.EqualFold(fields[i].name, name) {
+				continue
+			}
+			used[i] = true
+			signedNames = append(signedNames, strings.ToLower(name))
+			hashInput.WriteString(relaxedHeader(fields[i].name, fields[i].value))
+			hashInput.WriteString("\r\n")
+		}
+	}
+	if !slices.Contains(signedNames, "from") {
+		return nil, errs.New("message has no signed From header")
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	timestamp := now().Unix()
+
+	var tags strings.Builder
+	tags.WriteString("v=1; a=" + algo + "; c=relaxed/relaxed; d=" + s.Domain + "; s=" + s.Selector + ";\r\n")
+	tags.WriteString("\tt=" + strconv.FormatInt(timestamp, 10) + ";")
+	if s.Expiration > 0 {
+		tags.WriteString(" x=" + strconv.FormatInt(timestamp+int64(s.Expiration/time.Second), 10) + ";")
+	}
+	tags.WriteString("\r\n\th=" + strings.Join(signedNames, ":") + ";\r\n")
+	tags.WriteString("\tbh=" + base64.StdEncoding.EncodeToString(bodyHash[:]) + ";\r\n")
+	tags.WriteString("\tb=")
+	value := tags.String()
+
+	// The DKIM-Signature header itself is hashed
+	// with an empty b= tag and without trailing CRLF
+	hashInput.WriteString(relaxedHeader("DKIM-Signature", value))
+	digest := sha256.Sum256(hashInput.Bytes())
+
+	var signature []byte
+	switch key := s.Key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, digest[:])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Grow(len(raw) + len(value) + 512)
+	b.WriteString("DKIM-Signature: ")
+	b.WriteString(value)
+	b.WriteString(foldBase64(base64.StdEncoding.EncodeToString(signature)))
+	b.WriteString("\r\n")
+	b.Write(raw)
+	return b.Bytes(), nil
+}
+
+type headerField struct {
+	name  string
+	value string // unfolded raw value after the colon
+}
+
+func splitHeaderBody(raw []byte) (header, body []byte) {
+	if bytes.HasPrefix(raw, []byte("\r\n")) {
+		return nil, raw[2:]
+	}
+	i := bytes.Index(raw, []byte("\r\n\r\n"))
+	if i == -1 {
+		return raw, nil
+	}
+	return raw[:i+2], raw[i+4:]
+}
+
+func parseHeaderFields(header []byte) []headerField {
+	var fields []headerField
+	for _, line := range strings.SplitAfter(string(header), "\r\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(fields) > 0 {
+			// Continuation of a folded header field
+			fields[len(fields)-1].value += line
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		fields = append(fields, headerField{name: name, value: value})
+	}
+	return fields
+}
+
+// relaxedHeader implements the "relaxed" header canonicalization
+// of RFC 6376 section 3.4.2 without the trailing CRLF.
+func relaxedHeader(name, value string) string {
+	name = strings.ToLower(strings.TrimRight(name, " \t"))
+	value = strings.ReplaceAll(value, "\r\n", "")
+	return name + ":" + strings.TrimSpace(compressWSP(value))
+}
+
+// relaxedBody implements the "relaxed" body canonicalization
+// of RFC 6376 section 3.4.4.
+func relaxedBody(body []byte) []byte {
+	var b bytes.Buffer
+	b.Grow(len(body))
+	emptyLines := 0
+	for _, line := range bytes.Split(body, []byte("\r\n")) {
+		line = bytes.TrimRight([]byte(compressWSP(string(line))), " ")
+		if len(line) == 0 {
+			emptyLines++
+			continue
+		}
+		for ; emptyLines > 0; emptyLines-- {
+			b.WriteString("\r\n")
+		}
+		b.Write(line)
+		b.WriteString("\r\n")
+	}
+	return b.Bytes()
+}
+
+func compressWSP(s string) string {
+	if !strings.ContainsAny(s, "\t ") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	inWSP := false
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c == ' ' || c == '\t' {
+			if !inWSP {
+				b.WriteByte(' ')
+			}
+			inWSP = true
+		} else {
+			b.WriteByte(c)
+			inWSP = false
+		}
+	}
+	return b.String()
+}
+
+// foldBase64 folds a base64 tag value into lines of at most 72 characters.
+// Whitespace within the b= tag is ignored by verifiers.
+func foldBase64(s string) string {
+	const lineLen = 72
+	var b strings.Builder
+	for len(s) > lineLen {
+		b.WriteString(s[:lineLen])
+		b.WriteString("\r\n\t ")
+		s = s[lineLen:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package send
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/email"
+)
+
+func TestRelaxedCanonicalization(t *testing.T) {
+	// Example from RFC 6376 section 3.4.5
+	fields := parseHeaderFields([]byte("A: X\r\nB : Y\t\r\n\tZ  \r\n"))
+	require.Len(t, fields, 2)
+	assert.Equal(t, "a:X", relaxedHeader(fields[0].name, fields[0].value))
+	assert.Equal(t, "b:Y Z", relaxedHeader(fields[1].name, fields[1].value))
+
+	assert.Equal(t, " C\r\nD E\r\n", string(relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))))
+	assert.Equal(t, "", string(relaxedBody(nil)))
+	assert.Equal(t, "A\r\n\r\nB\r\n", string(relaxedBody([]byte("A\r\n\r\nB"))))
+}
+
+type recordingTransport struct {
+	from       string
+	recipients []string
+	raw        []byte
+}
+
+func (t *recordingTransport) SendRaw(ctx context.Context, from string, recipients []string, raw []byte) error {
+	t.from, t.recipients, t.raw = from, recipients, raw
+	return nil
+}
+
+func TestSender_Send(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := NewDKIMSigner("example.com", "mail", key)
+	require.NoError(t, err)
+	signer.Now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	msg := email.NewMessage("Sender <sender@example.com>", "to@example.org", "Hello", "Hello World\n", "")
+	msg.Bcc = "hidden@example.org"
+
+	transport := new(recordingTransport)
+	err = NewSender(transport, signer).Send(t.Context(), msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sender@example.com", transport.from)
+	assert.Equal(t, []string{"to@example.org", "hidden@example.org"}, transport.recipients)
+
+	raw := string(transport.raw)
+	require.True(t, strings.HasPrefix(raw, "DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=example.com; s=mail;"), raw)
+	assert.Contains(t, raw, "t=1700000000;")
+	assert.NotContains(t, raw, "hidden@example.org", "Bcc header must not be submitted")
+
+	verifyDKIM(t, transport.raw, func(digest, signature []byte) error {
+		return rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest, signature)
+	})
+}
+
+func TestDKIMSigner_Ed25519(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := NewDKIMSigner("example.com", "ed", key)
+	require.NoError(t, err)
+
+	signed, err := signer.Sign([]byte("From: a@example.com\r\nSubject: Test\r\n\r\nBody\r\n"))
+	require.NoError(t, err)
+	require.Contains(t, string(signed), "a=ed25519-sha256;")
+
+	verifyDKIM(t, signed, func(digest, signature []byte) error {
+		if !ed25519.Verify(pub, digest, signature) {
+			return assert.AnError
+		}
+		return nil
+	})
+
+	_, err = signer.Sign([]byte("Subject: No From\r\n\r\nBody\r\n"))
+	require.Error(t, err, "From header is required")
+}
+
+// rfc8463Message is the ed25519-sha256 signed example message
+// of RFC 8463 appendix A.3 without the rsa-sha256 signature.
+const rfc8463Message = "DKIM-Signature: v=1; a=ed25519-sha256; c=relaxed/relaxed;\r\n" +
+	" d=football.example.com; i=@football.example.com;\r\n" +
+	" q=dns/txt; s=brisbane; t=1528637909; h=from : to :\r\n" +
+	" subject : date : message-id : from : subject : date;\r\n" +
+	" bh=2jUSOH9NhtVGCQWNr9BrIAPreKQjO6Sn7XIkfJVOzv8=;\r\n" +
+	" b=/gCrinpcQOoIfuHNQIbq4pgh9kyIK3AQUdt9OdqQehSwhEIug4D11BusFa3bT3FY5OsU7ZbnKELq+eXdp1Q1Dw==\r\n" +
+	"From: Joe SixPack <joe@football.example.com>\r\n" +
+	"To: Suzie Q <suzie@shopping.example.net>\r\n" +
+	"Subject: Is dinner ready?\r\n" +
+	"Date: Fri, 11 Jul 2003 21:00:37 -0700 (PDT)\r\n" +
+	"Message-ID: <20030712040037.46341.5F8J@football.example.com>\r\n" +
+	"\r\n" +
+	"Hi.\r\n" +
+	"\r\n" +
+	"We lost the game.  Are you hungry yet?\r\n" +
+	"\r\n" +
+	"Joe.\r\n"
+
+func TestDKIMSigner_RFC8463(t *testing.T) {
+	// Keys of RFC 8463 appendix A.1 and A.2
+	seed, err := base64.StdEncoding.DecodeString("nWGxne/9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A=")
+	require.NoError(t, err)
+	key := ed25519.NewKeyFromSeed(seed)
+	pub := key.Public().(ed25519.PublicKey)
+	require.Equal(t, "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=", base64.StdEncoding.EncodeToString(pub))
+	verify := func(digest, signature []byte) error {
+		if !ed25519.Verify(pub, digest, signature) {
+			return assert.AnError
+		}
+		return nil
+	}
+
+	// The known-good signature of the RFC verifies
+	// with the canonicalization used for signing
+	verifyDKIM(t, []byte(rfc8463Message), verify)
+
+	signer, err := NewDKIMSigner("football.example.com", "brisbane", key)
+	require.NoError(t, err)
+	signer.Headers = []string{"From", "To", "Subject", "Date", "Message-ID"}
+	signer.Now = func() time.Time { return time.Unix(1528637909, 0) }
+
+	// Signing the unsigned message must produce the body hash of the RFC
+	signed, err := signer.Sign([]byte(rfc8463Message[strings.Index(rfc8463Message, "From:"):]))
+	require.NoError(t, err)
+	assert.Contains(t, string(signed), "\th=from:to:subject:date:message-id;\r\n\tbh=2jUSOH9NhtVGCQWNr9BrIAPreKQjO6Sn7XIkfJVOzv8=;\r\n")
+	verifyDKIM(t, signed, verify)
+}
+
+// verifyDKIM recomputes the hashes of a signed message
+// like a verifier would after receiving it.
+func verifyDKIM(t *testing.T, signed []byte, verify func(digest, signature []byte) error) {
+	t.Helper()
+
+	header, body := splitHeaderBody(signed)
+	fields := parseHeaderFields(header)
+	require.Equal(t, "DKIM-Signature", fields[0].name)
+
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(fields[0].value, ";") {
+		name, value, _ := strings.Cut(tag, "=")
+		tags[strings.TrimSpace(name)] = strings.Join(strings.Fields(value), "")
+	}
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+	require.Equal(t, base64.StdEncoding.EncodeToString(bodyHash[:]), tags["bh"], "body hash")
+
+	var hashInput strings.Builder
+	used := make(map[int]bool)
+	for _, name := range strings.Split(tags["h"], ":") {
+		name = strings.TrimSpace(name)
+		for i := len(fields) - 1; i > 0; i-- {
+			if !used[i] && strings.EqualFold(fields[i].name, name) {
+				used[i] = true
+				hashInput.WriteString(relaxedHeader(fields[i].name, fields[i].value) + "\r\n")
+				break
+			}
+		}
+	}
+	// The b= tag is the last one and hashed with an empty value
+	b := regexp.MustCompile(`;\s*b=`).FindStringIndex(fields[0].value)
+	require.NotNil(t, b, "b= tag")
+	hashInput.WriteString(relaxedHeader(fields[0].name, fields[0].value[:b[1]]))
+
+	signature, err := base64.StdEncoding.DecodeString(tags["b"])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(hashInput.String()))
+	require.NoError(t, verify(digest[:], signature), "signature")
+}
@@ -0,0 +1,129 @@
+// Package send implements the submission of composed email.Message
+// values to a mail transfer agent via SMTP or a local sendmail binary,
+// with optional DKIM signing of the outgoing messages.
+package send
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/domonda/go-errs"
+
+	"github.com/domonda/go-types/email"
+)
+
+// Transport submits a raw RFC 5322 message
+// with the passed envelope sender and recipients.
+type Transport interface {
+	SendRaw(ctx context.Context, from string, recipients []string, raw []byte) error
+}
+
+// DSNTransport is implemented by transports that can request
+// delivery status notifications according to RFC 3461.
+type DSNTransport interface {
+	Transport
+
+	// SendRawDSN is like SendRaw but passes the parameters of
+	// the DSN request with the envelope sender and recipients.
+	SendRawDSN(ctx context.Context, from string, recipients []string, raw []byte, dsn *email.DSNRequest) error
+}
+
+// Sender combines a Transport with an optional DKIMSigner
+// to send composed email.Message values.
+type Sender struct {
+	Transport Transport
+
+	// DKIM signs the raw message before submission if not nil.
+	DKIM *DKIMSigner
+
+	// Strict builds the raw message with
+	// email.Message.BuildRawMessageStrict for picky receivers.
+	Strict bool
+}
+
+// NewSender returns a Sender using the passed transport
+// and the optional DKIM signer that can be nil.
+func NewSender(transport Transport, dkim *DKIMSigner) *Sender {
+	return &Sender{Transport: transport, DKIM: dkim}
+}
+
+// Send builds the raw message using email.Message.BuildRawMessage,
+// or email.Message.BuildRawMessageStrict if s.Strict is true, signs it if a DKIMSigner is configured and submits it via the Transport.
+//
+// The envelope sender is the address part of msg.From
+// and the envelope recipients are the addresses of
+// msg.To, msg.Cc, and msg.Bcc.
+// The Bcc header is not included in the submitted message.
+//
+// If msg.DSN is not nil, then the Transport must implement DSNTransport.
+func (s *Sender) Send(ctx context.Context, msg *email.Message) (err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, msg)
+
+	from, err := msg.From.AddressPartString()
+	if err != nil {
+		return err
+	}
+	recipients := msg.Recipients()
+	if len(recipients) == 0 {
+		return errs.New("message has no valid recipients")
+	}
+	var dsnTransport DSNTransport
+	if msg.DSN != nil {
+		err = msg.DSN.Validate()
+		if err != nil {
+			return err
+		}
+		var ok bool
+		dsnTransport, ok = s.Transport.(DSNTransport)
+		if !ok {
+			return errs.Errorf("transport %T does not support DSN requests", s.Transport)
+		}
+	}
+
+	withoutBcc := *msg
+	withoutBcc.Bcc = ""
+	var raw []byte
+	if s.Strict {
+		raw, err = withoutBcc.BuildRawMessageStrict()
+	} else {
+		raw, err = withoutBcc.BuildRawMessage()
+	}
+	if err != nil {
+		return err
+	}
+	raw = normalizeCRLF(raw)
+
+	if s.DKIM != nil {
+		raw, err = s.DKIM.Sign(raw)
+		if err != nil {
+			return err
+		}
+	}
+	if dsnTransport != nil {
+		return dsnTransport.SendRawDSN(ctx, from, recipients, raw, msg.DSN)
+	}
+	return s.Transport.SendRaw(ctx, from, recipients, raw)
+}
+
+// normalizeCRLF converts all bare LF and CR line endings to CRLF
+// so that a DKIM signature matches the message as transmitted.
+func normalizeCRLF(raw []byte) []byte {
+	if !bytes.ContainsAny(raw, "\r\n") {
+		return raw
+	}
+	normalized := make([]byte, 0, len(raw)+len(raw)/40)
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; c {
+		case '\r':
+			normalized = append(normalized, '\r', '\n')
+			if i+1 < len(raw) && raw[i+1] == '\n' {
+				i++
+			}
+		case '\n':
+			normalized = append(normalized, '\r', '\n')
+		default:
+			normalized = append(normalized, c)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,77 @@
+package send
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/domonda/go-errs"
+
+	"github.com/domonda/go-types/email"
+)
+
+var _ DSNTransport = Sendmail("")
+
+// DefaultSendmailPath is used by Sendmail if its value is empty.
+const DefaultSendmailPath = "/usr/sbin/sendmail"
+
+// Sendmail is a Transport that submits messages by executing
+// a sendmail compatible binary at the path of the string value.
+// An empty string uses DefaultSendmailPath.
+type Sendmail string
+
+// SendRaw implements the Transport interface.
+func (s Sendmail) SendRaw(ctx context.Context, from string, recipients []string, raw []byte) (err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, from, recipients, len(raw)) // Only the length of the raw message to keep its content out of errors
+
+	// -i: don't treat a line with only a dot as end of input
+	// -f: envelope sender
+	return s.run(ctx, []string{"-i", "-f", from}, recipients, raw)
+}
+
+// SendRawDSN implements the DSNTransport interface
+// using the -N, -R, and -V options of sendmail.
+func (s Sendmail) SendRawDSN(ctx context.Context, from string, recipients []string, raw []byte, dsn *email.DSNRequest) (err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, from, recipients, len(raw), dsn) // Only the length of the raw message to keep its content out of errors
+
+	err = dsn.Validate()
+	if err != nil {
+		return err
+	}
+	options := []string{"-i", "-f", from}
+	if len(dsn.Notify) > 0 {
+		notify := make([]string, len(dsn.Notify))
+		for i, n := range dsn.Notify {
+			notify[i] = strings.ToLower(string(n))
+		}
+		options = append(options, "-N", strings.Join(notify, ","))
+	}
+	if dsn.Return != "" {
+		options = append(options, "-R", strings.ToLower(string(dsn.Return)))
+	}
+	if dsn.EnvelopeID != "" {
+		options = append(options, "-V", dsn.EnvelopeID)
+	}
+	return s.run(ctx, options, recipients, raw)
+}
+
+func (s Sendmail) run(ctx context.Context, options, recipients []string, raw []byte) error {
+	path := string(s)
+	if path == "" {
+		path = DefaultSendmailPath
+	}
+	args := append(append(options, "--"), recipients...)
+	cmd := exec.CommandContext(ctx, path, args...) //#nosec G204 -- path is configured by the application
+	cmd.Stdin = bytes.NewReader(raw)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errs.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
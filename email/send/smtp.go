@@ -0,0 +1,361 @@
+package send
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/domonda/go-errs"
+
+	"github.com/domonda/go-types/email"
+)
+
+var _ DSNTransport = new(SMTP)
+
+// SMTPConfig configures an SMTP transport.
+type SMTPConfig struct {
+	// Addr is the "host:port" address of the SMTP server.
+	Addr string
+
+	// Auth is used to authenticate if not nil.
+	// The server must support the AUTH extension.
+	Auth smtp.Auth
+
+	// TLSConfig is used for STARTTLS and implicit TLS.
+	// If nil, a config with the host of Addr as ServerName is used.
+	TLSConfig *tls.Config
+
+	// ImplicitTLS connects using TLS from the start
+	// (submissions on port 465) instead of STARTTLS.
+	ImplicitTLS bool
+
+	// AllowInsecure permits sending without TLS
+	// if the server does not support STARTTLS.
+	AllowInsecure bool
+
+	// LocalName is sent with the HELO/EHLO command,
+	// "localhost" is used if empty.
+	LocalName string
+
+	// MaxIdleConns is the maximum number of idle connections
+	// kept open for reuse. Zero disables connection pooling.
+	MaxIdleConns int
+
+	// IdleTimeout closes idle connections
+	// that have not been used for this duration if not zero.
+	IdleTimeout time.Duration
+
+	// DialTimeout is the timeout for establishing a connection if not zero.
+	DialTimeout time.Duration
+}
+
+// SMTP is a Transport that submits messages to an SMTP server
+// using STARTTLS or implicit TLS and keeps idle connections
+// in a pool for reuse.
+//
+// The connection is closed to abort a running dial or send
+// when the context is canceled.
+//
+// SMTP is safe for concurrent use.
+type SMTP struct {
+	config SMTPConfig
+	tls    *tls.Config
+
+	mutex  sync.Mutex
+	idle   []*smtpConn
+	closed bool
+}
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+// NewSMTP returns an SMTP transport for the passed config.
+func NewSMTP(config SMTPConfig) (*SMTP, error) {
+	host, _, err := net.SplitHostPort(config.Addr)
+	if err != nil {
+		return nil, errs.Errorf("invalid SMTP address %q: %w", config.Addr, err)
+	}
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = host
+	}
+	return &SMTP{config: config, tls: tlsConfig}, nil
+}
+
+// SendRaw implements the Transport interface.
+func (s *SMTP) SendRaw(ctx context.Context, from string, recipients []string, raw []byte) (err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, from, recipients, len(raw)) // Only the length of the raw message to keep its content out of errors
+
+	return s.send(ctx, from, recipients, raw, nil)
+}
+
+// SendRawDSN implements the DSNTransport interface.
+// Returns an error if the server does not support the DSN extension.
+func (s *SMTP) SendRawDSN(ctx context.Context, from string, recipients []string, raw []byte, dsn *email.DSNRequest) (err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, from, recipients, len(raw), dsn) // Only the length of the raw message to keep its content out of errors
+
+	return s.send(ctx, from, recipients, raw, dsn)
+}
+
+func (s *SMTP) send(ctx context.Context, from string, recipients []string, raw []byte, dsn *email.DSNRequest) error {
+	c, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	if dsn != nil {
+		if ok, _ := c.client.Extension("DSN"); !ok {
+			s.release(c)
+			return errs.Errorf("SMTP server %s does not support DSN", s.config.Addr)
+		}
+	}
+	// Close the connection to abort the submission when ctx is done
+	stop := context.AfterFunc(ctx, func() { c.conn.Close() }) //#nosec G104 -- aborting
+	err = submit(c.client, from, recipients, raw, dsn)
+	if !stop() {
+		s.discard(c)
+		return ctx.Err()
+	}
+	if err != nil {
+		s.discard(c)
+		return err
+	}
+	s.release(c)
+	return nil
+}
+
+func submit(client *smtp.Client, from string, recipients []string, raw []byte, dsn *email.DSNRequest) error {
+	if dsn == nil {
+		err := client.Mail(from)
+		if err != nil {
+			return err
+		}
+		for _, rcpt := range recipients {
+			err = client.Rcpt(rcpt)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		// net/smtp.Client does not support DSN parameters
+		mailParams := dsn.MailParameters()
+		if ok, _ := client.Extension("8BITMIME"); ok {
+			mailParams = append(mailParams, "BODY=8BITMIME")
+		}
+		if ok, _ := client.Extension("SMTPUTF8"); ok {
+			mailParams = append(mailParams, "SMTPUTF8")
+		}
+		err := smtpCommand(client, 250, "MAIL FROM:<"+from+">", mailParams)
+		if err != nil {
+			return err
+		}
+		rcptParams := dsn.RcptParameters()
+		for _, rcpt := range recipients {
+			err = smtpCommand(client, 25, "RCPT TO:<"+rcpt+">", rcptParams)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+	if err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Close closes all idle connections and prevents
+// connections in use from being returned to the pool.
+func (s *SMTP) Close() error {
+	s.mutex.Lock()
+	idle := s.idle
+	s.idle = nil
+	s.closed = true
+	s.mutex.Unlock()
+
+	var err error
+	for _, c := range idle {
+		err = errors.Join(err, c.client.Quit())
+	}
+	return err
+}
+
+func (s *SMTP) acquire(ctx context.Context) (*smtpConn, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c := s.popIdle()
+		if c == nil {
+			return s.dial(ctx)
+		}
+		// Reset the session state and check
+		// that the server did not close the connection
+		stop := context.AfterFunc(ctx, func() { c.conn.Close() }) //#nosec G104 -- aborting
+		err := setContextDeadline(ctx, c.conn)
+		if err == nil {
+			err = c.client.Reset()
+		}
+		if stop() && err == nil {
+			return c, nil
+		}
+		c.client.Close() //#nosec G104 -- connection is broken anyway
+	}
+}
+
+func (s *SMTP) popIdle() *smtpConn {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for len(s.idle) > 0 {
+		c := s.idle[len(s.idle)-1]
+		s.idle = s.idle[:len(s.idle)-1]
+		if s.config.IdleTimeout > 0 && time.Since(c.lastUsed) > s.config.IdleTimeout {
+			go c.client.Quit() //#nosec G104 -- closing expired connection
+			continue
+		}
+		return c
+	}
+	return nil
+}
+
+func (s *SMTP) release(c *smtpConn) {
+	s.mutex.Lock()
+	if !s.closed && len(s.idle) < s.config.MaxIdleConns {
+		c.lastUsed = time.Now()
+		s.idle = append(s.idle, c)
+		c = nil
+	}
+	s.mutex.Unlock()
+
+	if c != nil {
+		c.client.Quit() //#nosec G104 -- message was already accepted
+	}
+}
+
+func (s *SMTP) discard(c *smtpConn) {
+	c.client.Close() //#nosec G104 -- already returning an error
+}
+
+func (s *SMTP) dial(ctx context.Context) (c *smtpConn, err error) {
+	dialer := &net.Dialer{Timeout: s.config.DialTimeout}
+	var conn net.Conn
+	if s.config.ImplicitTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tls}).DialContext(ctx, "tcp", s.config.Addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.config.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = setContextDeadline(ctx, conn)
+	if err != nil {
+		conn.Close() //#nosec G104 -- already returning an error
+		return nil, err
+	}
+
+	// Close the connection to abort the handshake when ctx is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() }) //#nosec G104 -- aborting
+	client, err := s.handshake(conn)
+	if !stop() {
+		if client != nil {
+			client.Close() //#nosec G104 -- already returning an error
+		}
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &smtpConn{conn: conn, client: client}, nil
+}
+
+// handshake greets the server on conn and negotiates
+// TLS and authentication as configured.
+// The connection is closed if an error is returned.
+func (s *SMTP) handshake(conn net.Conn) (client *smtp.Client, err error) {
+	client, err = smtp.NewClient(conn, s.tls.ServerName)
+	if err != nil {
+		conn.Close() //#nosec G104 -- already returning an error
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			client.Close() //#nosec G104 -- already returning an error
+		}
+	}()
+
+	localName := s.config.LocalName
+	if localName == "" {
+		localName = "localhost"
+	}
+	err = client.Hello(localName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.config.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			err = client.StartTLS(s.tls)
+			if err != nil {
+				return nil, err
+			}
+		} else if !s.config.AllowInsecure {
+			return nil, errs.Errorf("SMTP server %s does not support STARTTLS", s.config.Addr)
+		}
+	}
+
+	if s.config.Auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return nil, errs.Errorf("SMTP server %s does not support AUTH", s.config.Addr)
+		}
+		err = client.Auth(s.config.Auth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// smtpCommand sends the command with the space separated
+// parameters and reads the response expecting the code or code prefix.
+func smtpCommand(client *smtp.Client, expectCode int, command string, params []string) error {
+	for _, p := range params {
+		command += " " + p
+	}
+	if strings.ContainsAny(command, "\r\n") {
+		return fmt.Errorf("SMTP command contains line breaks: %q", command)
+	}
+	id, err := client.Text.Cmd("%s", command)
+	if err != nil {
+		return err
+	}
+	client.Text.StartResponse(id)
+	defer client.Text.EndResponse(id)
+	_, _, err = client.Text.ReadResponse(expectCode)
+	return err
+}
+
+// setContextDeadline sets the deadline of the context for conn
+// or clears a deadline from a previous usage of a pooled connection.
+func setContextDeadline(ctx context.Context, conn net.Conn) error {
+	deadline, _ := ctx.Deadline()
+	return conn.SetDeadline(deadline)
+}
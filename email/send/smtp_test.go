@@ -0,0 +1,153 @@
+package send
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/email"
+)
+
+// serveSMTP accepts a single connection on the listener
+// and answers with an SMTP server dialog that supports
+// the passed EHLO extensions and records the received commands.
+func serveSMTP(t *testing.T, listener net.Listener, extensions []string) <-chan []string {
+	t.Helper()
+	commands := make(chan []string, 1)
+	go func() {
+		var received []string
+		defer func() { commands <- received }()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		write := func(lines ...string) {
+			for _, line := range lines {
+				conn.Write([]byte(line + "\r\n")) //#nosec G104 -- test server
+			}
+		}
+		write("220 localhost ESMTP")
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			if inData {
+				if line == "." {
+					inData = false
+					write("250 OK")
+				}
+				continue
+			}
+			received = append(received, line)
+			switch cmd, _, _ := strings.Cut(line, " "); strings.ToUpper(cmd) {
+			case "EHLO":
+				reply := []string{"250-localhost"}
+				for _, ext := range extensions {
+					reply = append(reply, "250-"+ext)
+				}
+				write(append(reply, "250 HELP")...)
+			case "DATA":
+				inData = true
+				write("354 Go ahead")
+			case "QUIT":
+				write("221 Bye")
+				return
+			default:
+				write("250 OK")
+			}
+		}
+	}()
+	return commands
+}
+
+func TestSMTP_SendRawDSN(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	commands := serveSMTP(t, listener, []string{"DSN", "8BITMIME"})
+
+	transport, err := NewSMTP(SMTPConfig{Addr: listener.Addr().String(), AllowInsecure: true})
+	require.NoError(t, err)
+
+	msg := email.NewMessage("sender@example.com", "to@example.org, other@example.org", "Hello", "Hello World\n", "")
+	err = msg.Apply(email.WithDSNRequest(email.DSNRequest{
+		Notify:     []email.DSNNotify{email.DSNNotifySuccess, email.DSNNotifyFailure},
+		Return:     email.DSNReturnHeaders,
+		EnvelopeID: "msg-1",
+	}))
+	require.NoError(t, err)
+	err = NewSender(transport, nil).Send(t.Context(), msg)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{
+			"EHLO localhost",
+			"MAIL FROM:<sender@example.com> RET=HDRS ENVID=msg-1 BODY=8BITMIME",
+			"RCPT TO:<to@example.org> NOTIFY=SUCCESS,FAILURE",
+			"RCPT TO:<other@example.org> NOTIFY=SUCCESS,FAILURE",
+			"DATA",
+			"QUIT",
+		},
+		<-commands,
+	)
+}
+
+func TestSMTP_SendRawDSN_Unsupported(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	commands := serveSMTP(t, listener, nil)
+
+	transport, err := NewSMTP(SMTPConfig{Addr: listener.Addr().String(), AllowInsecure: true})
+	require.NoError(t, err)
+	err = transport.SendRawDSN(t.Context(), "sender@example.com", []string{"to@example.org"}, []byte("Subject: Hello\r\n\r\nHello\r\n"), &email.DSNRequest{Return: email.DSNReturnFull})
+	require.ErrorContains(t, err, "does not support DSN")
+	assert.Equal(t, []string{"EHLO localhost", "QUIT"}, <-commands)
+}
+
+func TestSMTP_SendRaw_Canceled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	// Accept the connection but never send the server greeting
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			conn.Read(make([]byte, 1)) //#nosec G104 -- returns when the client closes
+		}
+	}()
+
+	transport, err := NewSMTP(SMTPConfig{Addr: listener.Addr().String(), AllowInsecure: true})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	done := make(chan error, 1)
+	go func() {
+		done <- transport.SendRaw(ctx, "sender@example.com", []string{"to@example.org"}, []byte("Subject: Hello\r\n\r\nHello\r\n"))
+	}()
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("SendRaw not aborted by canceled context without deadline")
+	}
+}
+
+func TestSender_Send_DSNNotSupportedByTransport(t *testing.T) {
+	msg := email.NewMessage("sender@example.com", "to@example.org", "Hello", "Hello World\n", "")
+	msg.DSN = &email.DSNRequest{Notify: []email.DSNNotify{email.DSNNotifyNever}}
+	err := NewSender(new(recordingTransport), nil).Send(t.Context(), msg)
+	require.ErrorContains(t, err, "does not support DSN")
+}
@@ -0,0 +1,136 @@
+package email
+
+import (
+	"path"
+	"strings"
+)
+
+// Signals are structured indicators of a message
+// for downstream spam and bulk mail scoring.
+// Signals don't make a verdict themselves.
+type Signals struct {
+	// Precedence is the lower case value of the "Precedence" header.
+	Precedence string `json:"precedence,omitempty"`
+	// Bulk is true if the "Precedence" header is "bulk", "list", or "junk".
+	Bulk bool `json:"bulk,omitempty"`
+	// AutoSubmitted is true if the "Auto-Submitted" header
+	// is set to a value other than "no".
+	AutoSubmitted bool `json:"autoSubmitted,omitempty"`
+	// ListHeaders are the canonical names of the present
+	// mailing list headers of RFC 2369 and RFC 2919
+	// like "List-Id" or "List-Unsubscribe".
+	ListHeaders []string `json:"listHeaders,omitempty"`
+	// ListUnsubscribe is true if a "List-Unsubscribe" header is present.
+	ListUnsubscribe bool `json:"listUnsubscribe,omitempty"`
+	// FeedbackID is true if a "Feedback-Id" header is present.
+	FeedbackID bool `json:"feedbackID,omitempty"`
+	// ReplyToMismatch is true if the domain of the "Reply-To"
+	// address is different from the domain of the "From" address.
+	ReplyToMismatch bool `json:"replyToMismatch,omitempty"`
+	// HTMLOnly is true if the message has an HTML body
+	// but no plaintext body.
+	HTMLOnly bool `json:"htmlOnly,omitempty"`
+	// URLCount is the number of distinct HTTP URLs in the bodies.
+	URLCount int `json:"urlCount"`
+	// DoubleExtensionAttachments are the filenames of attachments
+	// hiding an executable behind a harmless looking extension
+	// like "invoice.pdf.exe".
+	// They are also listed in ExecutableAttachments.
+	DoubleExtensionAttachments []string `json:"doubleExtensionAttachments,omitempty"`
+	// ExecutableAttachments are the filenames of attachments
+	// with an executable or script file extension.
+	ExecutableAttachments []string `json:"executableAttachments,omitempty"`
+	// MacroEnabledAttachments are the filenames of macro-enabled
+	// Microsoft Office attachments like "invoice.xlsm".
+	MacroEnabledAttachments []string `json:"macroEnabledAttachments,omitempty"`
+}
+
+// SuspiciousAttachments returns true if the message has any
+// attachment with a double extension, an executable extension,
+// or a macro-enabled Office type.
+func (s *Signals) SuspiciousAttachments() bool {
+	return len(s.DoubleExtensionAttachments) > 0 ||
+		len(s.ExecutableAttachments) > 0 ||
+		len(s.MacroEnabledAttachments) > 0
+}
+
+var signalsListHeaders = []string{
+	"List-Id",
+	"List-Unsubscribe",
+	"List-Unsubscribe-Post",
+	"List-Subscribe",
+	"List-Post",
+	"List-Help",
+	"List-Owner",
+	"List-Archive",
+}
+
+var executableFileExts = map[string]struct{}{
+	".exe": {}, ".scr": {}, ".com": {}, ".pif": {}, ".bat": {}, ".cmd": {},
+	".msi": {}, ".msp": {}, ".cpl": {}, ".dll": {}, ".hta": {}, ".lnk": {},
+	".js": {}, ".jse": {}, ".vbs": {}, ".vbe": {}, ".wsf": {}, ".wsh": {},
+	".ps1": {}, ".jar": {}, ".reg": {}, ".iso": {}, ".img": {},
+}
+
+var macroEnabledFileExts = map[string]struct{}{
+	".docm": {}, ".dotm": {},
+	".xlsm": {}, ".xltm": {}, ".xlam": {}, ".xlsb": {},
+	".pptm": {}, ".potm": {}, ".ppam": {}, ".ppsm": {}, ".sldm": {},
+}
+
+// Signals extracts structured spam and bulk mail indicators
+// from the headers, bodies, and attachments of the message.
+func (msg *Message) Signals() Signals {
+	var s Signals
+
+	s.Precedence = strings.ToLower(strings.TrimSpace(msg.ExtraHeader.Get("Precedence")))
+	switch s.Precedence {
+	case "bulk", "list", "junk":
+		s.Bulk = true
+	}
+	s.AutoSubmitted = msg.IsAutoSubmitted()
+	for _, key := range signalsListHeaders {
+		if len(msg.ExtraHeader.Values(key)) > 0 {
+			s.ListHeaders = append(s.ListHeaders, key)
+		}
+	}
+	s.ListUnsubscribe = msg.ExtraHeader.Get("List-Unsubscribe") != ""
+	s.FeedbackID = msg.FeedbackID() != ""
+
+	if msg.ReplyTo.IsNotNull() {
+		replyTo := strings.ToLower(msg.ReplyTo.DomainPart())
+		from := strings.ToLower(msg.From.DomainPart())
+		s.ReplyToMismatch = replyTo != "" && from != "" && replyTo != from
+	}
+	s.HTMLOnly = msg.BodyHTML.IsNotNull() && strings.TrimSpace(msg.Body) == ""
+	s.URLCount = len(ExtractBodyURLs(msg))
+
+	for _, a := range msg.Attachments {
+		if a == nil {
+			continue
+		}
+		filename := strings.ToLower(strings.TrimRight(a.Filename, " ."))
+		ext := path.Ext(filename)
+		if _, ok := executableFileExts[ext]; ok {
+			s.ExecutableAttachments = append(s.ExecutableAttachments, a.Filename)
+			if inner := path.Ext(strings.TrimSuffix(filename, ext)); inner != "" && !isNumeric(inner[1:]) {
+				s.DoubleExtensionAttachments = append(s.DoubleExtensionAttachments, a.Filename)
+			}
+		}
+		_, macroExt := macroEnabledFileExts[ext]
+		if macroExt || strings.Contains(strings.ToLower(a.ContentType), "macroenabled") {
+			s.MacroEnabledAttachments = append(s.MacroEnabledAttachments, a.Filename)
+		}
+	}
+
+	return s
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
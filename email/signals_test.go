@@ -0,0 +1,47 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Signals(t *testing.T) {
+	msg := NewMessage("Shop <news@shop.example.com>", "to@example.org", "Offer", "Visit https://shop.example.com and https://shop.example.com/sale", "")
+	assert.Equal(t, Signals{URLCount: 2}, msg.Signals())
+	assert.False(t, (&Signals{}).SuspiciousAttachments())
+
+	msg.ReplyTo = "Support <support@other.example.net>"
+	msg.ExtraHeader.Set("Precedence", " Bulk")
+	msg.ExtraHeader.Set("List-Id", "<news.shop.example.com>")
+	msg.ExtraHeader.Set("Feedback-Id", "news:shop")
+	msg.Body = ""
+	msg.BodyHTML = `<a href="https://shop.example.com/sale">Sale</a>`
+	assert.NoError(t, msg.Apply(
+		WithListUnsubscribe(true, "https://shop.example.com/unsubscribe"),
+		WithAutoSubmitted(AutoSubmittedGenerated),
+	))
+	msg.AddAttachment("1", "Invoice.PDF.exe", []byte("MZ"))
+	msg.AddAttachment("2", "setup-1.2.msi", []byte("MZ"))
+	msg.AddAttachment("3", "Report.xlsm", []byte("PK"))
+	msg.AddAttachment("4", "data", []byte("PK"))
+	msg.Attachments[3].ContentType = "application/vnd.ms-word.document.macroEnabled.12"
+	msg.AddAttachment("5", "invoice.pdf", []byte("%PDF"))
+
+	s := msg.Signals()
+	assert.Equal(t, Signals{
+		Precedence:                 "bulk",
+		Bulk:                       true,
+		AutoSubmitted:              true,
+		ListHeaders:                []string{"List-Id", "List-Unsubscribe", "List-Unsubscribe-Post"},
+		ListUnsubscribe:            true,
+		FeedbackID:                 true,
+		ReplyToMismatch:            true,
+		HTMLOnly:                   true,
+		URLCount:                   1,
+		DoubleExtensionAttachments: []string{"Invoice.PDF.exe"},
+		ExecutableAttachments:      []string{"Invoice.PDF.exe", "setup-1.2.msi"},
+		MacroEnabledAttachments:    []string{"Report.xlsm", "data"},
+	}, s)
+	assert.True(t, s.SuspiciousAttachments())
+}
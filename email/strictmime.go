@@ -0,0 +1,449 @@
+package email
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/domonda/go-errs"
+	"github.com/domonda/go-types/strutil"
+	"github.com/domonda/go-types/uu"
+)
+
+const (
+	// MaxLineLength is the maximum length of a line
+	// without CRLF according to RFC 5322 section 2.1.1.
+	MaxLineLength = 998
+
+	// RecommendedLineLength is the recommended maximum length
+	// of a line without CRLF according to RFC 5322 section 2.1.1.
+	RecommendedLineLength = 78
+)
+
+// BuildRawMessageStrict builds the raw message like BuildRawMessage
+// but conforming to the line length and 7-bit rules for picky receivers:
+//
+//   - Header fields are folded at whitespace to at most
+//     RecommendedLineLength characters per line.
+//   - Non ASCII header values are encoded as RFC 2047 encoded-words,
+//     display names of address headers separately from the address.
+//   - Text bodies use CRLF line endings and are sent as 7bit if possible,
+//     else quoted-printable or base64 for mostly non ASCII text.
+//   - Attachments are base64 encoded with 76 character lines.
+//
+// The generated bytes are checked with ValidateRawMessage
+// and an error is returned if they don't conform.
+func (msg *Message) BuildRawMessageStrict() (raw []byte, err error) {
+	defer errs.WrapWithFuncParams(&err)
+
+	return msg.buildRawMessageStrict(func() string {
+		return "=_" + uu.IDv4().Hex()
+	})
+}
+
+// BuildRawMessageDeterministic builds the raw message like BuildRawMessageStrict
+// but with multipart boundaries derived from seed instead of random ones,
+// so that the same message and seed always result in the same bytes
+// for golden file tests and content hashes.
+//
+// The header fields are written in the canonical order
+// Date, From, Reply-To, To, Cc, Bcc, Message-Id, In-Reply-To,
+// References, Subject, the ExtraHeader fields sorted by key, and MIME-Version.
+// The message must have a Date because the current time
+// would be used otherwise.
+func (msg *Message) BuildRawMessageDeterministic(seed string) (raw []byte, err error) {
+	defer errs.WrapWithFuncParams(&err)
+
+	if msg.Date == nil || msg.Date.IsZero() {
+		return nil, errors.New("deterministic message needs a Date")
+	}
+	n := 0
+	return msg.buildRawMessageStrict(func() string {
+		n++
+		hash := sha256.Sum256(fmt.Appendf(nil, "%s\x00%d", seed, n))
+		return "=_" + hex.EncodeToString(hash[:16])
+	})
+}
+
+// buildRawMessageStrict builds the message using newBoundary
+// for the boundaries of multipart parts.
+func (msg *Message) buildRawMessageStrict(newBoundary func() string) (raw []byte, err error) {
+	var header strictHeader
+	header.add("Date", formatDate(msg.Date))
+	if err = header.addAddresses("From", string(msg.From)); err != nil {
+		return nil, err
+	}
+	if msg.ReplyTo.IsNotNull() {
+		if err = header.addAddresses("Reply-To", string(msg.ReplyTo)); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range []strictHeaderField{{"To", string(msg.To)}, {"Cc", string(msg.Cc)}, {"Bcc", string(msg.Bcc)}} {
+		if field.value == "" {
+			continue
+		}
+		if err = header.addAddresses(field.name, field.value); err != nil {
+			return nil, err
+		}
+	}
+	if msg.MessageID.IsNotNull() {
+		header.add("Message-Id", msg.MessageID.Get())
+	}
+	if msg.InReplyTo.IsNotNull() {
+		header.add("In-Reply-To", msg.InReplyTo.Get())
+	}
+	if msg.References.IsNotNull() {
+		header.add("References", msg.References.Get())
+	}
+	header.addText("Subject", strutil.TrimSpace(msg.Subject))
+	for _, key := range slices.Sorted(maps.Keys(msg.ExtraHeader)) {
+		for _, val := range msg.ExtraHeader[key] {
+			header.addText(key, val)
+		}
+	}
+	header.add("MIME-Version", "1.0")
+
+	// Same part structure as BuildRawMessage
+	var root *strictPart
+	if msg.Body != "" || msg.BodyHTML == "" {
+		root = newStrictTextPart("text/plain", msg.Body)
+	}
+	if msg.BodyHTML != "" {
+		html := newStrictTextPart("text/html", string(msg.BodyHTML))
+		if root == nil {
+			root = html
+		} else {
+			root = &strictPart{contentType: "multipart/alternative", children: []*strictPart{root, html}}
+		}
+	}
+	if len(msg.Attachments) > 0 {
+		root = &strictPart{contentType: "multipart/mixed", children: []*strictPart{root}}
+		for _, att := range msg.Attachments {
+			root.children = append(root.children, newStrictAttachmentPart(att))
+		}
+	}
+
+	var buf bytes.Buffer
+	header.write(&buf)
+	root.write(&buf, newBoundary)
+	raw = buf.Bytes()
+
+	if err = ValidateRawMessage(raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// ValidateRawMessage checks that a raw message only consists of
+// 7-bit ASCII characters without NUL, uses CRLF line endings,
+// has no line longer than MaxLineLength characters,
+// has a valid header section and folds header lines longer
+// than RecommendedLineLength characters where possible.
+func ValidateRawMessage(raw []byte) error {
+	inHeader := true
+	for lineNum, pos := 1, 0; pos < len(raw); lineNum++ {
+		end := bytes.IndexByte(raw[pos:], '\n')
+		if end == -1 {
+			return fmt.Errorf("line %d does not end with CRLF", lineNum)
+		}
+		line := raw[pos : pos+end]
+		pos += end + 1
+		if len(line) == 0 || line[len(line)-1] != '\r' {
+			return fmt.Errorf("line %d has a bare LF line ending", lineNum)
+		}
+		line = line[:len(line)-1]
+
+		for _, c := range line {
+			switch {
+			case c == '\r':
+				return fmt.Errorf("line %d contains a bare CR", lineNum)
+			case c == 0:
+				return fmt.Errorf("line %d contains a NUL character", lineNum)
+			case c > 127:
+				return fmt.Errorf("line %d contains the non 7-bit byte 0x%X", lineNum, c)
+			}
+		}
+		if len(line) > MaxLineLength {
+			return fmt.Errorf("line %d is %d characters long, maximum is %d", lineNum, len(line), MaxLineLength)
+		}
+
+		if !inHeader {
+			continue
+		}
+		if len(line) == 0 {
+			inHeader = false
+			continue
+		}
+		isContinuation := line[0] == ' ' || line[0] == '\t'
+		value := line
+		if !isContinuation {
+			colon := bytes.IndexByte(line, ':')
+			if colon < 1 || !validHeaderFieldName(line[:colon]) {
+				return fmt.Errorf("line %d is not a valid header field: %q", lineNum, line)
+			}
+			value = line[colon+1:]
+		} else if lineNum == 1 {
+			return fmt.Errorf("message starts with a folded header line")
+		}
+		if len(line) > RecommendedLineLength && bytes.ContainsAny(bytes.TrimLeft(value, " \t"), " \t") {
+			return fmt.Errorf("header line %d is %d characters long and should be folded at %d", lineNum, len(line), RecommendedLineLength)
+		}
+	}
+	if inHeader {
+		return errors.New("message has no empty line after the header")
+	}
+	return nil
+}
+
+func validHeaderFieldName(name []byte) bool {
+	for _, c := range name {
+		if c < 33 || c > 126 || c == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+type strictHeaderField struct {
+	name, value string
+}
+
+type strictHeader []strictHeaderField
+
+// add adds a field with an ASCII value.
+func (h *strictHeader) add(name, value string) {
+	*h = append(*h, strictHeaderField{name: name, value: value})
+}
+
+// addText adds a field with an unstructured text value
+// that is encoded as RFC 2047 encoded-words if not ASCII.
+func (h *strictHeader) addText(name, value string) {
+	h.add(name, encodeHeaderText(value))
+}
+
+// addAddresses adds a field with an address list
+// with any non ASCII display names encoded as RFC 2047 encoded-words.
+func (h *strictHeader) addAddresses(name, list string) error {
+	addrs, err := mail.ParseAddressList(list)
+	if err != nil {
+		return fmt.Errorf("invalid %s header %q: %w", name, list, err)
+	}
+	strs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		// mail.Address.String encodes non ASCII names
+		strs[i] = addr.String()
+	}
+	h.add(name, strings.Join(strs, ", "))
+	return nil
+}
+
+func (h strictHeader) write(buf *bytes.Buffer) {
+	for _, field := range h {
+		writeFoldedHeader(buf, field.name, field.value)
+	}
+}
+
+// writeFoldedHeader writes a header field folded before whitespace
+// so that lines are not longer than RecommendedLineLength
+// if possible, a single word that is longer gets a line of its own.
+// The whitespace of the value is kept unchanged,
+// folding only inserts a line break before it.
+func writeFoldedHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteByte(':')
+	if value == "" || !isHeaderWhitespace(value[0]) {
+		value = " " + value
+	}
+	lineLen := len(name) + 1
+	for value != "" {
+		// Token of whitespace followed by a word
+		end := 0
+		for end < len(value) && isHeaderWhitespace(value[end]) {
+			end++
+		}
+		for end < len(value) && !isHeaderWhitespace(value[end]) {
+			end++
+		}
+		if lineLen > 0 && lineLen+end > RecommendedLineLength {
+			buf.WriteString("\r\n")
+			lineLen = 0
+		}
+		buf.WriteString(value[:end])
+		lineLen += end
+		value = value[end:]
+	}
+	buf.WriteString("\r\n")
+}
+
+func isHeaderWhitespace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// encodeHeaderText returns value unchanged if it is printable ASCII,
+// else as RFC 2047 encoded-words using B encoding for mostly
+// non ASCII text and Q encoding otherwise.
+func encodeHeaderText(value string) string {
+	switch selectStrictEncoding([]byte(value), true) {
+	case "base64":
+		return mime.BEncoding.Encode("utf-8", value)
+	case "quoted-printable":
+		return mime.QEncoding.Encode("utf-8", value)
+	default:
+		return value
+	}
+}
+
+// selectStrictEncoding returns the content transfer encoding
+// for text: "7bit" if it is ASCII with line lengths within RecommendedLineLength,
+// "base64" if more than 20 percent of the bytes are not ASCII,
+// else "quoted-printable".
+// Line breaks count as non ASCII if inHeader is true
+// and the line length is measured between whitespace
+// where the header can be folded.
+func selectStrictEncoding(text []byte, inHeader bool) string {
+	nonASCII, lineLen, longLine := 0, 0, false
+	for _, c := range text {
+		switch {
+		case c == '\n' && !inHeader:
+			lineLen = 0
+			continue
+		case c == '\r' && !inHeader:
+			continue
+		case isHeaderWhitespace(c) && inHeader:
+			lineLen = 0
+		case (c < ' ' && c != '\t') || c > '~':
+			nonASCII++
+		}
+		lineLen++
+		longLine = longLine || lineLen > RecommendedLineLength
+	}
+	switch {
+	case nonASCII > len(text)/5:
+		return "base64"
+	case nonASCII > 0 || longLine:
+		return "quoted-printable"
+	default:
+		return "7bit"
+	}
+}
+
+type strictPart struct {
+	contentType string
+	params      map[string]string
+	disposition string
+	encoding    string
+	content     []byte
+	children    []*strictPart
+}
+
+func newStrictTextPart(contentType, text string) *strictPart {
+	// Canonical text form uses CRLF line endings,
+	// also for base64 and quoted-printable encoding
+	content := []byte(strings.ReplaceAll(strutil.SanitizeLineEndings(text), "\n", "\r\n"))
+	return &strictPart{
+		contentType: contentType,
+		params:      map[string]string{"charset": "utf-8"},
+		encoding:    selectStrictEncoding(content, false),
+		content:     content,
+	}
+}
+
+func newStrictAttachmentPart(att *Attachment) *strictPart {
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", nil
+	}
+	part := &strictPart{
+		contentType: mediaType,
+		params:      params,
+		disposition: "attachment",
+		encoding:    "base64",
+		content:     att.Content,
+	}
+	if att.Inline {
+		part.disposition = "inline"
+	}
+	if att.Filename != "" {
+		if part.params == nil {
+			part.params = make(map[string]string)
+		}
+		part.params["name"] = att.Filename
+	}
+	return part
+}
+
+func (p *strictPart) write(buf *bytes.Buffer, newBoundary func() string) {
+	params := p.params
+	boundary := ""
+	if len(p.children) > 0 {
+		// A boundary starting with "=_" can't occur
+		// in quoted-printable or base64 encoded parts
+		// but has to be checked for 7bit text
+		for boundary == "" || p.boundaryInContent(boundary) {
+			boundary = newBoundary()
+		}
+		params = map[string]string{"boundary": boundary}
+	}
+	writeFoldedHeader(buf, "Content-Type", mime.FormatMediaType(p.contentType, params))
+	if p.disposition != "" {
+		var dispParams map[string]string
+		if name, ok := p.params["name"]; ok {
+			dispParams = map[string]string{"filename": name}
+		}
+		writeFoldedHeader(buf, "Content-Disposition", mime.FormatMediaType(p.disposition, dispParams))
+	}
+	if p.encoding != "" && p.encoding != "7bit" {
+		writeFoldedHeader(buf, "Content-Transfer-Encoding", p.encoding)
+	}
+	buf.WriteString("\r\n")
+
+	if len(p.children) > 0 {
+		for _, child := range p.children {
+			buf.WriteString("--" + boundary + "\r\n")
+			child.write(buf, newBoundary)
+		}
+		buf.WriteString("--" + boundary + "--\r\n")
+		return
+	}
+
+	switch p.encoding {
+	case "base64":
+		encoded := base64.StdEncoding.EncodeToString(p.content)
+		for len(encoded) > 76 {
+			buf.WriteString(encoded[:76])
+			buf.WriteString("\r\n")
+			encoded = encoded[76:]
+		}
+		buf.WriteString(encoded)
+	case "quoted-printable":
+		qp := quotedprintable.NewWriter(buf)
+		qp.Write(p.content) //#nosec G104 -- bytes.Buffer does not return errors
+		qp.Close()          //#nosec G104 -- bytes.Buffer does not return errors
+	default:
+		buf.Write(p.content)
+	}
+	// The CRLF before a boundary delimiter belongs to the delimiter
+	buf.WriteString("\r\n")
+}
+
+func (p *strictPart) boundaryInContent(boundary string) bool {
+	for _, child := range p.children {
+		if (child.encoding == "7bit" && bytes.Contains(child.content, []byte(boundary))) || child.boundaryInContent(boundary) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package email
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/strutil"
+)
+
+func TestMessage_BuildRawMessageStrict(t *testing.T) {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	longSubject := strings.Repeat("Invoice reminder for the delivered goods ", 4) + "ÄÖÜ"
+	longLine := strings.Repeat("0123456789", 120)
+	msg := NewMessage(
+		`"Jürgen Müller" <juergen@example.com>`,
+		"receiver@example.com, Another Receiver <another@example.com>",
+		longSubject,
+		"Line 1\nLine 2\r\n"+longLine+"\n",
+		"",
+	)
+	msg.Date = &date
+	msg.Attachments = append(msg.Attachments, NewAttachment("1", "invoice.pdf", []byte("%PDF-1.4 binary \x00\xff")))
+
+	raw, err := msg.BuildRawMessageStrict()
+	require.NoError(t, err)
+	require.NoError(t, ValidateRawMessage(raw))
+	for _, line := range bytes.Split(raw, []byte("\r\n")) {
+		require.LessOrEqual(t, len(line), RecommendedLineLength, "line %q", line)
+	}
+	require.Contains(t, string(raw), "From: =?utf-8?q?J=C3=BCrgen_M=C3=BCller?= <juergen@example.com>\r\n")
+	require.Contains(t, string(raw), "Content-Transfer-Encoding: quoted-printable\r\n")
+	require.Contains(t, string(raw), "Content-Transfer-Encoding: base64\r\n")
+
+	parsed, err := ParseMessage(raw)
+	require.NoError(t, err)
+	require.Equal(t, longSubject, parsed.Subject)
+	require.Equal(t, "Line 1\nLine 2\n"+longLine+"\n", strutil.SanitizeLineEndings(parsed.Body))
+	require.Len(t, parsed.Attachments, 1)
+	require.Equal(t, "invoice.pdf", parsed.Attachments[0].Filename)
+	require.Equal(t, msg.Attachments[0].Content, parsed.Attachments[0].Content)
+}
+
+func TestMessage_BuildRawMessageStrict_7bit(t *testing.T) {
+	msg := NewMessage("sender@example.com", "receiver@example.com", "Hello", "Hello World\n", "")
+	raw, err := msg.BuildRawMessageStrict()
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "Content-Transfer-Encoding")
+	require.True(t, bytes.HasSuffix(raw, []byte("\r\n\r\nHello World\r\n\r\n")), "raw message: %q", raw)
+
+	msg.From = "invalid"
+	_, err = msg.BuildRawMessageStrict()
+	require.Error(t, err)
+}
+
+func TestWriteFoldedHeader(t *testing.T) {
+	// Long ASCII text is folded and not encoded
+	value := strings.Repeat("Reminder  for\tthe delivered goods ", 4) + "end"
+	require.Equal(t, value, encodeHeaderText(value))
+
+	var buf bytes.Buffer
+	writeFoldedHeader(&buf, "Subject", value)
+	folded := buf.String()
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), RecommendedLineLength, "line %q", line)
+	}
+	// Unfolding restores the unchanged whitespace of the value
+	unfolded := strings.NewReplacer("\r\n ", " ", "\r\n\t", "\t").Replace(folded)
+	require.Equal(t, "Subject: "+value+"\r\n", unfolded)
+	require.Greater(t, strings.Count(folded, "\r\n"), 1, "folded")
+}
+
+func TestMessage_BuildRawMessageDeterministic(t *testing.T) {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := NewMessage("sender@example.com", "receiver@example.com", "Hello", "Hello World\n", "<p>Hello World</p>")
+	msg.Date = &date
+	msg.ExtraHeader = Header{"X-B": {"b"}, "X-A": {"a"}}
+	msg.Attachments = append(msg.Attachments, NewAttachment("1", "invoice.pdf", []byte("%PDF-1.4")))
+
+	raw, err := msg.BuildRawMessageDeterministic("seed")
+	require.NoError(t, err)
+	again, err := msg.BuildRawMessageDeterministic("seed")
+	require.NoError(t, err)
+	require.Equal(t, string(raw), string(again))
+	const golden = "Date: Fri, 01 Mar 2024 12:00:00 +0000\r\n" +
+		"From: <sender@example.com>\r\n" +
+		"To: <receiver@example.com>\r\n" +
+		"Subject: Hello\r\n" +
+		"X-A: a\r\n" +
+		"X-B: b\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=\"=_f98f786772d042a7c4947a202de839a9\"\r\n" +
+		"\r\n" +
+		"--=_f98f786772d042a7c4947a202de839a9\r\n" +
+		"Content-Type: multipart/alternative;\r\n" +
+		" boundary=\"=_de5b0d30cef4b67afba88699ff8872a0\"\r\n" +
+		"\r\n" +
+		"--=_de5b0d30cef4b67afba88699ff8872a0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"Hello World\r\n" +
+		"\r\n" +
+		"--=_de5b0d30cef4b67afba88699ff8872a0\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<p>Hello World</p>\r\n" +
+		"--=_de5b0d30cef4b67afba88699ff8872a0--\r\n" +
+		"--=_f98f786772d042a7c4947a202de839a9\r\n" +
+		"Content-Type: application/pdf; name=invoice.pdf\r\n" +
+		"Content-Disposition: attachment; filename=invoice.pdf\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"JVBERi0xLjQ=\r\n" +
+		"--=_f98f786772d042a7c4947a202de839a9--\r\n"
+	require.Equal(t, golden, string(raw))
+
+	other, err := msg.BuildRawMessageDeterministic("other seed")
+	require.NoError(t, err)
+	require.NotEqual(t, string(raw), string(other))
+
+	parsed, err := ParseMessage(raw)
+	require.NoError(t, err)
+	require.Equal(t, "Hello World\n", strutil.SanitizeLineEndings(parsed.Body))
+	require.Len(t, parsed.Attachments, 1)
+
+	msg.Date = nil
+	_, err = msg.BuildRawMessageDeterministic("seed")
+	require.Error(t, err, "missing Date")
+}
+
+func TestValidateRawMessage(t *testing.T) {
+	valid := "From: sender@example.com\r\nSubject: Hello\r\n World\r\n\r\nBody\r\n"
+	require.NoError(t, ValidateRawMessage([]byte(valid)))
+	// An unbreakable word may exceed the recommended length
+	require.NoError(t, ValidateRawMessage([]byte("X-Token: "+strings.Repeat("a", 100)+"\r\n\r\n")))
+
+	for name, raw := range map[string]string{
+		"bare LF":            "From: sender@example.com\nSubject: Hello\r\n\r\nBody\r\n",
+		"bare CR":            "From: sender@example.com\r\n\r\nBo\rdy\r\n",
+		"missing CRLF":       "From: sender@example.com\r\n\r\nBody",
+		"8-bit":              "From: sender@example.com\r\n\r\nGrüße\r\n",
+		"NUL":                "From: sender@example.com\r\n\r\nBody\x00\r\n",
+		"long line":          "From: sender@example.com\r\n\r\n" + strings.Repeat("a", MaxLineLength+1) + "\r\n",
+		"unfolded header":    "Subject: " + strings.Repeat("word ", 20) + "\r\n\r\n",
+		"invalid field name": "Invalid Name: value\r\n\r\n",
+		"leading fold":       " Subject: Hello\r\n\r\n",
+		"no header end":      "From: sender@example.com\r\n",
+	} {
+		require.Error(t, ValidateRawMessage([]byte(raw)), name)
+	}
+}
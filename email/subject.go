@@ -0,0 +1,143 @@
+package email
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// subjectReplyPrefixes are the lower case reply and forward
+// prefixes of common mail clients in different languages.
+var subjectReplyPrefixes = map[string]struct{}{
+	// Reply
+	"re":      {}, // English, Latin
+	"aw":      {}, // German "Antwort"
+	"antw":    {}, // Dutch, German
+	"antwort": {}, // German
+	"sv":      {}, // Scandinavian "Svar"
+	"vs":      {}, // Finnish "Vastaus"
+	"odp":     {}, // Polish "Odpowiedź"
+	"rif":     {}, // Italian "Riferimento"
+	"res":     {}, // Portuguese "Resposta"
+	"ynt":     {}, // Turkish "Yanıt"
+	"atb":     {}, // Latvian "Atbilde"
+	"vá":      {}, // Hungarian "Válasz"
+	"отв":     {}, // Russian "Ответ"
+	"ответ":   {}, // Russian
+	"回复":      {}, // Chinese
+	"答复":      {}, // Chinese
+	// Forward
+	"fwd":        {}, // English
+	"fw":         {}, // English
+	"wg":         {}, // German "Weitergeleitet"
+	"tr":         {}, // French "Transféré"
+	"enc":        {}, // Portuguese "Encaminhado"
+	"rv":         {}, // Spanish "Reenviado"
+	"doorst":     {}, // Dutch "Doorsturen"
+	"vb":         {}, // Swedish "Vidarebefordrat"
+	"vl":         {}, // Finnish "Välitetty"
+	"pd":         {}, // Polish "Przekaż dalej"
+	"továbbítás": {}, // Hungarian
+	"пересл":     {}, // Russian "Переслать"
+	"转发":         {}, // Chinese
+}
+
+// NormalizeSubject returns the subject without chains of reply
+// and forward prefixes like "Re:", "Fwd:", "AW:", or "WG:"
+// in multiple languages, also with counters like "Re[2]:" or "RE :".
+// Bracketed tags like "[EXT]" or "[Ticket#1234]" are removed from the
+// beginning, between the prefixes, and from the end of the subject
+// and returned without brackets and duplicates in the order of appearance.
+// Whitespace is trimmed and each sequence of whitespace
+// is replaced with a single space.
+//
+// The clean subject can be used to find the thread of a message
+// and the tags to route it.
+func NormalizeSubject(subject string) (clean string, tags []string) {
+	s := strings.Join(strings.Fields(subject), " ")
+	addTag := func(tag string) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	// Remove leading tags and prefixes
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if strings.HasPrefix(s, "[") {
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				break
+			}
+			addTag(s[1:end])
+			s = s[end+1:]
+			continue
+		}
+		rest, ok := trimSubjectReplyPrefix(s)
+		if !ok {
+			break
+		}
+		s = rest
+	}
+
+	// Remove trailing tags
+	var trailing []string
+	for {
+		s = strings.TrimRightFunc(s, unicode.IsSpace)
+		if !strings.HasSuffix(s, "]") {
+			break
+		}
+		start := strings.LastIndexByte(s, '[')
+		if start == -1 {
+			break
+		}
+		trailing = append(trailing, s[start+1:len(s)-1])
+		s = s[:start]
+	}
+	for _, tag := range slices.Backward(trailing) {
+		addTag(tag)
+	}
+
+	return s, tags
+}
+
+// trimSubjectReplyPrefix returns s without a leading
+// reply or forward prefix and true if s started with one.
+func trimSubjectReplyPrefix(s string) (rest string, ok bool) {
+	i := 0
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) {
+			break
+		}
+		i += size
+	}
+	if _, isPrefix := subjectReplyPrefixes[strings.ToLower(s[:i])]; !isPrefix {
+		return s, false
+	}
+	rest = s[i:]
+	// Counter like "Re[2]:" or "Re(2):"
+	if len(rest) > 0 && (rest[0] == '[' || rest[0] == '(') {
+		closing := byte(']')
+		if rest[0] == '(' {
+			closing = ')'
+		}
+		end := strings.IndexByte(rest, closing)
+		if end < 2 || strings.TrimFunc(rest[1:end], unicode.IsDigit) != "" {
+			return s, false
+		}
+		rest = rest[end+1:]
+	}
+	// French style "RE :"
+	rest = strings.TrimPrefix(rest, " ")
+	switch {
+	case strings.HasPrefix(rest, ":"):
+		return rest[1:], true
+	case strings.HasPrefix(rest, "："): // Full width colon
+		return rest[len("："):], true
+	default:
+		return s, false
+	}
+}
@@ -0,0 +1,47 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		clean   string
+		tags    []string
+	}{
+		{subject: "", clean: ""},
+		{subject: "Invoice 2024-001", clean: "Invoice 2024-001"},
+		{subject: "  Re:  Invoice \t 2024-001 ", clean: "Invoice 2024-001"},
+		{subject: "RE: Fwd: AW: WG: Invoice", clean: "Invoice"},
+		{subject: "Re:Re:Invoice", clean: "Invoice"},
+		{subject: "Re[2]: Invoice", clean: "Invoice"},
+		{subject: "RE(3): Invoice", clean: "Invoice"},
+		{subject: "RE : Facture", clean: "Facture"},
+		{subject: "TR: Réf : Facture", clean: "Réf : Facture"},
+		{subject: "SV: VS: Odp: Rif: Faktura", clean: "Faktura"},
+		{subject: "回复：发票", clean: "发票"},
+		{subject: "Ответ: Re: Счёт", clean: "Счёт"},
+		{subject: "Reply: Invoice", clean: "Reply: Invoice"},
+		{subject: "Re[x]: Invoice", clean: "Re[x]: Invoice"},
+		{subject: "Rechnung: Re: Invoice", clean: "Rechnung: Re: Invoice"},
+		{
+			subject: "[EXT] Re: [Ticket#1234] AW: Login not working [Ticket#1234] [prio:high]",
+			clean:   "Login not working",
+			tags:    []string{"EXT", "Ticket#1234", "prio:high"},
+		},
+		{subject: "Price list [net] 2024", clean: "Price list [net] 2024"},
+		{subject: "[]  [ list ] Hello", clean: "Hello", tags: []string{"list"}},
+		{subject: "[unclosed Hello", clean: "[unclosed Hello"},
+		{subject: "[only-tag]", clean: "", tags: []string{"only-tag"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			clean, tags := NormalizeSubject(tt.subject)
+			require.Equal(t, tt.clean, clean)
+			require.Equal(t, tt.tags, tags)
+		})
+	}
+}
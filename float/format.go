@@ -1,10 +1,11 @@
 package float
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"strconv"
-	"strings"
+	"unicode/utf8"
 )
 
 // Format a float similar to strconv.Format with the 'f' format option,
@@ -23,6 +24,14 @@ import (
 // '0' characters to reach the length of precision.
 // See: https://en.wikipedia.org/wiki/Decimal_separator
 func Format[T ~float32 | ~float64](f T, thousandsSep, decimalSep rune, precision int, padPrecision bool) string {
+	var buf [64]byte
+	return string(AppendFormat(buf[:0], f, thousandsSep, decimalSep, precision, padPrecision))
+}
+
+// AppendFormat appends the float formatted like Format to dst
+// and returns the extended buffer.
+// It doesn't allocate memory if dst has enough capacity.
+func AppendFormat[T ~float32 | ~float64](dst []byte, f T, thousandsSep, decimalSep rune, precision int, padPrecision bool) []byte {
 	if thousandsSep != 0 && thousandsSep != '.' && thousandsSep != ',' && thousandsSep != ' ' && thousandsSep != '\'' {
 		panic(fmt.Errorf("invalid thousandsSep: '%s'", string(thousandsSep)))
 	}
@@ -36,12 +45,14 @@ func Format[T ~float32 | ~float64](f T, thousandsSep, decimalSep rune, precision
 		panic(fmt.Errorf("precision < -1: %d", precision))
 	}
 
-	str := strconv.FormatFloat(float64(f), 'f', precision, 64)
+	var buf [64]byte
+	num := strconv.AppendFloat(buf[:0], float64(f), 'f', precision, 64)
+	pointPos := bytes.IndexByte(num, '.')
+	if pointPos == -1 {
+		pointPos = len(num)
+	}
+
 	if thousandsSep != 0 && math.Abs(float64(f)) >= 1000 {
-		pointPos := strings.IndexByte(str, '.')
-		if pointPos == -1 {
-			pointPos = len(str)
-		}
 		prefixLen := 0
 		if f < 0 {
 			prefixLen = 1
@@ -55,74 +66,30 @@ func Format[T ~float32 | ~float64](f T, thousandsSep, decimalSep rune, precision
 		}
 		numGroupSeps := (integerLen - 1) / 3
 
-		b := strings.Builder{}
-		b.Grow(len(str) + numGroupSeps)
-
-		b.WriteString(str[:firstGroupLen])
+		dst = append(dst, num[:firstGroupLen]...)
 		for i := 0; i < numGroupSeps; i++ {
-			b.WriteRune(thousandsSep)
+			dst = utf8.AppendRune(dst, thousandsSep)
 			start := firstGroupLen + i*3
-			b.WriteString(str[start : start+3])
-		}
-
-		if pointPos != len(str) {
-			b.WriteRune(decimalSep)
-			fraction := str[pointPos+1:]
-			b.WriteString(fraction)
-			if padPrecision {
-				for i := len(fraction); i < precision; i++ {
-					b.WriteByte('0')
-				}
-			}
-		} else if padPrecision && precision > 0 {
-			b.WriteRune(decimalSep)
-			for i := 0; i < precision; i++ {
-				b.WriteByte('0')
-			}
+			dst = append(dst, num[start:start+3]...)
 		}
-
-		return b.String()
+	} else {
+		dst = append(dst, num[:pointPos]...)
 	}
 
-	if decimalSep != '.' {
-		for i, c := range str {
-			if c == '.' {
-				// TODO optimize
-				fraction := str[i+1:]
-				if padPrecision {
-					for i := len(fraction); i < precision; i++ {
-						fraction += "0"
-					}
-				}
-				return str[:i] + string(decimalSep) + fraction
+	if pointPos != len(num) {
+		dst = utf8.AppendRune(dst, decimalSep)
+		fraction := num[pointPos+1:]
+		dst = append(dst, fraction...)
+		if padPrecision {
+			for i := len(fraction); i < precision; i++ {
+				dst = append(dst, '0')
 			}
 		}
-	}
-
-	if padPrecision && precision > 0 {
-		pointPos := strings.IndexByte(str, '.')
-		if pointPos == -1 {
-			var b strings.Builder
-			b.Grow(len(str) + 1 + precision)
-			b.WriteString(str)
-			b.WriteByte('.')
-			for i := 0; i < precision; i++ {
-				b.WriteByte('0')
-			}
-			return b.String()
-		}
-
-		numMissingZeros := precision - (len(str) - (pointPos + 1))
-		if numMissingZeros > 0 {
-			var b strings.Builder
-			b.Grow(len(str) + numMissingZeros)
-			b.WriteString(str)
-			for i := 0; i < numMissingZeros; i++ {
-				b.WriteByte('0')
-			}
-			return b.String()
+	} else if padPrecision && precision > 0 {
+		dst = utf8.AppendRune(dst, decimalSep)
+		for i := 0; i < precision; i++ {
+			dst = append(dst, '0')
 		}
 	}
-
-	return str
+	return dst
 }
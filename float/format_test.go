@@ -151,3 +151,31 @@ func Test_FormatFloat_invalid(t *testing.T) {
 		})
 	}
 }
+
+func Test_AppendFormat(t *testing.T) {
+	buf := []byte("prefix:")
+	buf = AppendFormat(buf, 1234567.891, '.', ',', 2, true)
+	assert.Equal(t, "prefix:1.234.567,89", string(buf))
+
+	buf = make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendFormat(buf[:0], -1234567.891, ',', '.', 2, true)
+	})
+	assert.Zero(t, allocs)
+	assert.Equal(t, "-1,234,567.89", string(buf))
+}
+
+func BenchmarkFormat(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = Format(1234567.891, '.', ',', 2, true)
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendFormat(buf[:0], 1234567.891, '.', ',', 2, true)
+	}
+}
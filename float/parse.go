@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
-	"strings"
+	"unicode/utf8"
 
 	"github.com/domonda/go-types/strutil"
 )
@@ -22,6 +22,10 @@ func Parse(str string) (float64, error) {
 // and returns the detected integer thousands separator and decimal separator characters.
 // If a separator was not detected, then zero will be returned for thousandsSep or decimalSep.
 // See: https://en.wikipedia.org/wiki/Decimal_separator
+//
+// The string is parsed in a single pass into a stack buffer
+// so that parsing doesn't allocate memory for strings
+// shorter than 32 bytes unless an error is returned.
 func ParseDetails(str string) (f float64, thousandsSep, decimalSep rune, decimals int, err error) {
 	str = strutil.TrimSpace(str)
 
@@ -36,169 +40,121 @@ func ParseDetails(str string) (f float64, thousandsSep, decimalSep rune, decimal
 		return math.Inf(-1), 0, 0, 0, nil
 	}
 
+	// The sign is allowed as first or last character
 	var (
-		lastDigitIndex    = -1
-		lastNonDigitIndex = -1
+		trimmed  = str
+		numMinus int
+	)
+	if c := trimmed[0]; c == '-' || c == '+' {
+		if c == '-' {
+			numMinus++
+		}
+		trimmed = trimmed[1:]
+	}
+	if n := len(trimmed); n > 0 && (trimmed[n-1] == '-' || trimmed[n-1] == '+') {
+		if trimmed[n-1] == '-' {
+			numMinus++
+		}
+		trimmed = trimmed[:n-1]
+	}
+	if numMinus > 1 {
+		return 0, 0, 0, 0, fmt.Errorf("minus can only be used as first or last character: %q", str)
+	}
+	// Trim space in case the removal of the sign left one
+	trimmed = strutil.TrimSpace(trimmed)
+
+	var (
+		buf [64]byte
+		b   = buf[:0] // normalized float string for strconv.ParseFloat
 
-		pointWritten = false
-		eIndex       = -1
+		lastDigitIndex = -1
+		eIndex         = -1 // index of 'e' in trimmed
+		ePos           = -1 // position of 'e' in b
+		pointPos       = -1 // position of '.' in b
 
-		numMinus          int
 		numGroupingRunes  int
 		lastGroupingRune  rune
-		lastGroupingIndex int
-
-		skipFirst int // skip first bytes of str
-		skipLast  int // skip last bytes of str
-
-		floatBuilder strings.Builder
+		lastGroupingIndex int // index of the last grouping rune in trimmed
+		lastGroupingPos   int // position in b where the last grouping rune was skipped
 	)
-
-	floatBuilder.Grow(len(str))
-
-	// detect the sign, allowed positions are start and end
-	for i, r := range str {
-		switch {
-		case r == 'e', r == 'E':
-			eIndex = i
-
-		case r == '-':
-			switch {
-			case i == 0:
-				skipFirst = 1
-			case i == len(str)-1:
-				skipLast = 1
-			case i == eIndex+1:
-				continue
-			default:
-				return 0, 0, 0, 0, fmt.Errorf("minus can only be used as first or last character: %q", str)
-			}
-			floatBuilder.WriteByte(byte(r))
-			numMinus = 1
-
-		case r == '+':
-			switch {
-			case i == 0:
-				skipFirst = 1
-			case i == len(str)-1:
-				skipLast = 1
-			case i == eIndex+1:
-				continue
-			default:
-				return 0, 0, 0, 0, fmt.Errorf("plus can only be used as first or last character: %q", str)
-			}
-		}
+	if numMinus > 0 {
+		b = append(b, '-')
 	}
 
-	eIndex = -1
-
-	// remove the sign from the string and trim space in case the removal left one
-	trimmedSignsStr := strutil.TrimSpace(str[skipFirst : len(str)-skipLast])
-	for i, r := range trimmedSignsStr {
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
 		switch {
-		case r >= '0' && r <= '9':
+		case c >= '0' && c <= '9':
+			b = append(b, c)
 			lastDigitIndex = i
 
-		case r == '.' || r == ',' || r == '\'':
-			if pointWritten {
+		case c == '.' || c == ',' || c == '\'' || c == ' ':
+			if pointPos != -1 {
 				return 0, 0, 0, 0, fmt.Errorf("no further separators allowed after decimal separator: %q", str)
 			}
-
-			// Write everything after the lastNonDigitIndex and before current index
-			floatBuilder.WriteString(trimmedSignsStr[lastNonDigitIndex+1 : i])
-
 			if numGroupingRunes == 0 {
 				// This is the first grouping rune, just save it
 				numGroupingRunes = 1
-				lastGroupingRune = r
+				lastGroupingRune = rune(c)
 				lastGroupingIndex = i
-			} else {
-				// It's a further grouping rune, has to be 3 bytes since last grouping rune
-				if i-(lastGroupingIndex+1) != 3 {
-					return 0, 0, 0, 0, fmt.Errorf("thousands separators have to be 3 characters apart: %q", str)
-				}
-				numGroupingRunes++
-				if r == lastGroupingRune {
-					if numGroupingRunes == 2 {
-						if floatBuilder.Len()-numMinus > 6 {
-							return 0, 0, 0, 0, fmt.Errorf("thousands separators have to be 3 characters apart: %q", str)
-						}
-					}
-					// If it's the same grouping rune, then just save it
-					lastGroupingRune = r
-					lastGroupingIndex = i
-				} else {
-					// If it's a different grouping rune, then we have
-					// reached the decimal separator
-					floatBuilder.WriteByte('.')
-					pointWritten = true
-					thousandsSep = lastGroupingRune
-					decimalSep = r
-				}
+				lastGroupingPos = len(b)
+				continue
 			}
-			lastNonDigitIndex = i
-
-		case r == ' ':
-			if pointWritten {
-				return 0, 0, 0, 0, fmt.Errorf("no further separators allowed after decimal separator: %q", str)
+			// It's a further grouping rune, has to be 3 bytes since last grouping rune
+			if i-(lastGroupingIndex+1) != 3 {
+				return 0, 0, 0, 0, fmt.Errorf("thousands separators have to be 3 characters apart: %q", str)
 			}
-
-			// Write everything after the lastNonDigitIndex and before current index
-			floatBuilder.WriteString(trimmedSignsStr[lastNonDigitIndex+1 : i])
-
-			if numGroupingRunes == 0 {
-
-				// This is the first grouping rune, just save it
-				numGroupingRunes = 1
-				lastGroupingRune = r
-				lastGroupingIndex = i
-			} else {
-				// It's a further grouping rune, has to be 3 bytes since last grouping rune
-				if i-(lastGroupingIndex+1) != 3 {
+			numGroupingRunes++
+			switch {
+			case rune(c) == lastGroupingRune:
+				if numGroupingRunes == 2 && len(b)-numMinus > 6 {
 					return 0, 0, 0, 0, fmt.Errorf("thousands separators have to be 3 characters apart: %q", str)
 				}
-
-				numGroupingRunes++
-				if r == lastGroupingRune {
-					if numGroupingRunes == 2 {
-						if floatBuilder.Len()-numMinus > 6 {
-							return 0, 0, 0, 0, fmt.Errorf("thousands separators have to be 3 characters apart: %q", str)
-						}
-					}
-					// If it's the same grouping rune, then just save it
-					lastGroupingRune = r
-					lastGroupingIndex = i
-				} else {
-					// Spaces only are used as thousands separators.
-					// If the the last separator was not a space, something is wrong
-					return 0, 0, 0, 0, fmt.Errorf("space can not be used after another thousands separator: %q", str)
-				}
+				// If it's the same grouping rune, then just save it
+				lastGroupingIndex = i
+				lastGroupingPos = len(b)
+			case c == ' ':
+				// Spaces only are used as thousands separators.
+				// If the the last separator was not a space, something is wrong
+				return 0, 0, 0, 0, fmt.Errorf("space can not be used after another thousands separator: %q", str)
+			default:
+				// If it's a different grouping rune, then we have
+				// reached the decimal separator
+				pointPos = len(b)
+				b = append(b, '.')
+				thousandsSep = lastGroupingRune
+				decimalSep = rune(c)
 			}
-			lastNonDigitIndex = i
 
-		case r == 'e', r == 'E':
+		case c == 'e' || c == 'E':
 			if i == 0 || eIndex != -1 {
 				return 0, 0, 0, 0, fmt.Errorf("e can't be the first or a repeating character: %q", str)
 			}
-			if numGroupingRunes > 0 && !pointWritten {
-				floatBuilder.WriteByte('.')
-				pointWritten = true
+			if numGroupingRunes > 0 && pointPos == -1 {
+				b = insertPoint(b, lastGroupingPos)
+				pointPos = lastGroupingPos
 				decimalSep = '.'
 			}
-			floatBuilder.WriteString(trimmedSignsStr[lastNonDigitIndex+1 : i+1]) // i+1 to write including the 'e'
-			lastNonDigitIndex = i
+			ePos = len(b)
+			b = append(b, c)
 			eIndex = i
 
-		case (r == '-' || r == '+') && i == eIndex+1:
-			floatBuilder.WriteRune(r)
-			lastNonDigitIndex = i
+		case (c == '-' || c == '+') && eIndex != -1 && i == eIndex+1:
+			b = append(b, c)
+
+		case c == '-':
+			return 0, 0, 0, 0, fmt.Errorf("minus can only be used as first or last character: %q", str)
+
+		case c == '+':
+			return 0, 0, 0, 0, fmt.Errorf("plus can only be used as first or last character: %q", str)
 
 		default:
+			r, _ := utf8.DecodeRuneInString(trimmed[i:])
 			return 0, 0, 0, 0, fmt.Errorf("invalid rune '%s' in %q", string(r), str)
 		}
 	}
 
-	if numGroupingRunes > 0 && !pointWritten {
+	if numGroupingRunes > 0 && pointPos == -1 {
 		if numGroupingRunes > 1 {
 			// If more than one grouping rune has been written, but no point
 			// then it was pure integer grouping, so the last there
@@ -208,29 +164,30 @@ func ParseDetails(str string) (f float64, thousandsSep, decimalSep rune, decimal
 			}
 			thousandsSep = lastGroupingRune
 		} else {
-			floatBuilder.WriteByte('.')
-			pointWritten = true
+			b = insertPoint(b, lastGroupingPos)
+			pointPos = lastGroupingPos
 			decimalSep = lastGroupingRune
 		}
 	}
-	if lastDigitIndex >= lastNonDigitIndex {
-		floatBuilder.WriteString(trimmedSignsStr[lastNonDigitIndex+1 : lastDigitIndex+1])
-	}
 
-	floatStr := floatBuilder.String()
-	f, err = strconv.ParseFloat(floatStr, 64)
+	f, err = strconv.ParseFloat(string(b), 64)
 	if err != nil {
 		return 0, 0, 0, 0, err
 	}
-	pointPos := strings.IndexByte(floatStr, '.')
 	if pointPos != -1 {
-		if eIndex != -1 {
-			ePos := strings.LastIndexAny(floatStr, "eE")
+		if ePos != -1 {
 			decimals = ePos - (pointPos + 1)
 		} else {
-			decimals = len(floatStr) - (pointPos + 1)
+			decimals = len(b) - (pointPos + 1)
 		}
-
 	}
 	return f, thousandsSep, decimalSep, decimals, nil
 }
+
+// insertPoint inserts a '.' at pos into b
+func insertPoint(b []byte, pos int) []byte {
+	b = append(b, 0)
+	copy(b[pos+1:], b[pos:])
+	b[pos] = '.'
+	return b
+}
@@ -133,3 +133,19 @@ func Test_ParseFloat_invalid(t *testing.T) {
 		assert.Error(t, err, "ParseFloat(%#v)", s)
 	}
 }
+
+func Test_ParseDetailsAllocs(t *testing.T) {
+	for _, str := range []string{"1.234.567,89", "-1,234,567.89", "1 234,5 ", "12.5e-3", "158,00-"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _, _, _, _ = ParseDetails(str)
+		})
+		assert.Zero(t, allocs, str)
+	}
+}
+
+func BenchmarkParseDetails(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _, _, _, _ = ParseDetails("-1.234.567,89")
+	}
+}
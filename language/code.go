@@ -7,6 +7,7 @@
 // - Database integration (Scanner/Valuer interfaces)
 // - JSON marshalling/unmarshalling
 // - Support for common language codes and names
+// - Simple text language detection
 package language
 
 import (
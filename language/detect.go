@@ -0,0 +1,124 @@
+package language
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Detection is a detected language with a confidence
+// in the range 0 to 1.
+type Detection struct {
+	Code       Code    `json:"code"`
+	Confidence float64 `json:"confidence"`
+}
+
+// detectionStopwords maps frequent short words that rarely
+// appear in other languages to the languages using them.
+var detectionStopwords = func() map[string][]Code {
+	words := map[Code]string{
+		EN: "the and of to is in that it for with you this are was have be not on your we at from by as or will can our please thank regards",
+		DE: "der die das und ist nicht ich sie es mit sich des auf für ein eine dem den zu von wir ihr bitte danke grüße freundlichen sehr auch noch wie oder",
+		FR: "le la les de et est des une un du pour pas que qui dans sur vous nous avec ce sont merci cordialement bonjour au aux ne",
+		ES: "el los las de y es del una por para con que se su no al como más pero gracias saludos hola está muy",
+		IT: "il gli e è della di che per non una sono con al nel si grazie saluti cordiali buongiorno questo anche",
+		NL: "de het een en is van dat niet zijn op te met voor ik je wij u bedankt groeten ook naar maar",
+		PT: "o os as de e é do da dos das um uma para com não que em por obrigado cumprimentos olá mais você",
+		PL: "w nie się na jest że do to z jak dla dziękuję pozdrawiam proszę oraz tak",
+		SV: "och att det som är en på för med inte jag av till har tack hälsningar vi ni",
+		DA: "og at det er en på for med ikke jeg af til har tak hilsen vi jer",
+		CS: "a je se na že v to s do jak pro děkuji pozdravem není jsem",
+	}
+	m := make(map[string][]Code)
+	for code, list := range words {
+		for _, word := range strings.Fields(list) {
+			m[word] = append(m[word], code)
+		}
+	}
+	return m
+}()
+
+// DetectText detects the languages of a text
+// and returns them ranked by descending confidence.
+// The confidences of all returned languages add up to 1.
+// Returns nil if no language could be detected.
+//
+// Latin script languages are detected by counting
+// frequent words, other languages by their script,
+// so the detection works best for longer texts
+// and supports only a limited set of common languages.
+func DetectText(text string) []Detection {
+	var (
+		scores  = make(map[Code]float64)
+		han     float64
+		hasKana bool
+	)
+	for word := range strings.FieldsFuncSeq(text, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		for _, code := range detectionStopwords[strings.ToLower(word)] {
+			scores[code]++
+		}
+		first, _ := utf8.DecodeRuneInString(word)
+		switch {
+		case first < 0x0370:
+			// Latin script
+		case unicode.Is(unicode.Greek, first):
+			scores[EL]++
+		case unicode.Is(unicode.Cyrillic, first):
+			scores[RU]++
+		case unicode.Is(unicode.Hangul, first):
+			scores[KO]++
+		default:
+			// Scripts without spaces between words,
+			// count every character as half a word
+			for _, r := range word {
+				switch {
+				case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+					hasKana = true
+					scores[JA] += 0.5
+				case unicode.Is(unicode.Han, r):
+					han += 0.5
+				}
+			}
+		}
+	}
+	// Japanese uses Han characters together with Kana
+	if hasKana {
+		scores[JA] += han
+	} else {
+		scores[ZH] += han
+	}
+	return RankDetections(scores)
+}
+
+// RankDetections returns the scores of languages as Detection slice
+// with the confidence of each language being its fraction
+// of the sum of all scores, sorted by descending confidence
+// and code for equal confidences.
+// Scores of zero or less are ignored.
+// Returns nil if there are no positive scores.
+func RankDetections(scores map[Code]float64) []Detection {
+	var total float64
+	for _, score := range scores {
+		if score > 0 {
+			total += score
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	detections := make([]Detection, 0, len(scores))
+	for code, score := range scores {
+		if score > 0 {
+			detections = append(detections, Detection{Code: code, Confidence: score / total})
+		}
+	}
+	slices.SortFunc(detections, func(a, b Detection) int {
+		if c := cmp.Compare(b.Confidence, a.Confidence); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return detections
+}
@@ -0,0 +1,52 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectText(t *testing.T) {
+	tests := []struct {
+		text string
+		want Code
+	}{
+		{text: "Thank you for the invoice, we will pay it with the next run.", want: EN},
+		{text: "Sehr geehrte Damen und Herren, anbei die Rechnung für den Monat März. Mit freundlichen Grüßen", want: DE},
+		{text: "Bonjour, veuillez trouver ci-joint la facture pour le mois de mars. Cordialement", want: FR},
+		{text: "Hola, adjunto la factura del mes de marzo para su revisión. Saludos", want: ES},
+		{text: "Buongiorno, in allegato la fattura per il mese di marzo. Cordiali saluti", want: IT},
+		{text: "Goedemorgen, in de bijlage vindt u de factuur van maart. Met vriendelijke groeten", want: NL},
+		{text: "Здравствуйте, счёт во вложении", want: RU},
+		{text: "Καλημέρα, επισυνάπτεται το τιμολόγιο", want: EL},
+		{text: "請查收附件中的發票", want: ZH},
+		{text: "請求書を添付いたします", want: JA},
+		{text: "청구서를 첨부합니다", want: KO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			detections := DetectText(tt.text)
+			require.NotEmpty(t, detections)
+			assert.Equal(t, tt.want, detections[0].Code)
+
+			var sum float64
+			for _, d := range detections {
+				sum += d.Confidence
+			}
+			assert.InDelta(t, 1, sum, 1e-9)
+		})
+	}
+
+	assert.Nil(t, DetectText(""))
+	assert.Nil(t, DetectText("12345 !!!"))
+}
+
+func TestRankDetections(t *testing.T) {
+	assert.Nil(t, RankDetections(nil))
+	assert.Nil(t, RankDetections(map[Code]float64{DE: 0}))
+	assert.Equal(t,
+		[]Detection{{DE, 0.5}, {EN, 0.25}, {FR, 0.25}},
+		RankDetections(map[Code]float64{FR: 1, DE: 2, EN: 1, IT: -1}),
+	)
+}
@@ -0,0 +1,132 @@
+package language
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-types"
+)
+
+// CodeSet is a set of language codes.
+// See types.Set.
+type CodeSet = types.Set[Code]
+
+// NewCodeSet returns a CodeSet with the normalized
+// language codes or an error if a code is not valid.
+func NewCodeSet(codes ...Code) (CodeSet, error) {
+	set := make(CodeSet, len(codes))
+	for _, code := range codes {
+		norm, err := code.Normalized()
+		if err != nil {
+			return nil, err
+		}
+		set.Add(norm)
+	}
+	return set, nil
+}
+
+// AcceptedLanguage is a language range
+// of an HTTP Accept-Language header.
+type AcceptedLanguage struct {
+	// Code is the normalized primary language
+	// of the Tag or Null for the wildcard "*".
+	Code Code `json:"code"`
+	// Tag is the language range as found in the header like "de-AT".
+	Tag string `json:"tag"`
+	// Quality is the q value in the range 0 to 1
+	// where 0 means not acceptable.
+	Quality float64 `json:"quality"`
+}
+
+// IsWildcard returns if the language range is "*".
+func (a AcceptedLanguage) IsWildcard() bool {
+	return a.Tag == "*"
+}
+
+// ParseAcceptLanguage parses the value of an HTTP Accept-Language header
+// like "de-AT,de;q=0.9,en;q=0.8,*;q=0.5" and returns the language ranges
+// sorted by descending quality keeping the header order for equal qualities.
+//
+// Ranges with an invalid primary language or q value are skipped,
+// so the header of any client can be passed without error handling.
+func ParseAcceptLanguage(header string) []AcceptedLanguage {
+	var accepted []AcceptedLanguage
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		lang := AcceptedLanguage{Tag: tag, Quality: 1}
+		if tag != "*" {
+			primary, _, _ := strings.Cut(tag, "-")
+			code, err := Code(primary).Normalized()
+			if err != nil {
+				continue
+			}
+			lang.Code = code
+		}
+		valid := true
+		for param := range strings.SplitSeq(params, ";") {
+			name, value, _ := strings.Cut(param, "=")
+			if !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			lang.Quality = q
+		}
+		if valid {
+			accepted = append(accepted, lang)
+		}
+	}
+	slices.SortStableFunc(accepted, func(a, b AcceptedLanguage) int {
+		switch {
+		case a.Quality > b.Quality:
+			return -1
+		case a.Quality < b.Quality:
+			return 1
+		}
+		return 0
+	})
+	return accepted
+}
+
+// Negotiate returns the supported language with the highest
+// quality of the accepted languages as returned by ParseAcceptLanguage.
+// Languages with a quality of zero are never returned,
+// also not if they would match a wildcard "*" range.
+// A wildcard matches the first of the sorted supported languages
+// that is not explicitly listed in accepted.
+// Returns Null if none of the supported languages is acceptable.
+func Negotiate(supported CodeSet, accepted []AcceptedLanguage) Code {
+	var (
+		best        = Null
+		bestQuality float64
+		listed      = make(CodeSet, len(accepted))
+	)
+	for _, lang := range accepted {
+		if lang.IsWildcard() || listed.Contains(lang.Code) {
+			continue
+		}
+		listed.Add(lang.Code)
+		if lang.Quality > bestQuality && supported.Contains(lang.Code) {
+			best, bestQuality = lang.Code, lang.Quality
+		}
+	}
+	for _, lang := range accepted {
+		if !lang.IsWildcard() || lang.Quality <= bestQuality {
+			continue
+		}
+		for _, code := range supported.Sorted() {
+			if !listed.Contains(code) {
+				return code
+			}
+		}
+	}
+	return best
+}
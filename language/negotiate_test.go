@@ -0,0 +1,47 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	accepted := ParseAcceptLanguage("en;q=0.8, de-AT,de;q=0.9 , xx, fr;q=abc, *;q=0.5, it;q=0")
+	assert.Equal(t, []AcceptedLanguage{
+		{Code: DE, Tag: "de-AT", Quality: 1},
+		{Code: DE, Tag: "de", Quality: 0.9},
+		{Code: EN, Tag: "en", Quality: 0.8},
+		{Code: Null, Tag: "*", Quality: 0.5},
+		{Code: IT, Tag: "it", Quality: 0},
+	}, accepted)
+
+	assert.Empty(t, ParseAcceptLanguage(""))
+}
+
+func TestNegotiate(t *testing.T) {
+	_, err := NewCodeSet("de", "xx")
+	require.Error(t, err)
+	supported, err := NewCodeSet("EN", "de", "it")
+	require.NoError(t, err)
+
+	tests := []struct {
+		header string
+		want   Code
+	}{
+		{"", Null},
+		{"fr", Null},
+		{"de-AT,de;q=0.9,en;q=0.8", DE},
+		{"fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7", EN},
+		{"fr, *;q=0.5", DE},
+		{"fr, de;q=0, *;q=0.5", EN},
+		{"de;q=0, en;q=0, it;q=0, *", Null},
+		{"de;q=0.1, *;q=0.5", EN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(supported, ParseAcceptLanguage(tt.header)))
+		})
+	}
+}
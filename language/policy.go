@@ -0,0 +1,14 @@
+package language
+
+import "github.com/domonda/go-types"
+
+// Policy evaluates language codes against
+// sets of allowed and denied languages.
+// See types.AllowDenyPolicy.
+type Policy = types.AllowDenyPolicy[Code]
+
+// NewPolicy returns a Policy with the normalized allowed
+// and denied language codes or an error if a code is not valid.
+func NewPolicy(allow, deny []Code) (Policy, error) {
+	return types.NewAllowDenyPolicy(allow, deny)
+}
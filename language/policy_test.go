@@ -0,0 +1,19 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := NewPolicy(nil, []Code{"RU"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.PolicyAllowed, policy.Evaluate(DE))
+	assert.Equal(t, types.PolicyDenied, policy.Evaluate("ru"))
+	assert.Equal(t, types.PolicyInvalid, policy.Evaluate("xx"))
+}
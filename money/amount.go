@@ -4,12 +4,23 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/domonda/go-types"
 	"github.com/domonda/go-types/float"
 	"github.com/domonda/go-types/nullable"
 )
 
+// Error codes of amount parsing errors
+// that can be used to look up localized messages.
+// See types.CodedError.
+const (
+	ErrCodeAmountInvalid  types.ErrorCode = "money.amount.invalid"
+	ErrCodeAmountDecimals types.ErrorCode = "money.amount.decimals"
+)
+
 // Amount adds money related methods to float64
 type Amount float64
 
@@ -19,10 +30,11 @@ type NullableAmount = nullable.Type[Amount]
 // If no acceptedDecimals are passed, then any decimal digit count is accepted.
 // Infinity and NaN are parsed and returned without error.
 // The Amount.Valid method can be aused to check for infinity and NaN.
+// Parsing doesn't allocate memory unless an error is returned.
 func ParseAmount(str string, acceptedDecimals ...int) (Amount, error) {
 	f, _, _, decimals, err := float.ParseDetails(str)
 	if err != nil {
-		return 0, err
+		return 0, types.NewCodedError(ErrCodeAmountInvalid, err.Error(), "value", str).Wrap(err)
 	}
 	if len(acceptedDecimals) == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
 		return Amount(f), nil
@@ -32,7 +44,16 @@ func ParseAmount(str string, acceptedDecimals ...int) (Amount, error) {
 			return Amount(f), nil
 		}
 	}
-	return 0, fmt.Errorf("parsing %q returned %d decimals wich is not in accepted list of %v", str, decimals, acceptedDecimals)
+	// Clone acceptedDecimals so that the variadic argument
+	// slice of the caller doesn't escape to the heap
+	accepted := slices.Clone(acceptedDecimals)
+	return 0, types.NewCodedError(
+		ErrCodeAmountDecimals,
+		fmt.Sprintf("parsing %q returned %d decimals wich is not in accepted list of %v", str, decimals, accepted),
+		"value", str,
+		"decimals", decimals,
+		"acceptedDecimals", accepted,
+	)
 }
 
 // NewAmount returns a pointer to an Amount
@@ -69,7 +90,7 @@ func (a *Amount) ScanString(source string, validate bool) error {
 		return err
 	}
 	if validate && !Amount(f).Valid() {
-		return fmt.Errorf("invalid amount: %q", source)
+		return types.NewCodedError(ErrCodeAmountInvalid, fmt.Sprintf("invalid amount: %q", source), "value", source)
 	}
 	*a = Amount(f)
 	return nil
@@ -142,6 +163,55 @@ func (a Amount) String() string {
 	// return b.String()
 }
 
+// CanonicalString returns the amount rounded to cents
+// in a canonical form that is guaranteed to stay stable
+// across versions of this package, so it can be used
+// as key material for unique indexes.
+//
+// See CanonicalStringWithDecimals for the format.
+func (a Amount) CanonicalString() string {
+	return a.CanonicalStringWithDecimals(2)
+}
+
+// CanonicalStringWithDecimals returns the amount rounded half away from zero
+// to the passed number of decimal places in a canonical form
+// that is guaranteed to stay stable across versions of this package:
+// a '-' prefix for negative amounts, the integer digits without
+// leading zeros or thousands separators, and if decimals is greater zero
+// a '.' followed by exactly decimals digits.
+// Zero is never signed, NaN and infinite amounts
+// are returned as "NaN", "+Inf", and "-Inf".
+func (a Amount) CanonicalStringWithDecimals(decimals int) string {
+	f := float64(a)
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	decimals = max(decimals, 0)
+	units := math.Round(math.Abs(f) * math.Pow10(decimals))
+	digits := strconv.FormatFloat(units, 'f', 0, 64)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	intLen := len(digits) - decimals
+
+	var b strings.Builder
+	b.Grow(len(digits) + 2)
+	if f < 0 && units != 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:intLen])
+	if decimals > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[intLen:])
+	}
+	return b.String()
+}
+
 // GoString returns the amount as string
 // in full float64 precision for debugging
 func (a Amount) GoString() string {
@@ -194,6 +264,13 @@ func (a Amount) Format(thousandsSep, decimalSep rune, precision int) string {
 	return float.Format(float64(a), thousandsSep, decimalSep, precision, true)
 }
 
+// AppendFormat appends the amount formatted like Amount.Format
+// to dst and returns the extended buffer.
+// It doesn't allocate memory if dst has enough capacity.
+func (a Amount) AppendFormat(dst []byte, thousandsSep, decimalSep rune, precision int) []byte {
+	return float.AppendFormat(dst, float64(a), thousandsSep, decimalSep, precision, true)
+}
+
 // BigFloat returns m as a new big.Float
 func (a Amount) BigFloat() *big.Float {
 	return big.NewFloat(float64(a))
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/language"
 )
 
 var amountTable2Decimals = map[string]Amount{
@@ -100,6 +102,49 @@ func Test_ParseAmount(t *testing.T) {
 	}
 }
 
+func Test_ParseAmountAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ParseAmount("1.234.567,89", 2)
+		_, _ = ParseAmount("-123.45")
+	})
+	assert.Zero(t, allocs)
+}
+
+func Test_Amount_AppendFormat(t *testing.T) {
+	buf := []byte("EUR ")
+	buf = Amount(1234.5).AppendFormat(buf, '.', ',', 2)
+	assert.Equal(t, "EUR 1.234,50", string(buf))
+
+	buf = make([]byte, 0, 32)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = Amount(-1234.5).AppendFormat(buf[:0], ',', '.', 2)
+	})
+	assert.Zero(t, allocs)
+	assert.Equal(t, "-1,234.50", string(buf))
+}
+
+func BenchmarkParseAmount(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = ParseAmount("1.234.567,89", 2)
+	}
+}
+
+func BenchmarkAmountFormat(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = Amount(1234567.89).Format('.', ',', 2)
+	}
+}
+
+func BenchmarkAmountAppendFormat(b *testing.B) {
+	buf := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = Amount(1234567.89).AppendFormat(buf[:0], '.', ',', 2)
+	}
+}
+
 var stringTable = map[Amount]string{
 	0:           "0.00",
 	0.99:        "0.99",
@@ -117,6 +162,26 @@ var stringTable = map[Amount]string{
 	0.054123:    "0.05",
 }
 
+func Test_ParseAmountWords(t *testing.T) {
+	amount, confidence, err := ParseAmountWords("dreitausendfünfhundert Euro und zwanzig Cent", language.DE)
+	assert.NoError(t, err)
+	assert.Equal(t, Amount(3500.20), amount)
+	assert.Equal(t, 1.0, confidence)
+
+	amount, confidence, err = ParseAmountWords("two hundred dollars and fifty cents only", language.Null)
+	assert.NoError(t, err)
+	assert.Equal(t, Amount(200.50), amount)
+	assert.Equal(t, 1.0, confidence)
+
+	amount, confidence, err = ParseAmountWords("one point two three four", language.EN)
+	assert.NoError(t, err)
+	assert.Equal(t, Amount(1.23), amount)
+	assert.Equal(t, 0.5, confidence)
+
+	_, _, err = ParseAmountWords("keine Zahl", language.DE)
+	assert.Error(t, err)
+}
+
 func Test_Amount_String(t *testing.T) {
 	for amount, refstr := range stringTable {
 		str := amount.String()
@@ -317,3 +382,40 @@ func TestAmount_GoString(t *testing.T) {
 		}
 	}
 }
+
+func TestAmount_CanonicalString(t *testing.T) {
+	tests := []struct {
+		a Amount
+		s string
+	}{
+		{a: 0, s: "0.00"},
+		{a: Amount(math.Copysign(0, -1)), s: "0.00"},
+		{a: -0.001, s: "0.00"},
+		{a: 1, s: "1.00"},
+		{a: 12.5, s: "12.50"},
+		{a: 0.05, s: "0.05"},
+		{a: -0.05, s: "-0.05"},
+		{a: 1.005, s: "1.00"},
+		{a: 0.125, s: "0.13"},
+		{a: -1234567.891, s: "-1234567.89"},
+		{a: 1e15, s: "1000000000000000.00"},
+		{a: Amount(math.NaN()), s: "NaN"},
+		{a: Amount(math.Inf(1)), s: "+Inf"},
+		{a: Amount(math.Inf(-1)), s: "-Inf"},
+	}
+	for _, tt := range tests {
+		if got := tt.a.CanonicalString(); got != tt.s {
+			t.Errorf("Amount(%#v).CanonicalString() = %v, want %v", tt.a, got, tt.s)
+		}
+	}
+
+	if got := Amount(1234.5).CanonicalStringWithDecimals(0); got != "1235" {
+		t.Errorf("CanonicalStringWithDecimals(0) = %v, want 1235", got)
+	}
+	if got := Amount(-1.25).CanonicalStringWithDecimals(3); got != "-1.250" {
+		t.Errorf("CanonicalStringWithDecimals(3) = %v, want -1.250", got)
+	}
+	if got := Amount(0.5).CanonicalStringWithDecimals(-1); got != "1" {
+		t.Errorf("CanonicalStringWithDecimals(-1) = %v, want 1", got)
+	}
+}
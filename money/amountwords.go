@@ -0,0 +1,26 @@
+package money
+
+import (
+	"github.com/domonda/go-types/language"
+	"github.com/domonda/go-types/strutil"
+)
+
+// ParseAmountWords parses an amount written in words
+// like "dreitausendfünfhundert Euro" or "two hundred dollars and fifty cents"
+// as found on checks and contracts and returns it rounded to cents
+// together with a confidence in the range 0 to 1.
+// German and English are supported, if lang is language.Null,
+// then the language is detected from the words.
+// See strutil.ParseNumberWords.
+func ParseAmountWords(str string, lang language.Code) (amount Amount, confidence float64, err error) {
+	n, err := strutil.ParseNumberWords(str, string(lang))
+	if err != nil {
+		return 0, 0, err
+	}
+	amount = Amount(n.Value)
+	if n.Decimals > 2 {
+		// More than cents is unusual for amounts
+		n.Confidence *= 0.5
+	}
+	return amount.RoundToCents(), n.Confidence, nil
+}
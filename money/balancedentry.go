@@ -0,0 +1,131 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// BalancedEntry is a double-entry ledger booking
+// with CurrencyAmount postings where debit postings are positive
+// and credit postings are negative amounts that must
+// sum up to zero per currency.
+//
+// BalancedEntry marshals as JSON array of postings
+// and returns an error for unbalanced entries
+// when marshalling or unmarshalling.
+// An entry without postings marshals as JSON null.
+type BalancedEntry []CurrencyAmount
+
+// NewBalancedEntry returns a BalancedEntry with the passed postings
+// or an error if the postings are not balanced.
+func NewBalancedEntry(postings ...CurrencyAmount) (BalancedEntry, error) {
+	entry := BalancedEntry(postings)
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Balances returns the sum of the postings by normalized currency.
+// The sums are rounded to the minor units of the currency.
+func (e BalancedEntry) Balances() map[Currency]Amount {
+	units := e.balanceMinorUnits()
+	balances := make(map[Currency]Amount, len(units))
+	for currency, sum := range units {
+		balances[currency] = minorUnitsAmount(sum, currency)
+	}
+	return balances
+}
+
+// balanceMinorUnits returns the sum of the postings by normalized
+// currency as integer count of the minor units of the currency.
+func (e BalancedEntry) balanceMinorUnits() map[Currency]int64 {
+	units := make(map[Currency]int64)
+	for _, posting := range e {
+		currency := posting.Currency
+		if norm, err := currency.Normalized(); err == nil {
+			currency = norm
+		}
+		scale := math.Pow10(currency.MinorUnits())
+		units[currency] += int64(math.Round(float64(posting.Amount) * scale))
+	}
+	return units
+}
+
+func minorUnitsAmount(units int64, currency Currency) Amount {
+	return Amount(units) / Amount(math.Pow10(currency.MinorUnits()))
+}
+
+// Currencies returns the sorted distinct currencies of the postings.
+func (e BalancedEntry) Currencies() []Currency {
+	return slices.Sorted(maps.Keys(e.balanceMinorUnits()))
+}
+
+// Valid returns if the entry has valid postings
+// that sum up to zero per currency.
+func (e BalancedEntry) Valid() bool {
+	return e.Validate() == nil
+}
+
+// Validate returns an error if the entry has no postings,
+// a posting has an invalid currency or amount,
+// or if the postings don't sum up to zero per currency
+// rounded to the minor units of the currency.
+func (e BalancedEntry) Validate() error {
+	if len(e) == 0 {
+		return errors.New("balanced entry has no postings")
+	}
+	for i, posting := range e {
+		if err := posting.Currency.Validate(); err != nil {
+			return fmt.Errorf("balanced entry posting %d: %w", i, err)
+		}
+		if !posting.Amount.Valid() {
+			return fmt.Errorf("balanced entry posting %d has invalid amount: %v", i, posting.Amount)
+		}
+	}
+	units := e.balanceMinorUnits()
+	for _, currency := range slices.Sorted(maps.Keys(units)) {
+		if sum := units[currency]; sum != 0 {
+			diff := minorUnitsAmount(sum, currency).Format(0, '.', currency.MinorUnits())
+			return fmt.Errorf("unbalanced entry: %s postings sum up to %s", currency, diff)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// returning an error if the entry is not valid.
+// An entry without postings is marshalled as null.
+func (e BalancedEntry) MarshalJSON() ([]byte, error) {
+	if len(e) == 0 {
+		return []byte("null"), nil
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]CurrencyAmount(e))
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// returning an error if the unmarshalled entry is not valid.
+// JSON null or an empty array result in a nil entry.
+func (e *BalancedEntry) UnmarshalJSON(data []byte) error {
+	var postings []CurrencyAmount
+	if err := json.Unmarshal(data, &postings); err != nil {
+		return fmt.Errorf("can't unmarshal JSON as money.BalancedEntry: %w", err)
+	}
+	if len(postings) == 0 {
+		*e = nil
+		return nil
+	}
+	entry := BalancedEntry(postings)
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	*e = entry
+	return nil
+}
@@ -0,0 +1,76 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancedEntry(t *testing.T) {
+	entry, err := NewBalancedEntry(
+		CurrencyAmountEUR(100),
+		CurrencyAmountEUR(20),
+		CurrencyAmount{Currency: "eur", Amount: -120},
+		CurrencyAmountUSD(0.1),
+		CurrencyAmountUSD(0.2),
+		CurrencyAmountUSD(-0.3),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []Currency{EUR, USD}, entry.Currencies())
+	assert.Equal(t, map[Currency]Amount{EUR: 0, USD: 0}, entry.Balances())
+
+	_, err = NewBalancedEntry(CurrencyAmountEUR(100), CurrencyAmountEUR(-99.99))
+	assert.EqualError(t, err, "unbalanced entry: EUR postings sum up to 0.01")
+
+	_, err = NewBalancedEntry(CurrencyAmountEUR(100), CurrencyAmountUSD(-100))
+	assert.Error(t, err, "balanced per currency")
+
+	_, err = NewBalancedEntry()
+	assert.Error(t, err, "no postings")
+
+	_, err = NewBalancedEntry(CurrencyAmount{Amount: 1}, CurrencyAmount{Amount: -1})
+	assert.Error(t, err, "missing currency")
+
+	// Balanced with the minor units of the currency
+	_, err = NewBalancedEntry(CurrencyAmount{Currency: JPY, Amount: 100}, CurrencyAmount{Currency: JPY, Amount: -100.4})
+	assert.NoError(t, err, "JPY has no minor units")
+	_, err = NewBalancedEntry(CurrencyAmount{Currency: JPY, Amount: 100}, CurrencyAmount{Currency: JPY, Amount: -99})
+	assert.EqualError(t, err, "unbalanced entry: JPY postings sum up to 1")
+	_, err = NewBalancedEntry(CurrencyAmount{Currency: KWD, Amount: 1.001}, CurrencyAmount{Currency: KWD, Amount: -1})
+	assert.EqualError(t, err, "unbalanced entry: KWD postings sum up to 0.001")
+	entry, err = NewBalancedEntry(CurrencyAmount{Currency: KWD, Amount: 1.0004}, CurrencyAmount{Currency: KWD, Amount: -1})
+	require.NoError(t, err)
+	assert.Equal(t, map[Currency]Amount{KWD: 0}, entry.Balances())
+}
+
+func TestBalancedEntry_JSON(t *testing.T) {
+	entry := BalancedEntry{CurrencyAmountEUR(50), CurrencyAmountEUR(-50)}
+	data, err := json.Marshal(entry)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"Currency":"EUR","Amount":50},{"Currency":"EUR","Amount":-50}]`, string(data))
+
+	var parsed BalancedEntry
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, entry, parsed)
+
+	err = json.Unmarshal([]byte(`[{"Currency":"EUR","Amount":50}]`), &parsed)
+	assert.Error(t, err)
+
+	_, err = json.Marshal(BalancedEntry{CurrencyAmountEUR(1)})
+	assert.Error(t, err)
+
+	// Unset entries marshal as null
+	var unset struct {
+		Entry BalancedEntry `json:"entry"`
+	}
+	data, err = json.Marshal(unset)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"entry":null}`, string(data))
+	unset.Entry = BalancedEntry{CurrencyAmountEUR(1)}
+	require.NoError(t, json.Unmarshal(data, &unset))
+	assert.Nil(t, unset.Entry)
+	require.NoError(t, json.Unmarshal([]byte(`[]`), &parsed))
+	assert.Nil(t, parsed)
+}
@@ -0,0 +1,186 @@
+package money
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/domonda/go-types/date"
+)
+
+// AmountsByCurrency holds the sums of amounts
+// by normalized currency rounded to cents.
+type AmountsByCurrency map[Currency]Amount
+
+// Add adds the amount to the sum of its normalized currency.
+// Returns an error if the currency or amount is not valid.
+func (a AmountsByCurrency) Add(amount CurrencyAmount) error {
+	currency, err := amount.Currency.Normalized()
+	if err != nil {
+		return err
+	}
+	if !amount.Amount.Valid() {
+		return fmt.Errorf("invalid amount: %v", amount.Amount)
+	}
+	a[currency] = (a[currency] + amount.Amount).RoundToCents()
+	return nil
+}
+
+// AddAll adds all sums of other.
+func (a AmountsByCurrency) AddAll(other AmountsByCurrency) {
+	for currency, amount := range other {
+		a[currency] = (a[currency] + amount).RoundToCents()
+	}
+}
+
+// Currencies returns the sorted currencies of the sums.
+func (a AmountsByCurrency) Currencies() []Currency {
+	return slices.Sorted(maps.Keys(a))
+}
+
+// CurrencyAmounts returns the sums as CurrencyAmount slice
+// sorted by currency.
+func (a AmountsByCurrency) CurrencyAmounts() []CurrencyAmount {
+	amounts := make([]CurrencyAmount, 0, len(a))
+	for _, currency := range a.Currencies() {
+		amounts = append(amounts, CurrencyAmount{Currency: currency, Amount: a[currency]})
+	}
+	return amounts
+}
+
+// CashFlowInterval is the length of the buckets of a cash flow time series.
+type CashFlowInterval string
+
+const (
+	CashFlowDaily   CashFlowInterval = "DAY"
+	CashFlowWeekly  CashFlowInterval = "WEEK" // ISO weeks starting on Monday
+	CashFlowMonthly CashFlowInterval = "MONTH"
+)
+
+// Valid returns true if the interval is one of the defined constants.
+func (i CashFlowInterval) Valid() bool {
+	return i.Validate() == nil
+}
+
+// Validate returns an error if the interval is not one of the defined constants.
+func (i CashFlowInterval) Validate() error {
+	switch i {
+	case CashFlowDaily, CashFlowWeekly, CashFlowMonthly:
+		return nil
+	}
+	return fmt.Errorf("invalid money.CashFlowInterval %q", string(i))
+}
+
+// BucketFrom returns the first date of the bucket containing the date.
+func (i CashFlowInterval) BucketFrom(d date.Date) date.Date {
+	switch i {
+	case CashFlowWeekly:
+		return d.BeginningOfWeek(time.Monday)
+	case CashFlowMonthly:
+		return d.BeginningOfMonth()
+	default:
+		return d
+	}
+}
+
+// nextBucketFrom returns the first date of the bucket
+// following the bucket starting at from.
+func (i CashFlowInterval) nextBucketFrom(from date.Date) date.Date {
+	switch i {
+	case CashFlowWeekly:
+		return from.AddDays(7)
+	case CashFlowMonthly:
+		return from.AddMonths(1)
+	default:
+		return from.AddDays(1)
+	}
+}
+
+// CashFlowBucket holds the sums of the cash flows
+// of a time interval from and until including the dates.
+type CashFlowBucket struct {
+	From    date.Date         `json:"from"`
+	Until   date.Date         `json:"until"`
+	Amounts AmountsByCurrency `json:"amounts"`
+}
+
+// CashFlowSeries aggregates a stream of dated cash flows to a time series
+// of buckets of the passed interval with the sums per currency.
+//
+// The series starts with the bucket containing from and ends
+// with the bucket containing until. If from or until are empty,
+// then the buckets of the first or last flow are used.
+// All flows of the first and last bucket are summed up,
+// also those before from or after until.
+// Flows outside of the buckets are ignored.
+// The series has no gaps, buckets without flows have empty Amounts.
+//
+// An error is returned for an invalid interval, date, currency, or amount.
+func CashFlowSeries(flows iter.Seq2[date.Date, CurrencyAmount], interval CashFlowInterval, from, until date.Date) ([]CashFlowBucket, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+	var err error
+	if from != "" {
+		if from, err = from.Normalized(); err != nil {
+			return nil, err
+		}
+		from = interval.BucketFrom(from)
+	}
+	if until != "" {
+		if until, err = until.Normalized(); err != nil {
+			return nil, err
+		}
+		until = interval.nextBucketFrom(interval.BucketFrom(until)).AddDays(-1)
+	}
+
+	sums := make(map[date.Date]AmountsByCurrency)
+	var first, last date.Date
+	for d, amount := range flows {
+		d, err = d.Normalized()
+		if err != nil {
+			return nil, err
+		}
+		if (from != "" && d.Before(from)) || (until != "" && d.After(until)) {
+			continue
+		}
+		bucket := interval.BucketFrom(d)
+		if sums[bucket] == nil {
+			sums[bucket] = make(AmountsByCurrency)
+		}
+		if err = sums[bucket].Add(amount); err != nil {
+			return nil, fmt.Errorf("cash flow at %s: %w", d, err)
+		}
+		if first == "" || bucket.Before(first) {
+			first = bucket
+		}
+		if last == "" || bucket.After(last) {
+			last = bucket
+		}
+	}
+	if from == "" {
+		from = first
+	}
+	if until == "" {
+		until = last
+	}
+	if from == "" || until == "" || until.Before(from) {
+		return nil, nil
+	}
+
+	var series []CashFlowBucket
+	for bucket := from; !bucket.After(until); bucket = interval.nextBucketFrom(bucket) {
+		amounts := sums[bucket]
+		if amounts == nil {
+			amounts = make(AmountsByCurrency)
+		}
+		series = append(series, CashFlowBucket{
+			From:    bucket,
+			Until:   interval.nextBucketFrom(bucket).AddDays(-1),
+			Amounts: amounts,
+		})
+	}
+	return series, nil
+}
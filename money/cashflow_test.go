@@ -0,0 +1,103 @@
+package money
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/date"
+)
+
+type testCashFlow struct {
+	date   date.Date
+	amount CurrencyAmount
+}
+
+func testCashFlows(flows ...testCashFlow) iter.Seq2[date.Date, CurrencyAmount] {
+	return func(yield func(date.Date, CurrencyAmount) bool) {
+		for _, f := range flows {
+			if !yield(f.date, f.amount) {
+				return
+			}
+		}
+	}
+}
+
+func TestAmountsByCurrency(t *testing.T) {
+	a := make(AmountsByCurrency)
+	require.NoError(t, a.Add(CurrencyAmount{Currency: "eur", Amount: 0.1}))
+	require.NoError(t, a.Add(CurrencyAmount{Currency: "EUR", Amount: 0.2}))
+	require.NoError(t, a.Add(CurrencyAmount{Currency: "USD", Amount: -5}))
+	require.Error(t, a.Add(CurrencyAmount{Currency: "XXXX", Amount: 1}))
+	require.Equal(t, AmountsByCurrency{"EUR": 0.3, "USD": -5}, a)
+	require.Equal(t, []Currency{"EUR", "USD"}, a.Currencies())
+
+	a.AddAll(AmountsByCurrency{"USD": 5, "CHF": 1})
+	require.Equal(t, []CurrencyAmount{{"CHF", 1}, {"EUR", 0.3}, {"USD", 0}}, a.CurrencyAmounts())
+}
+
+func TestCashFlowSeries(t *testing.T) {
+	flows := testCashFlows(
+		testCashFlow{"2024-01-31", CurrencyAmount{"EUR", 100}},
+		testCashFlow{"2024-01-01", CurrencyAmount{"EUR", -30.5}},
+		testCashFlow{"2024-03-15", CurrencyAmount{"USD", 10}},
+		testCashFlow{"2024-03-16", CurrencyAmount{"eur", 1}},
+	)
+
+	t.Run("monthly", func(t *testing.T) {
+		series, err := CashFlowSeries(flows, CashFlowMonthly, "", "")
+		require.NoError(t, err)
+		require.Equal(t, []CashFlowBucket{
+			{From: "2024-01-01", Until: "2024-01-31", Amounts: AmountsByCurrency{"EUR": 69.5}},
+			{From: "2024-02-01", Until: "2024-02-29", Amounts: AmountsByCurrency{}},
+			{From: "2024-03-01", Until: "2024-03-31", Amounts: AmountsByCurrency{"EUR": 1, "USD": 10}},
+		}, series)
+	})
+
+	t.Run("weekly with range", func(t *testing.T) {
+		// 2024-01-01 is a Monday
+		series, err := CashFlowSeries(flows, CashFlowWeekly, "2024-01-03", "2024-01-14")
+		require.NoError(t, err)
+		require.Equal(t, []CashFlowBucket{
+			{From: "2024-01-01", Until: "2024-01-07", Amounts: AmountsByCurrency{"EUR": -30.5}},
+			{From: "2024-01-08", Until: "2024-01-14", Amounts: AmountsByCurrency{}},
+		}, series)
+	})
+
+	t.Run("monthly with range within buckets", func(t *testing.T) {
+		// Flows before from and after until in the same buckets are included
+		series, err := CashFlowSeries(flows, CashFlowMonthly, "2024-01-15", "2024-03-15")
+		require.NoError(t, err)
+		require.Equal(t, []CashFlowBucket{
+			{From: "2024-01-01", Until: "2024-01-31", Amounts: AmountsByCurrency{"EUR": 69.5}},
+			{From: "2024-02-01", Until: "2024-02-29", Amounts: AmountsByCurrency{}},
+			{From: "2024-03-01", Until: "2024-03-31", Amounts: AmountsByCurrency{"EUR": 1, "USD": 10}},
+		}, series)
+	})
+
+	t.Run("daily", func(t *testing.T) {
+		series, err := CashFlowSeries(flows, CashFlowDaily, "2024-03-14", "")
+		require.NoError(t, err)
+		require.Equal(t, []CashFlowBucket{
+			{From: "2024-03-14", Until: "2024-03-14", Amounts: AmountsByCurrency{}},
+			{From: "2024-03-15", Until: "2024-03-15", Amounts: AmountsByCurrency{"USD": 10}},
+			{From: "2024-03-16", Until: "2024-03-16", Amounts: AmountsByCurrency{"EUR": 1}},
+		}, series)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		series, err := CashFlowSeries(testCashFlows(), CashFlowMonthly, "", "")
+		require.NoError(t, err)
+		require.Empty(t, series)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := CashFlowSeries(flows, "YEAR", "", "")
+		require.Error(t, err)
+		_, err = CashFlowSeries(testCashFlows(testCashFlow{"invalid", CurrencyAmount{"EUR", 1}}), CashFlowDaily, "", "")
+		require.Error(t, err)
+		_, err = CashFlowSeries(testCashFlows(testCashFlow{"2024-01-01", CurrencyAmount{"", 1}}), CashFlowDaily, "", "")
+		require.Error(t, err)
+	})
+}
@@ -172,6 +172,39 @@ const (
 	BTC = "BTC" // Bitcoin
 )
 
+// ISO 4217 fund codes
+const (
+	BOV = "BOV" // Bolivia Mvdol
+	CHE = "CHE" // WIR Euro
+	CHW = "CHW" // WIR Franc
+	CLF = "CLF" // Chile Unidad de Fomento
+	COU = "COU" // Colombia Unidad de Valor Real
+	MXV = "MXV" // Mexico Unidad de Inversion (UDI)
+	USN = "USN" // US Dollar (Next day)
+	UYI = "UYI" // Uruguay Peso en Unidades Indexadas (UI)
+	UYW = "UYW" // Uruguay Unidad Previsional
+)
+
+// ISO 4217 precious metal codes
+const (
+	XAG = "XAG" // Silver
+	XAU = "XAU" // Gold
+	XPD = "XPD" // Palladium
+	XPT = "XPT" // Platinum
+)
+
+// ISO 4217 codes for units of account, testing, and no currency
+const (
+	XBA = "XBA" // Bond Markets Unit European Composite Unit (EURCO)
+	XBB = "XBB" // Bond Markets Unit European Monetary Unit (E.M.U.-6)
+	XBC = "XBC" // Bond Markets Unit European Unit of Account 9 (E.U.A.-9)
+	XBD = "XBD" // Bond Markets Unit European Unit of Account 17 (E.U.A.-17)
+	XSU = "XSU" // Sucre
+	XUA = "XUA" // ADB Unit of Account
+	XTS = "XTS" // Code reserved for testing
+	XXX = "XXX" // No currency
+)
+
 var currencySymbolToCode = map[string]Currency{
 	"€":    EUR,
 	"$":    USD,
@@ -362,4 +395,59 @@ var currencyCodeToName = map[Currency]string{
 	ZAR: "South Africa Rand",
 	ZMW: "Zambia Kwacha",
 	ZWD: "Zimbabwe Dollar",
+
+	BOV: "Bolivia Mvdol",
+	CHE: "WIR Euro",
+	CHW: "WIR Franc",
+	CLF: "Chile Unidad de Fomento",
+	COU: "Colombia Unidad de Valor Real",
+	MXV: "Mexico Unidad de Inversion (UDI)",
+	USN: "US Dollar (Next day)",
+	UYI: "Uruguay Peso en Unidades Indexadas (UI)",
+	UYW: "Uruguay Unidad Previsional",
+
+	XAG: "Silver",
+	XAU: "Gold",
+	XPD: "Palladium",
+	XPT: "Platinum",
+
+	XBA: "Bond Markets Unit European Composite Unit (EURCO)",
+	XBB: "Bond Markets Unit European Monetary Unit (E.M.U.-6)",
+	XBC: "Bond Markets Unit European Unit of Account 9 (E.U.A.-9)",
+	XBD: "Bond Markets Unit European Unit of Account 17 (E.U.A.-17)",
+	XSU: "Sucre",
+	XUA: "ADB Unit of Account",
+	XTS: "Code reserved for testing",
+	XXX: "No currency",
+}
+
+// currencyMinorUnits holds the ISO 4217 minor units
+// of currencies that don't use 2 decimal places.
+var currencyMinorUnits = map[Currency]int{
+	BIF: 0,
+	CLP: 0,
+	DJF: 0,
+	GNF: 0,
+	ISK: 0,
+	JPY: 0,
+	KMF: 0,
+	KRW: 0,
+	PYG: 0,
+	RWF: 0,
+	UGX: 0,
+	VND: 0,
+	VUV: 0,
+	XAF: 0,
+	XOF: 0,
+	XPF: 0,
+	BHD: 3,
+	IQD: 3,
+	JOD: 3,
+	KWD: 3,
+	LYD: 3,
+	OMR: 3,
+	TND: 3,
+	UYI: 0,
+	CLF: 4,
+	UYW: 4,
 }
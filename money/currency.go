@@ -2,7 +2,6 @@ package money
 
 import (
 	"database/sql/driver"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -10,6 +9,16 @@ import (
 	"github.com/domonda/go-types/strutil"
 )
 
+// Error codes of currency validation errors
+// that can be used to look up localized messages.
+// See types.CodedError.
+const (
+	ErrCodeCurrencyEmpty   types.ErrorCode = "money.currency.empty"
+	ErrCodeCurrencyInvalid types.ErrorCode = "money.currency.invalid"
+	// ErrCodeCurrencyNonTransactional is used by Currency.ValidateTransactional
+	ErrCodeCurrencyNonTransactional types.ErrorCode = "money.currency.non_transactional"
+)
+
 // Compile-time check that Currency implements types.NormalizableValidator[Currency]
 var _ types.NormalizableValidator[Currency] = Currency("")
 
@@ -96,7 +105,7 @@ func (c Currency) Normalized() (Currency, error) {
 	str := strutil.TrimSpace(string(c))
 
 	if str == "" {
-		return "", errors.New("empty currency code")
+		return "", types.NewCodedError(ErrCodeCurrencyEmpty, "empty currency code")
 	}
 
 	result, found := currencySymbolToCode[str]
@@ -120,7 +129,7 @@ func (c Currency) Normalized() (Currency, error) {
 	}
 
 	if _, ok := currencyCodeToName[Currency(str)]; !ok {
-		return c, fmt.Errorf("invalid currency code %q", str)
+		return c, types.NewCodedError(ErrCodeCurrencyInvalid, fmt.Sprintf("invalid currency code %q", str), "value", str)
 	}
 
 	return Currency(str), nil
@@ -179,6 +188,41 @@ func (c Currency) EnglishName() string {
 	return currencyCodeToName[c]
 }
 
+// MinorUnits returns the number of decimal places
+// of the currency according to ISO 4217,
+// which is 2 for most currencies and for unknown currencies.
+//
+// Currencies without minor units in ISO 4217 ("N.A.")
+// like precious metals, units of account, XTS, and XXX
+// also return 2, use LookupMinorUnits to distinguish them.
+func (c Currency) MinorUnits() int {
+	if units, ok := c.LookupMinorUnits(); ok {
+		return units
+	}
+	return 2
+}
+
+// LookupMinorUnits returns the number of decimal places
+// of the currency according to ISO 4217 and true,
+// or false if the currency is not valid or has no
+// minor units in ISO 4217 ("N.A.") like precious metals,
+// units of account, XTS, and XXX.
+func (c Currency) LookupMinorUnits() (units int, ok bool) {
+	norm, err := c.Normalized()
+	if err != nil {
+		return 0, false
+	}
+	_, metal := metalCurrencies[norm]
+	_, unit := unitOfAccountCurrencies[norm]
+	if metal || unit || norm == XTS || norm == XXX {
+		return 0, false
+	}
+	if units, ok := currencyMinorUnits[norm]; ok {
+		return units, true
+	}
+	return 2, true
+}
+
 // String returns the normalized currency as string if possible,
 // else it will be returned unchanged as string.
 // String implements the fmt.Stringer interface.
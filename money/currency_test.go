@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types"
 )
 
 var currencyTestTable = []string{
@@ -47,3 +49,49 @@ func Test_NullCurrency(t *testing.T) {
 	assert.False(t, Currency("").Valid())
 	assert.True(t, NullableCurrency("").Valid())
 }
+
+func TestCurrency_Classification(t *testing.T) {
+	tests := []struct {
+		currency      Currency
+		fund          bool
+		metal         bool
+		test          bool
+		noCurrency    bool
+		transactional bool
+	}{
+		{"EUR", false, false, false, false, true},
+		{"jpy", false, false, false, false, true},
+		{"CHE", true, false, false, false, false},
+		{"clf", true, false, false, false, false},
+		{"XAU", false, true, false, false, false},
+		{"XTS", false, false, true, false, false},
+		{"XXX", false, false, false, true, false},
+		{"XDR", false, false, false, false, false},
+		{"XBA", false, false, false, false, false},
+		{"XYZ", false, false, false, false, false},
+		{"", false, false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.currency), func(t *testing.T) {
+			assert.Equal(t, tt.fund, tt.currency.IsFund(), "IsFund")
+			assert.Equal(t, tt.metal, tt.currency.IsMetal(), "IsMetal")
+			assert.Equal(t, tt.test, tt.currency.IsTestCode(), "IsTestCode")
+			assert.Equal(t, tt.noCurrency, tt.currency.IsNoCurrency(), "IsNoCurrency")
+			assert.Equal(t, tt.transactional, tt.currency.IsTransactional(), "IsTransactional")
+			assert.Equal(t, tt.transactional, tt.currency.ValidateTransactional() == nil, "ValidateTransactional")
+		})
+	}
+
+	// Non transactional codes are still parsed
+	norm, err := Currency("xau").Normalized()
+	assert.NoError(t, err)
+	assert.Equal(t, Currency(XAU), norm)
+	assert.Equal(t, 4, Currency(CLF).MinorUnits())
+
+	_, err = Currency("xau").NormalizedTransactional()
+	assert.ErrorIs(t, err, types.NewCodedError(ErrCodeCurrencyNonTransactional, ""))
+	assert.EqualError(t, err, "currency XAU (Gold) can't be used for payments")
+	norm, err = Currency("eur").NormalizedTransactional()
+	assert.NoError(t, err)
+	assert.Equal(t, Currency(EUR), norm)
+}
@@ -81,6 +81,20 @@ func (ca CurrencyAmount) String() string {
 	return ca.Format(true, 0, '.', 2)
 }
 
+// CanonicalString returns the normalized currency code followed by a space
+// and Amount.CanonicalStringWithDecimals with the currency's MinorUnits,
+// for example "EUR 12.50", "JPY 1000", or "KWD -1.250".
+// The format is guaranteed to stay stable across versions of this package,
+// so it can be used as key material for unique indexes.
+// Only the amount is returned if the currency is empty.
+func (ca CurrencyAmount) CanonicalString() string {
+	amountStr := ca.Amount.CanonicalStringWithDecimals(ca.Currency.MinorUnits())
+	if ca.Currency == "" {
+		return amountStr
+	}
+	return ca.Currency.String() + " " + amountStr
+}
+
 func (ca CurrencyAmount) Format(currencyFirst bool, thousandsSep, decimalSep rune, precision int) string {
 	amountStr := ca.Amount.Format(thousandsSep, decimalSep, precision)
 	if ca.Currency == "" {
@@ -152,3 +152,23 @@ func TestParseCurrencyAmount(t *testing.T) {
 		assert.Equal(t, expected, result, "ParseCurrencyAmount(%#v, %#v)", str, 2)
 	}
 }
+
+func TestCurrencyAmount_CanonicalString(t *testing.T) {
+	assert.Equal(t, "EUR 12.50", CurrencyAmount{"eur", 12.5}.CanonicalString())
+	assert.Equal(t, "JPY 1000", CurrencyAmount{JPY, 999.6}.CanonicalString())
+	assert.Equal(t, "KWD -1.250", CurrencyAmount{KWD, -1.25}.CanonicalString())
+	assert.Equal(t, "0.10", CurrencyAmount{"", 0.1}.CanonicalString())
+
+	assert.Equal(t, 2, Currency(EUR).MinorUnits())
+	assert.Equal(t, 0, Currency("jpy").MinorUnits())
+	assert.Equal(t, 3, Currency(BHD).MinorUnits())
+	assert.Equal(t, 2, Currency("invalid").MinorUnits())
+
+	units, ok := Currency("kwd").LookupMinorUnits()
+	assert.True(t, ok)
+	assert.Equal(t, 3, units)
+	for _, c := range []Currency{XAU, XAG, XPT, XPD, XDR, XTS, XXX, "invalid"} {
+		_, ok = c.LookupMinorUnits()
+		assert.False(t, ok, "no minor units for %s", c)
+	}
+}
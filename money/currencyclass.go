@@ -0,0 +1,96 @@
+package money
+
+import (
+	"fmt"
+
+	"github.com/domonda/go-types"
+)
+
+var fundCurrencies = map[Currency]struct{}{
+	BOV: {}, CHE: {}, CHW: {}, CLF: {}, COU: {}, MXV: {}, USN: {}, UYI: {}, UYW: {},
+}
+
+var metalCurrencies = map[Currency]struct{}{
+	XAG: {}, XAU: {}, XPD: {}, XPT: {},
+}
+
+// unitOfAccountCurrencies are ISO 4217 codes
+// of supranational units of account
+var unitOfAccountCurrencies = map[Currency]struct{}{
+	XBA: {}, XBB: {}, XBC: {}, XBD: {}, XDR: {}, XSU: {}, XUA: {},
+}
+
+// IsFund returns if the currency is an ISO 4217 fund code
+// like CHE (WIR Euro) or CLF (Chile Unidad de Fomento).
+func (c Currency) IsFund() bool {
+	_, ok := fundCurrencies[c.normalizedOrEmpty()]
+	return ok
+}
+
+// IsMetal returns if the currency is an ISO 4217
+// precious metal code like XAU for gold.
+func (c Currency) IsMetal() bool {
+	_, ok := metalCurrencies[c.normalizedOrEmpty()]
+	return ok
+}
+
+// IsTestCode returns if the currency is the
+// ISO 4217 code XTS reserved for testing.
+func (c Currency) IsTestCode() bool {
+	return c.normalizedOrEmpty() == XTS
+}
+
+// IsNoCurrency returns if the currency is the ISO 4217 code XXX
+// used for transactions where no currency is involved.
+func (c Currency) IsNoCurrency() bool {
+	return c.normalizedOrEmpty() == XXX
+}
+
+// IsTransactional returns if the currency is valid and can
+// be used for payments, which excludes fund codes, precious metals,
+// units of account like XDR, the test code XTS, and XXX for no currency.
+func (c Currency) IsTransactional() bool {
+	norm, err := c.Normalized()
+	if err != nil {
+		return false
+	}
+	_, fund := fundCurrencies[norm]
+	_, metal := metalCurrencies[norm]
+	_, unit := unitOfAccountCurrencies[norm]
+	return !fund && !metal && !unit && norm != XTS && norm != XXX
+}
+
+// ValidateTransactional returns an error if the currency
+// is not valid or can't be used for payments.
+// See Currency.IsTransactional.
+func (c Currency) ValidateTransactional() error {
+	_, err := c.NormalizedTransactional()
+	return err
+}
+
+// NormalizedTransactional is a strict variant of Currency.Normalized
+// for payment contexts that returns an error for currencies
+// that are valid ISO 4217 codes but can't be used for payments.
+// Use Currency.Normalized to accept all codes like in data feeds.
+func (c Currency) NormalizedTransactional() (Currency, error) {
+	norm, err := c.Normalized()
+	if err != nil {
+		return c, err
+	}
+	if !norm.IsTransactional() {
+		return c, types.NewCodedError(
+			ErrCodeCurrencyNonTransactional,
+			fmt.Sprintf("currency %s (%s) can't be used for payments", norm, norm.EnglishName()),
+			"value", string(norm),
+		)
+	}
+	return norm, nil
+}
+
+func (c Currency) normalizedOrEmpty() Currency {
+	norm, err := c.Normalized()
+	if err != nil {
+		return ""
+	}
+	return norm
+}
@@ -0,0 +1,237 @@
+package money
+
+import (
+	"github.com/domonda/go-types/language"
+)
+
+// CurrencyFormat holds the localized metadata
+// for rendering a currency in documents.
+type CurrencyFormat struct {
+	// Currency is the currency code the format is for.
+	Currency Currency `json:"currency"`
+	// Symbol is the localized standard symbol like "US$" or "€",
+	// or the currency code if there is no common symbol.
+	Symbol string `json:"symbol"`
+	// NarrowSymbol is the shortest symbol like "$" that
+	// might be ambiguous without the context of a country.
+	NarrowSymbol string `json:"narrowSymbol"`
+	// DisplayName is the localized name of the currency.
+	DisplayName string `json:"displayName"`
+	// Historical is true for currencies that are no longer in use
+	// like the ones replaced by the Euro, but that still
+	// have to be rendered for old documents.
+	Historical bool `json:"historical,omitempty"`
+}
+
+// LocalizedFormat returns the symbols and display name of the currency
+// in the passed language from a subset of the Unicode CLDR data.
+//
+// Languages without localized data fall back to English
+// and currencies without CLDR data fall back to Symbol
+// and EnglishName, or the currency code.
+func (c Currency) LocalizedFormat(lang language.Code) CurrencyFormat {
+	code, err := c.Normalized()
+	if err != nil {
+		// Historical currencies are not valid Currency values
+		code = c
+	}
+	lang, err = lang.Normalized()
+	if err != nil {
+		lang = language.EN
+	}
+
+	data, ok := cldrCurrencies[code]
+	if !ok {
+		name := code.EnglishName()
+		if name == "" {
+			name = string(code)
+		}
+		return CurrencyFormat{
+			Currency:     code,
+			Symbol:       code.Symbol(),
+			NarrowSymbol: code.Symbol(),
+			DisplayName:  name,
+		}
+	}
+
+	format := CurrencyFormat{
+		Currency:     code,
+		Symbol:       data.symbol,
+		NarrowSymbol: data.narrow,
+		DisplayName:  data.names[lang],
+		Historical:   data.historical,
+	}
+	if s, ok := data.symbols[lang]; ok {
+		format.Symbol = s
+	}
+	if format.Symbol == "" {
+		format.Symbol = string(code)
+	}
+	if format.NarrowSymbol == "" {
+		format.NarrowSymbol = format.Symbol
+	}
+	if format.DisplayName == "" {
+		format.DisplayName = data.names[language.EN]
+	}
+	return format
+}
+
+// LocalizedFormat returns the symbols and display name of the currency
+// in the passed language or an empty CurrencyFormat if the currency is null.
+// See Currency.LocalizedFormat.
+func (n NullableCurrency) LocalizedFormat(lang language.Code) CurrencyFormat {
+	if n.IsNull() {
+		return CurrencyFormat{}
+	}
+	return Currency(n).LocalizedFormat(lang)
+}
+
+type cldrCurrency struct {
+	// symbol is the default symbol if not in symbols,
+	// empty means the currency code is used.
+	symbol string
+	// symbols holds language specific symbols
+	// that differ from the default symbol.
+	symbols    map[language.Code]string
+	narrow     string
+	names      map[language.Code]string
+	historical bool
+}
+
+// cldrCurrencies is a subset of the Unicode CLDR currency data
+// (https://cldr.unicode.org) for the currencies and languages
+// most commonly found in documents processed by domonda.
+var cldrCurrencies = map[Currency]cldrCurrency{
+	EUR: {
+		symbol: "€",
+		narrow: "€",
+		names:  map[language.Code]string{language.EN: "Euro", language.DE: "Euro", language.FR: "euro", language.IT: "euro", language.ES: "euro"},
+	},
+	USD: {
+		symbol:  "US$",
+		symbols: map[language.Code]string{language.EN: "$", language.DE: "$", language.FR: "$US", language.IT: "USD"},
+		narrow:  "$",
+		names:   map[language.Code]string{language.EN: "US Dollar", language.DE: "US-Dollar", language.FR: "dollar des États-Unis", language.IT: "dollaro statunitense", language.ES: "dólar estadounidense"},
+	},
+	GBP: {
+		symbol:  "£",
+		symbols: map[language.Code]string{language.FR: "£GB"},
+		narrow:  "£",
+		names:   map[language.Code]string{language.EN: "British Pound", language.DE: "Britisches Pfund", language.FR: "livre sterling", language.IT: "sterlina britannica", language.ES: "libra esterlina"},
+	},
+	CHF: {
+		names: map[language.Code]string{language.EN: "Swiss Franc", language.DE: "Schweizer Franken", language.FR: "franc suisse", language.IT: "franco svizzero", language.ES: "franco suizo"},
+	},
+	JPY: {
+		symbol:  "JP¥",
+		symbols: map[language.Code]string{language.EN: "¥", language.DE: "¥", language.FR: "JPY", language.IT: "JPY", language.ES: "JPY"},
+		narrow:  "¥",
+		names:   map[language.Code]string{language.EN: "Japanese Yen", language.DE: "Japanischer Yen", language.FR: "yen japonais", language.IT: "yen giapponese", language.ES: "yen"},
+	},
+	CNY: {
+		symbol:  "CN¥",
+		symbols: map[language.Code]string{language.FR: "CNY", language.ES: "CNY"},
+		narrow:  "¥",
+		names:   map[language.Code]string{language.EN: "Chinese Yuan", language.DE: "Renminbi Yuan", language.FR: "yuan renminbi chinois", language.IT: "renminbi cinese", language.ES: "yuan"},
+	},
+	CAD: {
+		symbol:  "CA$",
+		symbols: map[language.Code]string{language.FR: "$CA"},
+		narrow:  "$",
+		names:   map[language.Code]string{language.EN: "Canadian Dollar", language.DE: "Kanadischer Dollar", language.FR: "dollar canadien", language.IT: "dollaro canadese", language.ES: "dólar canadiense"},
+	},
+	AUD: {
+		symbol:  "A$",
+		symbols: map[language.Code]string{language.DE: "AU$", language.FR: "$AU", language.ES: "AUD"},
+		narrow:  "$",
+		names:   map[language.Code]string{language.EN: "Australian Dollar", language.DE: "Australischer Dollar", language.FR: "dollar australien", language.IT: "dollaro australiano", language.ES: "dólar australiano"},
+	},
+	SEK: {
+		narrow: "kr",
+		names:  map[language.Code]string{language.EN: "Swedish Krona", language.DE: "Schwedische Krone", language.FR: "couronne suédoise", language.IT: "corona svedese", language.ES: "corona sueca"},
+	},
+	NOK: {
+		narrow: "kr",
+		names:  map[language.Code]string{language.EN: "Norwegian Krone", language.DE: "Norwegische Krone", language.FR: "couronne norvégienne", language.IT: "corona norvegese", language.ES: "corona noruega"},
+	},
+	DKK: {
+		narrow: "kr.",
+		names:  map[language.Code]string{language.EN: "Danish Krone", language.DE: "Dänische Krone", language.FR: "couronne danoise", language.IT: "corona danese", language.ES: "corona danesa"},
+	},
+	PLN: {
+		narrow: "zł",
+		names:  map[language.Code]string{language.EN: "Polish Zloty", language.DE: "Polnischer Złoty", language.FR: "zloty polonais", language.IT: "złoty polacco", language.ES: "esloti"},
+	},
+	CZK: {
+		narrow: "Kč",
+		names:  map[language.Code]string{language.EN: "Czech Koruna", language.DE: "Tschechische Krone", language.FR: "couronne tchèque", language.IT: "corona ceca", language.ES: "corona checa"},
+	},
+	HUF: {
+		narrow: "Ft",
+		names:  map[language.Code]string{language.EN: "Hungarian Forint", language.DE: "Ungarischer Forint", language.FR: "forint hongrois", language.IT: "fiorino ungherese", language.ES: "forinto húngaro"},
+	},
+	RON: {
+		narrow: "lei",
+		names:  map[language.Code]string{language.EN: "Romanian Leu", language.DE: "Rumänischer Leu", language.FR: "leu roumain", language.IT: "leu rumeno", language.ES: "leu rumano"},
+	},
+	BGN: {
+		names: map[language.Code]string{language.EN: "Bulgarian Lev", language.DE: "Bulgarischer Lew", language.FR: "lev bulgare", language.IT: "lev bulgaro", language.ES: "lev búlgaro"},
+	},
+	TRY: {
+		narrow: "₺",
+		names:  map[language.Code]string{language.EN: "Turkish Lira", language.DE: "Türkische Lira", language.FR: "livre turque", language.IT: "lira turca", language.ES: "lira turca"},
+	},
+	INR: {
+		symbol:  "₹",
+		symbols: map[language.Code]string{language.ES: "INR"},
+		narrow:  "₹",
+		names:   map[language.Code]string{language.EN: "Indian Rupee", language.DE: "Indische Rupie", language.FR: "roupie indienne", language.IT: "rupia indiana", language.ES: "rupia india"},
+	},
+
+	// Historical currencies
+
+	HRK: {
+		narrow:     "kn",
+		names:      map[language.Code]string{language.EN: "Croatian Kuna", language.DE: "Kroatischer Kuna", language.FR: "kuna croate", language.IT: "kuna croata", language.ES: "kuna"},
+		historical: true,
+	},
+	"DEM": {
+		symbols:    map[language.Code]string{language.DE: "DM"},
+		narrow:     "DM",
+		names:      map[language.Code]string{language.EN: "German Mark", language.DE: "Deutsche Mark", language.FR: "mark allemand", language.IT: "marco tedesco", language.ES: "marco alemán"},
+		historical: true,
+	},
+	"ATS": {
+		symbols:    map[language.Code]string{language.DE: "öS"},
+		narrow:     "öS",
+		names:      map[language.Code]string{language.EN: "Austrian Schilling", language.DE: "Österreichischer Schilling", language.FR: "schilling autrichien", language.IT: "scellino austriaco", language.ES: "chelín austriaco"},
+		historical: true,
+	},
+	"FRF": {
+		symbols:    map[language.Code]string{language.FR: "F"},
+		narrow:     "F",
+		names:      map[language.Code]string{language.EN: "French Franc", language.DE: "Französischer Franc", language.FR: "franc français", language.IT: "franco francese", language.ES: "franco francés"},
+		historical: true,
+	},
+	"ITL": {
+		symbols:    map[language.Code]string{language.IT: "₤"},
+		narrow:     "₤",
+		names:      map[language.Code]string{language.EN: "Italian Lira", language.DE: "Italienische Lira", language.FR: "lire italienne", language.IT: "lira italiana", language.ES: "lira italiana"},
+		historical: true,
+	},
+	"ESP": {
+		symbols:    map[language.Code]string{language.ES: "₧"},
+		narrow:     "₧",
+		names:      map[language.Code]string{language.EN: "Spanish Peseta", language.DE: "Spanische Peseta", language.FR: "peseta espagnole", language.IT: "peseta spagnola", language.ES: "peseta"},
+		historical: true,
+	},
+	"NLG": {
+		narrow:     "ƒ",
+		names:      map[language.Code]string{language.EN: "Dutch Guilder", language.DE: "Niederländischer Gulden", language.FR: "florin néerlandais", language.IT: "fiorino olandese", language.ES: "florín neerlandés"},
+		historical: true,
+	},
+	"BEF": {
+		names:      map[language.Code]string{language.EN: "Belgian Franc", language.DE: "Belgischer Franc", language.FR: "franc belge", language.IT: "franco belga", language.ES: "franco belga"},
+		historical: true,
+	},
+}
@@ -0,0 +1,39 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/language"
+)
+
+func TestCurrency_LocalizedFormat(t *testing.T) {
+	tests := []struct {
+		currency Currency
+		lang     language.Code
+		want     CurrencyFormat
+	}{
+		{currency: "eur", lang: language.DE, want: CurrencyFormat{Currency: EUR, Symbol: "€", NarrowSymbol: "€", DisplayName: "Euro"}},
+		{currency: USD, lang: language.EN, want: CurrencyFormat{Currency: USD, Symbol: "$", NarrowSymbol: "$", DisplayName: "US Dollar"}},
+		{currency: USD, lang: language.FR, want: CurrencyFormat{Currency: USD, Symbol: "$US", NarrowSymbol: "$", DisplayName: "dollar des États-Unis"}},
+		{currency: USD, lang: language.ES, want: CurrencyFormat{Currency: USD, Symbol: "US$", NarrowSymbol: "$", DisplayName: "dólar estadounidense"}},
+		{currency: CHF, lang: language.DE, want: CurrencyFormat{Currency: CHF, Symbol: "CHF", NarrowSymbol: "CHF", DisplayName: "Schweizer Franken"}},
+		{currency: PLN, lang: "DE", want: CurrencyFormat{Currency: PLN, Symbol: "PLN", NarrowSymbol: "zł", DisplayName: "Polnischer Złoty"}},
+		// Fallback to English for languages without data
+		{currency: GBP, lang: language.NL, want: CurrencyFormat{Currency: GBP, Symbol: "£", NarrowSymbol: "£", DisplayName: "British Pound"}},
+		{currency: GBP, lang: "invalid", want: CurrencyFormat{Currency: GBP, Symbol: "£", NarrowSymbol: "£", DisplayName: "British Pound"}},
+		// Fallback to EnglishName without CLDR data
+		{currency: THB, lang: language.DE, want: CurrencyFormat{Currency: THB, Symbol: "THB", NarrowSymbol: "THB", DisplayName: "Thailand Baht"}},
+		// Historical currencies
+		{currency: "DEM", lang: language.DE, want: CurrencyFormat{Currency: "DEM", Symbol: "DM", NarrowSymbol: "DM", DisplayName: "Deutsche Mark", Historical: true}},
+		{currency: "ATS", lang: language.EN, want: CurrencyFormat{Currency: "ATS", Symbol: "ATS", NarrowSymbol: "öS", DisplayName: "Austrian Schilling", Historical: true}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.currency)+"/"+string(tt.lang), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.currency.LocalizedFormat(tt.lang))
+		})
+	}
+
+	assert.Equal(t, CurrencyFormat{}, NullableCurrency(CurrencyNull).LocalizedFormat(language.DE))
+}
@@ -0,0 +1,236 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/domonda/go-types/strutil"
+)
+
+// CurrencyPair is a foreign exchange currency pair
+// like EUR/USD where one unit of the Base currency
+// is quoted in units of the Quote currency.
+type CurrencyPair struct {
+	Base  Currency
+	Quote Currency
+}
+
+// ParseCurrencyPair parses a currency pair in the formats
+// "EUR/USD", "EUR-USD", "EUR USD", or "EURUSD".
+// The currencies of the returned pair are normalized.
+func ParseCurrencyPair(str string) (CurrencyPair, error) {
+	s := strutil.TrimSpace(str)
+	var base, quote string
+	if i := strings.IndexAny(s, "/- "); i >= 0 {
+		base, quote = s[:i], s[i+1:]
+	} else if len(s) == 6 {
+		base, quote = s[:3], s[3:]
+	} else {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q", str)
+	}
+	pair, err := NewCurrencyPair(Currency(base), Currency(quote))
+	if err != nil {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q: %w", str, err)
+	}
+	return pair, nil
+}
+
+// NewCurrencyPair returns a CurrencyPair with the normalized
+// base and quote currencies or an error if one of them is invalid
+// or both are the same currency.
+func NewCurrencyPair(base, quote Currency) (CurrencyPair, error) {
+	pair := CurrencyPair{Base: base, Quote: quote}
+	return pair.Normalized()
+}
+
+// Normalized returns the pair with normalized currencies
+// or an error if the pair is not valid.
+func (p CurrencyPair) Normalized() (CurrencyPair, error) {
+	base, err := p.Base.Normalized()
+	if err != nil {
+		return p, err
+	}
+	quote, err := p.Quote.Normalized()
+	if err != nil {
+		return p, err
+	}
+	if base == quote {
+		return p, fmt.Errorf("currency pair with same base and quote currency %s", base)
+	}
+	return CurrencyPair{Base: base, Quote: quote}, nil
+}
+
+// Validate returns an error if the pair is not valid.
+func (p CurrencyPair) Validate() error {
+	_, err := p.Normalized()
+	return err
+}
+
+// Valid returns if the pair is valid.
+func (p CurrencyPair) Valid() bool {
+	return p.Validate() == nil
+}
+
+// IsZero returns if the pair has no currencies set.
+func (p CurrencyPair) IsZero() bool {
+	return p.Base == "" && p.Quote == ""
+}
+
+// Inverted returns the pair with swapped Base and Quote currencies.
+func (p CurrencyPair) Inverted() CurrencyPair {
+	return CurrencyPair{Base: p.Quote, Quote: p.Base}
+}
+
+// Contains returns if c is the Base or Quote currency of the pair.
+func (p CurrencyPair) Contains(c Currency) bool {
+	return p.Base == c || p.Quote == c
+}
+
+// String returns the pair in the format "EUR/USD".
+// String implements the fmt.Stringer interface.
+func (p CurrencyPair) String() string {
+	return string(p.Base) + "/" + string(p.Quote)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p CurrencyPair) MarshalText() ([]byte, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// An empty text will set the zero value.
+func (p *CurrencyPair) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = CurrencyPair{}
+		return nil
+	}
+	pair, err := ParseCurrencyPair(string(text))
+	if err != nil {
+		return err
+	}
+	*p = pair
+	return nil
+}
+
+// Quote is a foreign exchange rate quote for a CurrencyPair
+// with the Bid and Ask rates in units of the Quote currency
+// per one unit of the Base currency.
+type Quote struct {
+	Pair CurrencyPair `json:"pair"`
+	Bid  Rate         `json:"bid"`
+	Ask  Rate         `json:"ask"`
+	// Time is the time of the quote.
+	Time time.Time `json:"time"`
+	// Source is an optional name of the rate provider.
+	Source string `json:"source,omitempty"`
+}
+
+// NewMidQuote returns a Quote with Bid and Ask set to mid
+// for providers that only publish a single reference rate.
+func NewMidQuote(pair CurrencyPair, mid Rate, t time.Time, source string) Quote {
+	return Quote{Pair: pair, Bid: mid, Ask: mid, Time: t, Source: source}
+}
+
+// Mid returns the mid rate between Bid and Ask.
+func (q Quote) Mid() Rate {
+	return (q.Bid + q.Ask) / 2
+}
+
+// Spread returns the difference between Ask and Bid.
+func (q Quote) Spread() Rate {
+	return q.Ask - q.Bid
+}
+
+// Validate returns an error if the pair of the quote is invalid,
+// if Bid or Ask are not valid positive rates,
+// or if Bid is greater than Ask.
+func (q Quote) Validate() error {
+	if err := q.Pair.Validate(); err != nil {
+		return err
+	}
+	if !q.Bid.ValidAndGreaterZero() {
+		return fmt.Errorf("invalid %s bid rate %v", q.Pair, q.Bid)
+	}
+	if !q.Ask.ValidAndGreaterZero() {
+		return fmt.Errorf("invalid %s ask rate %v", q.Pair, q.Ask)
+	}
+	if q.Bid > q.Ask {
+		return fmt.Errorf("%s bid rate %v greater than ask rate %v", q.Pair, q.Bid, q.Ask)
+	}
+	return nil
+}
+
+// Valid returns if the quote is valid.
+func (q Quote) Valid() bool {
+	return q.Validate() == nil
+}
+
+// Inverted returns the quote for the inverted pair.
+// The inverted Bid is the inverse of the Ask and vice versa.
+func (q Quote) Inverted() Quote {
+	return Quote{
+		Pair:   q.Pair.Inverted(),
+		Bid:    q.Ask.Inverse(),
+		Ask:    q.Bid.Inverse(),
+		Time:   q.Time,
+		Source: q.Source,
+	}
+}
+
+// For returns the quote for pair which must be
+// either the pair of the quote or its inverted pair.
+func (q Quote) For(pair CurrencyPair) (Quote, error) {
+	switch pair {
+	case q.Pair:
+		return q, nil
+	case q.Pair.Inverted():
+		return q.Inverted(), nil
+	}
+	return Quote{}, fmt.Errorf("quote for %s can't be used for %s", q.Pair, pair)
+}
+
+// CrossQuote computes the quote for the currency pair
+// formed by the currencies of a and b that are not shared.
+// The currency shared by a and b is used as intermediary.
+// The resulting Base is the other currency of a and
+// the resulting Quote is the other currency of b,
+// so EUR/USD crossed with USD/JPY results in EUR/JPY.
+//
+// The Time of the result is the older of the two quotes,
+// the Source is only set if both quotes have the same source.
+func CrossQuote(a, b Quote) (Quote, error) {
+	var common Currency
+	switch {
+	case b.Pair.Contains(a.Pair.Quote):
+		common = a.Pair.Quote
+	case b.Pair.Contains(a.Pair.Base):
+		common = a.Pair.Base
+		a = a.Inverted()
+	default:
+		return Quote{}, fmt.Errorf("quotes %s and %s have no common currency", a.Pair, b.Pair)
+	}
+	if b.Pair.Quote == common {
+		b = b.Inverted()
+	}
+	if a.Pair.Base == b.Pair.Quote {
+		return Quote{}, errors.New("cross quote of a currency pair with itself")
+	}
+	cross := Quote{
+		Pair: CurrencyPair{Base: a.Pair.Base, Quote: b.Pair.Quote},
+		Bid:  a.Bid * b.Bid,
+		Ask:  a.Ask * b.Ask,
+		Time: a.Time,
+	}
+	if b.Time.Before(cross.Time) {
+		cross.Time = b.Time
+	}
+	if a.Source == b.Source {
+		cross.Source = a.Source
+	}
+	return cross, nil
+}
@@ -0,0 +1,82 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCurrencyPair(t *testing.T) {
+	eurUSD := CurrencyPair{Base: "EUR", Quote: "USD"}
+	for _, str := range []string{"EUR/USD", "eur/usd", " EUR-USD ", "EUR USD", "EURUSD", "€/USD"} {
+		pair, err := ParseCurrencyPair(str)
+		require.NoError(t, err, str)
+		require.Equal(t, eurUSD, pair, str)
+	}
+	for _, str := range []string{"", "EUR", "EUR/EUR", "EUR/XXXX", "EURUSDX"} {
+		_, err := ParseCurrencyPair(str)
+		require.Error(t, err, str)
+	}
+
+	require.Equal(t, "USD/EUR", eurUSD.Inverted().String())
+
+	j, err := json.Marshal(eurUSD)
+	require.NoError(t, err)
+	require.Equal(t, `"EUR/USD"`, string(j))
+	var parsed CurrencyPair
+	require.NoError(t, json.Unmarshal(j, &parsed))
+	require.Equal(t, eurUSD, parsed)
+}
+
+func TestQuote(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	q := Quote{Pair: CurrencyPair{"EUR", "USD"}, Bid: 1.0, Ask: 1.25, Time: now, Source: "ECB"}
+	require.NoError(t, q.Validate())
+	require.Equal(t, Rate(1.125), q.Mid())
+	require.Equal(t, Rate(0.25), q.Spread())
+
+	inv := q.Inverted()
+	require.Equal(t, CurrencyPair{"USD", "EUR"}, inv.Pair)
+	require.Equal(t, Rate(0.8), inv.Bid)
+	require.Equal(t, Rate(1.0), inv.Ask)
+	require.NoError(t, inv.Validate())
+
+	same, err := inv.For(q.Pair)
+	require.NoError(t, err)
+	require.Equal(t, q, same)
+	_, err = q.For(CurrencyPair{"EUR", "CHF"})
+	require.Error(t, err)
+
+	require.Error(t, Quote{Pair: q.Pair, Bid: 2, Ask: 1}.Validate())
+	require.Error(t, Quote{Pair: q.Pair, Bid: 0, Ask: 1}.Validate())
+}
+
+func TestCrossQuote(t *testing.T) {
+	t1 := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+	eurUSD := NewMidQuote(CurrencyPair{"EUR", "USD"}, 1.25, t2, "A")
+	usdJPY := NewMidQuote(CurrencyPair{"USD", "JPY"}, 100, t1, "A")
+
+	cross, err := CrossQuote(eurUSD, usdJPY)
+	require.NoError(t, err)
+	require.Equal(t, CurrencyPair{"EUR", "JPY"}, cross.Pair)
+	require.Equal(t, Rate(125), cross.Mid())
+	require.Equal(t, t1, cross.Time)
+	require.Equal(t, "A", cross.Source)
+
+	// Common currency in other positions
+	jpyUSD := usdJPY.Inverted()
+	jpyUSD.Source = "B"
+	cross, err = CrossQuote(eurUSD.Inverted(), jpyUSD)
+	require.NoError(t, err)
+	require.Equal(t, CurrencyPair{"EUR", "JPY"}, cross.Pair)
+	require.InDelta(t, 125, float64(cross.Mid()), 1e-9)
+	require.Empty(t, cross.Source)
+
+	_, err = CrossQuote(eurUSD, NewMidQuote(CurrencyPair{"CHF", "JPY"}, 170, t1, ""))
+	require.Error(t, err)
+	_, err = CrossQuote(eurUSD, eurUSD.Inverted())
+	require.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+)
+
+// ProratedPart is a part of a prorated total
+// with the audit trail of its rounding.
+type ProratedPart struct {
+	// Weight is the passed weight of the part.
+	Weight float64 `json:"weight"`
+	// Exact is the unrounded share of the total.
+	Exact Amount `json:"exact"`
+	// Amount is the rounded share of the total.
+	Amount Amount `json:"amount"`
+	// Delta is Amount minus Exact.
+	Delta Amount `json:"delta"`
+}
+
+// Prorate distributes the total rounded to cents to parts
+// proportional to the passed weights using the largest remainder method,
+// so the sum of the part amounts is exactly the rounded total.
+// See ProrateDecimals.
+func Prorate(total Amount, weights []float64) ([]ProratedPart, error) {
+	return ProrateDecimals(total, weights, 2)
+}
+
+// Prorate distributes the amount using the minor units
+// of the currency as decimals.
+// See ProrateDecimals.
+func (ca CurrencyAmount) Prorate(weights []float64) ([]ProratedPart, error) {
+	return ProrateDecimals(ca.Amount, weights, ca.Currency.MinorUnits())
+}
+
+// ProrateDecimals distributes the total rounded to the passed decimals
+// to parts proportional to the passed weights
+// so that the sum of the part amounts is exactly the rounded total.
+//
+// Every part first gets its exact share rounded toward zero,
+// then the remaining smallest units are given one by one
+// to the parts with the largest remainders of their exact shares.
+// Equal remainders are broken by the order of the weights,
+// so the result is deterministic.
+//
+// Weights must be finite and not negative with a sum greater zero.
+// They don't have to sum up to 1 or 100.
+func ProrateDecimals(total Amount, weights []float64, decimals int) ([]ProratedPart, error) {
+	if !total.Valid() {
+		return nil, fmt.Errorf("can't prorate invalid amount %f", total)
+	}
+	if len(weights) == 0 {
+		return nil, errors.New("no weights to prorate")
+	}
+	weightSum := new(big.Rat)
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid prorate weight %f at index %d", w, i)
+		}
+		weightSum.Add(weightSum, new(big.Rat).SetFloat64(w))
+	}
+	if weightSum.Sign() == 0 {
+		return nil, errors.New("sum of prorate weights is zero")
+	}
+
+	pow := math.Pow10(decimals)
+	sign := int64(1)
+	if total < 0 {
+		sign = -1
+	}
+	units := int64(math.Round(math.Abs(float64(total)) * pow))
+	totalUnits := new(big.Rat).SetInt64(units)
+
+	var (
+		parts      = make([]ProratedPart, len(weights))
+		partUnits  = make([]int64, len(weights))
+		remainders = make([]*big.Rat, len(weights))
+		leftover   = units
+	)
+	for i, w := range weights {
+		share := new(big.Rat).SetFloat64(w)
+		share.Mul(share, totalUnits).Quo(share, weightSum)
+		floor := new(big.Int).Quo(share.Num(), share.Denom())
+		partUnits[i] = floor.Int64()
+		remainders[i] = share.Sub(share, new(big.Rat).SetInt(floor))
+		leftover -= partUnits[i]
+
+		exact, _ := new(big.Rat).Quo(new(big.Rat).SetFloat64(w), weightSum).Float64()
+		parts[i].Weight = w
+		parts[i].Exact = Amount(exact * float64(total))
+	}
+
+	// Give the leftover units to the largest remainders
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return remainders[b].Cmp(remainders[a])
+	})
+	for _, i := range order[:leftover] {
+		partUnits[i]++
+	}
+
+	for i := range parts {
+		parts[i].Amount = Amount(float64(sign*partUnits[i]) / pow)
+		parts[i].Delta = parts[i].Amount - parts[i].Exact
+	}
+	return parts, nil
+}
@@ -0,0 +1,63 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProrate(t *testing.T) {
+	amounts := func(parts []ProratedPart) []Amount {
+		result := make([]Amount, len(parts))
+		for i, p := range parts {
+			result[i] = p.Amount
+		}
+		return result
+	}
+
+	parts, err := Prorate(100, []float64{1, 1, 1})
+	require.NoError(t, err)
+	require.Equal(t, []Amount{33.34, 33.33, 33.33}, amounts(parts), "tie broken by order")
+	require.InDelta(t, 33.333333, float64(parts[0].Exact), 0.000001)
+	require.InDelta(t, 0.006667, float64(parts[0].Delta), 0.000001)
+	require.InDelta(t, -0.003333, float64(parts[1].Delta), 0.000001)
+
+	parts, err = Prorate(-100, []float64{1, 1, 1})
+	require.NoError(t, err)
+	require.Equal(t, []Amount{-33.34, -33.33, -33.33}, amounts(parts))
+
+	// Exact shares 5, 2, 1.5, 1, 0.5 cents with the tie of .5 broken by order
+	parts, err = Prorate(0.10, []float64{50, 20, 15, 10, 5})
+	require.NoError(t, err)
+	require.Equal(t, []Amount{0.05, 0.02, 0.02, 0.01, 0.00}, amounts(parts))
+
+	parts, err = Prorate(1000, []float64{0.3, 0, 0.7})
+	require.NoError(t, err)
+	require.Equal(t, []Amount{300, 0, 700}, amounts(parts))
+
+	parts, err = CurrencyAmount{Currency: JPY, Amount: 1000}.Prorate([]float64{1, 1, 1})
+	require.NoError(t, err)
+	require.Equal(t, []Amount{334, 333, 333}, amounts(parts))
+
+	// Sum is always exact
+	weights := []float64{0.17, 3.3, 12, 1e-9, 7.77, 0.5}
+	for _, total := range []Amount{0, 0.01, 99.99, 1234567.89, -42.42} {
+		parts, err = Prorate(total, weights)
+		require.NoError(t, err)
+		var sumCents int64
+		for _, p := range parts {
+			sumCents += p.Amount.Cents()
+		}
+		require.Equal(t, total.Cents(), sumCents, total)
+	}
+
+	_, err = Prorate(100, nil)
+	require.Error(t, err)
+	_, err = Prorate(100, []float64{0, 0})
+	require.Error(t, err)
+	_, err = Prorate(100, []float64{1, -1})
+	require.Error(t, err)
+	_, err = Prorate(Amount(math.NaN()), []float64{1})
+	require.Error(t, err)
+}
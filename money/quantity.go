@@ -0,0 +1,138 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantity is an amount of units like 2.5 hours
+// that can be multiplied with a unit price.
+type Quantity struct {
+	// Value is the number of units.
+	Value float64 `json:"value"`
+	// Unit is the optional unit code like the
+	// UN/ECE Recommendation 20 codes "HUR" for hour
+	// or "C62" for one piece used by EN 16931.
+	// Units are compared case-insensitive.
+	Unit string `json:"unit,omitempty"`
+}
+
+// Valid returns true if the value is finite.
+func (q Quantity) Valid() bool {
+	return !math.IsNaN(q.Value) && !math.IsInf(q.Value, 0)
+}
+
+// String returns the value followed by the unit
+// separated by a space like "2.5 HUR".
+// String implements the fmt.Stringer interface.
+func (q Quantity) String() string {
+	s := strconv.FormatFloat(q.Value, 'f', -1, 64)
+	if q.Unit == "" {
+		return s
+	}
+	return s + " " + q.Unit
+}
+
+// SameUnit returns true if both quantities
+// have the same case-insensitive unit.
+func (q Quantity) SameUnit(other Quantity) bool {
+	return strings.EqualFold(strings.TrimSpace(q.Unit), strings.TrimSpace(other.Unit))
+}
+
+// Add returns the sum of both quantities with the unit of q
+// or an error if the units are different.
+func (q Quantity) Add(other Quantity) (Quantity, error) {
+	if !q.SameUnit(other) {
+		return q, fmt.Errorf("can't add quantity %s to %s with a different unit", other, q)
+	}
+	q.Value += other.Value
+	return q, nil
+}
+
+// MulQuantity returns the line total of the amount as unit price
+// multiplied with the quantity rounded with the passed rounding rule.
+// This is the line net amount of EN 16931 (BT-131)
+// for a price base quantity of one.
+// Use SumQuantityLines to control the rounding order for multiple lines.
+func (a Amount) MulQuantity(q Quantity, rounding Rounding) Amount {
+	return rounding.Round(a * Amount(q.Value))
+}
+
+// LineRoundingOrder defines when the line totals
+// of SumQuantityLines are rounded.
+type LineRoundingOrder int
+
+const (
+	// RoundAtLine rounds every line total
+	// and sums up the rounded line totals.
+	// This is the calculation required by EN 16931
+	// where the sum of line net amounts (BT-106)
+	// is the sum of the rounded line net amounts (BT-131).
+	RoundAtLine LineRoundingOrder = iota
+	// RoundAtTotal sums up the unrounded line totals
+	// and only rounds the sum, which can differ by some
+	// cents from the sum of the rounded line totals.
+	RoundAtTotal
+)
+
+// QuantityLine is an invoice line with a unit price
+// that is multiplied with a quantity.
+type QuantityLine struct {
+	// UnitPrice is the price for BaseQuantity units.
+	UnitPrice Amount `json:"unitPrice"`
+	// Quantity is the invoiced quantity.
+	Quantity Quantity `json:"quantity"`
+	// BaseQuantity is the optional number of units
+	// the UnitPrice is valid for (EN 16931 BT-149).
+	// Zero means one unit.
+	BaseQuantity float64 `json:"baseQuantity,omitempty"`
+}
+
+// Total returns UnitPrice multiplied with Quantity
+// divided by BaseQuantity without rounding.
+func (l QuantityLine) Total() Amount {
+	total := l.UnitPrice * Amount(l.Quantity.Value)
+	if l.BaseQuantity != 0 {
+		total /= Amount(l.BaseQuantity)
+	}
+	return total
+}
+
+// SumQuantityLines returns the totals of the passed lines
+// and their sum using the passed rounding rule and order.
+//
+// With RoundAtLine every returned line total is rounded
+// and the total is the sum of the rounded line totals.
+// With RoundAtTotal the returned line totals are not rounded
+// and only the total is rounded.
+//
+// Returns an error for an invalid rounding, an invalid unit price
+// or quantity, or a negative BaseQuantity.
+func SumQuantityLines(lines []QuantityLine, order LineRoundingOrder, rounding Rounding) (lineTotals []Amount, total Amount, err error) {
+	if err = rounding.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if order != RoundAtLine && order != RoundAtTotal {
+		return nil, 0, fmt.Errorf("invalid money.LineRoundingOrder %d", int(order))
+	}
+	lineTotals = make([]Amount, len(lines))
+	for i, line := range lines {
+		switch {
+		case !line.UnitPrice.Valid():
+			return nil, 0, fmt.Errorf("invalid unit price %s at line %d", line.UnitPrice.GoString(), i)
+		case !line.Quantity.Valid():
+			return nil, 0, fmt.Errorf("invalid quantity %s at line %d", line.Quantity, i)
+		case line.BaseQuantity < 0 || math.IsNaN(line.BaseQuantity) || math.IsInf(line.BaseQuantity, 0):
+			return nil, 0, fmt.Errorf("invalid base quantity %v at line %d", line.BaseQuantity, i)
+		}
+		lineTotals[i] = line.Total()
+		if order == RoundAtLine {
+			lineTotals[i] = rounding.Round(lineTotals[i])
+		}
+		total += lineTotals[i]
+	}
+	// Also with RoundAtLine to remove float64 representation errors of the sum
+	return lineTotals, rounding.Round(total), nil
+}
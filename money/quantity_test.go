@@ -0,0 +1,60 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantity(t *testing.T) {
+	hours := Quantity{Value: 2.5, Unit: "HUR"}
+	require.True(t, hours.Valid())
+	require.False(t, Quantity{Value: math.NaN()}.Valid())
+	require.Equal(t, "2.5 HUR", hours.String())
+	require.Equal(t, "3", Quantity{Value: 3}.String())
+
+	sum, err := hours.Add(Quantity{Value: 1, Unit: "hur"})
+	require.NoError(t, err)
+	require.Equal(t, Quantity{Value: 3.5, Unit: "HUR"}, sum)
+	_, err = hours.Add(Quantity{Value: 1, Unit: "C62"})
+	require.Error(t, err)
+}
+
+func TestAmount_MulQuantity(t *testing.T) {
+	require.Equal(t, Amount(33.33), Amount(13.333).MulQuantity(Quantity{Value: 2.5, Unit: "HUR"}, DefaultRounding))
+	require.Equal(t, Amount(-0.35), Amount(-0.115).MulQuantity(Quantity{Value: 3}, DefaultRounding))
+}
+
+func TestSumQuantityLines(t *testing.T) {
+	lines := []QuantityLine{
+		{UnitPrice: 0.333, Quantity: Quantity{Value: 1}},
+		{UnitPrice: 0.333, Quantity: Quantity{Value: 1}},
+		{UnitPrice: 0.333, Quantity: Quantity{Value: 1}},
+		{UnitPrice: 25, Quantity: Quantity{Value: 3, Unit: "C62"}, BaseQuantity: 10},
+	}
+
+	lineTotals, total, err := SumQuantityLines(lines, RoundAtLine, DefaultRounding)
+	require.NoError(t, err)
+	require.Equal(t, []Amount{0.33, 0.33, 0.33, 7.5}, lineTotals)
+	require.Equal(t, Amount(8.49), total)
+
+	lineTotals, total, err = SumQuantityLines(lines, RoundAtTotal, DefaultRounding)
+	require.NoError(t, err)
+	require.Equal(t, []Amount{0.333, 0.333, 0.333, 7.5}, lineTotals)
+	require.Equal(t, Amount(8.5), total)
+
+	lineTotals, total, err = SumQuantityLines(nil, RoundAtLine, DefaultRounding)
+	require.NoError(t, err)
+	require.Empty(t, lineTotals)
+	require.Zero(t, total)
+
+	_, _, err = SumQuantityLines(lines, LineRoundingOrder(9), DefaultRounding)
+	require.Error(t, err)
+	_, _, err = SumQuantityLines([]QuantityLine{{UnitPrice: 1, Quantity: Quantity{Value: math.Inf(1)}}}, RoundAtLine, DefaultRounding)
+	require.Error(t, err)
+	_, _, err = SumQuantityLines([]QuantityLine{{UnitPrice: 1, BaseQuantity: -1}}, RoundAtLine, DefaultRounding)
+	require.Error(t, err)
+	_, _, err = SumQuantityLines(lines, RoundAtLine, Rounding{Decimals: -1})
+	require.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package money
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+
+	"github.com/domonda/go-types"
+)
+
+// RoundingMode determines how amounts are rounded
+// to the decimals or increment of a Rounding.
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds ties away from zero,
+	// like 2.345 to 2.35 and -2.345 to -2.35.
+	// This is the commercial rounding used by Amount.RoundToCents.
+	RoundHalfAwayFromZero RoundingMode = iota
+	// RoundHalfEven rounds ties to the nearest even digit,
+	// like 2.345 to 2.34 and 2.355 to 2.36 (banker's rounding).
+	RoundHalfEven
+	// RoundHalfUp rounds ties towards positive infinity.
+	RoundHalfUp
+	// RoundHalfDown rounds ties towards negative infinity.
+	RoundHalfDown
+	// RoundAwayFromZero always rounds away from zero.
+	RoundAwayFromZero
+	// RoundTowardZero always rounds towards zero (truncation).
+	RoundTowardZero
+	// RoundCeiling always rounds towards positive infinity.
+	RoundCeiling
+	// RoundFloor always rounds towards negative infinity.
+	RoundFloor
+)
+
+// Valid returns true if the mode is one of the defined constants.
+func (m RoundingMode) Valid() bool {
+	return m >= RoundHalfAwayFromZero && m <= RoundFloor
+}
+
+// String implements the fmt.Stringer interface.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfAwayFromZero:
+		return "HALF_AWAY_FROM_ZERO"
+	case RoundHalfEven:
+		return "HALF_EVEN"
+	case RoundHalfUp:
+		return "HALF_UP"
+	case RoundHalfDown:
+		return "HALF_DOWN"
+	case RoundAwayFromZero:
+		return "AWAY_FROM_ZERO"
+	case RoundTowardZero:
+		return "TOWARD_ZERO"
+	case RoundCeiling:
+		return "CEILING"
+	case RoundFloor:
+		return "FLOOR"
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int(m))
+}
+
+// Rounding is a rounding rule for amounts.
+type Rounding struct {
+	Mode RoundingMode
+	// Decimals is the number of decimal places to round to
+	// and to format amounts with.
+	Decimals int
+	// Increment is the optional smallest unit to round to
+	// for cash rounding like 0.05 for the Swiss franc.
+	// Amounts are rounded to Decimals if Increment is zero.
+	Increment Amount
+}
+
+// DefaultRounding rounds half away from zero to cents
+// like Amount.RoundToCents.
+// It is used if a context has no Rounding.
+var DefaultRounding = Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2}
+
+// Validate returns an error if the rounding has an invalid mode,
+// negative decimals, or a negative or invalid increment.
+func (r Rounding) Validate() error {
+	switch {
+	case !r.Mode.Valid():
+		return fmt.Errorf("invalid money.RoundingMode %d", int(r.Mode))
+	case r.Decimals < 0:
+		return fmt.Errorf("negative money.Rounding decimals %d", r.Decimals)
+	case r.Increment < 0 || !r.Increment.Valid():
+		return fmt.Errorf("invalid money.Rounding increment %s", r.Increment.GoString())
+	}
+	return nil
+}
+
+// Round returns the amount rounded by the rounding rule.
+// NaN and infinite amounts are returned unchanged.
+func (r Rounding) Round(a Amount) Amount {
+	if !a.Valid() {
+		return a
+	}
+	if r.Increment > 0 {
+		units := roundWithMode(float64(a/r.Increment), r.Mode)
+		return (Amount(units) * r.Increment).RoundToDecimals(max(r.Decimals, 0))
+	}
+	pow := math.Pow10(max(r.Decimals, 0))
+	return Amount(roundWithMode(float64(a)*pow, r.Mode) / pow)
+}
+
+// Format returns the amount rounded by the rounding rule
+// and formatted with its decimals.
+// See Amount.Format for the separator arguments.
+func (r Rounding) Format(a Amount, thousandsSep, decimalSep rune) string {
+	return r.Round(a).Format(thousandsSep, decimalSep, max(r.Decimals, 0))
+}
+
+// roundWithMode rounds x to an integer.
+// Representation errors of the float64 multiplication
+// for the decimals like 2.675 * 100 = 267.49999999999997
+// are removed before rounding, so that decimal ties
+// and integers are recognized as such.
+func roundWithMode(x float64, mode RoundingMode) float64 {
+	if snapped := math.Round(x*1e6) / 1e6; !math.IsInf(snapped, 0) {
+		x = snapped
+	}
+	switch mode {
+	case RoundHalfEven:
+		return math.RoundToEven(x)
+	case RoundHalfUp:
+		return math.Floor(x + 0.5)
+	case RoundHalfDown:
+		return math.Ceil(x - 0.5)
+	case RoundAwayFromZero:
+		if x < 0 {
+			return math.Floor(x)
+		}
+		return math.Ceil(x)
+	case RoundTowardZero:
+		return math.Trunc(x)
+	case RoundCeiling:
+		return math.Ceil(x)
+	case RoundFloor:
+		return math.Floor(x)
+	default:
+		return math.Round(x)
+	}
+}
+
+var roundingCtxKey int
+
+// ContextWithRounding returns a context with the passed rounding rule
+// that is used by the context aware rounding and formatting
+// helpers like Amount.RoundContext instead of DefaultRounding.
+// This way multi-tenant services can apply different
+// legal rounding rules per request.
+func ContextWithRounding(ctx context.Context, rounding Rounding) context.Context {
+	return context.WithValue(ctx, &roundingCtxKey, rounding)
+}
+
+// RoundingFromContext returns the rounding rule added to the context
+// with ContextWithRounding or DefaultRounding.
+func RoundingFromContext(ctx context.Context) Rounding {
+	if rounding, ok := ctx.Value(&roundingCtxKey).(Rounding); ok {
+		return rounding
+	}
+	return DefaultRounding
+}
+
+// RoundContext returns the amount rounded
+// by the rounding rule of the context.
+func (a Amount) RoundContext(ctx context.Context) Amount {
+	return RoundingFromContext(ctx).Round(a)
+}
+
+// StringContext returns the amount rounded by the rounding rule
+// of the context formatted with its decimals
+// and a dot as decimal separator.
+func (a Amount) StringContext(ctx context.Context) string {
+	return RoundingFromContext(ctx).Format(a, 0, '.')
+}
+
+// MultipliedByRateContext returns the amount multiplied by a rate
+// rounded by the rounding rule of the context.
+func (a Amount) MultipliedByRateContext(ctx context.Context, rate Rate) Amount {
+	return a.MultipliedByRate(rate).RoundContext(ctx)
+}
+
+// PercentageContext returns the amount multiplied by (percent / 100)
+// rounded by the rounding rule of the context.
+func (a Amount) PercentageContext(ctx context.Context, percent float64) Amount {
+	return a.Percentage(percent).RoundContext(ctx)
+}
+
+// SplitEquallyContext divides the amount equally into count amounts
+// that are rounded by the rounding rule of the context
+// and sum up to the initial amount rounded by the same rule.
+// The last amount may slightly differ from the others amounts
+// to guarantee that the sum of the rounded amounts
+// equals the rounded initial amount.
+func (a Amount) SplitEquallyContext(ctx context.Context, count int) []Amount {
+	if count < 1 {
+		return nil
+	}
+	rounding := RoundingFromContext(ctx)
+	// Cleanup of float errors independent of the rounding mode
+	cleanup := Rounding{Mode: RoundHalfAwayFromZero, Decimals: rounding.Decimals}
+	result := make([]Amount, count)
+	part := rounding.Round(a / Amount(count))
+	for i := range count - 1 {
+		result[i] = part
+	}
+	result[count-1] = cleanup.Round(rounding.Round(a) - part*Amount(count-1))
+	return result
+}
+
+// RoundContext returns the currency amount with the amount
+// rounded by the rounding rule of the context.
+func (ca CurrencyAmount) RoundContext(ctx context.Context) CurrencyAmount {
+	return CurrencyAmount{Currency: ca.Currency, Amount: ca.Amount.RoundContext(ctx)}
+}
+
+// StringContext returns the currency followed by the amount
+// rounded and formatted by the rounding rule of the context.
+func (ca CurrencyAmount) StringContext(ctx context.Context) string {
+	rounding := RoundingFromContext(ctx)
+	return CurrencyAmount{Currency: ca.Currency, Amount: rounding.Round(ca.Amount)}.
+		Format(true, 0, '.', max(rounding.Decimals, 0))
+}
+
+// Compile-time check that the amount types implement types.ValuerContext
+var (
+	_ types.ValuerContext = Amount(0)
+	_ types.ValuerContext = CurrencyAmount{}
+)
+
+// ValueContext implements the types.ValuerContext interface
+// by returning the amount rounded by the rounding rule
+// of the context as float64.
+func (a Amount) ValueContext(ctx context.Context) (driver.Value, error) {
+	return float64(a.RoundContext(ctx)), nil
+}
+
+// ValueContext implements the types.ValuerContext interface
+// by returning the result of the StringContext method.
+func (ca CurrencyAmount) ValueContext(ctx context.Context) (driver.Value, error) {
+	return ca.StringContext(ctx), nil
+}
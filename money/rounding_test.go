@@ -0,0 +1,95 @@
+package money
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types"
+)
+
+func TestRounding_Round(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding Rounding
+		amount   Amount
+		want     Amount
+	}{
+		{name: "default", rounding: DefaultRounding, amount: 2.345, want: 2.35},
+		{name: "default negative", rounding: DefaultRounding, amount: -2.345, want: -2.35},
+		{name: "float tie", rounding: DefaultRounding, amount: 2.675, want: 2.68},
+		{name: "half even down", rounding: Rounding{Mode: RoundHalfEven, Decimals: 2}, amount: 2.345, want: 2.34},
+		{name: "half even up", rounding: Rounding{Mode: RoundHalfEven, Decimals: 2}, amount: 2.355, want: 2.36},
+		{name: "half up negative", rounding: Rounding{Mode: RoundHalfUp, Decimals: 2}, amount: -2.345, want: -2.34},
+		{name: "half down", rounding: Rounding{Mode: RoundHalfDown, Decimals: 2}, amount: 2.345, want: 2.34},
+		{name: "away from zero", rounding: Rounding{Mode: RoundAwayFromZero, Decimals: 2}, amount: -2.341, want: -2.35},
+		{name: "toward zero", rounding: Rounding{Mode: RoundTowardZero, Decimals: 2}, amount: -2.349, want: -2.34},
+		{name: "ceiling", rounding: Rounding{Mode: RoundCeiling, Decimals: 2}, amount: -2.349, want: -2.34},
+		{name: "floor", rounding: Rounding{Mode: RoundFloor, Decimals: 2}, amount: 2.349, want: 2.34},
+		{name: "floor exact", rounding: Rounding{Mode: RoundFloor, Decimals: 2}, amount: 0.29, want: 0.29},
+		{name: "no decimals", rounding: Rounding{Mode: RoundHalfEven}, amount: 2.5, want: 2},
+		{name: "cash rounding", rounding: Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2, Increment: 0.05}, amount: 1.025, want: 1.05},
+		{name: "cash rounding down", rounding: Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2, Increment: 0.05}, amount: 1.02, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.rounding.Round(tt.amount))
+		})
+	}
+}
+
+func TestRounding_Validate(t *testing.T) {
+	require.NoError(t, DefaultRounding.Validate())
+	require.NoError(t, Rounding{Mode: RoundFloor, Increment: 0.05}.Validate())
+	require.Error(t, Rounding{Mode: RoundFloor + 1}.Validate())
+	require.Error(t, Rounding{Decimals: -1}.Validate())
+	require.Error(t, Rounding{Increment: -0.05}.Validate())
+}
+
+func TestContextWithRounding(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, DefaultRounding, RoundingFromContext(ctx))
+	require.Equal(t, Amount(2.35), Amount(2.345).RoundContext(ctx))
+	require.Equal(t, "2.35", Amount(2.345).StringContext(ctx))
+
+	swiss := Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2, Increment: 0.05}
+	ctx = ContextWithRounding(ctx, swiss)
+	require.Equal(t, swiss, RoundingFromContext(ctx))
+	require.Equal(t, Amount(1.05), Amount(1.03).RoundContext(ctx))
+	require.Equal(t, "1.05", Amount(1.03).StringContext(ctx))
+	require.Equal(t, Amount(0.15), Amount(1.5).PercentageContext(ctx, 10))
+	require.Equal(t, Amount(1.25), Amount(10).MultipliedByRateContext(ctx, 0.123))
+	require.Equal(t, "CHF 1.05", CurrencyAmount{Currency: "CHF", Amount: 1.03}.StringContext(ctx))
+	require.Equal(t, CurrencyAmount{Currency: "CHF", Amount: 1.05}, CurrencyAmount{Currency: "CHF", Amount: 1.03}.RoundContext(ctx))
+
+	yen := ContextWithRounding(context.Background(), Rounding{Mode: RoundHalfEven})
+	require.Equal(t, "1000", Amount(999.5).StringContext(yen))
+	require.Equal(t, "JPY 1000", CurrencyAmount{Currency: "JPY", Amount: 1000.5}.StringContext(yen))
+}
+
+func TestAmount_SplitEquallyContext(t *testing.T) {
+	ctx := ContextWithRounding(context.Background(), Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2, Increment: 0.05})
+	parts := Amount(10).SplitEquallyContext(ctx, 3)
+	require.Equal(t, []Amount{3.35, 3.35, 3.3}, parts)
+	require.Nil(t, Amount(10).SplitEquallyContext(ctx, 0))
+
+	parts = Amount(100).SplitEquallyContext(context.Background(), 3)
+	require.Equal(t, []Amount{33.33, 33.33, 33.34}, parts)
+}
+
+func TestValueContext(t *testing.T) {
+	ctx := ContextWithRounding(context.Background(), Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2, Increment: 0.05})
+
+	value, err := Amount(1.03).ValueContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1.05, value)
+
+	value, err = CurrencyAmount{Currency: "CHF", Amount: 1.03}.ValueContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "CHF 1.05", value)
+
+	value, err = types.ValuerWithContext(ctx, Amount(1.12)).Value()
+	require.NoError(t, err)
+	require.Equal(t, 1.1, value)
+}
@@ -0,0 +1,57 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInconsistentTaxRounding is returned by NetFromGross and GrossFromNet
+// if the rounded result can't be converted back to the passed amount.
+var ErrInconsistentTaxRounding = errors.New("inconsistent tax rounding")
+
+// NetFromGross returns the net amount of a tax inclusive gross amount
+// for a tax rate like 0.19 for 19% VAT rounded with the passed rounding rule.
+//
+// If GrossFromNet of the rounded net amount doesn't result
+// in the gross amount rounded with the same rule, then the net amount
+// is returned together with an error wrapping ErrInconsistentTaxRounding.
+// This happens for gross amounts that can't be the result
+// of any net amount like 0.03 with 19% when rounding to cents.
+func NetFromGross(gross Amount, taxRate Rate, rounding Rounding) (net Amount, err error) {
+	if err = validateTaxArgs(gross, taxRate, rounding); err != nil {
+		return 0, err
+	}
+	net = rounding.Round(gross / Amount(1+taxRate))
+	if back := rounding.Round(net * Amount(1+taxRate)); back != rounding.Round(gross) {
+		return net, fmt.Errorf("%w: net %s of gross %s results in gross %s with tax rate %v", ErrInconsistentTaxRounding, net, gross, back, float64(taxRate))
+	}
+	return net, nil
+}
+
+// GrossFromNet returns the tax inclusive gross amount of a net amount
+// for a tax rate like 0.19 for 19% VAT rounded with the passed rounding rule.
+//
+// If NetFromGross of the rounded gross amount doesn't result
+// in the net amount rounded with the same rule, then the gross amount
+// is returned together with an error wrapping ErrInconsistentTaxRounding.
+// This can happen with rounding modes that are not rounding to the nearest value.
+func GrossFromNet(net Amount, taxRate Rate, rounding Rounding) (gross Amount, err error) {
+	if err = validateTaxArgs(net, taxRate, rounding); err != nil {
+		return 0, err
+	}
+	gross = rounding.Round(net * Amount(1+taxRate))
+	if back := rounding.Round(gross / Amount(1+taxRate)); back != rounding.Round(net) {
+		return gross, fmt.Errorf("%w: gross %s of net %s results in net %s with tax rate %v", ErrInconsistentTaxRounding, gross, net, back, float64(taxRate))
+	}
+	return gross, nil
+}
+
+func validateTaxArgs(amount Amount, taxRate Rate, rounding Rounding) error {
+	switch {
+	case !amount.Valid():
+		return fmt.Errorf("invalid amount %s", amount.GoString())
+	case !taxRate.Valid() || taxRate <= -1:
+		return fmt.Errorf("invalid tax rate %v", float64(taxRate))
+	}
+	return rounding.Validate()
+}
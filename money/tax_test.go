@@ -0,0 +1,60 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetFromGross(t *testing.T) {
+	cents := Rounding{Mode: RoundHalfAwayFromZero, Decimals: 2}
+
+	net, err := NetFromGross(119, 0.19, cents)
+	require.NoError(t, err)
+	require.Equal(t, Amount(100), net)
+
+	net, err = NetFromGross(10, 0.2, cents)
+	require.NoError(t, err)
+	require.Equal(t, Amount(8.33), net)
+
+	_, err = NetFromGross(9.99, 0.2, cents)
+	require.ErrorIs(t, err, ErrInconsistentTaxRounding)
+
+	net, err = NetFromGross(-11.9, 0.19, cents)
+	require.NoError(t, err)
+	require.Equal(t, Amount(-10), net)
+
+	net, err = NetFromGross(0.03, 0.19, cents)
+	require.ErrorIs(t, err, ErrInconsistentTaxRounding)
+	require.Equal(t, Amount(0.03), net)
+
+	_, err = NetFromGross(100, -1, cents)
+	require.Error(t, err)
+	_, err = NetFromGross(100, 0.19, Rounding{Decimals: -1})
+	require.Error(t, err)
+}
+
+func TestGrossFromNet(t *testing.T) {
+	cents := Rounding{Mode: RoundHalfEven, Decimals: 2}
+
+	gross, err := GrossFromNet(100, 0.19, cents)
+	require.NoError(t, err)
+	require.Equal(t, Amount(119), gross)
+
+	gross, err = GrossFromNet(8.33, 0.2, cents)
+	require.NoError(t, err)
+	require.Equal(t, Amount(10), gross)
+
+	for n := 1; n <= 1000; n++ {
+		net := Amount(n) / 100
+		gross, err := GrossFromNet(net, 0.19, cents)
+		require.NoError(t, err, "net %s", net)
+		back, err := NetFromGross(gross, 0.19, cents)
+		require.NoError(t, err, "gross %s", gross)
+		require.Equal(t, net, back)
+	}
+
+	floor := Rounding{Mode: RoundFloor, Decimals: 0}
+	_, err = GrossFromNet(1, 0.5, floor)
+	require.ErrorIs(t, err, ErrInconsistentTaxRounding)
+}
@@ -0,0 +1,419 @@
+package notnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/domonda/go-types/float"
+	"github.com/domonda/go-types/strutil"
+)
+
+var (
+	_ driver.Valuer    = PositiveAmount(1)
+	_ sql.Scanner      = new(PositiveAmount)
+	_ json.Unmarshaler = new(PositiveAmount)
+	_ driver.Valuer    = NonNegativeQuantity(0)
+	_ sql.Scanner      = new(NonNegativeQuantity)
+	_ json.Unmarshaler = new(NonNegativeQuantity)
+	_ driver.Valuer    = NonNegativeInt(0)
+	_ sql.Scanner      = new(NonNegativeInt)
+	_ json.Unmarshaler = new(NonNegativeInt)
+	_ driver.Valuer    = Percent0to100(0)
+	_ sql.Scanner      = new(Percent0to100)
+	_ json.Unmarshaler = new(Percent0to100)
+)
+
+// PositiveAmount is a float64 amount that must be
+// a finite number greater than zero.
+//
+// The constraint is validated by Scan, UnmarshalJSON,
+// UnmarshalText, and Value so that invalid values
+// can't enter or leave an API struct or the database.
+// JSON null and SQL NULL are not allowed.
+type PositiveAmount float64
+
+// ParsePositiveAmount parses str as PositiveAmount using float.Parse
+// and returns an error if the value is not greater than zero.
+func ParsePositiveAmount(str string) (PositiveAmount, error) {
+	f, err := float.Parse(str)
+	if err != nil {
+		return 0, err
+	}
+	a := PositiveAmount(f)
+	return a, a.Validate()
+}
+
+// Valid returns if a is a finite number greater than zero.
+func (a PositiveAmount) Valid() bool {
+	return a.Validate() == nil
+}
+
+// Validate returns an error if a is not a finite number greater than zero.
+func (a PositiveAmount) Validate() error {
+	if !float.Valid(a) || a <= 0 {
+		return fmt.Errorf("notnull.PositiveAmount must be greater than zero: %v", float64(a))
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (a PositiveAmount) String() string {
+	return strconv.FormatFloat(float64(a), 'f', -1, 64)
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (a *PositiveAmount) Scan(value any) error {
+	f, err := scanNumber(value, "notnull.PositiveAmount")
+	if err != nil {
+		return err
+	}
+	return a.set(f)
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (a PositiveAmount) Value() (driver.Value, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return float64(a), nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// and accepts numbers and number strings.
+func (a *PositiveAmount) UnmarshalJSON(j []byte) error {
+	f, err := unmarshalJSONNumber(j, "notnull.PositiveAmount")
+	if err != nil {
+		return err
+	}
+	return a.set(f)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *PositiveAmount) UnmarshalText(text []byte) error {
+	parsed, err := ParsePositiveAmount(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+func (a *PositiveAmount) set(f float64) error {
+	if err := PositiveAmount(f).Validate(); err != nil {
+		return err
+	}
+	*a = PositiveAmount(f)
+	return nil
+}
+
+// NonNegativeQuantity is a float64 quantity that must be
+// a finite number greater than or equal to zero.
+//
+// The constraint is validated by Scan, UnmarshalJSON,
+// UnmarshalText, and Value so that invalid values
+// can't enter or leave an API struct or the database.
+// JSON null and SQL NULL are not allowed.
+type NonNegativeQuantity float64
+
+// ParseNonNegativeQuantity parses str as NonNegativeQuantity using float.Parse
+// and returns an error if the value is negative.
+func ParseNonNegativeQuantity(str string) (NonNegativeQuantity, error) {
+	f, err := float.Parse(str)
+	if err != nil {
+		return 0, err
+	}
+	q := NonNegativeQuantity(f)
+	return q, q.Validate()
+}
+
+// Valid returns if q is a finite number greater than or equal to zero.
+func (q NonNegativeQuantity) Valid() bool {
+	return q.Validate() == nil
+}
+
+// Validate returns an error if q is not a finite number greater than or equal to zero.
+func (q NonNegativeQuantity) Validate() error {
+	if !float.Valid(q) || q < 0 {
+		return fmt.Errorf("notnull.NonNegativeQuantity must not be negative: %v", float64(q))
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (q NonNegativeQuantity) String() string {
+	return strconv.FormatFloat(float64(q), 'f', -1, 64)
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (q *NonNegativeQuantity) Scan(value any) error {
+	f, err := scanNumber(value, "notnull.NonNegativeQuantity")
+	if err != nil {
+		return err
+	}
+	return q.set(f)
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (q NonNegativeQuantity) Value() (driver.Value, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	return float64(q), nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// and accepts numbers and number strings.
+func (q *NonNegativeQuantity) UnmarshalJSON(j []byte) error {
+	f, err := unmarshalJSONNumber(j, "notnull.NonNegativeQuantity")
+	if err != nil {
+		return err
+	}
+	return q.set(f)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (q *NonNegativeQuantity) UnmarshalText(text []byte) error {
+	parsed, err := ParseNonNegativeQuantity(string(text))
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
+}
+
+func (q *NonNegativeQuantity) set(f float64) error {
+	if err := NonNegativeQuantity(f).Validate(); err != nil {
+		return err
+	}
+	*q = NonNegativeQuantity(f)
+	return nil
+}
+
+// NonNegativeInt is an int64 that must be greater than or equal to zero.
+//
+// The constraint is validated by Scan, UnmarshalJSON,
+// UnmarshalText, and Value so that invalid values
+// can't enter or leave an API struct or the database.
+// JSON null and SQL NULL are not allowed.
+type NonNegativeInt int64
+
+// ParseNonNegativeInt parses str as decimal NonNegativeInt
+// and returns an error if the value is negative.
+func ParseNonNegativeInt(str string) (NonNegativeInt, error) {
+	i, err := strconv.ParseInt(strutil.TrimSpace(str), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n := NonNegativeInt(i)
+	return n, n.Validate()
+}
+
+// Valid returns if n is greater than or equal to zero.
+func (n NonNegativeInt) Valid() bool {
+	return n >= 0
+}
+
+// Validate returns an error if n is negative.
+func (n NonNegativeInt) Validate() error {
+	if n < 0 {
+		return fmt.Errorf("notnull.NonNegativeInt must not be negative: %d", int64(n))
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (n NonNegativeInt) String() string {
+	return strconv.FormatInt(int64(n), 10)
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (n *NonNegativeInt) Scan(value any) error {
+	var i int64
+	switch x := value.(type) {
+	case int64:
+		i = x
+	case string:
+		parsed, err := ParseNonNegativeInt(x)
+		if err != nil {
+			return err
+		}
+		i = int64(parsed)
+	case []byte:
+		parsed, err := ParseNonNegativeInt(string(x))
+		if err != nil {
+			return err
+		}
+		i = int64(parsed)
+	default:
+		return fmt.Errorf("can't scan SQL value of type %T as notnull.NonNegativeInt", value)
+	}
+	return n.set(i)
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (n NonNegativeInt) Value() (driver.Value, error) {
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+	return int64(n), nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// and accepts integer numbers and integer strings.
+func (n *NonNegativeInt) UnmarshalJSON(j []byte) error {
+	s := string(j)
+	if l := len(s); l > 2 && s[0] == '"' && s[l-1] == '"' {
+		s = s[1 : l-1]
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal JSON(%s) as notnull.NonNegativeInt because of: %w", j, err)
+	}
+	return n.set(i)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (n *NonNegativeInt) UnmarshalText(text []byte) error {
+	parsed, err := ParseNonNegativeInt(string(text))
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+func (n *NonNegativeInt) set(i int64) error {
+	if err := NonNegativeInt(i).Validate(); err != nil {
+		return err
+	}
+	*n = NonNegativeInt(i)
+	return nil
+}
+
+// Percent0to100 is a float64 percentage that must be
+// a number in the range from zero to one hundred.
+//
+// The constraint is validated by Scan, UnmarshalJSON,
+// UnmarshalText, and Value so that invalid values
+// can't enter or leave an API struct or the database.
+// JSON null and SQL NULL are not allowed.
+type Percent0to100 float64
+
+// ParsePercent0to100 parses str as Percent0to100 using float.Parse
+// after removing an optional trailing percent sign
+// and returns an error if the value is out of range.
+func ParsePercent0to100(str string) (Percent0to100, error) {
+	str = strutil.TrimSpace(str)
+	if l := len(str); l > 0 && str[l-1] == '%' {
+		str = str[:l-1]
+	}
+	f, err := float.Parse(str)
+	if err != nil {
+		return 0, err
+	}
+	p := Percent0to100(f)
+	return p, p.Validate()
+}
+
+// Valid returns if p is in the range from zero to one hundred.
+func (p Percent0to100) Valid() bool {
+	return p.Validate() == nil
+}
+
+// Validate returns an error if p is not in the range from zero to one hundred.
+func (p Percent0to100) Validate() error {
+	// Comparisons with NaN are always false
+	if !(p >= 0 && p <= 100) {
+		return fmt.Errorf("notnull.Percent0to100 must be in the range 0 to 100: %v", float64(p))
+	}
+	return nil
+}
+
+// Fraction returns the percentage as fraction in the range 0 to 1.
+func (p Percent0to100) Fraction() float64 {
+	return float64(p) / 100
+}
+
+// String implements the fmt.Stringer interface.
+func (p Percent0to100) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "%"
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (p *Percent0to100) Scan(value any) error {
+	f, err := scanNumber(value, "notnull.Percent0to100")
+	if err != nil {
+		return err
+	}
+	return p.set(f)
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (p Percent0to100) Value() (driver.Value, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return float64(p), nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// and accepts numbers and number strings.
+func (p *Percent0to100) UnmarshalJSON(j []byte) error {
+	f, err := unmarshalJSONNumber(j, "notnull.Percent0to100")
+	if err != nil {
+		return err
+	}
+	return p.set(f)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *Percent0to100) UnmarshalText(text []byte) error {
+	parsed, err := ParsePercent0to100(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+func (p *Percent0to100) set(f float64) error {
+	if err := Percent0to100(f).Validate(); err != nil {
+		return err
+	}
+	*p = Percent0to100(f)
+	return nil
+}
+
+func scanNumber(value any, typeName string) (float64, error) {
+	switch x := value.(type) {
+	case float64:
+		return x, nil
+	case int64:
+		return float64(x), nil
+	case string:
+		return float.Parse(x)
+	case []byte:
+		return float.Parse(string(x))
+	default:
+		return 0, fmt.Errorf("can't scan SQL value of type %T as %s", value, typeName)
+	}
+}
+
+func unmarshalJSONNumber(j []byte, typeName string) (float64, error) {
+	s := string(j)
+	if l := len(s); l > 2 && s[0] == '"' && s[l-1] == '"' {
+		s = s[1 : l-1]
+	}
+	f, err := float.Parse(s)
+	if err != nil {
+		return 0, fmt.Errorf("can't unmarshal JSON(%s) as %s because of: %w", j, typeName, err)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("can't unmarshal JSON(%s) as %s because it's not a finite number", j, typeName)
+	}
+	return f, nil
+}
@@ -0,0 +1,58 @@
+package notnull
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberConstraints(t *testing.T) {
+	var s struct {
+		Amount   PositiveAmount      `json:"amount"`
+		Quantity NonNegativeQuantity `json:"quantity"`
+		Count    NonNegativeInt      `json:"count"`
+		Discount Percent0to100       `json:"discount"`
+	}
+	err := json.Unmarshal([]byte(`{"amount":9.99,"quantity":"1.5","count":0,"discount":12.5}`), &s)
+	require.NoError(t, err)
+	require.Equal(t, PositiveAmount(9.99), s.Amount)
+	require.Equal(t, NonNegativeQuantity(1.5), s.Quantity)
+	require.Equal(t, NonNegativeInt(0), s.Count)
+	require.Equal(t, Percent0to100(12.5), s.Discount)
+	require.Equal(t, 0.125, s.Discount.Fraction())
+
+	invalid := []string{
+		`{"amount":0}`,
+		`{"amount":-1}`,
+		`{"amount":null}`,
+		`{"quantity":-0.5}`,
+		`{"count":-1}`,
+		`{"count":1.5}`,
+		`{"discount":100.01}`,
+		`{"discount":"-1"}`,
+	}
+	for _, j := range invalid {
+		require.Error(t, json.Unmarshal([]byte(j), &s), j)
+	}
+
+	var a PositiveAmount
+	require.NoError(t, a.Scan([]byte("12.34")))
+	require.Equal(t, PositiveAmount(12.34), a)
+	require.Error(t, a.Scan(int64(0)))
+	require.Error(t, a.Scan(nil))
+	require.Equal(t, PositiveAmount(12.34), a, "unchanged after error")
+
+	_, err = PositiveAmount(0).Value()
+	require.Error(t, err)
+
+	var n NonNegativeInt
+	require.NoError(t, n.Scan(int64(7)))
+	require.Equal(t, NonNegativeInt(7), n)
+	require.Error(t, n.Scan(int64(-7)))
+
+	p, err := ParsePercent0to100("19 %")
+	require.NoError(t, err)
+	require.Equal(t, Percent0to100(19), p)
+	require.Equal(t, "19%", p.String())
+}
@@ -19,6 +19,10 @@ type FloatArray []float64
 // IsNull implements the Nullable interface.
 func (a FloatArray) IsNull() bool { return a == nil }
 
+// IsZero returns true if a is nil or empty
+// so that encoding/json omits it with the omitzero tag option.
+func (a FloatArray) IsZero() bool { return len(a) == 0 }
+
 // String implements the fmt.Stringer interface
 func (a FloatArray) String() string {
 	if a.IsNull() {
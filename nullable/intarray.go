@@ -18,6 +18,10 @@ type IntArray []int64
 // IsNull implements the Nullable interface.
 func (a IntArray) IsNull() bool { return a == nil }
 
+// IsZero returns true if a is nil or empty
+// so that encoding/json omits it with the omitzero tag option.
+func (a IntArray) IsZero() bool { return len(a) == 0 }
+
 // String implements the fmt.Stringer interface.
 func (a IntArray) String() string {
 	value, _ := a.Value()
@@ -27,6 +27,10 @@ func MarshalJSON(source any) (JSON, error) {
 // IsNull implements the Nullable interface.
 func (j JSON) IsNull() bool { return j == nil }
 
+// IsZero returns true if j is empty or the JSON null literal
+// so that encoding/json omits it with the omitzero tag option.
+func (j JSON) IsZero() bool { return len(j) == 0 || string(j) == "null" }
+
 // MarshalFrom marshalles source as JSON and sets it
 // at j when there was no error.
 func (j *JSON) MarshalFrom(source any) error {
@@ -18,6 +18,10 @@ type NullBoolArray []sql.NullBool
 // IsNull implements the Nullable interface.
 func (a NullBoolArray) IsNull() bool { return a == nil }
 
+// IsZero returns true if a is nil or empty
+// so that encoding/json omits it with the omitzero tag option.
+func (a NullBoolArray) IsZero() bool { return len(a) == 0 }
+
 // Bools returns all NullBoolArray elements as []bool with NULL elements set to false.
 func (a NullBoolArray) Bools() []bool {
 	return notnull.NullBoolArray(a).Bools()
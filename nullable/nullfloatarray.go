@@ -18,6 +18,10 @@ type NullFloatArray []sql.NullFloat64
 // IsNull implements the Nullable interface.
 func (a NullFloatArray) IsNull() bool { return a == nil }
 
+// IsZero returns true if a is nil or empty
+// so that encoding/json omits it with the omitzero tag option.
+func (a NullFloatArray) IsZero() bool { return len(a) == 0 }
+
 // Floats returns all NullFloatArray elements as []float64 with NULL elements set to 0.
 func (a NullFloatArray) Floats() []float64 {
 	if len(a) == 0 {
@@ -18,6 +18,10 @@ type NullIntArray []sql.NullInt64
 // IsNull implements the Nullable interface.
 func (a NullIntArray) IsNull() bool { return a == nil }
 
+// IsZero returns true if a is nil or empty
+// so that encoding/json omits it with the omitzero tag option.
+func (a NullIntArray) IsZero() bool { return len(a) == 0 }
+
 // Ints returns all NullIntArray elements as []int64 with NULL elements set to 0.
 func (a NullIntArray) Ints() []int64 {
 	if len(a) == 0 {
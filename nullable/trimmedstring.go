@@ -110,6 +110,13 @@ func (s TrimmedString) IsNull() bool {
 	return true
 }
 
+// IsZero returns true if the string is empty or only whitespace
+// which is marshalled as JSON null, so that the string
+// is omitted by encoding/json with the omitzero tag option.
+func (s TrimmedString) IsZero() bool {
+	return s.IsNull()
+}
+
 // IsNotNull returns true if the string is not empty.
 func (s TrimmedString) IsNotNull() bool {
 	return !s.IsNull()
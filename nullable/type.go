@@ -74,6 +74,14 @@ func (t Type[T]) IsNull() bool {
 	return !t.valid
 }
 
+// IsZero returns true if the value is null.
+// IsZero is used by encoding/json for the omitzero tag option
+// so that a null value is omitted independent of the
+// underlying value that might still be set.
+func (t Type[T]) IsZero() bool {
+	return !t.valid
+}
+
 // IsNotNull returns true if the value is not null.
 func (t Type[T]) IsNotNull() bool {
 	return t.valid
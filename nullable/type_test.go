@@ -13,3 +13,29 @@ func TestType_JSONSchema(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, `{"$schema":"https://json-schema.org/draft/2020-12/schema","oneOf":[{"type":"integer"},{"type":"null"}],"default":null}`, string(jsonSchemaBytes))
 }
+
+func TestOmitZero(t *testing.T) {
+	type omitZero struct {
+		Type    Type[int]     `json:"type,omitzero"`
+		Trimmed TrimmedString `json:"trimmed,omitzero"`
+		Floats  FloatArray    `json:"floats,omitzero"`
+		Ints    NullIntArray  `json:"ints,omitzero"`
+		JSON    JSON          `json:"json,omitzero"`
+	}
+	j, err := json.Marshal(omitZero{
+		Trimmed: "  ",
+		Floats:  FloatArray{},
+		JSON:    JSON("null"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(j))
+
+	j, err = json.Marshal(omitZero{
+		Type:    TypeFromPtr(new(int)),
+		Trimmed: " x ",
+		Floats:  FloatArray{1},
+		JSON:    JSON(`{}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"type":0,"trimmed":"x","floats":[1],"json":{}}`, string(j))
+}
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PolicyDecision is the result of evaluating
+// a value with an AllowDenyPolicy.
+type PolicyDecision int
+
+const (
+	// PolicyAllowed means the value is allowed.
+	PolicyAllowed PolicyDecision = iota
+	// PolicyDenied means the value is in the deny set.
+	PolicyDenied
+	// PolicyNotAllowed means the allow set is not empty
+	// and the value is not in it.
+	PolicyNotAllowed
+	// PolicyInvalid means the value could not be normalized.
+	PolicyInvalid
+)
+
+// Allowed returns true for PolicyAllowed.
+func (d PolicyDecision) Allowed() bool {
+	return d == PolicyAllowed
+}
+
+// String implements the fmt.Stringer interface.
+func (d PolicyDecision) String() string {
+	switch d {
+	case PolicyAllowed:
+		return "allowed"
+	case PolicyDenied:
+		return "denied"
+	case PolicyNotAllowed:
+		return "not allowed"
+	case PolicyInvalid:
+		return "invalid"
+	default:
+		return fmt.Sprintf("PolicyDecision(%d)", int(d))
+	}
+}
+
+// NormalizableString is a constraint for string types
+// like country.Code or language.Code that can be normalized.
+type NormalizableString[T any] interface {
+	~string
+	Normalizable[T]
+}
+
+// AllowDenyPolicy evaluates normalizable string values
+// like country or language codes against sets of allowed
+// and denied values.
+//
+// The deny set takes precedence over the allow set
+// and an empty allow set allows all values that are not denied,
+// so the zero value allows all valid values.
+//
+// The JSON representation is an object with optional
+// "allow" and "deny" arrays. Unmarshalling normalizes the values
+// and returns an error for invalid values.
+type AllowDenyPolicy[T NormalizableString[T]] struct {
+	Allow Set[T] `json:"allow,omitempty"`
+	Deny  Set[T] `json:"deny,omitempty"`
+}
+
+// NewAllowDenyPolicy returns an AllowDenyPolicy with the normalized
+// values of allow and deny or an error if a value is not valid.
+func NewAllowDenyPolicy[T NormalizableString[T]](allow, deny []T) (policy AllowDenyPolicy[T], err error) {
+	policy.Allow, err = normalizedSet(allow)
+	if err != nil {
+		return AllowDenyPolicy[T]{}, err
+	}
+	policy.Deny, err = normalizedSet(deny)
+	if err != nil {
+		return AllowDenyPolicy[T]{}, err
+	}
+	return policy, nil
+}
+
+func normalizedSet[T NormalizableString[T]](vals []T) (Set[T], error) {
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	set := make(Set[T], len(vals))
+	for _, val := range vals {
+		norm, err := val.Normalized()
+		if err != nil {
+			return nil, err
+		}
+		set.Add(norm)
+	}
+	return set, nil
+}
+
+// Evaluate returns the PolicyDecision for the normalized value.
+func (p AllowDenyPolicy[T]) Evaluate(value T) PolicyDecision {
+	norm, err := value.Normalized()
+	if err != nil {
+		return PolicyInvalid
+	}
+	if p.Deny.Contains(norm) {
+		return PolicyDenied
+	}
+	if len(p.Allow) > 0 && !p.Allow.Contains(norm) {
+		return PolicyNotAllowed
+	}
+	return PolicyAllowed
+}
+
+// Allows returns if Evaluate returns PolicyAllowed for the value.
+func (p AllowDenyPolicy[T]) Allows(value T) bool {
+	return p.Evaluate(value).Allowed()
+}
+
+// IsZero returns true if the policy has no allowed or denied values.
+func (p AllowDenyPolicy[T]) IsZero() bool {
+	return len(p.Allow) == 0 && len(p.Deny) == 0
+}
+
+// Validate returns an error if the policy contains values
+// that are not valid or not normalized.
+func (p AllowDenyPolicy[T]) Validate() error {
+	for _, set := range []Set[T]{p.Allow, p.Deny} {
+		for val := range set {
+			norm, err := val.Normalized()
+			if err != nil {
+				return err
+			}
+			if norm != val {
+				return fmt.Errorf("policy value %q is not normalized", string(val))
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// normalizing the allowed and denied values.
+func (p *AllowDenyPolicy[T]) UnmarshalJSON(j []byte) error {
+	var data struct {
+		Allow []T `json:"allow"`
+		Deny  []T `json:"deny"`
+	}
+	err := json.Unmarshal(j, &data)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal %T from JSON: %w", *p, err)
+	}
+	policy, err := NewAllowDenyPolicy(data.Allow, data.Deny)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal %T from JSON: %w", *p, err)
+	}
+	*p = policy
+	return nil
+}
@@ -199,6 +199,13 @@ func (set Set[T]) IsNull() bool {
 	return set == nil
 }
 
+// IsZero returns true if the set is nil or empty
+// so that encoding/json omits it with the omitzero tag option
+// like with omitempty.
+func (set Set[T]) IsZero() bool {
+	return len(set) == 0
+}
+
 // String implements the fmt.Stringer interface.
 // Returns a string representation of the set with values in sorted order.
 func (set Set[T]) String() string { //#nosec
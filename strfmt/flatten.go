@@ -0,0 +1,293 @@
+package strfmt
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-types/nullable"
+)
+
+// FlattenConfig configures how Flatten converts
+// nested structs to a flat map of formatted strings.
+type FlattenConfig struct {
+	// Format is used to format the leaf values,
+	// NewFormatConfig is used if nil.
+	Format *FormatConfig
+	// Tag is the struct tag used for the keys of struct fields
+	// like "json" or "csv". Field names are used if empty
+	// or if the tag has no name. Fields tagged with "-" are skipped.
+	Tag string
+	// Separator joins the keys of nested values, "." is used if empty.
+	Separator string
+}
+
+// NewFlattenConfig returns a FlattenConfig using the passed
+// FormatConfig, the "json" struct tag and "." as separator.
+func NewFlattenConfig(format *FormatConfig) *FlattenConfig {
+	return &FlattenConfig{
+		Format:    format,
+		Tag:       "json",
+		Separator: ".",
+	}
+}
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+	nullableType      = reflect.TypeFor[nullable.Nullable]()
+)
+
+// Flatten is the inverse of scanning and returns the exported fields
+// of the passed struct or pointer to a struct as map of key paths
+// to values formatted by FormatValue, for example to generate
+// CSV or XLSX exports of API models.
+//
+// Nested structs, slices, arrays and maps are flattened
+// with their field names, indices or formatted map keys joined
+// by the config Separator to key paths like "address.city" or "items.0.amount".
+// Exported embedded structs without tag name are flattened
+// into the parent like with encoding/json.
+// Types with a Formatter in the FormatConfig or that implement
+// encoding.TextMarshaler, fmt.Stringer or nullable.Nullable
+// are formatted as single values.
+// The fields of nil struct pointers are included with the Nil
+// string of the FormatConfig so that all values of a type
+// have the same struct keys.
+// Recursive fields of a struct type that is already being flattened
+// and pointers back to a value that is already being flattened
+// are written as a single Nil entry to terminate cyclic types and values.
+//
+// A nil config uses NewFlattenConfig(nil).
+func Flatten(value any, config *FlattenConfig) (map[string]string, error) {
+	flat := make(map[string]string)
+	err := flatten(value, config, func(key, val string) { flat[key] = val })
+	if err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+// FlattenKeys returns the keys that Flatten returns for the passed value
+// in the order of the struct fields, slice and array indices,
+// and sorted map keys, to be used as column order of exports.
+func FlattenKeys(value any, config *FlattenConfig) ([]string, error) {
+	var keys []string
+	err := flatten(value, config, func(key, _ string) { keys = append(keys, key) })
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func flatten(value any, config *FlattenConfig, emit func(key, val string)) error {
+	if config == nil {
+		config = NewFlattenConfig(nil)
+	}
+	format := config.Format
+	if format == nil {
+		format = NewFormatConfig()
+	}
+	sep := config.Separator
+	if sep == "" {
+		sep = "."
+	}
+
+	val, ok := value.(reflect.Value)
+	if !ok {
+		val = reflect.ValueOf(value)
+	}
+	if !val.IsValid() {
+		return errors.New("can't flatten nil")
+	}
+	t := val.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("can't flatten non struct type %s", val.Type())
+	}
+	f := &flattener{
+		tag:      config.Tag,
+		sep:      sep,
+		format:   format,
+		emit:     emit,
+		types:    make(map[reflect.Type]bool),
+		pointers: make(map[flattenPointer]bool),
+	}
+	f.value("", val)
+	return nil
+}
+
+type flattener struct {
+	tag    string
+	sep    string
+	format *FormatConfig
+	emit   func(key, val string)
+
+	// Struct types and pointers that are currently
+	// being flattened to detect cycles
+	types    map[reflect.Type]bool
+	pointers map[flattenPointer]bool
+}
+
+// flattenPointer identifies a pointer together with its type
+// because a struct and its first field have the same address.
+type flattenPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+func (f *flattener) key(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + f.sep + name
+}
+
+func (f *flattener) isLeaf(t reflect.Type) bool {
+	if _, ok := f.format.TypeFormatters[t]; ok {
+		return true
+	}
+	for _, iface := range []reflect.Type{textMarshalerType, stringerType, nullableType} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Array:
+		return false
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	default:
+		return true
+	}
+}
+
+func (f *flattener) value(key string, val reflect.Value) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			if val.Kind() == reflect.Pointer {
+				f.nilType(key, val.Type().Elem())
+			} else {
+				f.emit(key, f.format.Nil)
+			}
+			return
+		}
+		if val.Kind() == reflect.Pointer {
+			ptr := flattenPointer{val.Pointer(), val.Type()}
+			if f.pointers[ptr] {
+				// Cyclic pointer graph
+				f.emit(key, f.format.Nil)
+				return
+			}
+			f.pointers[ptr] = true
+			defer delete(f.pointers, ptr)
+		}
+		val = val.Elem()
+	}
+	t := val.Type()
+	if f.isLeaf(t) {
+		f.emit(key, FormatValue(val, f.format))
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if !f.types[t] {
+			f.types[t] = true
+			defer delete(f.types, t)
+		}
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, embedded, ok := f.fieldName(field)
+			if !ok {
+				continue
+			}
+			if embedded {
+				f.value(key, val.Field(i))
+			} else {
+				f.value(f.key(key, name), val.Field(i))
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := range val.Len() {
+			f.value(f.key(key, strconv.Itoa(i)), val.Index(i))
+		}
+
+	case reflect.Map:
+		keys := make(map[string]reflect.Value, val.Len())
+		for _, k := range val.MapKeys() {
+			keys[FormatValue(k, f.format)] = k
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		slices.Sort(sorted)
+		for _, k := range sorted {
+			f.value(f.key(key, k), val.MapIndex(keys[k]))
+		}
+	}
+}
+
+// nilType emits the Nil string for all struct field keys
+// of a nil pointer to t, or a single Nil string
+// if t is a recursive struct type that is already being flattened.
+func (f *flattener) nilType(key string, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if f.isLeaf(t) || t.Kind() != reflect.Struct || f.types[t] {
+		f.emit(key, f.format.Nil)
+		return
+	}
+	f.types[t] = true
+	defer delete(f.types, t)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, embedded, ok := f.fieldName(field)
+		if !ok {
+			continue
+		}
+		if embedded {
+			f.nilType(key, field.Type)
+		} else {
+			f.nilType(f.key(key, name), field.Type)
+		}
+	}
+}
+
+// fieldName returns the key name of a struct field,
+// if it is an embedded struct that is flattened into the parent,
+// and false for unexported or skipped fields.
+func (f *flattener) fieldName(field reflect.StructField) (name string, embedded, ok bool) {
+	if f.tag != "" {
+		tagName, _, _ := strings.Cut(field.Tag.Get(f.tag), ",")
+		if tagName == "-" {
+			return "", false, false
+		}
+		name = tagName
+	}
+	if !field.IsExported() {
+		return "", false, false
+	}
+	if field.Anonymous && name == "" {
+		t := field.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct && !f.isLeaf(t) {
+			return "", true, true
+		}
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, false, true
+}
@@ -0,0 +1,129 @@
+package strfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/uu"
+)
+
+type flattenAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type FlattenBase struct {
+	ID uu.ID `json:"id"`
+}
+
+type flattenInvoice struct {
+	FlattenBase
+	Number   string            `json:"number"`
+	Date     date.Date         `json:"date"`
+	Total    money.Amount      `json:"total"`
+	Paid     bool              `json:"paid"`
+	Address  flattenAddress    `json:"address"`
+	Shipping *flattenAddress   `json:"shipping,omitempty"`
+	Items    []flattenItem     `json:"items"`
+	Labels   map[string]string `json:"labels"`
+	Internal string            `json:"-"`
+	NoTag    int
+	private  string
+}
+
+type flattenItem struct {
+	Name   string       `json:"name"`
+	Amount money.Amount `json:"amount"`
+}
+
+func TestFlatten(t *testing.T) {
+	invoice := &flattenInvoice{
+		FlattenBase: FlattenBase{ID: uu.IDMust("6ba7b810-9dad-11d1-80b4-00c04fd430c8")},
+		Number:      "RE-1",
+		Date:        "2024-03-01",
+		Total:       1234.5,
+		Paid:        true,
+		Address:     flattenAddress{Street: "Hauptstraße 1", City: "Wien"},
+		Items:       []flattenItem{{Name: "A", Amount: 1000}, {Name: "B", Amount: 234.5}},
+		Labels:      map[string]string{"b": "2", "a": "1"},
+		Internal:    "secret",
+		NoTag:       7,
+		private:     "private",
+	}
+
+	flat, err := Flatten(invoice, NewFlattenConfig(NewGermanFormatConfig()))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"id":              "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"number":          "RE-1",
+		"date":            "01.03.2024",
+		"total":           "1.234,50",
+		"paid":            "ja",
+		"address.street":  "Hauptstraße 1",
+		"address.city":    "Wien",
+		"shipping.street": "",
+		"shipping.city":   "",
+		"items.0.name":    "A",
+		"items.0.amount":  "1.000,00",
+		"items.1.name":    "B",
+		"items.1.amount":  "234,50",
+		"labels.a":        "1",
+		"labels.b":        "2",
+		"NoTag":           "7",
+	}, flat)
+
+	keys, err := FlattenKeys(invoice, &FlattenConfig{Separator: "/"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ID",
+		"Number",
+		"Date",
+		"Total",
+		"Paid",
+		"Address/Street",
+		"Address/City",
+		"Shipping/Street",
+		"Shipping/City",
+		"Items/0/Name",
+		"Items/0/Amount",
+		"Items/1/Name",
+		"Items/1/Amount",
+		"Labels/a",
+		"Labels/b",
+		"Internal",
+		"NoTag",
+	}, keys)
+
+	_, err = Flatten("not a struct", nil)
+	require.Error(t, err)
+	_, err = Flatten(nil, nil)
+	require.Error(t, err)
+}
+
+type flattenNode struct {
+	Name string       `json:"name"`
+	Next *flattenNode `json:"next"`
+}
+
+func TestFlatten_Recursive(t *testing.T) {
+	format := NewFormatConfig()
+	config := NewFlattenConfig(format)
+
+	flat, err := Flatten(flattenNode{Name: "a"}, config)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "a", "next": format.Nil}, flat)
+
+	list := &flattenNode{Name: "a", Next: &flattenNode{Name: "b"}}
+	flat, err = Flatten(list, config)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "a", "next.name": "b", "next.next": format.Nil}, flat)
+
+	// Cyclic pointer graph
+	list.Next.Next = list
+	flat, err = Flatten(list, config)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "a", "next.name": "b", "next.next": format.Nil}, flat)
+}
@@ -0,0 +1,249 @@
+package strfmt
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/domonda/go-types"
+	"github.com/domonda/go-types/bank"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/language"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/vat"
+)
+
+// TranslateFunc is a translation hook returning the localized
+// message for an error code with its parameters
+// or false if the code can't be translated.
+type TranslateFunc func(lang language.Code, code types.ErrorCode, params map[string]any) (msg string, ok bool)
+
+// MessageBundle holds localized message templates
+// for the codes of types.CodedError errors.
+//
+// Templates reference the parameters of an error by name
+// in curly braces like "Invalid IBAN {value}".
+// Parameter values are formatted with the FormatConfig
+// registered for the language, slice parameters
+// are joined with ", ".
+//
+// A MessageBundle is safe for concurrent use.
+type MessageBundle struct {
+	// Fallback is the language used if there is
+	// no template for the requested language.
+	Fallback language.Code
+	// Translate is an optional hook that is called
+	// before the templates of the bundle are used.
+	Translate TranslateFunc
+
+	mtx       sync.RWMutex
+	templates map[language.Code]map[types.ErrorCode]string
+	formats   map[language.Code]*FormatConfig
+}
+
+// NewMessageBundle returns an empty MessageBundle
+// using the passed fallback language.
+func NewMessageBundle(fallback language.Code) *MessageBundle {
+	return &MessageBundle{
+		Fallback:  fallback,
+		templates: make(map[language.Code]map[types.ErrorCode]string),
+		formats:   make(map[language.Code]*FormatConfig),
+	}
+}
+
+// NewDefaultMessageBundle returns a MessageBundle
+// with English and German templates for the error codes
+// of the packages money, date, bank, and vat
+// using English as fallback language.
+func NewDefaultMessageBundle() *MessageBundle {
+	b := NewMessageBundle(language.EN)
+	b.SetFormatConfig(language.EN, NewEnglishFormatConfig())
+	b.SetFormatConfig(language.DE, NewGermanFormatConfig())
+	err := b.AddTemplates(language.EN, defaultEnglishMessages)
+	if err != nil {
+		panic(err)
+	}
+	err = b.AddTemplates(language.DE, defaultGermanMessages)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// AddTemplates adds or replaces the message templates
+// of the error codes for a language.
+func (b *MessageBundle) AddTemplates(lang language.Code, templates map[types.ErrorCode]string) error {
+	lang, err := lang.Normalized()
+	if err != nil {
+		return err
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.templates[lang] == nil {
+		b.templates[lang] = make(map[types.ErrorCode]string, len(templates))
+	}
+	for code, template := range templates {
+		b.templates[lang][code] = template
+	}
+	return nil
+}
+
+// SetFormatConfig sets the FormatConfig used to format
+// the parameter values of messages of a language.
+// NewFormatConfig is used for languages without FormatConfig.
+func (b *MessageBundle) SetFormatConfig(lang language.Code, config *FormatConfig) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.formats[lang] = config
+}
+
+// Template returns the message template for an error code
+// in the requested language or the Fallback language.
+func (b *MessageBundle) Template(lang language.Code, code types.ErrorCode) (template string, templateLang language.Code, ok bool) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	if norm, err := lang.Normalized(); err == nil {
+		if template, ok := b.templates[norm][code]; ok {
+			return template, norm, true
+		}
+	}
+	if template, ok := b.templates[b.Fallback][code]; ok {
+		return template, b.Fallback, true
+	}
+	return "", "", false
+}
+
+// Message returns the localized message of err for a language.
+//
+// The first types.CodedError in the tree of err is translated
+// by the Translate hook or the templates of the bundle.
+// The result of err.Error() is returned if err has no
+// error code or no translation for the code is available.
+// Returns an empty string for a nil error.
+func (b *MessageBundle) Message(lang language.Code, err error) string {
+	if err == nil {
+		return ""
+	}
+	code, params, ok := types.ErrorCodeOf(err)
+	if !ok {
+		return err.Error()
+	}
+	if b.Translate != nil {
+		if msg, ok := b.Translate(lang, code, params); ok {
+			return msg
+		}
+	}
+	template, templateLang, ok := b.Template(lang, code)
+	if !ok {
+		return err.Error()
+	}
+	b.mtx.RLock()
+	config := b.formats[templateLang]
+	b.mtx.RUnlock()
+	return ExpandMessageTemplate(template, params, config)
+}
+
+// ExpandMessageTemplate replaces the parameter names in curly braces
+// like "{value}" in template with the formatted parameter values.
+// Names that are not in params are left unchanged.
+// NewFormatConfig is used if config is nil.
+func ExpandMessageTemplate(template string, params map[string]any, config *FormatConfig) string {
+	if !strings.ContainsRune(template, '{') {
+		return template
+	}
+	if config == nil {
+		config = NewFormatConfig()
+	}
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		value, ok := params[template[start+1:end]]
+		if !ok {
+			b.WriteString(template[:end+1])
+			template = template[end+1:]
+			continue
+		}
+		b.WriteString(template[:start])
+		b.WriteString(formatMessageParam(value, config))
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+func formatMessageParam(value any, config *FormatConfig) string {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Slice || val.Type().Elem().Kind() == reflect.Uint8 {
+		return Format(val, config)
+	}
+	elems := make([]string, val.Len())
+	for i := range elems {
+		elems[i] = Format(val.Index(i), config)
+	}
+	return strings.Join(elems, ", ")
+}
+
+var defaultEnglishMessages = map[types.ErrorCode]string{
+	money.ErrCodeAmountInvalid:            "Invalid amount: {value}",
+	money.ErrCodeAmountDecimals:           "The amount {value} must have {acceptedDecimals} decimal places",
+	money.ErrCodeCurrencyEmpty:            "Missing currency",
+	money.ErrCodeCurrencyInvalid:          "Invalid currency: {value}",
+	money.ErrCodeCurrencyNonTransactional: "The currency {value} can't be used for payments",
+	date.ErrCodeInvalid:                   "Invalid date: {value}",
+	date.ErrCodeTooShort:                  "Too short for a date: {value}",
+	date.ErrCodeInvalidPeriod:             "Invalid period: {value}",
+	bank.ErrCodeIBANEmpty:                 "Missing IBAN",
+	bank.ErrCodeIBANTooShort:              "The IBAN {value} is too short",
+	bank.ErrCodeIBANCountryCode:           "The IBAN {value} has an invalid country code",
+	bank.ErrCodeIBANLength:                "The IBAN {value} must have {expectedLength} characters",
+	bank.ErrCodeIBANCharacters:            "The IBAN {value} contains invalid characters",
+	bank.ErrCodeIBANCheckSum:              "The IBAN {value} has invalid check digits",
+	bank.ErrCodeIBANBBANStructure:         "The IBAN {value} has an invalid account number format",
+	bank.ErrCodeBICLength:                 "The BIC {value} must have 8 or 11 characters",
+	bank.ErrCodeBICInvalid:                "Invalid BIC: {value}",
+	bank.ErrCodeBICBlocked:                "The BIC {value} is not valid",
+	vat.ErrCodeIDTooShort:                 "The VAT ID {value} is too short",
+	vat.ErrCodeIDTooLong:                  "The VAT ID {value} is too long",
+	vat.ErrCodeIDCountryCode:              "The VAT ID {value} has an invalid country code",
+	vat.ErrCodeIDUnsupportedCountry:       "VAT IDs of the country {countryCode} are not supported",
+	vat.ErrCodeIDFormat:                   "The VAT ID {value} has an invalid format",
+	vat.ErrCodeIDCheckSum:                 "The VAT ID {value} has an invalid check digit",
+}
+
+var defaultGermanMessages = map[types.ErrorCode]string{
+	money.ErrCodeAmountInvalid:            "Ungültiger Betrag: {value}",
+	money.ErrCodeAmountDecimals:           "Der Betrag {value} muss {acceptedDecimals} Nachkommastellen haben",
+	money.ErrCodeCurrencyEmpty:            "Fehlende Währung",
+	money.ErrCodeCurrencyInvalid:          "Ungültige Währung: {value}",
+	money.ErrCodeCurrencyNonTransactional: "Die Währung {value} kann nicht für Zahlungen verwendet werden",
+	date.ErrCodeInvalid:                   "Ungültiges Datum: {value}",
+	date.ErrCodeTooShort:                  "Zu kurz für ein Datum: {value}",
+	date.ErrCodeInvalidPeriod:             "Ungültiger Zeitraum: {value}",
+	bank.ErrCodeIBANEmpty:                 "Fehlende IBAN",
+	bank.ErrCodeIBANTooShort:              "Die IBAN {value} ist zu kurz",
+	bank.ErrCodeIBANCountryCode:           "Die IBAN {value} hat einen ungültigen Ländercode",
+	bank.ErrCodeIBANLength:                "Die IBAN {value} muss {expectedLength} Zeichen lang sein",
+	bank.ErrCodeIBANCharacters:            "Die IBAN {value} enthält ungültige Zeichen",
+	bank.ErrCodeIBANCheckSum:              "Die IBAN {value} hat eine ungültige Prüfziffer",
+	bank.ErrCodeIBANBBANStructure:         "Die IBAN {value} hat ein ungültiges Kontonummernformat",
+	bank.ErrCodeBICLength:                 "Die BIC {value} muss 8 oder 11 Zeichen lang sein",
+	bank.ErrCodeBICInvalid:                "Ungültige BIC: {value}",
+	bank.ErrCodeBICBlocked:                "Die BIC {value} ist nicht gültig",
+	vat.ErrCodeIDTooShort:                 "Die UID-Nummer {value} ist zu kurz",
+	vat.ErrCodeIDTooLong:                  "Die UID-Nummer {value} ist zu lang",
+	vat.ErrCodeIDCountryCode:              "Die UID-Nummer {value} hat einen ungültigen Ländercode",
+	vat.ErrCodeIDUnsupportedCountry:       "UID-Nummern des Landes {countryCode} werden nicht unterstützt",
+	vat.ErrCodeIDFormat:                   "Die UID-Nummer {value} hat ein ungültiges Format",
+	vat.ErrCodeIDCheckSum:                 "Die UID-Nummer {value} hat eine ungültige Prüfziffer",
+}
@@ -0,0 +1,68 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types"
+	"github.com/domonda/go-types/bank"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/language"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/vat"
+)
+
+func TestMessageBundle(t *testing.T) {
+	bundle := NewDefaultMessageBundle()
+
+	_, err := bank.IBAN("DE89370400440532013001").Normalized()
+	require.ErrorIs(t, err, types.NewCodedError(bank.ErrCodeIBANCheckSum, ""))
+	require.Equal(t, "invalid IBAN check sum", err.Error(), "default message unchanged")
+	require.Equal(t, "Die IBAN DE89370400440532013001 hat eine ungültige Prüfziffer", bundle.Message(language.DE, err))
+	require.Equal(t, "The IBAN DE89370400440532013001 has invalid check digits", bundle.Message(language.EN, err))
+	require.Equal(t, "The IBAN DE89370400440532013001 has invalid check digits", bundle.Message(language.FR, err), "fallback language")
+	require.Equal(t, "Die IBAN DE89370400440532013001 hat eine ungültige Prüfziffer", bundle.Message("DE", fmt.Errorf("wrapped: %w", err)))
+
+	_, err = vat.ID("AT1").Normalized()
+	require.ErrorIs(t, err, vat.ErrInvalidID)
+	require.Equal(t, "Die UID-Nummer AT1 ist zu kurz", bundle.Message(language.DE, err))
+
+	_, err = money.ParseAmount("1.5", 0, 2)
+	require.Equal(t, "The amount 1.5 must have 0, 2 decimal places", bundle.Message(language.EN, err))
+
+	_, err = date.Date("31.02.2024").Normalized()
+	require.Equal(t, "Ungültiges Datum: 31.02.2024", bundle.Message(language.DE, err))
+
+	_, err = money.Currency("").Normalized()
+	require.Equal(t, "Fehlende Währung", bundle.Message(language.DE, err))
+
+	plain := errors.New("plain error")
+	require.Equal(t, "plain error", bundle.Message(language.DE, plain))
+	require.Equal(t, "", bundle.Message(language.DE, nil))
+
+	bundle.Translate = func(lang language.Code, code types.ErrorCode, params map[string]any) (string, bool) {
+		if lang == language.IT && code == money.ErrCodeCurrencyEmpty {
+			return "Valuta mancante", true
+		}
+		return "", false
+	}
+	require.Equal(t, "Valuta mancante", bundle.Message(language.IT, err))
+	require.Equal(t, "Fehlende Währung", bundle.Message(language.DE, err), "hook falls back to templates")
+
+	require.Error(t, bundle.AddTemplates("xx", nil))
+	require.NoError(t, bundle.AddTemplates(language.IT, map[types.ErrorCode]string{
+		money.ErrCodeCurrencyInvalid: "Valuta non valida: {value}",
+	}))
+	_, err = money.Currency("XYZ").Normalized()
+	require.Equal(t, "Valuta non valida: XYZ", bundle.Message(language.IT, err))
+}
+
+func TestExpandMessageTemplate(t *testing.T) {
+	params := map[string]any{"value": "abc", "amount": 1234.5, "list": []int{1, 2}}
+	require.Equal(t, "no params", ExpandMessageTemplate("no params", params, nil))
+	require.Equal(t, "abc: 1234.5 [1, 2] {unknown} {", ExpandMessageTemplate("{value}: {amount} [{list}] {unknown} {", params, nil))
+	require.Equal(t, "1234,5", ExpandMessageTemplate("{amount}", params, NewGermanFormatConfig()))
+}
@@ -0,0 +1,80 @@
+package strutil
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// companyLegalForms are the lower case legal form abbreviations
+// and words without dots that are removed by CompanyNameMatchKey.
+var companyLegalForms = NewStringSet(
+	// DE, AT, CH
+	"gmbh", "mbh", "ag", "kg", "kgaa", "ohg", "og", "keg", "gbr", "ug",
+	"haftungsbeschraenkt", "ev", "eg", "ek", "ekfm", "eu", "cokg", "gesmbh",
+	"gesellschaft", "aktiengesellschaft", "kommanditgesellschaft",
+	// EN
+	"ltd", "limited", "llc", "llp", "lp", "plc", "inc", "incorporated", "corp", "corporation", "company",
+	// FR
+	"sa", "sarl", "sas", "sasu", "eurl", "sci", "snc", "sca", "scs",
+	// IT
+	"spa", "srl", "srls", "sapa", "ss", "scarl", "scrl",
+	// Other common European forms
+	"bv", "nv", "ab", "as", "oy", "kft", "sro", "doo", "sl", "slu",
+)
+
+// companyNameStopWords are lower case articles and conjunctions
+// across DE, EN, FR and IT that are removed by CompanyNameMatchKey.
+var companyNameStopWords = NewStringSet(
+	"the", "and", "of", "co",
+	"der", "die", "das", "und", "von",
+	"le", "la", "les", "et", "de", "du", "des",
+	"il", "lo", "gli", "e", "di", "del", "della",
+)
+
+// CompanyNameMatchKey returns a canonical key for matching
+// company names like supplier names from different documents.
+//
+// The key is the lower case transliterated ASCII words of the name
+// joined by single spaces, without punctuation, legal form
+// abbreviations like "GmbH", "Ltd.", "S.à r.l." or "S.p.A.",
+// and common DE, EN, FR and IT stop-words like "und", "the", "et" or "di".
+// Dots within words are removed so that "G.m.b.H." is recognized as "GmbH"
+// and legal forms split into two words like "Co. KG" are recognized too.
+//
+// If no words are left after removing legal forms and stop-words,
+// then the key is built from all words of the name.
+//
+// Example:
+//
+//	CompanyNameMatchKey("Müller & Söhne GmbH & Co. KG") == "mueller soehne"
+func CompanyNameMatchKey(name string) string {
+	words := companyNameWords(name)
+	key := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if i+1 < len(words) && companyLegalForms.Contains(word+words[i+1]) {
+			i++
+			continue
+		}
+		if !companyLegalForms.Contains(word) && !companyNameStopWords.Contains(word) {
+			key = append(key, word)
+		}
+	}
+	if len(key) == 0 {
+		key = words
+	}
+	return strings.Join(key, " ")
+}
+
+// companyNameWords returns the lower case ASCII transliterated words
+// of name with dots within words removed.
+func companyNameWords(name string) []string {
+	name = TransliterateSpecialCharacters(strings.ToLower(name))
+	// Remove remaining diacritics like in "č" or "ș"
+	name = RemoveRunesString(norm.NFD.String(name), IsRune('.'), func(r rune) bool { return unicode.Is(unicode.Mn, r) })
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,35 @@
+package strutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyNameMatchKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "", want: ""},
+		{name: "Müller & Söhne GmbH & Co. KG", want: "mueller soehne"},
+		{name: "Mueller und Soehne G.m.b.H.", want: "mueller soehne"},
+		{name: "domonda GmbH", want: "domonda"},
+		{name: "DOMONDA   G.M.B.H", want: "domonda"},
+		{name: "The Coca-Cola Company", want: "coca cola"},
+		{name: "Acme Ltd.", want: "acme"},
+		{name: "Acme Inc", want: "acme"},
+		{name: "Société Générale S.A.", want: "societe generale"},
+		{name: "Boulangerie de la Gare S.à r.l.", want: "boulangerie gare"},
+		{name: "Fratelli Rossi S.p.A.", want: "fratelli rossi"},
+		{name: "Banca di Roma s.r.l.", want: "banca roma"},
+		{name: "Živnostenská banka a.s.", want: "zivnostenska banka"},
+		{name: "AG", want: "ag"},
+		{name: "3M Deutschland GmbH", want: "3m deutschland"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CompanyNameMatchKey(tt.name))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package strutil
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DiffOp is the operation of a DiffEdit.
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffDelete
+	DiffInsert
+)
+
+// String implements the fmt.Stringer interface.
+func (op DiffOp) String() string {
+	switch op {
+	case DiffEqual:
+		return "EQUAL"
+	case DiffDelete:
+		return "DELETE"
+	case DiffInsert:
+		return "INSERT"
+	}
+	return "INVALID"
+}
+
+// DiffEdit is a text that is equal in,
+// deleted from, or inserted into a text.
+type DiffEdit struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffEdits are the edits needed to change a source into a target text.
+type DiffEdits []DiffEdit
+
+// DiffWords returns the word level edits needed to change the text a into b.
+// Words are sequences of letters and digits, every other rune and
+// sequences of whitespace are compared separately.
+// Adjacent edits of the same operation are merged
+// and deletions are returned before insertions of the same change.
+//
+// The diff is calculated with a longest common subsequence
+// of quadratic complexity, so it is intended for short texts
+// like the field values of a document.
+func DiffWords(a, b string) DiffEdits {
+	if a == b {
+		if a == "" {
+			return nil
+		}
+		return DiffEdits{{Op: DiffEqual, Text: a}}
+	}
+	ta, tb := diffTokens(a), diffTokens(b)
+
+	// Common prefix and suffix don't need the LCS matrix
+	prefix := 0
+	for prefix < len(ta) && prefix < len(tb) && ta[prefix] == tb[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(ta)-prefix && suffix < len(tb)-prefix && ta[len(ta)-1-suffix] == tb[len(tb)-1-suffix] {
+		suffix++
+	}
+
+	var edits DiffEdits
+	for _, t := range ta[:prefix] {
+		edits = edits.add(DiffEqual, t)
+	}
+	edits = diffLCS(edits, ta[prefix:len(ta)-suffix], tb[prefix:len(tb)-suffix])
+	for _, t := range ta[len(ta)-suffix:] {
+		edits = edits.add(DiffEqual, t)
+	}
+	return edits.cleanup()
+}
+
+// diffTokens splits str into words, whitespace sequences and single other runes.
+func diffTokens(str string) []string {
+	var tokens []string
+	for len(str) > 0 {
+		r, size := utf8.DecodeRuneInString(str)
+		end := size
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			end = diffTokenEnd(str, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+		case unicode.IsSpace(r):
+			end = diffTokenEnd(str, unicode.IsSpace)
+		}
+		tokens = append(tokens, str[:end])
+		str = str[end:]
+	}
+	return tokens
+}
+
+func diffTokenEnd(str string, inToken func(rune) bool) int {
+	for i, r := range str {
+		if !inToken(r) {
+			return i
+		}
+	}
+	return len(str)
+}
+
+// diffLCS appends the edits from a to b using
+// a longest common subsequence matrix of the tokens.
+func diffLCS(edits DiffEdits, a, b []string) DiffEdits {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = edits.add(DiffEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = edits.add(DiffDelete, a[i])
+			i++
+		default:
+			edits = edits.add(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = edits.add(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		edits = edits.add(DiffInsert, b[j])
+	}
+	return edits
+}
+
+// add appends text with op or merges it
+// with the last edit if it has the same op.
+func (edits DiffEdits) add(op DiffOp, text string) DiffEdits {
+	if text == "" {
+		return edits
+	}
+	if n := len(edits); n > 0 && edits[n-1].Op == op {
+		edits[n-1].Text += text
+		return edits
+	}
+	return append(edits, DiffEdit{Op: op, Text: text})
+}
+
+// cleanup merges whitespace that is equal between changes
+// into the changes and groups the deletions of a change
+// before its insertions, so that "a b" to "c d" becomes
+// a deletion of "a b" and an insertion of "c d".
+func (edits DiffEdits) cleanup() DiffEdits {
+	var (
+		result            DiffEdits
+		deleted, inserted strings.Builder
+	)
+	flush := func() {
+		result = result.add(DiffDelete, deleted.String())
+		result = result.add(DiffInsert, inserted.String())
+		deleted.Reset()
+		inserted.Reset()
+	}
+	for i, edit := range edits {
+		switch {
+		case edit.Op == DiffDelete:
+			deleted.WriteString(edit.Text)
+		case edit.Op == DiffInsert:
+			inserted.WriteString(edit.Text)
+		case i > 0 && i < len(edits)-1 && strings.TrimSpace(edit.Text) == "":
+			deleted.WriteString(edit.Text)
+			inserted.WriteString(edit.Text)
+		default:
+			flush()
+			result = result.add(DiffEqual, edit.Text)
+		}
+	}
+	flush()
+	return result
+}
+
+// HasChanges returns true if the edits contain deletions or insertions.
+func (edits DiffEdits) HasChanges() bool {
+	for _, edit := range edits {
+		if edit.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// Source returns the text the edits were calculated from.
+func (edits DiffEdits) Source() string {
+	return edits.join(DiffDelete)
+}
+
+// Target returns the text that results from applying the edits.
+func (edits DiffEdits) Target() string {
+	return edits.join(DiffInsert)
+}
+
+func (edits DiffEdits) join(changeOp DiffOp) string {
+	var b strings.Builder
+	for _, edit := range edits {
+		if edit.Op == DiffEqual || edit.Op == changeOp {
+			b.WriteString(edit.Text)
+		}
+	}
+	return b.String()
+}
+
+// Patch applies the edits to text and returns the target text.
+// Returns an error if text is not the source of the edits.
+func (edits DiffEdits) Patch(text string) (string, error) {
+	if edits.Source() != text {
+		return "", errors.New("text does not match the source of the diff edits")
+	}
+	return edits.Target(), nil
+}
+
+// HTML returns the edits as HTML with deletions
+// wrapped in <del> and insertions in <ins> elements.
+// All texts are HTML escaped.
+func (edits DiffEdits) HTML() string {
+	var b strings.Builder
+	for _, edit := range edits {
+		switch edit.Op {
+		case DiffDelete:
+			b.WriteString("<del>" + html.EscapeString(edit.Text) + "</del>")
+		case DiffInsert:
+			b.WriteString("<ins>" + html.EscapeString(edit.Text) + "</ins>")
+		default:
+			b.WriteString(html.EscapeString(edit.Text))
+		}
+	}
+	return b.String()
+}
+
+// ANSI escape sequences used by DiffEdits.ANSI
+const (
+	diffANSIDelete = "\x1b[9;31m" // Red strikethrough
+	diffANSIInsert = "\x1b[32m"   // Green
+	diffANSIReset  = "\x1b[0m"
+)
+
+// ANSI returns the edits for terminal output with deletions
+// in red strikethrough and insertions in green
+// using ANSI escape sequences.
+func (edits DiffEdits) ANSI() string {
+	var b strings.Builder
+	for _, edit := range edits {
+		switch edit.Op {
+		case DiffDelete:
+			b.WriteString(diffANSIDelete + edit.Text + diffANSIReset)
+		case DiffInsert:
+			b.WriteString(diffANSIInsert + edit.Text + diffANSIReset)
+		default:
+			b.WriteString(edit.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package strutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want DiffEdits
+	}{
+		{a: "", b: "", want: nil},
+		{a: "same", b: "same", want: DiffEdits{{DiffEqual, "same"}}},
+		{a: "", b: "new", want: DiffEdits{{DiffInsert, "new"}}},
+		{a: "old", b: "", want: DiffEdits{{DiffDelete, "old"}}},
+		{
+			a: "Invoice 2024-001",
+			b: "Invoice 2024-007",
+			want: DiffEdits{
+				{DiffEqual, "Invoice 2024-"},
+				{DiffDelete, "001"},
+				{DiffInsert, "007"},
+			},
+		},
+		{
+			a: "Muster GmbH Wien",
+			b: "Muster AG Wien",
+			want: DiffEdits{
+				{DiffEqual, "Muster "},
+				{DiffDelete, "GmbH"},
+				{DiffInsert, "AG"},
+				{DiffEqual, " Wien"},
+			},
+		},
+		{
+			a: "Hauptstr. 1",
+			b: "Hauptstraße 1, 1010",
+			want: DiffEdits{
+				{DiffDelete, "Hauptstr."},
+				{DiffInsert, "Hauptstraße"},
+				{DiffEqual, " 1"},
+				{DiffInsert, ", 1010"},
+			},
+		},
+		{
+			// Whitespace between changes is merged into the change
+			a: "one two three",
+			b: "uno dos three",
+			want: DiffEdits{
+				{DiffDelete, "one two"},
+				{DiffInsert, "uno dos"},
+				{DiffEqual, " three"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"->"+tt.b, func(t *testing.T) {
+			edits := DiffWords(tt.a, tt.b)
+			assert.Equal(t, tt.want, edits)
+			assert.Equal(t, tt.a, edits.Source())
+			assert.Equal(t, tt.b, edits.Target())
+			assert.Equal(t, tt.a != tt.b, edits.HasChanges())
+
+			patched, err := edits.Patch(tt.a)
+			require.NoError(t, err)
+			assert.Equal(t, tt.b, patched)
+		})
+	}
+
+	_, err := DiffWords("a", "b").Patch("c")
+	assert.Error(t, err)
+}
+
+func TestDiffEditsRender(t *testing.T) {
+	edits := DiffWords("Total <100>", "Total <200>")
+	assert.Equal(t, "Total &lt;<del>100</del><ins>200</ins>&gt;", edits.HTML())
+	assert.Equal(t, "Total <\x1b[9;31m100\x1b[0m\x1b[32m200\x1b[0m>", edits.ANSI())
+	assert.Equal(t, "DELETE", DiffDelete.String())
+}
@@ -0,0 +1,451 @@
+package strutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NumberWords is a number parsed from words by ParseNumberWords.
+type NumberWords struct {
+	// Value of the parsed number.
+	Value float64 `json:"value"`
+	// Decimals is the number of decimal digits of Value,
+	// zero for integers.
+	Decimals int `json:"decimals"`
+	// Lang is the ISO 639-1 code of the language
+	// of the parsed words, either "de" or "en".
+	Lang string `json:"lang"`
+	// Confidence in the range 0 to 1.
+	// Every word that is not a number, currency or filler word
+	// like "only" or "nur" halves the confidence.
+	Confidence float64 `json:"confidence"`
+}
+
+// IsInteger returns if the number has no decimals.
+func (n NumberWords) IsInteger() bool {
+	return n.Decimals == 0
+}
+
+type numberWordKind int
+
+const (
+	numberWordUnit numberWordKind = iota + 1 // 0-9
+	numberWordTeen                           // 10-19
+	numberWordTen                            // 20, 30, ... 90
+	numberWordHundred
+	numberWordScale // thousand, million, billion
+	numberWordAnd
+	numberWordPoint
+	numberWordMajor // major currency unit like euro
+	numberWordMinor // minor currency unit like cent
+	numberWordFiller
+)
+
+type numberWord struct {
+	kind  numberWordKind
+	value int64
+}
+
+// numberWordsLexicon builds a lexicon from space separated word lists
+// where the index of a word in a list is its value.
+func numberWordsLexicon(units, teens, tens string, words map[string]numberWord) map[string]numberWord {
+	lex := make(map[string]numberWord, len(words)+40)
+	for i, list := range strings.Split(units, "|") {
+		for _, w := range strings.Fields(list) {
+			lex[w] = numberWord{numberWordUnit, int64(i)}
+		}
+	}
+	for i, list := range strings.Split(teens, "|") {
+		for _, w := range strings.Fields(list) {
+			lex[w] = numberWord{numberWordTeen, int64(10 + i)}
+		}
+	}
+	for i, list := range strings.Split(tens, "|") {
+		for _, w := range strings.Fields(list) {
+			lex[w] = numberWord{numberWordTen, int64(20 + i*10)}
+		}
+	}
+	for w, n := range words {
+		lex[w] = n
+	}
+	return lex
+}
+
+var (
+	numberWordsCurrencies = map[string]numberWord{
+		"eur": {numberWordMajor, 0},
+		"usd": {numberWordMajor, 0},
+		"chf": {numberWordMajor, 0},
+		"gbp": {numberWordMajor, 0},
+	}
+
+	numberWordsEN = numberWordsLexicon(
+		"zero|one a|two|three|four|five|six|seven|eight|nine",
+		"ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen",
+		"twenty|thirty|forty fourty|fifty|sixty|seventy|eighty|ninety",
+		map[string]numberWord{
+			"hundred":  {numberWordHundred, 100},
+			"thousand": {numberWordScale, 1e3},
+			"million":  {numberWordScale, 1e6},
+			"billion":  {numberWordScale, 1e9},
+			"and":      {numberWordAnd, 0},
+			"point":    {numberWordPoint, 0},
+			"euro":     {numberWordMajor, 0},
+			"euros":    {numberWordMajor, 0},
+			"dollar":   {numberWordMajor, 0},
+			"dollars":  {numberWordMajor, 0},
+			"pound":    {numberWordMajor, 0},
+			"pounds":   {numberWordMajor, 0},
+			"franc":    {numberWordMajor, 0},
+			"francs":   {numberWordMajor, 0},
+			"cent":     {numberWordMinor, 0},
+			"cents":    {numberWordMinor, 0},
+			"penny":    {numberWordMinor, 0},
+			"pence":    {numberWordMinor, 0},
+			"only":     {numberWordFiller, 0},
+			"exactly":  {numberWordFiller, 0},
+			"in":       {numberWordFiller, 0},
+			"words":    {numberWordFiller, 0},
+			"eur":      numberWordsCurrencies["eur"],
+			"usd":      numberWordsCurrencies["usd"],
+			"chf":      numberWordsCurrencies["chf"],
+			"gbp":      numberWordsCurrencies["gbp"],
+		},
+	)
+
+	numberWordsDE = numberWordsLexicon(
+		"null|ein eins eine|zwei zwo|drei|vier|fünf fuenf|sechs|sieben|acht|neun",
+		"zehn|elf|zwölf zwoelf|dreizehn|vierzehn|fünfzehn fuenfzehn|sechzehn|siebzehn|achtzehn|neunzehn",
+		"zwanzig|dreißig dreissig|vierzig|fünfzig fuenfzig|sechzig|siebzig|achtzig|neunzig",
+		map[string]numberWord{
+			"hundert":    {numberWordHundred, 100},
+			"tausend":    {numberWordScale, 1e3},
+			"million":    {numberWordScale, 1e6},
+			"millionen":  {numberWordScale, 1e6},
+			"milliarde":  {numberWordScale, 1e9},
+			"milliarden": {numberWordScale, 1e9},
+			"und":        {numberWordAnd, 0},
+			"komma":      {numberWordPoint, 0},
+			"euro":       {numberWordMajor, 0},
+			"dollar":     {numberWordMajor, 0},
+			"franken":    {numberWordMajor, 0},
+			"pfund":      {numberWordMajor, 0},
+			"cent":       {numberWordMinor, 0},
+			"rappen":     {numberWordMinor, 0},
+			"nur":        {numberWordFiller, 0},
+			"genau":      {numberWordFiller, 0},
+			"in":         {numberWordFiller, 0},
+			"worten":     {numberWordFiller, 0},
+			"eur":        numberWordsCurrencies["eur"],
+			"usd":        numberWordsCurrencies["usd"],
+			"chf":        numberWordsCurrencies["chf"],
+			"gbp":        numberWordsCurrencies["gbp"],
+		},
+	)
+)
+
+// ParseNumberWords parses a number written in words
+// like "dreitausendfünfhundert" or "three thousand five hundred"
+// as found on checks and contracts.
+//
+// Supported languages are German and English passed as ISO 639-1 code
+// "de" or "en" optionally followed by a region like "de-AT".
+// If lang is empty, then both languages are tried
+// and the result with the higher confidence is returned.
+//
+// Decimals can be written with "komma" or "point"
+// followed by digits like "drei komma eins vier".
+// Major and minor currency units like "Euro" and "Cent"
+// are interpreted as amount with two decimals:
+// "zweihundert Euro und fünfzig Cent" returns 200.5
+// with the Decimals 2.
+//
+// Words that are not part of the number lower the Confidence
+// of the result, but an error is returned if no number word was found.
+func ParseNumberWords(str, lang string) (NumberWords, error) {
+	if len(lang) > 2 && (lang[2] == '-' || lang[2] == '_') {
+		lang = lang[:2]
+	}
+	switch strings.ToLower(lang) {
+	case "de":
+		return parseNumberWords(str, "de", numberWordsDE)
+	case "en":
+		return parseNumberWords(str, "en", numberWordsEN)
+	case "":
+		de, deErr := parseNumberWords(str, "de", numberWordsDE)
+		en, enErr := parseNumberWords(str, "en", numberWordsEN)
+		switch {
+		case deErr != nil && enErr != nil:
+			return NumberWords{}, deErr
+		case deErr != nil:
+			return en, nil
+		case enErr != nil || de.Confidence >= en.Confidence:
+			return de, nil
+		default:
+			return en, nil
+		}
+	default:
+		return NumberWords{}, fmt.Errorf("unsupported language %q for number words", lang)
+	}
+}
+
+type numberWordToken struct {
+	numberWord
+	word string
+}
+
+// tokenizeNumberWords splits str into known words of the lexicon
+// and returns the count of unknown words.
+// German compound words like "dreitausendfünfhundert"
+// are split by longest prefix matches.
+func tokenizeNumberWords(str string, lex map[string]numberWord, compound bool) (tokens []numberWordToken, unknown int) {
+	fields := strings.FieldsFunc(strings.ToLower(str), func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, field := range fields {
+		if w, ok := lex[field]; ok {
+			tokens = append(tokens, numberWordToken{w, field})
+			continue
+		}
+		if !compound {
+			unknown++
+			continue
+		}
+		var parts []numberWordToken
+		for rest := field; rest != ""; {
+			found := false
+			for end := len(rest); end > 0; end-- {
+				if w, ok := lex[rest[:end]]; ok {
+					parts = append(parts, numberWordToken{w, rest[:end]})
+					rest = rest[end:]
+					found = true
+					break
+				}
+			}
+			if !found {
+				parts = nil
+				break
+			}
+		}
+		if parts == nil {
+			unknown++
+			continue
+		}
+		tokens = append(tokens, parts...)
+	}
+	return tokens, unknown
+}
+
+// numberWordsAccumulator sums up the values of integer number words
+// and checks that they follow the grammar of the language.
+type numberWordsAccumulator struct {
+	german    bool
+	total     int64
+	current   int64
+	last      numberWordKind
+	lastScale int64
+	connector bool
+	zero      bool
+	count     int
+}
+
+func (a *numberWordsAccumulator) add(t numberWordToken) error {
+	if a.zero {
+		return fmt.Errorf("unexpected number word %q after zero", t.word)
+	}
+	switch t.kind {
+	case numberWordAnd:
+		a.connector = true
+		return nil
+
+	case numberWordUnit, numberWordTeen, numberWordTen:
+		if t.kind == numberWordUnit && t.value == 0 {
+			if a.count > 0 {
+				return fmt.Errorf("unexpected number word %q", t.word)
+			}
+			a.zero = true
+		}
+		switch a.last {
+		case numberWordUnit, numberWordTeen, numberWordTen:
+			var valid bool
+			if a.german {
+				// siebenundzwanzig
+				valid = a.last == numberWordUnit && t.kind == numberWordTen && a.connector
+			} else {
+				// twenty-seven
+				valid = a.last == numberWordTen && t.kind == numberWordUnit
+			}
+			if !valid {
+				return fmt.Errorf("unexpected number word %q", t.word)
+			}
+		}
+		a.current += t.value
+
+	case numberWordHundred:
+		if a.last == numberWordHundred || a.current >= 100 {
+			return fmt.Errorf("unexpected number word %q", t.word)
+		}
+		if a.current == 0 {
+			a.current = 1
+		}
+		a.current *= t.value
+
+	case numberWordScale:
+		if a.last == numberWordScale || (a.lastScale != 0 && t.value >= a.lastScale) {
+			return fmt.Errorf("unexpected number word %q", t.word)
+		}
+		if a.current == 0 {
+			a.current = 1
+		}
+		a.total += a.current * t.value
+		a.current = 0
+		a.lastScale = t.value
+
+	default:
+		return fmt.Errorf("unexpected word %q", t.word)
+	}
+	a.last = t.kind
+	a.connector = false
+	a.count++
+	return nil
+}
+
+func (a *numberWordsAccumulator) value() int64 {
+	return a.total + a.current
+}
+
+// numberWordsFraction returns the fraction and its decimal count
+// of the words after a decimal point.
+// Single digits are read one by one like "eins vier" for 0.14,
+// else the words are read as one integer like "fünfundzwanzig" for 0.25.
+func numberWordsFraction(tokens []numberWordToken, german bool) (float64, int, error) {
+	if len(tokens) == 0 {
+		return 0, 0, errors.New("missing number words after decimal point")
+	}
+	digits := true
+	for _, t := range tokens {
+		if t.kind != numberWordUnit {
+			digits = false
+			break
+		}
+	}
+	if digits {
+		var n int64
+		for _, t := range tokens {
+			n = n*10 + t.value
+		}
+		return float64(n) / math.Pow10(len(tokens)), len(tokens), nil
+	}
+	acc := numberWordsAccumulator{german: german}
+	for _, t := range tokens {
+		if err := acc.add(t); err != nil {
+			return 0, 0, err
+		}
+	}
+	decimals := len(strconv.FormatInt(acc.value(), 10))
+	return float64(acc.value()) / math.Pow10(decimals), decimals, nil
+}
+
+func parseNumberWords(str, lang string, lex map[string]numberWord) (NumberWords, error) {
+	german := lang == "de"
+	tokens, unknown := tokenizeNumberWords(str, lex, german)
+
+	var (
+		acc          = numberWordsAccumulator{german: german}
+		hasPoint     bool
+		fraction     []numberWordToken
+		hasMajor     bool
+		major        float64
+		majorDecs    int
+		result       NumberWords
+		numberTokens int
+	)
+	// number returns the integer and fraction read since the last currency word
+	number := func() (float64, int, error) {
+		value := float64(acc.value())
+		if !hasPoint {
+			return value, 0, nil
+		}
+		f, decimals, err := numberWordsFraction(fraction, german)
+		return value + f, decimals, err
+	}
+	for i, t := range tokens {
+		switch t.kind {
+		case numberWordFiller:
+			continue
+
+		case numberWordPoint:
+			if hasPoint || acc.count == 0 {
+				return NumberWords{}, fmt.Errorf("unexpected word %q", t.word)
+			}
+			hasPoint = true
+
+		case numberWordMajor:
+			if hasMajor {
+				return NumberWords{}, fmt.Errorf("unexpected word %q", t.word)
+			}
+			if acc.count == 0 {
+				// Currency word before the number like "EUR dreihundert"
+				continue
+			}
+			var err error
+			major, majorDecs, err = number()
+			if err != nil {
+				return NumberWords{}, err
+			}
+			hasMajor = true
+			acc = numberWordsAccumulator{german: german}
+			hasPoint, fraction = false, nil
+
+		case numberWordMinor:
+			if acc.count == 0 || hasPoint || (hasMajor && acc.value() >= 100) {
+				return NumberWords{}, fmt.Errorf("unexpected word %q", t.word)
+			}
+			for _, rest := range tokens[i+1:] {
+				if rest.kind != numberWordFiller {
+					return NumberWords{}, fmt.Errorf("unexpected word %q", rest.word)
+				}
+			}
+			result.Value = major + float64(acc.value())/100
+			result.Decimals = 2
+			acc = numberWordsAccumulator{german: german}
+			hasMajor = false
+
+		default:
+			numberTokens++
+			if hasPoint {
+				fraction = append(fraction, t)
+				continue
+			}
+			if err := acc.add(t); err != nil {
+				return NumberWords{}, err
+			}
+		}
+	}
+	if numberTokens == 0 {
+		return NumberWords{}, fmt.Errorf("no number words found in %q", str)
+	}
+
+	switch {
+	case hasMajor && acc.count > 0:
+		// Minor unit without currency word like "drei Euro fünfzig"
+		if hasPoint || acc.value() >= 100 {
+			return NumberWords{}, fmt.Errorf("invalid minor currency units in %q", str)
+		}
+		result.Value = major + float64(acc.value())/100
+		result.Decimals = 2
+	case hasMajor:
+		result.Value, result.Decimals = major, majorDecs
+	case acc.count > 0 || hasPoint:
+		value, decimals, err := number()
+		if err != nil {
+			return NumberWords{}, err
+		}
+		result.Value, result.Decimals = value, decimals
+	}
+	result.Lang = lang
+	result.Confidence = math.Pow(0.5, float64(unknown))
+	return result, nil
+}
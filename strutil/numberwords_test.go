@@ -0,0 +1,85 @@
+package strutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumberWords(t *testing.T) {
+	tests := []struct {
+		str      string
+		lang     string
+		want     float64
+		decimals int
+	}{
+		{"null", "de", 0, 0},
+		{"eins", "de", 1, 0},
+		{"siebenundzwanzig", "de", 27, 0},
+		{"dreitausendfünfhundert", "de", 3500, 0},
+		{"Dreitausendfünfhundertsiebenundzwanzig", "de", 3527, 0},
+		{"zweihundertdreiundvierzig", "de", 243, 0},
+		{"tausendundeins", "de", 1001, 0},
+		{"hunderttausend", "de", 100000, 0},
+		{"zwei Millionen dreihunderttausend", "de", 2300000, 0},
+		{"eine Milliarde", "de", 1e9, 0},
+		{"dreissig", "de", 30, 0},
+		{"fuenfzehn", "de-AT", 15, 0},
+		{"drei Komma eins vier", "de", 3.14, 2},
+		{"zwei komma fünfundzwanzig", "de", 2.25, 2},
+		{"zweihundert Euro und fünfzig Cent", "de", 200.5, 2},
+		{"drei Euro fünfzig", "de", 3.5, 2},
+		{"fünfzig Cent", "de", 0.5, 2},
+		{"EUR dreihundert", "de", 300, 0},
+		{"in Worten: nur eintausendzweihundert Euro", "de", 1200, 0},
+		{"zero", "en", 0, 0},
+		{"twenty-seven", "en", 27, 0},
+		{"three thousand five hundred", "en", 3500, 0},
+		{"one hundred and five", "en", 105, 0},
+		{"a hundred", "en", 100, 0},
+		{"nineteen hundred", "en", 1900, 0},
+		{"two million three hundred thousand and one", "en", 2300001, 0},
+		{"three point one four", "en", 3.14, 2},
+		{"Twelve Dollars and 00/100 Only", "en", 12, 0},
+		{"two hundred euros and fifty cents only", "en", 200.5, 2},
+		{"dreitausendfünfhundert", "", 3500, 0},
+		{"three thousand five hundred", "", 3500, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			got, err := ParseNumberWords(tt.str, tt.lang)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got.Value, 1e-9)
+			assert.Equal(t, tt.decimals, got.Decimals)
+			assert.Equal(t, 1.0, got.Confidence)
+		})
+	}
+
+	invalid := []struct {
+		str  string
+		lang string
+	}{
+		{"", "de"},
+		{"Hallo Welt", "de"},
+		{"dreizwanzig", "de"},
+		{"zwanzigdrei", "de"},
+		{"null eins", "de"},
+		{"tausend tausend", "de"},
+		{"five twenty", "en"},
+		{"one two", "en"},
+		{"hundred hundred", "en"},
+		{"thousand million", "en"},
+		{"three", "fr"},
+	}
+	for _, tt := range invalid {
+		_, err := ParseNumberWords(tt.str, tt.lang)
+		assert.Error(t, err, tt.str)
+	}
+
+	got, err := ParseNumberWords("Betrag dreihundert", "de")
+	require.NoError(t, err)
+	assert.Equal(t, 300.0, got.Value)
+	assert.Equal(t, 0.5, got.Confidence)
+	assert.Equal(t, "de", got.Lang)
+}
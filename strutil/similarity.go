@@ -0,0 +1,48 @@
+package strutil
+
+import "unicode/utf8"
+
+// LevenshteinDistance returns the minimum number of single rune
+// insertions, deletions, or substitutions needed to change a into b.
+func LevenshteinDistance[S ~string](a, b S) int {
+	if a == b {
+		return 0
+	}
+	ra, rb := []rune(string(a)), []rune(string(b))
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+	// Only keep the previous and the current row of the matrix
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range ra {
+		curr[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			curr[j+1] = min(prev[j+1]+1, curr[j]+1, prev[j]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Similarity returns a fuzzy similarity ratio between 0 and 1
+// of two strings based on their LevenshteinDistance
+// relative to the rune count of the longer string.
+// Equal strings including two empty strings have a similarity of 1.
+func Similarity[S ~string](a, b S) float64 {
+	maxLen := max(utf8.RuneCountInString(string(a)), utf8.RuneCountInString(string(b)))
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(LevenshteinDistance(a, b))/float64(maxLen)
+}
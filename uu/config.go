@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -34,7 +35,11 @@ const IDRegex = "[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}"
 const epochStart = 122192928000000000
 
 // Used in string method conversion
-const dash byte = '-'
+const (
+	dash           byte = '-'
+	hexDigitsLower      = "0123456789abcdef"
+	hexDigitsUpper      = "0123456789ABCDEF"
+)
 
 // Predefined namespace UUIDs.
 var (
@@ -86,8 +91,17 @@ func initStorage() {
 	initHardwareAddr()
 }
 
+// randReader is the entropy source set with ReplaceRandReader
+// or nil to use crypto/rand
+var randReader atomic.Pointer[lockedReader]
+
 func safeRandom(dest []byte) {
-	_, err := rand.Read(dest)
+	var err error
+	if r := randReader.Load(); r != nil {
+		_, err = r.Read(dest)
+	} else {
+		_, err = rand.Read(dest)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,15 @@
+// Package uu provides UUID types and functions
+// like ID, NullableID, IDSet, FrozenIDSet and IDSlice.
+//
+// All parsing functions and unmarshalling methods of the package
+// return errors for malformed input and never panic.
+// This is enforced by the fuzz targets of the subpackage uufuzz
+// which also provides seed corpora and property checks
+// that can be reused in the fuzz targets of other projects.
+//
+// The types implement the database/sql interfaces
+// using the text format of UUIDs.
+// For the binary uuid and uuid[] formats with pgx v5 use
+// the separate module github.com/domonda/go-types/uupgx
+// which keeps pgx out of the dependencies of this package.
+package uu
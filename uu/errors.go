@@ -17,3 +17,56 @@ type ErrInvalidVersion int
 func (e ErrInvalidVersion) Error() string {
 	return fmt.Sprintf("invalid UUID version: %d", e)
 }
+
+// ErrDuplicateID is returned when an ID occurs more than once
+// where unique IDs are required.
+type ErrDuplicateID struct {
+	ID ID
+	// Index of the duplicate occurrence.
+	Index int
+	// FirstIndex is the index of the first occurrence.
+	FirstIndex int
+}
+
+func (e ErrDuplicateID) Error() string {
+	return fmt.Sprintf("duplicate UUID %s at index %d, first occurrence at index %d", e.ID, e.Index, e.FirstIndex)
+}
+
+// ErrInvalidIDAtIndex is returned when an ID
+// of a collection could not be parsed or is not valid.
+type ErrInvalidIDAtIndex struct {
+	// Index of the invalid ID in the collection.
+	Index int
+	// Err is the parsing or validation error.
+	Err error
+}
+
+func (e ErrInvalidIDAtIndex) Error() string {
+	return fmt.Sprintf("invalid UUID at index %d: %s", e.Index, e.Err)
+}
+
+func (e ErrInvalidIDAtIndex) Unwrap() error {
+	return e.Err
+}
+
+// ErrNamespaceCollision is returned by RegisterNamespace
+// when the name or the ID is already registered differently.
+type ErrNamespaceCollision struct {
+	// Name to be registered.
+	Name string
+	// ID to be registered.
+	ID ID
+	// RegisteredName is the name already registered for ID
+	// or empty if ID is not registered.
+	RegisteredName string
+	// RegisteredID is the ID already registered for Name
+	// or the Nil ID if Name is not registered.
+	RegisteredID ID
+}
+
+func (e ErrNamespaceCollision) Error() string {
+	if e.RegisteredName != "" && e.RegisteredName != e.Name {
+		return fmt.Sprintf("UUID namespace %s for %q already registered as %q", e.ID, e.Name, e.RegisteredName)
+	}
+	return fmt.Sprintf("UUID namespace %q already registered as %s instead of %s", e.Name, e.RegisteredID, e.ID)
+}
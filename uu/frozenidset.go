@@ -0,0 +1,229 @@
+package uu
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"hash/fnv"
+	"io"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var _ IDs = FrozenIDSet{}
+
+// FrozenIDSet is an immutable set of uu.IDs
+// that is safe to be shared between goroutines without locking.
+//
+// The IDs are stored sorted in an immutable string
+// and looked up with a binary search.
+// The hash is computed once when the set is created,
+// which makes it useful
+// for configuration-like sets that are created once
+// and read very often.
+//
+// FrozenIDSet values are comparable with ==
+// which is true for sets with the same IDs,
+// so they can be used as map keys.
+// Methods returning collections return copies,
+// so the set can't be modified through them.
+// The zero value is an empty set.
+type FrozenIDSet struct {
+	ids  string // sorted IDs with 16 bytes each
+	hash uint64
+}
+
+// MakeFrozenIDSet returns a FrozenIDSet with the passed ids.
+func MakeFrozenIDSet(ids ...ID) FrozenIDSet {
+	return IDSlice(ids).AsSet().Freeze()
+}
+
+// Freeze returns a FrozenIDSet with the IDs of the set.
+// Later changes of s don't affect the returned FrozenIDSet.
+func (s IDSet) Freeze() FrozenIDSet {
+	if len(s) == 0 {
+		return FrozenIDSet{}
+	}
+	sorted := s.AsSortedSlice()
+	var b strings.Builder
+	b.Grow(len(sorted) * 16)
+	for _, id := range sorted {
+		b.Write(id[:])
+	}
+	return FrozenIDSet{
+		ids:  b.String(),
+		hash: canonicalIDsHash(sorted),
+	}
+}
+
+// canonicalIDsHash returns the FNV-1a hash of the bytes of ids
+// sorted in canonical byte order which, unlike the native-endian
+// ID.Less order, is the same on all platforms.
+// The order of ids is changed.
+func canonicalIDsHash(ids IDSlice) uint64 {
+	slices.SortFunc(ids, func(a, b ID) int { return bytes.Compare(a[:], b[:]) })
+	h := fnv.New64a()
+	for _, id := range ids {
+		h.Write(id[:]) //#nosec G104 -- hash.Hash.Write never returns an error
+	}
+	return h.Sum64()
+}
+
+// at returns the ID at index i of the sorted IDs.
+func (f FrozenIDSet) at(i int) (id ID) {
+	copy(id[:], f.ids[i*16:])
+	return id
+}
+
+// String implements the fmt.Stringer interface
+// with the same format as IDSet.String.
+func (f FrozenIDSet) String() string {
+	return "set" + f.AsSlice().String()
+}
+
+// PrettyPrint using FrozenIDSet.String.
+// Implements the pretty.Printable interface.
+func (f FrozenIDSet) PrettyPrint(w io.Writer) {
+	w.Write([]byte(f.String())) //#nosec G104 -- go-pretty does not check write errors
+}
+
+// Hash returns the 64 bit FNV-1a hash of the bytes of the IDs
+// sorted in canonical byte order (the order of the hex strings),
+// which is equal for sets with the same IDs and zero for an empty set.
+// It is stable across processes and platforms and can be used as cache key.
+func (f FrozenIDSet) Hash() uint64 {
+	return f.hash
+}
+
+// Len returns the number of IDs in the set.
+func (f FrozenIDSet) Len() int {
+	return len(f.ids) / 16
+}
+
+// IsEmpty returns true if the set is empty.
+func (f FrozenIDSet) IsEmpty() bool {
+	return len(f.ids) == 0
+}
+
+// IsZero returns true if the set is empty.
+// IsZero is used by encoding/json for the omitzero tag option.
+func (f FrozenIDSet) IsZero() bool {
+	return len(f.ids) == 0
+}
+
+// Contains returns true if the set contains the passed id
+// using a binary search.
+func (f FrozenIDSet) Contains(id ID) bool {
+	n := f.Len()
+	i := sort.Search(n, func(i int) bool { return !f.at(i).Less(id) })
+	return i < n && f.at(i) == id
+}
+
+// Equal returns true if both sets contain the same IDs
+// which is the same as comparing them with ==.
+func (f FrozenIDSet) Equal(other FrozenIDSet) bool {
+	return f == other
+}
+
+// All returns an iterator over the IDs of the set in sorted order
+// without allocating a copy.
+func (f FrozenIDSet) All() iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for i := range f.Len() {
+			if !yield(f.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls the passed function for each ID in sorted order.
+// Any error from the callback function is returned
+// by ForEach immediatly.
+// Returning a sentinel error is a way to stop the loop
+// with a known cause that might not be a real error.
+func (f FrozenIDSet) ForEach(callback func(ID) error) error {
+	for i := range f.Len() {
+		if err := callback(f.at(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsSet returns a mutable copy of the set.
+func (f FrozenIDSet) AsSet() IDSet {
+	if f.IsEmpty() {
+		return nil
+	}
+	set := make(IDSet, f.Len())
+	for id := range f.All() {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// AsSlice returns a copy of the IDs of the set as sorted IDSlice.
+func (f FrozenIDSet) AsSlice() IDSlice {
+	if f.IsEmpty() {
+		return nil
+	}
+	return slices.AppendSeq(make(IDSlice, 0, f.Len()), f.All())
+}
+
+// AsSortedSlice returns a copy of the IDs of the set as sorted IDSlice.
+func (f FrozenIDSet) AsSortedSlice() IDSlice {
+	return f.AsSlice()
+}
+
+// Strings returns a sorted slice with all IDs converted to strings
+func (f FrozenIDSet) Strings() []string {
+	return f.AsSlice().Strings()
+}
+
+// Value implements the driver database/sql/driver.Valuer interface
+// returning an empty array for an empty set.
+func (f FrozenIDSet) Value() (driver.Value, error) {
+	if f.IsEmpty() {
+		return "{}", nil
+	}
+	return f.AsSlice().Value()
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// returning an empty array for an empty set.
+func (f FrozenIDSet) MarshalJSON() ([]byte, error) {
+	if f.IsEmpty() {
+		return []byte("[]"), nil
+	}
+	return f.AsSlice().MarshalJSON()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// by assigning a new FrozenIDSet to *f.
+func (f *FrozenIDSet) UnmarshalJSON(data []byte) error {
+	var set IDSet
+	err := set.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*f = set.Freeze()
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (f FrozenIDSet) MarshalText() (text []byte, err error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+// by assigning a new FrozenIDSet to *f.
+func (f *FrozenIDSet) UnmarshalText(text []byte) error {
+	set, err := IDSetFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*f = set.Freeze()
+	return nil
+}
@@ -0,0 +1,144 @@
+package uu
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrozenIDSet(t *testing.T) {
+	a := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+	b := IDMust("ec449f0f-e10c-4edb-8b59-0e6c896fdca5")
+	c := IDMust("f3e52e97-e976-4a4c-a602-294310bcf935")
+
+	set := MakeIDSet(b, a)
+	frozen := set.Freeze()
+	set.Add(c)
+
+	assert.Equal(t, 2, frozen.Len())
+	assert.True(t, frozen.Contains(a))
+	assert.False(t, frozen.Contains(c))
+	assert.Equal(t, IDSlice{a, b}, frozen.AsSortedSlice())
+	assert.Equal(t, []ID{a, b}, slices.Collect(frozen.All()))
+	assert.Equal(t, MakeIDSet(a, b).String(), frozen.String())
+
+	// Returned collections are copies
+	frozen.AsSet().Add(c)
+	frozen.AsSlice()[0] = c
+	assert.False(t, frozen.Contains(c))
+	assert.Equal(t, IDSlice{a, b}, frozen.AsSlice())
+
+	assert.True(t, frozen.Equal(MakeFrozenIDSet(a, b, a)))
+	assert.Equal(t, frozen.Hash(), MakeFrozenIDSet(b, a).Hash())
+	assert.False(t, frozen.Equal(MakeFrozenIDSet(a, c)))
+	assert.NotEqual(t, frozen.Hash(), MakeFrozenIDSet(a).Hash())
+}
+
+func TestFrozenIDSetComparable(t *testing.T) {
+	ids := make(IDSlice, 100)
+	for i := range ids {
+		ids[i] = IDv4()
+	}
+	frozen := MakeFrozenIDSet(ids...)
+	for _, id := range ids {
+		assert.True(t, frozen.Contains(id), "Contains(%s)", id)
+	}
+	assert.False(t, frozen.Contains(IDNil))
+	assert.False(t, frozen.Contains(IDv4()))
+
+	// Comparable with == and usable as map key
+	reversed := slices.Clone(ids)
+	slices.Reverse(reversed)
+	assert.True(t, frozen == MakeFrozenIDSet(reversed...))
+	assert.False(t, frozen == MakeFrozenIDSet(ids[1:]...))
+	assert.True(t, FrozenIDSet{} == MakeFrozenIDSet())
+	cache := map[FrozenIDSet]int{frozen: 1}
+	assert.Equal(t, 1, cache[ids.AsSet().Freeze()])
+
+	// Stable hash for use as cache key
+	a := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+	assert.Equal(t, uint64(0xd4a3a77831ced216), MakeFrozenIDSet(a).Hash())
+	assert.Equal(t, a.Hash(), MakeFrozenIDSet(a).Hash(), "FNV-1a of the sorted ID bytes")
+
+	// Canonical byte order independent of the native-endian ID.Less
+	lo := IDMust("01000000-0000-0000-0000-0000000000ff")
+	hi := IDMust("ff000000-0000-0000-0000-000000000001")
+	h := fnv.New64a()
+	h.Write(lo[:])
+	h.Write(hi[:])
+	assert.Equal(t, h.Sum64(), MakeFrozenIDSet(hi, lo).Hash())
+}
+
+func BenchmarkFrozenIDSetContains(b *testing.B) {
+	ids := make(IDSlice, 10_000)
+	for i := range ids {
+		ids[i] = IDv4()
+	}
+	frozen := MakeFrozenIDSet(ids...)
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, id := range ids[:100] {
+			_ = frozen.Contains(id)
+		}
+	}
+}
+
+func TestFrozenIDSetEmpty(t *testing.T) {
+	var zero FrozenIDSet
+	assert.True(t, zero.IsEmpty())
+	assert.Zero(t, zero.Hash())
+	assert.False(t, zero.Contains(IDv4()))
+	assert.True(t, zero.Equal(IDSet(nil).Freeze()))
+	assert.True(t, zero.Equal(MakeFrozenIDSet()))
+	assert.Equal(t, "set[]", zero.String())
+
+	j, err := json.Marshal(zero)
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(j))
+	v, err := zero.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", v)
+}
+
+func TestFrozenIDSetMarshalling(t *testing.T) {
+	frozen := MakeFrozenIDSet(IDv4(), IDv4(), IDv4())
+
+	j, err := json.Marshal(frozen)
+	require.NoError(t, err)
+	var parsed FrozenIDSet
+	require.NoError(t, json.Unmarshal(j, &parsed))
+	assert.True(t, frozen.Equal(parsed))
+
+	text, err := frozen.MarshalText()
+	require.NoError(t, err)
+	parsed = FrozenIDSet{}
+	require.NoError(t, parsed.UnmarshalText(text))
+	assert.True(t, frozen.Equal(parsed))
+
+	require.Error(t, parsed.UnmarshalJSON([]byte(`["invalid"]`)))
+}
+
+func TestFrozenIDSetConcurrentReads(t *testing.T) {
+	ids := IDSlice{IDv4(), IDv4(), IDv4()}
+	frozen := MakeFrozenIDSet(ids...)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, id := range ids {
+				assert.True(t, frozen.Contains(id))
+			}
+			_ = frozen.String()
+			_ = frozen.Hash()
+			_ = frozen.AsSlice()
+		}()
+	}
+	wg.Wait()
+}
@@ -5,13 +5,13 @@ import (
 	"crypto/md5"  //#nosec G501 -- Needed for standard conform IDv3
 	"crypto/sha1" //#nosec G505 -- Needed for standard conform IDv5
 	"database/sql/driver"
+	"encoding"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"hash"
 	"io"
-	"strings"
 	"sync"
 	"time"
 	"unsafe"
@@ -27,6 +27,12 @@ var IDNil ID
 // described in RFC 4122.
 type ID [16]byte
 
+// Compile-time check that ID implements the encoding appender interfaces
+var (
+	_ encoding.TextAppender   = ID{}
+	_ encoding.BinaryAppender = ID{}
+)
+
 // IDv1 returns a version 1 ID based on current timestamp and MAC address.
 func IDv1() (id ID) {
 	timeNow, clockSeq, hardwareAddr := getStorage()
@@ -99,23 +105,148 @@ func IDHash(h hash.Hash, namespace ID, data []byte, version int) (id ID) {
 	return id
 }
 
-// IDv7 returns a version 7 ID with the first 48 bits
-// containing a sortable timestamp and random
-// data after the version and variant information.
+// IDv6 returns a version 6 ID as specified by RFC 9562
+// based on current timestamp and MAC address like IDv1,
+// but with the timestamp bytes ordered from most to least
+// significant so that the IDs are sortable by time.
+//
+// See [ID.ToV6] to convert version 1 IDs.
+func IDv6() (id ID) {
+	timeNow, clockSeq, hardwareAddr := getStorage()
+
+	putV6Timestamp(&id, timeNow)
+	binary.BigEndian.PutUint16(id[8:], clockSeq)
+
+	copy(id[10:], hardwareAddr)
+
+	id.SetVersion(6)
+	id.SetVariant()
+
+	return id
+}
+
+// ToV6 converts a version 1 ID to a version 6 ID
+// with the same timestamp, clock sequence and node
+// so that legacy version 1 IDs become sortable by time.
+// A version 6 ID is returned unchanged,
+// all other versions result in an ErrInvalidVersion error.
+func (id ID) ToV6() (ID, error) {
+	switch v := id.Version(); v {
+	case 1:
+		v6 := id
+		putV6Timestamp(&v6, id.v1Timestamp())
+		v6.SetVersion(6)
+		return v6, nil
+	case 6:
+		return id, nil
+	default:
+		return IDNil, ErrInvalidVersion(v)
+	}
+}
+
+// v1Timestamp returns the 60 bit timestamp of a version 1 ID
+func (id ID) v1Timestamp() uint64 {
+	low := uint64(binary.BigEndian.Uint32(id[0:]))
+	mid := uint64(binary.BigEndian.Uint16(id[4:]))
+	high := uint64(binary.BigEndian.Uint16(id[6:]) & 0x0fff)
+	return high<<48 | mid<<32 | low
+}
+
+// v6Timestamp returns the 60 bit timestamp of a version 6 ID
+func (id ID) v6Timestamp() uint64 {
+	high := uint64(binary.BigEndian.Uint32(id[0:]))
+	mid := uint64(binary.BigEndian.Uint16(id[4:]))
+	low := uint64(binary.BigEndian.Uint16(id[6:]) & 0x0fff)
+	return high<<28 | mid<<12 | low
+}
+
+// putV6Timestamp puts the 60 bit timestamp ts
+// into the first 8 bytes of id in version 6 layout
+// leaving the version bits zero.
+func putV6Timestamp(id *ID, ts uint64) {
+	binary.BigEndian.PutUint32(id[0:], uint32(ts>>28))    //#nosec G115 -- integer conversion OK
+	binary.BigEndian.PutUint16(id[4:], uint16(ts>>12))    //#nosec G115 -- integer conversion OK
+	binary.BigEndian.PutUint16(id[6:], uint16(ts&0x0fff)) //#nosec G115 -- integer conversion OK
+}
+
+// IDv7 returns a version 7 ID as specified by RFC 9562
+// with the first 48 bits containing a big-endian Unix Epoch
+// timestamp in milliseconds followed by a 12 bit counter
+// and random data after the version and variant information.
+//
+// IDs returned by IDv7 are strictly monotonic within the process
+// so they sort in generation order also when generated within
+// the same millisecond (RFC 9562 section 6.2, method 1).
+// The counter starts at a random value for every new millisecond
+// and on overflow the timestamp is incremented by one millisecond.
 func IDv7() ID {
-	return IDv7WithTime(time.Now())
+	milli, counter := v7Clock.next(time.Now().UnixMilli())
+	var id ID
+	putV7Milli(&id, milli)
+	binary.BigEndian.PutUint16(id[6:], counter)
+	safeRandom(id[8:])
+	id.SetVersion(7)
+	id.SetVariant()
+	return id
+}
+
+// v7Clock is the monotonic clock of IDv7
+var v7Clock v7MonotonicClock
+
+type v7MonotonicClock struct {
+	lastMilli int64
+	counter   uint16
+	mutex     sync.Mutex
+}
+
+// next returns the millisecond timestamp and 12 bit counter
+// for the next version 7 ID generated at nowMilli.
+func (c *v7MonotonicClock) next(nowMilli int64) (milli int64, counter uint16) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if nowMilli > c.lastMilli {
+		// Start with a random counter in the lower half
+		// of the 12 bits to leave room for incrementing
+		var r [2]byte
+		safeRandom(r[:])
+		c.lastMilli = nowMilli
+		c.counter = binary.BigEndian.Uint16(r[:]) & 0x07ff
+	} else {
+		// Same millisecond or the clock went backwards
+		c.counter++
+		if c.counter > 0x0fff {
+			c.lastMilli++
+			c.counter = 0
+		}
+	}
+	return c.lastMilli, c.counter
+}
+
+// putV7Milli puts the lower 48 bits of milli
+// big-endian into the first 6 bytes of id.
+func putV7Milli(id *ID, milli int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(milli)) //#nosec G115 -- integer conversion OK
+	copy(id[:6], b[2:])
 }
 
 // IDv7WithTime returns a version 7 ID with the first 48 bits
 // containing a sortable timestamp created from the passed time
 // and random data after the version and variant information.
 //
-// Note that the precision is only milliseconds.
+// The timestamp is stored big-endian as specified by RFC 9562.
+// Earlier versions of this package stored it little-endian,
+// use [ID.V7TimeLittleEndian] to decode such IDs.
+//
+// Note that the precision is only milliseconds
+// and that IDs with the same millisecond are not monotonic,
+// use [IDv7] for that.
 //
 // See [ID.V7Time] for the reverse operation.
 func IDv7WithTime(t time.Time) ID {
 	var id ID
-	*(*int64)(unsafe.Pointer(&id[0])) = t.UnixMilli() //#nosec G103 -- unsafe OK
+	putV7Milli(&id, t.UnixMilli())
 	safeRandom(id[6:])
 	id.SetVersion(7)
 	id.SetVariant()
@@ -127,13 +258,58 @@ func IDv7WithTime(t time.Time) ID {
 //
 // Note that the precision is only milliseconds.
 //
+// The timestamp is decoded big-endian as specified by RFC 9562
+// which is incompatible with version 7 UUIDs generated by
+// earlier versions of this package with a little-endian timestamp.
+// Their timestamps are decoded wrongly by V7Time, [ID.Time],
+// and [IDSlice.SortByTime], use [ID.V7TimeLittleEndian] for them.
+//
 // See [IDv7WithTime] for the reverse operation.
 func (id ID) V7Time() time.Time {
 	if id.Version() != 7 {
 		return time.Time{}
 	}
-	u := *(*uint64)(unsafe.Pointer(&id[0]))              //#nosec G103 -- unsafe OK
-	return time.UnixMilli(int64(u & 0x0000ffffffffffff)) //#nosec G115 -- integer conversion OK
+	var b [8]byte
+	copy(b[2:], id[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(b[:]))) //#nosec G115 -- integer conversion OK
+}
+
+// V7TimeLittleEndian returns the timestamp of a version 7 UUID
+// generated by earlier versions of this package that stored
+// the timestamp little-endian instead of big-endian as
+// specified by RFC 9562.
+// If the UUID is not a version 7 UUID, a zero time is returned.
+//
+// See [ID.V7Time] for UUIDs conforming to RFC 9562.
+func (id ID) V7TimeLittleEndian() time.Time {
+	if id.Version() != 7 {
+		return time.Time{}
+	}
+	var b [8]byte
+	copy(b[:6], id[:6])
+	return time.UnixMilli(int64(binary.LittleEndian.Uint64(b[:]))) //#nosec G115 -- integer conversion OK
+}
+
+// Time returns the timestamp embedded in a version 1, 6, or 7 UUID
+// or an ErrInvalidVersion error for all other versions.
+//
+// Note that the precision of version 1 and 6 timestamps
+// is 100 nanoseconds and of version 7 timestamps only milliseconds.
+func (id ID) Time() (time.Time, error) {
+	var ts uint64
+	switch v := id.Version(); v {
+	case 1:
+		ts = id.v1Timestamp()
+	case 6:
+		ts = id.v6Timestamp()
+	case 7:
+		return id.V7Time(), nil
+	default:
+		return time.Time{}, ErrInvalidVersion(v)
+	}
+	// Timestamp in 100 nanosecond intervals since the UUID epoch
+	unix100ns := int64(ts) - epochStart //#nosec G115 -- integer conversion OK
+	return time.Unix(unix100ns/1e7, unix100ns%1e7*100), nil
 }
 
 // IDv7Deterministic returns a version 7 ID with
@@ -144,7 +320,7 @@ func (id ID) V7Time() time.Time {
 // see also IDv7DeterministicFunc.
 func IDv7Deterministic(unixMilli int64) ID {
 	var id ID
-	*(*int64)(unsafe.Pointer(&id[0])) = unixMilli //#nosec G103 -- unsafe OK
+	putV7Milli(&id, unixMilli)
 	id.SetVersion(7)
 	id.SetVariant()
 	return id
@@ -176,9 +352,68 @@ func (d *v7Series) nextID() ID {
 	return id
 }
 
+// IDv4Sequential returns a version 4 UUID with
+// the passed number encoded in the last 62 bits
+// and all other non version/variant bits set to zero.
+//
+// The resulting IDs are easy to read and compare
+// in test output and golden files:
+//
+//	IDv4Sequential(1) == "00000000-0000-4000-8000-000000000001"
+//
+// See also [NewSequentialIDs].
+func IDv4Sequential(n uint64) ID {
+	var id ID
+	binary.BigEndian.PutUint64(id[8:], n)
+	id.SetVersion(4)
+	id.SetVariant()
+	return id
+}
+
+// NewSequentialIDs returns a function that generates
+// readable deterministic version 4 UUIDs using [IDv4Sequential]
+// starting with the passed seed and counting up from there
+// for every call of the returned function.
+//
+// Intended for table-driven tests and golden files,
+// the returned function can be used with [ContextWithIDFunc]
+// or as package default with [ReplaceIDvDefault].
+//
+// The returned function is safe for concurrent use.
+func NewSequentialIDs(seed uint64) func() ID {
+	var (
+		next  = seed
+		mutex sync.Mutex
+	)
+	return func() ID {
+		mutex.Lock()
+		id := IDv4Sequential(next)
+		next++
+		mutex.Unlock()
+		return id
+	}
+}
+
+// ReplaceIDvDefault sets IDvDefault to the passed function
+// and returns a function that restores the previous IDvDefault.
+//
+// Intended for tests that need deterministic IDs
+// from code that uses [NewID] without a context function:
+//
+//	t.Cleanup(uu.ReplaceIDvDefault(uu.NewSequentialIDs(1)))
+//
+// Not safe for concurrent use with ID generation
+// because IDvDefault is a package variable.
+func ReplaceIDvDefault(f func() ID) (restore func()) {
+	prev := IDvDefault
+	IDvDefault = f
+	return func() { IDvDefault = prev }
+}
+
 // IDFromBytes parses a byte slice as UUID.
 // If the slice has a length of 16, it will be interpred as a binary UUID,
 // if the length is 22, 32, or 36, it will be parsed as string.
+// Malformed input never causes a panic but returns an error.
 func IDFromBytes(b []byte) (ID, error) {
 	if len(b) < 16 {
 		return IDNil, fmt.Errorf("uu.ID %q is too short", b)
@@ -197,13 +432,7 @@ func IDFromBytes(b []byte) (ID, error) {
 		return parseDashedFormat(text, b)
 	}
 
-	text := b
-	if text[0] == '"' && text[len(text)-1] == '"' {
-		text = text[1 : len(text)-1]
-	}
-	if text[0] == '{' && text[len(text)-1] == '}' {
-		text = text[1 : len(text)-1]
-	}
+	text := trimEnclosing(trimEnclosing(b, '"', '"'), '{', '}')
 
 	switch len(text) {
 	case 22:
@@ -241,7 +470,12 @@ func IDFromBytesOrNil(s []byte) ID {
 }
 
 // IDFromString parses a string as ID.
-// The string is expected in a form accepted by UnmarshalText.
+// The string is expected in a form accepted by UnmarshalText
+// including the 22 character base64 URL encoding of ID.Base64.
+// Unlike IDFromBase64, non canonical base64 encodings
+// with non zero trailing bits are accepted for compatibility.
+// The version and variant of the ID are not checked,
+// use IDFromStringVersions or IDParseConfig for that.
 func IDFromString(s string) (ID, error) {
 	if len(s) < 22 {
 		return IDNil, fmt.Errorf("uu.ID string too short: %q", s)
@@ -428,19 +662,16 @@ func (id ID) Bytes() []byte {
 // StringBytes returns the canonical string representation of the UUID as byte slice:
 // xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
 func (id ID) StringBytes() []byte {
-	b := make([]byte, 36)
-
-	hex.Encode(b[0:8], id[0:4])
-	b[8] = dash
-	hex.Encode(b[9:13], id[4:6])
-	b[13] = dash
-	hex.Encode(b[14:18], id[6:8])
-	b[18] = dash
-	hex.Encode(b[19:23], id[8:10])
-	b[23] = dash
-	hex.Encode(b[24:36], id[10:16])
+	return id.AppendString(make([]byte, 0, 36))
+}
 
-	return b
+// AppendString appends the canonical string representation
+// of the UUID to dst and returns the extended slice:
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+func (id ID) AppendString(dst []byte) []byte {
+	var b [36]byte
+	id.encodeString(&b, hexDigitsLower)
+	return append(dst, b[:]...)
 }
 
 // String returns the canonical string representation of the UUID:
@@ -449,19 +680,9 @@ func (id ID) StringBytes() []byte {
 //
 // String implements the fmt.Stringer interface.
 func (id ID) String() string {
-	b := make([]byte, 36)
-
-	hex.Encode(b[0:8], id[0:4])
-	b[8] = dash
-	hex.Encode(b[9:13], id[4:6])
-	b[13] = dash
-	hex.Encode(b[14:18], id[6:8])
-	b[18] = dash
-	hex.Encode(b[19:23], id[8:10])
-	b[23] = dash
-	hex.Encode(b[24:36], id[10:16])
-
-	return string(b)
+	var b [36]byte
+	id.encodeString(&b, hexDigitsLower)
+	return string(b[:])
 }
 
 // StringUpper returns the upper case version
@@ -469,7 +690,25 @@ func (id ID) String() string {
 //
 //	XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX
 func (id ID) StringUpper() string {
-	return strings.ToUpper(id.String())
+	var b [36]byte
+	id.encodeString(&b, hexDigitsUpper)
+	return string(b[:])
+}
+
+// encodeString writes the canonical string representation
+// of the UUID into b using the passed hex digits.
+func (id ID) encodeString(b *[36]byte, digits string) {
+	i := 0
+	for j, c := range id {
+		switch j {
+		case 4, 6, 8, 10:
+			b[i] = dash
+			i++
+		}
+		b[i] = digits[c>>4]
+		b[i+1] = digits[c&0x0f]
+		i += 2
+	}
 }
 
 // GoString returns a pseudo Go literal for the ID in the format:
@@ -482,21 +721,68 @@ func (id ID) GoString() string {
 // PrettyPrint the ID in the format xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
 // Implements the pretty.Printable interface.
 func (id ID) PrettyPrint(w io.Writer) {
-	w.Write(id.StringBytes()) //#nosec G104 -- go-pretty does not check write errors
+	var b [36]byte
+	id.encodeString(&b, hexDigitsLower)
+	w.Write(b[:]) //#nosec G104 -- go-pretty does not check write errors
 }
 
 // Hex returns the hex representation without dashes of the UUID
 // The returned string is always 32 characters long.
 func (id ID) Hex() string {
-	return hex.EncodeToString(id[:])
+	var b [32]byte
+	id.encodeHex(&b)
+	return string(b[:])
+}
+
+// AppendHex appends the hex representation without dashes
+// of the UUID to dst and returns the extended slice.
+func (id ID) AppendHex(dst []byte) []byte {
+	var b [32]byte
+	id.encodeHex(&b)
+	return append(dst, b[:]...)
+}
+
+func (id ID) encodeHex(b *[32]byte) {
+	for i, c := range id {
+		b[i*2] = hexDigitsLower[c>>4]
+		b[i*2+1] = hexDigitsLower[c&0x0f]
+	}
 }
 
 // Base64 returns the unpadded base64 URL encoding of the UUID.
-// The returned string is always 22 characters long.
+// The returned string is always 22 characters long
+// and only contains the characters A-Z, a-z, 0-9, '-', and '_'
+// so it can be used in URLs and as compact JSON key.
+//
+// The string can be used as XML NCName if it starts
+// with a letter or '_', else it has to be prefixed
+// because digits and '-' are not valid first NCName characters.
+//
+// See [IDFromBase64] for the reverse operation.
 func (id ID) Base64() string {
 	return base64.RawURLEncoding.EncodeToString(id[:])
 }
 
+// IDFromBase64 parses a 22 character string
+// returned by ID.Base64 as ID.
+// Non canonical encodings with non zero
+// trailing bits are rejected.
+//
+// IDFromString and IDFromBytes also accept this format
+// but are lenient about non zero trailing bits,
+// so they accept some strings that IDFromBase64 rejects.
+func IDFromBase64(s string) (ID, error) {
+	if len(s) != 22 {
+		return IDNil, fmt.Errorf("uu.ID base64 string must be 22 characters long, got %d: %q", len(s), s)
+	}
+	var id ID
+	_, err := base64.RawURLEncoding.Strict().Decode(id[:], []byte(s))
+	if err != nil {
+		return IDNil, fmt.Errorf("uu.ID string %q base64 decoding error: %w", s, err)
+	}
+	return id, nil
+}
+
 // SetVersion sets version bits.
 func (id *ID) SetVersion(v int) {
 	id[6] = (id[6] & 0x0f) | byte(v<<4)
@@ -513,6 +799,13 @@ func (id ID) MarshalText() (text []byte, err error) {
 	return id.StringBytes(), nil
 }
 
+// AppendText implements the encoding.TextAppender interface
+// by appending the same encoding as returned by String
+// without allocating if dst has enough capacity.
+func (id ID) AppendText(dst []byte) ([]byte, error) {
+	return id.AppendString(dst), nil
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 // Following formats are supported:
 // `6ba7b8109dad11d180b400c04fd430c8`
@@ -520,6 +813,7 @@ func (id ID) MarshalText() (text []byte, err error) {
 // `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
 // `{6ba7b810-9dad-11d1-80b4-00c04fd430c8}`
 // `urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c`
+// `a6e4EJ2tEdGAtADAT9QwyA` (see ID.Base64)
 // Surrounding double quotes will be removed before parsing.
 func (id *ID) UnmarshalText(text []byte) (err error) {
 	if len(text) < 22 {
@@ -538,6 +832,12 @@ func (id ID) MarshalBinary() (data []byte, err error) {
 	return id[:], nil
 }
 
+// AppendBinary implements the encoding.BinaryAppender interface
+// by appending the 16 bytes of the ID.
+func (id ID) AppendBinary(dst []byte) ([]byte, error) {
+	return append(dst, id[:]...), nil
+}
+
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 // It will return error if the slice isn't 16 bytes long,
 // but does not check the validity of the UUID.
@@ -555,8 +855,11 @@ func (id ID) Value() (driver.Value, error) {
 }
 
 // Scan implements the sql.Scanner interface.
-// A 16-byte slice is handled by UnmarshalBinary, while
-// a longer byte slice or a string is handled by UnmarshalText.
+// A 16-byte slice like a binary uuid or bytea column value
+// is handled by UnmarshalBinary, while a longer byte slice
+// or a string is handled by UnmarshalText.
+// The [16]byte array values returned by some drivers
+// for binary uuid columns are also accepted.
 func (id *ID) Scan(src any) error {
 	switch src := src.(type) {
 	case []byte:
@@ -567,6 +870,14 @@ func (id *ID) Scan(src any) error {
 
 	case string:
 		return id.UnmarshalText([]byte(src))
+
+	case [16]byte:
+		*id = src
+		return nil
+
+	case ID:
+		*id = src
+		return nil
 	}
 
 	return fmt.Errorf("cannot convert %T to uu.ID", src)
@@ -607,7 +918,50 @@ func (id ID) Less(rhs ID) bool {
 	return l[1] < r[1] || (l[1] == r[1] && l[0] < r[0])
 }
 
+// Hash returns the 64 bit FNV-1a hash of the 16 bytes of the id
+// in their canonical order as defined by the hash/fnv package.
+// The algorithm is stable across versions of this package
+// and can be reproduced in any language.
+func (id ID) Hash() uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for _, b := range id {
+		h ^= uint64(b)
+		h *= prime64
+	}
+	return h
+}
+
+// ShardIndex returns the index of the shard from 0 to numShards-1
+// the id belongs to, calculated as id.Hash() modulo numShards.
+// The result is stable, so every service routes
+// the same id to the same shard for the same numShards.
+// Panics if numShards is less than 1.
+func (id ID) ShardIndex(numShards int) int {
+	if numShards < 1 {
+		panic("uu.ID.ShardIndex: numShards must be at least 1")
+	}
+	return int(id.Hash() % uint64(numShards)) //#nosec G115 -- integer conversion OK
+}
+
+// trimEnclosing returns text without the enclosing first and last bytes
+// if they match, or text unchanged if not or if it is too short.
+func trimEnclosing(text []byte, first, last byte) []byte {
+	if len(text) >= 2 && text[0] == first && text[len(text)-1] == last {
+		return text[1 : len(text)-1]
+	}
+	return text
+}
+
+// parseDashedFormat parses text with a length of 36 bytes
+// and returns an error for invalid formats.
 func parseDashedFormat(text, original []byte) (newID ID, err error) {
+	if len(text) != 36 {
+		return IDNil, fmt.Errorf("uu.ID string has wrong length: %q", original)
+	}
 	if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
 		return IDNil, fmt.Errorf("invalid UUID string format: %q", original)
 	}
@@ -2,9 +2,12 @@ package uu
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,6 +51,89 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestStringEncodings(t *testing.T) {
+	for range 100 {
+		id := IDv4()
+		h := hex.EncodeToString(id[:])
+		canonical := h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+
+		require.Equal(t, canonical, id.String())
+		require.Equal(t, strings.ToUpper(canonical), id.StringUpper())
+		require.Equal(t, []byte(canonical), id.StringBytes())
+		require.Equal(t, h, id.Hex())
+		require.Equal(t, "prefix:"+canonical, string(id.AppendString([]byte("prefix:"))))
+		require.Equal(t, "prefix:"+h, string(id.AppendHex([]byte("prefix:"))))
+		text, err := id.AppendText([]byte("prefix:"))
+		require.NoError(t, err)
+		require.Equal(t, "prefix:"+canonical, string(text))
+		bin, err := id.AppendBinary([]byte{0xff})
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{0xff}, id[:]...), bin)
+	}
+}
+
+func TestAppendAllocs(t *testing.T) {
+	id := IDv4()
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = id.AppendString(buf[:0])
+		buf = id.AppendHex(buf[:0])
+		buf, _ = id.AppendText(buf[:0])
+		buf, _ = id.AppendBinary(buf[:0])
+		buf, _ = NullableID(id).AppendText(buf[:0])
+	})
+	require.Zero(t, allocs)
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = id.String()
+	})
+	require.LessOrEqual(t, allocs, 1.0)
+}
+
+func BenchmarkIDString(b *testing.B) {
+	id := IDv4()
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = id.String()
+	}
+}
+
+func BenchmarkIDAppendString(b *testing.B) {
+	id := IDv4()
+	buf := make([]byte, 0, 36)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = id.AppendString(buf[:0])
+	}
+}
+
+func BenchmarkIDAppendText(b *testing.B) {
+	id := IDv4()
+	buf := make([]byte, 0, 36)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf, _ = id.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkIDHex(b *testing.B) {
+	id := IDv4()
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = id.Hex()
+	}
+}
+
+func BenchmarkIDSliceMarshalJSON(b *testing.B) {
+	s := make(IDSlice, 100)
+	for i := range s {
+		s[i] = IDv4()
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = s.MarshalJSON()
+	}
+}
+
 func TestOr(t *testing.T) {
 	u1 := ID{0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff}
 	u2 := ID{0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00, 0xff, 0x00}
@@ -412,6 +498,28 @@ func TestScanBinary(t *testing.T) {
 	}
 }
 
+func TestScanArray(t *testing.T) {
+	u := ID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	u1 := ID{}
+	err := u1.Scan([16]byte(u))
+	if err != nil {
+		t.Errorf("Error scanning [16]byte: %s", err)
+	}
+	if u != u1 {
+		t.Errorf("UUIDs should be equal: %s and %s", u, u1)
+	}
+
+	var n NullableID
+	err = n.Scan([16]byte(u))
+	if err != nil {
+		t.Errorf("Error scanning [16]byte as NullableID: %s", err)
+	}
+	if n.Get() != u {
+		t.Errorf("UUIDs should be equal: %s and %s", u, n)
+	}
+}
+
 func TestScanString(t *testing.T) {
 	u := ID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
 	s1 := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
@@ -567,6 +675,41 @@ func TestIDv3(t *testing.T) {
 	}
 }
 
+func TestIDv6(t *testing.T) {
+	id := IDv6()
+	require.NoError(t, id.Validate(), "validating UUID")
+	require.Equal(t, 6, id.Version(), "detecting version 6")
+	require.Equal(t, IDVariantRFC4122, id.Variant())
+
+	prev := IDv6()
+	for range 1000 {
+		next := IDv6()
+		require.LessOrEqual(t, bytes.Compare(prev[:8], next[:8]), 0, "timestamps are sortable: %s <= %s", prev, next)
+		prev = next
+	}
+}
+
+func TestID_ToV6(t *testing.T) {
+	// Test vectors from RFC 9562 appendix A.1 and A.5
+	v1 := IDMust("C232AB00-9414-11EC-B3C8-9F6BDECED846")
+	v6 := IDMust("1EC9414C-232A-6B00-B3C8-9F6BDECED846")
+
+	converted, err := v1.ToV6()
+	require.NoError(t, err)
+	require.Equal(t, v6, converted)
+
+	converted, err = v6.ToV6()
+	require.NoError(t, err)
+	require.Equal(t, v6, converted, "version 6 is returned unchanged")
+
+	_, err = IDv4().ToV6()
+	require.Equal(t, ErrInvalidVersion(4), err)
+
+	converted, err = IDv1().ToV6()
+	require.NoError(t, err)
+	require.Equal(t, 6, converted.Version())
+}
+
 func TestIDv4(t *testing.T) {
 	u := IDv4()
 
@@ -627,6 +770,39 @@ func TestIDv7(t *testing.T) {
 	time.Sleep(time.Millisecond)
 	id2 := IDv7()
 	require.NotEqual(t, id, id2, "different timestamps should produce different UUIDs")
+
+	prev := IDv7()
+	for range 10000 {
+		next := IDv7()
+		require.Equal(t, 7, next.Version(), "detecting version 7")
+		require.Equal(t, -1, bytes.Compare(prev[:], next[:]), "IDs are monotonic: %s < %s", prev, next)
+		prev = next
+	}
+
+	early := IDv7WithTime(time.UnixMilli(1743076688815))
+	late := IDv7WithTime(time.UnixMilli(1743076688816))
+	require.Equal(t, -1, bytes.Compare(early[:], late[:]), "IDs are sortable by time")
+	require.Equal(t, "0195d777-83af", early.String()[:13], "big-endian timestamp")
+}
+
+func TestV7MonotonicClock(t *testing.T) {
+	var c v7MonotonicClock
+	milli, counter := c.next(1000)
+	require.Equal(t, int64(1000), milli)
+	require.LessOrEqual(t, counter, uint16(0x07ff))
+
+	c.counter = 0x0ffe
+	milli, counter = c.next(1000)
+	require.Equal(t, int64(1000), milli)
+	require.Equal(t, uint16(0x0fff), counter)
+
+	milli, counter = c.next(1000)
+	require.Equal(t, int64(1001), milli, "counter overflow increments timestamp")
+	require.Equal(t, uint16(0), counter)
+
+	milli, counter = c.next(999)
+	require.Equal(t, int64(1001), milli, "clock going backwards keeps timestamp")
+	require.Equal(t, uint16(1), counter)
 }
 
 func TestIDv7DeterministicFunc(t *testing.T) {
@@ -641,6 +817,22 @@ func TestIDv7DeterministicFunc(t *testing.T) {
 	require.Equal(t, 7, id.Version(), "detecting version 7")
 }
 
+func TestNewSequentialIDs(t *testing.T) {
+	require.Equal(t, IDMust("00000000-0000-4000-8000-000000000001"), IDv4Sequential(1))
+	require.Equal(t, IDMust("00000000-0000-4000-8000-0000000000ff"), IDv4Sequential(255))
+
+	next := NewSequentialIDs(9)
+	require.Equal(t, IDMust("00000000-0000-4000-8000-000000000009"), next())
+	require.Equal(t, IDMust("00000000-0000-4000-8000-00000000000a"), next())
+	require.NoError(t, next().Validate(), "validating UUID")
+
+	restore := ReplaceIDvDefault(NewSequentialIDs(1))
+	require.Equal(t, IDv4Sequential(1), NewID(t.Context()))
+	require.Equal(t, IDv4Sequential(2), NewID(t.Context()))
+	restore()
+	require.Equal(t, 7, NewID(t.Context()).Version(), "restored default IDv7")
+}
+
 func TestID_GoString(t *testing.T) {
 	tests := []struct {
 		name string
@@ -676,8 +868,27 @@ func TestID_Base64(t *testing.T) {
 			parsed, err := IDFromString(b)
 			require.NoError(t, err, "can parse")
 			require.Equal(t, id, parsed, "parsed base64 UUID equal to original")
+			parsed, err = IDFromBase64(b)
+			require.NoError(t, err, "can parse")
+			require.Equal(t, id, parsed, "parsed base64 UUID equal to original")
 		})
 	}
+
+	refID := IDMustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.Equal(t, "a6e4EJ2tEdGAtADAT9QwyA", refID.Base64())
+	parsed, err := IDFromString("a6e4EJ2tEdGAtADAT9QwyA")
+	require.NoError(t, err)
+	require.Equal(t, refID, parsed)
+
+	_, err = IDFromBase64("a6e4EJ2tEdGAtADAT9QwyB")
+	require.Error(t, err, "non zero trailing bits")
+	parsed, err = IDFromString("a6e4EJ2tEdGAtADAT9QwyB")
+	require.NoError(t, err, "IDFromString accepts non zero trailing bits")
+	require.Equal(t, refID, parsed)
+	_, err = IDFromBase64("a6e4EJ2tEdGAtADAT9Qwy+")
+	require.Error(t, err, "standard base64 character")
+	_, err = IDFromBase64("a6e4EJ2tEdGAtADAT9Qwy")
+	require.Error(t, err, "too short")
 }
 
 func TestIDFrom(t *testing.T) {
@@ -842,6 +1053,7 @@ func TestID_V7Time(t *testing.T) {
 		{name: "version 4 UUID", id: IDv4(), want: time.Time{}},
 		{name: "version 7 UUID fixed timestamp", id: IDv7WithTime(timestamp), want: timestamp},
 		{name: "version 7 UUID current timestamp", id: IDv7WithTime(now), want: now},
+		{name: "version 7 UUID parsed", id: IDMust("019222e8-1ec3-7e4f-97e2-919670df6d6b"), want: time.UnixMilli(0x019222e81ec3)},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -850,6 +1062,40 @@ func TestID_V7Time(t *testing.T) {
 	}
 }
 
+func TestID_V7TimeLittleEndian(t *testing.T) {
+	// Little-endian timestamp 0x019222e81ec3 of earlier package versions
+	legacy := IDMust("c31ee822-9201-7e4f-97e2-919670df6d6b")
+	require.Equal(t, time.UnixMilli(0x019222e81ec3), legacy.V7TimeLittleEndian())
+	require.NotEqual(t, time.UnixMilli(0x019222e81ec3), legacy.V7Time())
+	require.Equal(t, time.Time{}, IDv4().V7TimeLittleEndian())
+}
+
+func TestID_Time(t *testing.T) {
+	// Test vectors from RFC 9562 appendix A.1, A.5, and A.6
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	for _, str := range []string{
+		"C232AB00-9414-11EC-B3C8-9F6BDECED846",
+		"1EC9414C-232A-6B00-B3C8-9F6BDECED846",
+		"017F22E2-79B0-7CC3-98C4-DC0C0C07398F",
+	} {
+		got, err := IDMust(str).Time()
+		require.NoError(t, err, str)
+		require.True(t, want.Equal(got), "%s: %s != %s", str, got, want)
+	}
+
+	before := time.Now().Add(-time.Millisecond)
+	for _, id := range []ID{IDv1(), IDv6(), IDv7()} {
+		got, err := id.Time()
+		require.NoError(t, err)
+		require.WithinDuration(t, before, got, time.Second, "version %d", id.Version())
+	}
+
+	_, err := IDv4().Time()
+	require.Equal(t, ErrInvalidVersion(4), err)
+	_, err = IDNil.Time()
+	require.Error(t, err)
+}
+
 func ExampleID_JSONSchema() {
 	reflector := jsonschema.Reflector{DoNotReference: true}
 	schema, _ := json.MarshalIndent(reflector.Reflect(ID{}), "", "  ")
@@ -864,3 +1110,50 @@ func ExampleID_JSONSchema() {
 	//   "title": "UUID"
 	// }
 }
+
+func BenchmarkIDSliceUnmarshalBinary(b *testing.B) {
+	s := make(IDSlice, 100_000)
+	for i := range s {
+		s[i] = IDv4()
+	}
+	data, _ := s.MarshalBinary()
+	b.ReportAllocs()
+	for b.Loop() {
+		var parsed IDSlice
+		_ = parsed.UnmarshalBinary(data)
+	}
+}
+
+func BenchmarkIDSliceUnmarshalText(b *testing.B) {
+	s := make(IDSlice, 100_000)
+	for i := range s {
+		s[i] = IDv4()
+	}
+	data, _ := s.MarshalText()
+	b.ReportAllocs()
+	for b.Loop() {
+		var parsed IDSlice
+		_ = parsed.UnmarshalText(data)
+	}
+}
+
+func TestID_HashShardIndex(t *testing.T) {
+	id := IDMustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	h := fnv.New64a()
+	h.Write(id[:])
+	require.Equal(t, h.Sum64(), id.Hash())
+	// Pinned value, the algorithm must never change
+	require.Equal(t, uint64(10447262647429769244), id.Hash())
+
+	require.Equal(t, 0, id.ShardIndex(1))
+	require.Equal(t, int(id.Hash()%7), id.ShardIndex(7))
+	require.Panics(t, func() { id.ShardIndex(0) })
+
+	counts := make([]int, 4)
+	for range 4000 {
+		counts[IDv7().ShardIndex(len(counts))]++
+	}
+	for i, n := range counts {
+		require.Greater(t, n, 800, "shard %d", i)
+	}
+}
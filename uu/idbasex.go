@@ -0,0 +1,110 @@
+package uu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// baseXLength is the number of base 58 or base 62
+	// digits needed to encode 128 bits
+	baseXLength = 22
+)
+
+// Base58 returns the UUID encoded with the Bitcoin base58 alphabet
+// that omits the easily confused characters 0, O, I, and l.
+// The returned string is always 22 characters long,
+// padded with the zero digit '1' at the start,
+// so that the strings sort in the same order as the UUID bytes.
+//
+// See [IDFromBase58] for the reverse operation.
+func (id ID) Base58() string {
+	return id.encodeBaseX(base58Alphabet)
+}
+
+// Base62 returns the UUID encoded with the alphanumeric
+// characters 0-9, A-Z, and a-z.
+// The returned string is always 22 characters long,
+// padded with the zero digit '0' at the start,
+// so that the strings sort in the same order as the UUID bytes.
+//
+// See [IDFromBase62] for the reverse operation.
+func (id ID) Base62() string {
+	return id.encodeBaseX(base62Alphabet)
+}
+
+// IDFromBase58 parses a string returned by ID.Base58.
+// Shorter strings without zero digit padding are also accepted.
+//
+// Strings longer than 22 characters are parsed with IDFromString,
+// so that short and canonical UUID strings can be accepted
+// by the same function. Note that 22 character strings
+// are interpreted as base64 by IDFromString,
+// so base58 strings can't be parsed with it.
+func IDFromBase58(s string) (ID, error) {
+	return idFromBaseX(s, base58Alphabet, "base58")
+}
+
+// IDFromBase62 parses a string returned by ID.Base62.
+// Shorter strings without zero digit padding are also accepted.
+//
+// Strings longer than 22 characters are parsed with IDFromString,
+// so that short and canonical UUID strings can be accepted
+// by the same function. Note that 22 character strings
+// are interpreted as base64 by IDFromString,
+// so base62 strings can't be parsed with it.
+func IDFromBase62(s string) (ID, error) {
+	return idFromBaseX(s, base62Alphabet, "base62")
+}
+
+func (id ID) encodeBaseX(alphabet string) string {
+	var (
+		base = uint64(len(alphabet))
+		hi   = binary.BigEndian.Uint64(id[:8])
+		lo   = binary.BigEndian.Uint64(id[8:])
+		b    [baseXLength]byte
+		rem  uint64
+	)
+	for i := baseXLength - 1; i >= 0; i-- {
+		hi, rem = bits.Div64(0, hi, base)
+		lo, rem = bits.Div64(rem, lo, base)
+		b[i] = alphabet[rem]
+	}
+	return string(b[:])
+}
+
+func idFromBaseX(s, alphabet, name string) (ID, error) {
+	text := string(trimEnclosing(trimEnclosing([]byte(s), '"', '"'), '{', '}'))
+	if len(text) > baseXLength {
+		return IDFromString(s)
+	}
+	if text == "" {
+		return IDNil, fmt.Errorf("empty uu.ID %s string", name)
+	}
+	base := uint64(len(alphabet))
+	var hi, lo uint64
+	for i := 0; i < len(text); i++ {
+		digit := strings.IndexByte(alphabet, text[i])
+		if digit < 0 {
+			return IDNil, fmt.Errorf("invalid character %q in uu.ID %s string %q", text[i], name, s)
+		}
+		// (hi, lo) = (hi, lo) * base + digit
+		overflow, hiMul := bits.Mul64(hi, base)
+		carry, loMul := bits.Mul64(lo, base)
+		var c uint64
+		lo, c = bits.Add64(loMul, uint64(digit), 0) //#nosec G115 -- integer conversion OK
+		hi, c = bits.Add64(hiMul, carry, c)
+		if overflow != 0 || c != 0 {
+			return IDNil, fmt.Errorf("uu.ID %s string %q overflows 128 bits", name, s)
+		}
+	}
+	var id ID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
@@ -0,0 +1,57 @@
+package uu
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestID_Base58(t *testing.T) {
+	maxID := ID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	require.Equal(t, "1111111111111111111111", IDNil.Base58())
+	require.Equal(t, "0000000000000000000000", IDNil.Base62())
+	require.Equal(t, "YcVfxkQb6JRzqk5kF2tNLv", maxID.Base58())
+	require.Equal(t, "7n42DGM5Tflk9n8mt7Fhc7", maxID.Base62())
+
+	for _, id := range []ID{IDNil, maxID, IDv4(), IDv7(), IDv4Sequential(1)} {
+		b58 := id.Base58()
+		require.Len(t, b58, 22)
+		parsed, err := IDFromBase58(b58)
+		require.NoError(t, err)
+		require.Equal(t, id, parsed, b58)
+
+		b62 := id.Base62()
+		require.Len(t, b62, 22)
+		parsed, err = IDFromBase62(b62)
+		require.NoError(t, err)
+		require.Equal(t, id, parsed, b62)
+
+		parsed, err = IDFromBase62(`"` + b62 + `"`)
+		require.NoError(t, err)
+		require.Equal(t, id, parsed, "enclosing quotes")
+	}
+
+	// Unpadded and canonical strings
+	parsed, err := IDFromBase58(strings.TrimLeft(IDv4Sequential(1).Base58(), "1"))
+	require.NoError(t, err)
+	require.Equal(t, IDv4Sequential(1), parsed)
+	id := IDv4()
+	parsed, err = IDFromBase62(id.String())
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	_, err = IDFromBase58("0OIl")
+	require.Error(t, err, "invalid base58 characters")
+	_, err = IDFromBase62("7n42DGM5Tflk9n8mt7Fhc8")
+	require.Error(t, err, "overflow")
+	_, err = IDFromBase62("")
+	require.Error(t, err)
+
+	// Encoded strings sort like the IDs
+	ids := IDSlice{IDv7(), IDv7(), IDv7()}
+	strs := []string{ids[2].Base62(), ids[0].Base62(), ids[1].Base62()}
+	slices.Sort(strs)
+	require.Equal(t, []string{ids[0].Base62(), ids[1].Base62(), ids[2].Base62()}, strs)
+}
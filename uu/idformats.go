@@ -0,0 +1,59 @@
+package uu
+
+import (
+	"fmt"
+	"strings"
+)
+
+const urnPrefix = "urn:uuid:"
+
+// URN returns the UUID as URN according to RFC 9562:
+//
+//	urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+//
+// See [IDFromURN] for the reverse operation.
+func (id ID) URN() string {
+	var b [len(urnPrefix) + 36]byte
+	copy(b[:], urnPrefix)
+	id.encodeString((*[36]byte)(b[len(urnPrefix):]), hexDigitsLower)
+	return string(b[:])
+}
+
+// BracedUpper returns the upper case canonical string format
+// enclosed in curly braces as used for GUIDs by Microsoft systems
+// like Active Directory and SharePoint:
+//
+//	{XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}
+//
+// See [IDFromBraced] for the reverse operation.
+func (id ID) BracedUpper() string {
+	var b [38]byte
+	b[0] = '{'
+	id.encodeString((*[36]byte)(b[1:37]), hexDigitsUpper)
+	b[37] = '}'
+	return string(b[:])
+}
+
+// IDFromURN parses a UUID URN like returned by ID.URN.
+// The "urn:uuid:" prefix is required but matched case-insensitive,
+// the hex digits can be upper or lower case,
+// and surrounding whitespace is ignored.
+func IDFromURN(s string) (ID, error) {
+	text := strings.TrimSpace(s)
+	if len(text) < len(urnPrefix) || !strings.EqualFold(text[:len(urnPrefix)], urnPrefix) {
+		return IDNil, fmt.Errorf("uu.ID URN must start with %q: %q", urnPrefix, s)
+	}
+	return parseDashedFormat([]byte(text[len(urnPrefix):]), []byte(s))
+}
+
+// IDFromBraced parses a UUID enclosed in curly braces
+// like returned by ID.BracedUpper.
+// The braces are required, the hex digits can be
+// upper or lower case, and surrounding whitespace is ignored.
+func IDFromBraced(s string) (ID, error) {
+	text := strings.TrimSpace(s)
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return IDNil, fmt.Errorf("uu.ID must be enclosed in curly braces: %q", s)
+	}
+	return parseDashedFormat([]byte(text[1:len(text)-1]), []byte(s))
+}
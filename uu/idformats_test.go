@@ -0,0 +1,63 @@
+package uu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestID_URN(t *testing.T) {
+	id := IDMustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.Equal(t, "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", id.URN())
+
+	for _, s := range []string{
+		id.URN(),
+		"URN:UUID:6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		" urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8\n",
+	} {
+		parsed, err := IDFromURN(s)
+		require.NoError(t, err, s)
+		require.Equal(t, id, parsed, s)
+	}
+	for _, s := range []string{
+		"",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"urn:uuid:",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"urn:uuid:6ba7b8109dad11d180b400c04fd430c8",
+		"urn:uuid:{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+	} {
+		_, err := IDFromURN(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestID_BracedUpper(t *testing.T) {
+	id := IDMustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.Equal(t, "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}", id.BracedUpper())
+
+	for _, s := range []string{
+		id.BracedUpper(),
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"\t{6BA7B810-9dad-11D1-80b4-00C04FD430C8} ",
+	} {
+		parsed, err := IDFromBraced(s)
+		require.NoError(t, err, s)
+		require.Equal(t, id, parsed, s)
+	}
+	for _, s := range []string{
+		"",
+		"{}",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"{6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"{6BA7B8109DAD11D180B400C04FD430C8}",
+		"{XBA7B810-9DAD-11D1-80B4-00C04FD430C8}",
+	} {
+		_, err := IDFromBraced(s)
+		require.Error(t, err, s)
+	}
+
+	// The general parser accepts both forms too
+	require.Equal(t, id, IDMustFromString(id.BracedUpper()))
+	require.Equal(t, id, IDMustFromString(id.URN()))
+}
@@ -0,0 +1,122 @@
+package uu
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// IDMap is a map with uu.ID keys.
+// It is a map[ID]V underneath.
+// Implements the database/sql.Scanner and database/sql/driver.Valuer interfaces
+// using JSON for columns of type jsonb with the nil map value used as SQL NULL.
+type IDMap[V any] map[ID]V
+
+// Has returns if the map contains the key id.
+func (m IDMap[V]) Has(id ID) bool {
+	_, ok := m[id]
+	return ok
+}
+
+// GetOrDefault returns the value for id
+// or defaultVal if the map does not contain id.
+func (m IDMap[V]) GetOrDefault(id ID, defaultVal V) V {
+	if v, ok := m[id]; ok {
+		return v
+	}
+	return defaultVal
+}
+
+// Keys returns the keys of the map as IDSet.
+func (m IDMap[V]) Keys() IDSet {
+	set := make(IDSet, len(m))
+	for id := range m {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// SortedKeys returns the keys of the map as IDSlice
+// sorted by ID.Less like IDSlice.Sort.
+func (m IDMap[V]) SortedKeys() IDSlice {
+	return m.Keys().AsSortedSlice()
+}
+
+// Clone returns a shallow copy of the map
+// or nil if the map is nil.
+func (m IDMap[V]) Clone() IDMap[V] {
+	return maps.Clone(m)
+}
+
+// Len returns the number of map entries.
+func (m IDMap[V]) Len() int {
+	return len(m)
+}
+
+// IsNull returns true if the map is nil.
+func (m IDMap[V]) IsNull() bool {
+	return m == nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// by marshalling the map as JSON object with the IDs
+// as keys sorted in hex string order or as null for a nil map.
+//
+// Note that the hex string order is not the order of ID.Less
+// used by SortedKeys.
+func (m IDMap[V]) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	// encoding/json sorts the keys by their MarshalText strings
+	return json.Marshal(map[ID]V(m))
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// It does assign a new IDMap to *m instead of modifying the existing map,
+// so it can be used with uninitialized IDMap variable.
+func (m *IDMap[V]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = nil
+		return nil
+	}
+	var parsed map[ID]V
+	err := json.Unmarshal(data, &parsed)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan implements the database/sql.Scanner interface
+// by unmarshalling a JSON object with the nil map value used as SQL NULL.
+// It does assign a new IDMap to *m instead of modifying the existing map,
+// so it can be used with uninitialized IDMap variable.
+func (m *IDMap[V]) Scan(value any) error {
+	switch x := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case string:
+		return m.UnmarshalJSON([]byte(x))
+	case []byte:
+		return m.UnmarshalJSON(x)
+	}
+	return fmt.Errorf("can't scan value '%#v' of type %T as uu.IDMap", value, value)
+}
+
+// Value implements the driver database/sql/driver.Valuer interface
+// by returning the map as JSON string with the nil map value used as SQL NULL.
+func (m IDMap[V]) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	j, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
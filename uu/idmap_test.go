@@ -0,0 +1,58 @@
+package uu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDMap(t *testing.T) {
+	id1 := IDMustFromString("0d1a5e9c-4b2f-4a3e-8c7d-1e2f3a4b5c6d")
+	id2 := IDMustFromString("7f3e2d1c-0b9a-4876-9543-210fedcba987")
+	id3 := IDMustFromString("c0ffee00-1234-4567-89ab-cdef01234567")
+
+	m := IDMap[int]{id3: 3, id1: 1, id2: 2}
+	require.True(t, m.Has(id1))
+	require.False(t, m.Has(IDNil))
+	require.Equal(t, 2, m.GetOrDefault(id2, -1))
+	require.Equal(t, -1, m.GetOrDefault(IDNil, -1))
+	require.Equal(t, MakeIDSet(id1, id2, id3), m.Keys())
+	require.Equal(t, IDSlice{id1, id2, id3}.SortedClone(), m.SortedKeys())
+	require.Equal(t, m, m.Clone())
+
+	j, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `{"0d1a5e9c-4b2f-4a3e-8c7d-1e2f3a4b5c6d":1,"7f3e2d1c-0b9a-4876-9543-210fedcba987":2,"c0ffee00-1234-4567-89ab-cdef01234567":3}`, string(j))
+
+	var parsed IDMap[int]
+	require.NoError(t, json.Unmarshal(j, &parsed))
+	require.Equal(t, m, parsed)
+
+	value, err := m.Value()
+	require.NoError(t, err)
+	require.Equal(t, string(j), value)
+
+	var scanned IDMap[int]
+	require.NoError(t, scanned.Scan([]byte(`{"7F3E2D1C-0B9A-4876-9543-210FEDCBA987": 2}`)))
+	require.Equal(t, IDMap[int]{id2: 2}, scanned)
+	require.NoError(t, scanned.Scan(nil))
+	require.Nil(t, scanned)
+	require.Error(t, scanned.Scan(`{"invalid": 1}`))
+	require.Error(t, scanned.Scan(1))
+
+	var nilMap IDMap[string]
+	j, err = json.Marshal(nilMap)
+	require.NoError(t, err)
+	require.Equal(t, "null", string(j))
+	value, err = nilMap.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	type wrapper struct {
+		Names IDMap[string] `json:"names"`
+	}
+	var w wrapper
+	require.NoError(t, json.Unmarshal([]byte(`{"names":{"0d1a5e9c-4b2f-4a3e-8c7d-1e2f3a4b5c6d":"one"}}`), &w))
+	require.Equal(t, "one", w.Names[id1])
+}
@@ -0,0 +1,84 @@
+package uu
+
+import (
+	"fmt"
+	"slices"
+)
+
+// IDParseConfig configures constraints
+// for parsing IDs from external sources.
+//
+// The zero value rejects the Nil UUID and IDs
+// that are not valid according to ID.Validate.
+type IDParseConfig struct {
+	// AllowNil accepts the Nil UUID
+	// which is not checked for version and variant.
+	AllowNil bool
+	// Variants are the accepted variants like IDVariantRFC4122.
+	// If empty, then all variants except IDVariantInvalid are accepted.
+	Variants []int
+	// Versions are the accepted versions.
+	// If empty, then the versions 1 to 8 are accepted.
+	Versions []int
+}
+
+// Parse parses a string as ID like IDFromString
+// and returns an error if the ID does not satisfy the constraints
+// of the config.
+// The error wraps ErrNilID, ErrInvalidVariant, or ErrInvalidVersion
+// for a parsed ID not satisfying the constraints.
+func (c *IDParseConfig) Parse(s string) (ID, error) {
+	id, err := IDFromString(s)
+	if err != nil {
+		return IDNil, err
+	}
+	if err = c.Check(id); err != nil {
+		return IDNil, fmt.Errorf("uu.ID %q: %w", s, err)
+	}
+	return id, nil
+}
+
+// ParseBytes parses a byte slice as ID like IDFromBytes
+// and returns an error if the ID does not satisfy the constraints
+// of the config.
+func (c *IDParseConfig) ParseBytes(b []byte) (ID, error) {
+	id, err := IDFromBytes(b)
+	if err != nil {
+		return IDNil, err
+	}
+	if err = c.Check(id); err != nil {
+		return IDNil, fmt.Errorf("uu.ID %q: %w", b, err)
+	}
+	return id, nil
+}
+
+// Check returns ErrNilID, ErrInvalidVariant, or an ErrInvalidVersion
+// if the id does not satisfy the constraints of the config.
+func (c *IDParseConfig) Check(id ID) error {
+	if id.IsNil() {
+		if c.AllowNil {
+			return nil
+		}
+		return ErrNilID
+	}
+	if variant := id.Variant(); variant == IDVariantInvalid ||
+		len(c.Variants) > 0 && !slices.Contains(c.Variants, variant) {
+		return ErrInvalidVariant
+	}
+	if version := id.Version(); version < 1 || version > 8 ||
+		len(c.Versions) > 0 && !slices.Contains(c.Versions, version) {
+		return ErrInvalidVersion(version)
+	}
+	return nil
+}
+
+// IDFromStringVersions parses a string as ID like IDFromString
+// and returns an error if the ID is the Nil UUID,
+// not of the RFC 4122 variant, or not of one of the passed versions.
+// If no versions are passed, then the versions 1 to 8 are accepted.
+//
+// Use IDParseConfig for other constraints.
+func IDFromStringVersions(s string, versions ...int) (ID, error) {
+	config := IDParseConfig{Variants: []int{IDVariantRFC4122}, Versions: versions}
+	return config.Parse(s)
+}
@@ -0,0 +1,57 @@
+package uu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDFromStringVersions(t *testing.T) {
+	v4 := IDv4()
+	v7 := IDv7()
+
+	id, err := IDFromStringVersions(v4.String(), 4, 7)
+	require.NoError(t, err)
+	require.Equal(t, v4, id)
+	id, err = IDFromStringVersions(v7.String())
+	require.NoError(t, err)
+	require.Equal(t, v7, id)
+
+	_, err = IDFromStringVersions(v7.String(), 4)
+	require.ErrorIs(t, err, ErrInvalidVersion(7))
+	_, err = IDFromStringVersions(IDNil.String(), 4)
+	require.ErrorIs(t, err, ErrNilID)
+	ncs := v4
+	ncs[8] &= 0x7f
+	_, err = IDFromStringVersions(ncs.String(), 4)
+	require.ErrorIs(t, err, ErrInvalidVariant)
+	_, err = IDFromStringVersions("not-a-uuid", 4)
+	require.Error(t, err)
+}
+
+func TestIDParseConfig(t *testing.T) {
+	var strict IDParseConfig
+	_, err := strict.Parse(IDNil.String())
+	require.ErrorIs(t, err, ErrNilID)
+	_, err = strict.Parse("00000000-0000-0000-8000-000000000001")
+	require.ErrorIs(t, err, ErrInvalidVersion(0))
+
+	ncs := IDv4()
+	ncs[8] &= 0x7f
+	id, err := strict.Parse(ncs.String())
+	require.NoError(t, err, "all variants accepted by zero config")
+	require.Equal(t, ncs, id)
+
+	allowNil := IDParseConfig{AllowNil: true, Versions: []int{7}}
+	id, err = allowNil.Parse(IDNil.String())
+	require.NoError(t, err)
+	require.Equal(t, IDNil, id)
+	_, err = allowNil.ParseBytes([]byte(IDv4().String()))
+	require.ErrorIs(t, err, ErrInvalidVersion(4))
+	v7 := IDv7()
+	id, err = allowNil.ParseBytes(v7[:])
+	require.NoError(t, err)
+	require.Equal(t, v7, id)
+
+	require.NoError(t, strict.Check(v7))
+}
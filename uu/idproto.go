@@ -0,0 +1,119 @@
+package uu
+
+import "errors"
+
+// IDSliceFromProtoStrings parses and validates the IDs
+// of a repeated string protobuf field in one pass.
+// Use IDSlice.Strings for the reverse conversion.
+// All invalid IDs are returned as ErrInvalidIDAtIndex
+// errors joined with errors.Join so that every
+// field violation of a request can be reported.
+// Returns nil if strs is empty.
+func IDSliceFromProtoStrings(strs []string) (IDSlice, error) {
+	if len(strs) == 0 {
+		return nil, nil
+	}
+	s := make(IDSlice, len(strs))
+	var errs []error
+	for i, str := range strs {
+		id, err := IDFromString(str)
+		if err == nil {
+			err = id.Validate()
+		}
+		if err != nil {
+			errs = append(errs, ErrInvalidIDAtIndex{Index: i, Err: err})
+			continue
+		}
+		s[i] = id
+	}
+	if errs != nil {
+		return nil, errors.Join(errs...)
+	}
+	return s, nil
+}
+
+// IDSliceFromProtoBytes parses and validates the IDs
+// of a repeated bytes protobuf field in one pass.
+// The bytes are expected as 16 byte binary UUIDs
+// or in a text form accepted by IDFromBytes.
+// All invalid IDs are returned as ErrInvalidIDAtIndex
+// errors joined with errors.Join so that every
+// field violation of a request can be reported.
+// Returns nil if bs is empty.
+func IDSliceFromProtoBytes(bs [][]byte) (IDSlice, error) {
+	if len(bs) == 0 {
+		return nil, nil
+	}
+	s := make(IDSlice, len(bs))
+	var errs []error
+	for i, b := range bs {
+		id, err := IDFromBytes(b)
+		if err == nil {
+			err = id.Validate()
+		}
+		if err != nil {
+			errs = append(errs, ErrInvalidIDAtIndex{Index: i, Err: err})
+			continue
+		}
+		s[i] = id
+	}
+	if errs != nil {
+		return nil, errors.Join(errs...)
+	}
+	return s, nil
+}
+
+// IDSetFromProtoStrings parses and validates the IDs of a repeated
+// string protobuf field like IDSliceFromProtoStrings
+// and returns them as IDSet.
+// Returns nil if the input is empty.
+func IDSetFromProtoStrings(strs []string) (IDSet, error) {
+	s, err := IDSliceFromProtoStrings(strs)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, nil
+	}
+	return s.AsSet(), nil
+}
+
+// IDSetFromProtoBytes parses and validates the IDs of a repeated
+// bytes protobuf field like IDSliceFromProtoBytes
+// and returns them as IDSet.
+// Returns nil if the input is empty.
+func IDSetFromProtoBytes(bs [][]byte) (IDSet, error) {
+	s, err := IDSliceFromProtoBytes(bs)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, nil
+	}
+	return s.AsSet(), nil
+}
+
+// ProtoBytes returns the IDs as 16 byte binary UUIDs
+// for a repeated bytes protobuf field.
+// All byte slices share one allocated backing array.
+// Returns nil if the slice is empty.
+func (s IDSlice) ProtoBytes() [][]byte {
+	if len(s) == 0 {
+		return nil
+	}
+	data := make([]byte, len(s)*16)
+	bs := make([][]byte, len(s))
+	for i, id := range s {
+		b := data[i*16 : (i+1)*16 : (i+1)*16]
+		copy(b, id[:])
+		bs[i] = b
+	}
+	return bs
+}
+
+// ProtoBytes returns the sorted IDs as 16 byte binary UUIDs
+// for a repeated bytes protobuf field.
+// Returns nil if the set is empty.
+func (s IDSet) ProtoBytes() [][]byte {
+	return s.AsSortedSlice().ProtoBytes()
+}
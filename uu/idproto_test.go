@@ -0,0 +1,66 @@
+package uu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDSliceFromProtoStrings(t *testing.T) {
+	ids := IDSlice{IDv4(), IDv7()}
+
+	parsed, err := IDSliceFromProtoStrings(ids.Strings())
+	require.NoError(t, err)
+	require.Equal(t, ids, parsed)
+
+	parsed, err = IDSliceFromProtoStrings(nil)
+	require.NoError(t, err)
+	require.Nil(t, parsed)
+
+	_, err = IDSliceFromProtoStrings([]string{ids[0].String(), "invalid", IDNil.String()})
+	require.Error(t, err)
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, errs, 2)
+	require.Equal(t, 1, errs[0].(ErrInvalidIDAtIndex).Index)
+	require.Equal(t, 2, errs[1].(ErrInvalidIDAtIndex).Index)
+	require.ErrorIs(t, err, ErrNilID)
+
+	set, err := IDSetFromProtoStrings(append(ids.Strings(), ids[0].String()))
+	require.NoError(t, err)
+	require.Equal(t, ids.AsSet(), set)
+
+	set, err = IDSetFromProtoStrings(nil)
+	require.NoError(t, err)
+	require.Nil(t, set)
+	set, err = IDSetFromProtoBytes([][]byte{})
+	require.NoError(t, err)
+	require.Nil(t, set)
+}
+
+func TestIDSliceFromProtoBytes(t *testing.T) {
+	ids := IDSlice{IDv4(), IDv7()}
+
+	bs := ids.ProtoBytes()
+	require.Len(t, bs, 2)
+	require.Equal(t, ids[0][:], bs[0])
+	require.Equal(t, ids[1][:], bs[1])
+	require.Len(t, append(bs[0], 0), 17, "append must not overwrite the next ID")
+	require.Equal(t, ids[1][:], bs[1])
+
+	parsed, err := IDSliceFromProtoBytes(bs)
+	require.NoError(t, err)
+	require.Equal(t, ids, parsed)
+
+	_, err = IDSliceFromProtoBytes([][]byte{ids[0][:], {1, 2, 3}})
+	var invalid ErrInvalidIDAtIndex
+	require.True(t, errors.As(err, &invalid))
+	require.Equal(t, 1, invalid.Index)
+
+	set, err := IDSetFromProtoBytes(ids.AsSet().ProtoBytes())
+	require.NoError(t, err)
+	require.Equal(t, ids.AsSet(), set)
+
+	require.Nil(t, IDSlice(nil).ProtoBytes())
+	require.Nil(t, IDSet(nil).ProtoBytes())
+}
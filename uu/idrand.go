@@ -0,0 +1,103 @@
+package uu
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// IDv4FromReader returns a version 4 UUID
+// with the random bits read from r.
+//
+// Pass a reader with deterministic output
+// to generate reproducible IDs in tests.
+func IDv4FromReader(r io.Reader) (id ID, err error) {
+	if _, err = io.ReadFull(r, id[:]); err != nil {
+		return IDNil, err
+	}
+	id.SetVersion(4)
+	id.SetVariant()
+	return id, nil
+}
+
+// IDv7FromReader returns a version 7 UUID with the first 48 bits
+// containing a sortable timestamp created from the passed time
+// like IDv7WithTime and the random bits read from r.
+//
+// Pass a fixed time and a reader with deterministic output
+// to generate reproducible IDs in tests.
+func IDv7FromReader(r io.Reader, t time.Time) (id ID, err error) {
+	putV7Milli(&id, t.UnixMilli())
+	if _, err = io.ReadFull(r, id[6:]); err != nil {
+		return IDNil, err
+	}
+	id.SetVersion(7)
+	id.SetVariant()
+	return id, nil
+}
+
+// NewSeededIDs returns a function that generates
+// random looking but reproducible version 4 UUIDs
+// from a pseudo random generator initialized with the passed seed.
+// The same seed always results in the same series of IDs.
+//
+// Intended for test fixtures that should look like
+// real random IDs, the returned function can be used
+// with [ContextWithIDFunc] or as package default with [ReplaceIDvDefault].
+// See [NewSequentialIDs] for IDs that are easier to read.
+//
+// The returned function is safe for concurrent use.
+func NewSeededIDs(seed uint64) func() ID {
+	var chachaSeed [32]byte
+	binary.LittleEndian.PutUint64(chachaSeed[:], seed)
+	r := &lockedReader{r: rand.NewChaCha8(chachaSeed)}
+	return func() ID {
+		id, err := IDv4FromReader(r)
+		if err != nil {
+			panic(err) // ChaCha8.Read never returns an error
+		}
+		return id
+	}
+}
+
+// ReplaceRandReader sets r as entropy source for the random bits
+// of all generated IDs instead of crypto/rand and returns
+// a function that restores the previous source.
+// Passing nil restores crypto/rand.
+// Reads from r are serialized, so r does not have to be
+// safe for concurrent use. A read error causes a panic
+// of the ID generating function.
+//
+// Intended for tests that need reproducible IDs
+// from code that calls IDv4 directly:
+//
+//	t.Cleanup(uu.ReplaceRandReader(rand.NewChaCha8(seed)))
+//
+// Note that IDs of other versions like IDv7 still contain
+// the current time and are not fully reproducible.
+// Prefer [ContextWithIDFunc] or [ReplaceIDvDefault] with [NewSeededIDs]
+// which don't affect IDs generated by parallel tests.
+func ReplaceRandReader(r io.Reader) (restore func()) {
+	var next *lockedReader
+	if r != nil {
+		next = &lockedReader{r: r}
+	}
+	prev := randReader.Swap(next)
+	return func() { randReader.Store(prev) }
+}
+
+// lockedReader serializes reads of an io.Reader
+// and reads the full length of the passed buffer.
+type lockedReader struct {
+	r   io.Reader
+	mtx sync.Mutex
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return io.ReadFull(l.r, p)
+}
@@ -0,0 +1,53 @@
+package uu
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDv4FromReader(t *testing.T) {
+	id, err := IDv4FromReader(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)))
+	require.NoError(t, err)
+	assert.Equal(t, IDMustFromString("ffffffff-ffff-4fff-bfff-ffffffffffff"), id)
+
+	_, err = IDv4FromReader(bytes.NewReader(make([]byte, 15)))
+	assert.Error(t, err, "short read")
+
+	tm := time.UnixMilli(1700000000000)
+	id, err = IDv7FromReader(bytes.NewReader(make([]byte, 10)), tm)
+	require.NoError(t, err)
+	assert.Equal(t, IDv7Deterministic(tm.UnixMilli()), id)
+	assert.True(t, id.V7Time().Equal(tm))
+	_, err = IDv7FromReader(bytes.NewReader(nil), tm)
+	assert.Error(t, err)
+}
+
+func TestNewSeededIDs(t *testing.T) {
+	a, b, c := NewSeededIDs(1), NewSeededIDs(1), NewSeededIDs(2)
+	for range 10 {
+		id := a()
+		assert.Equal(t, 4, id.Version())
+		assert.NoError(t, id.Validate())
+		assert.Equal(t, id, b(), "same seed")
+		assert.NotEqual(t, id, c(), "different seed")
+	}
+}
+
+func TestReplaceRandReader(t *testing.T) {
+	restore := ReplaceRandReader(rand.NewChaCha8([32]byte{1}))
+	first := IDSlice{IDv4(), IDv4()}
+	restore()
+
+	restore = ReplaceRandReader(rand.NewChaCha8([32]byte{1}))
+	second := IDSlice{IDv4(), IDv4()}
+	restore()
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first[0], first[1])
+
+	assert.NotEqual(t, first[0], IDv4(), "crypto/rand restored")
+}
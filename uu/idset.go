@@ -3,8 +3,11 @@ package uu
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding/binary"
 	"io"
+	"iter"
 	"maps"
+	"math/rand/v2"
 	"sort"
 	"strings"
 
@@ -114,6 +117,34 @@ func (s IDSet) GetOne() ID {
 	return IDNil
 }
 
+// Pop removes and returns one ID in undefined order from the set.
+// Returns IDNil and false if the set is empty.
+func (s IDSet) Pop() (ID, bool) {
+	for id := range s {
+		delete(s, id)
+		return id, true
+	}
+	return IDNil, false
+}
+
+// TakeN removes and returns up to n IDs in undefined order from the set.
+// Returns nil if the set is empty or n is not greater zero.
+func (s IDSet) TakeN(n int) IDSlice {
+	n = min(n, len(s))
+	if n <= 0 {
+		return nil
+	}
+	taken := make(IDSlice, 0, n)
+	for id := range s {
+		delete(s, id)
+		taken = append(taken, id)
+		if len(taken) == n {
+			break
+		}
+	}
+	return taken
+}
+
 // AsSet returns s unchanged to implement the IDs interface.
 func (s IDSet) AsSet() IDSet {
 	return s
@@ -148,6 +179,49 @@ func (s IDSet) ForEach(callback func(ID) error) error {
 	return nil
 }
 
+// All returns an iterator over the IDs of the set
+// in undefined order without allocating a copy.
+func (s IDSet) All() iter.Seq[ID] {
+	return maps.Keys(s)
+}
+
+// Sorted returns an iterator over the IDs of the set
+// sorted like IDSlice.Sort.
+// The IDs are copied and sorted once
+// when the iteration starts.
+func (s IDSet) Sorted() iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for _, id := range s.AsSortedSlice() {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a new set with the IDs
+// for which keep returns true.
+// Returns nil if the set is nil.
+func (s IDSet) Filter(keep func(ID) bool) IDSet {
+	if s == nil {
+		return nil
+	}
+	filtered := make(IDSet)
+	for id := range s {
+		if keep(id) {
+			filtered[id] = struct{}{}
+		}
+	}
+	return filtered
+}
+
+// MapIDSet returns a slice with the results of calling f
+// for every ID of s sorted like AsSortedSlice.
+// Returns nil for an empty set.
+func MapIDSet[T any](s IDSet, f func(ID) T) []T {
+	return MapIDSlice(s.AsSortedSlice(), f)
+}
+
 // AsSortedSlice returns the IDs of the set as sorted IDSlice.
 func (s IDSet) AsSortedSlice() IDSlice {
 	sl := s.AsSlice()
@@ -155,6 +229,47 @@ func (s IDSet) AsSortedSlice() IDSlice {
 	return sl
 }
 
+// Chunks returns the IDs of the set sorted like AsSortedSlice
+// split into chunks of n IDs where the last chunk may be shorter.
+// The chunks share the memory of one sorted slice.
+// Returns nil for an empty set.
+// Panics if n is less than 1.
+// See IDSlice.Chunk.
+func (s IDSet) Chunks(n int) []IDSlice {
+	if n < 1 {
+		panic("uu.IDSet.Chunks: n must be at least 1")
+	}
+	return s.AsSortedSlice().Chunk(n)
+}
+
+// Sample returns n randomly chosen distinct IDs of the set
+// using rng as source of randomness,
+// or all IDs in random order if n is not smaller than the set length.
+// If rng is nil, then the top-level functions
+// of the math/rand/v2 package are used.
+//
+// The IDs are sorted before sampling so that a rand.Rand
+// with a fixed seed returns reproducible results
+// independent of the map iteration order.
+func (s IDSet) Sample(n int, rng *rand.Rand) IDSlice {
+	n = min(n, len(s))
+	if n <= 0 {
+		return nil
+	}
+	sl := s.AsSortedSlice()
+	// Partial Fisher-Yates shuffle of the first n elements
+	for i := range n {
+		var j int
+		if rng == nil {
+			j = i + rand.IntN(len(sl)-i)
+		} else {
+			j = i + rng.IntN(len(sl)-i)
+		}
+		sl[i], sl[j] = sl[j], sl[i]
+	}
+	return sl[:n:n]
+}
+
 func (set IDSet) AddSlice(s IDSlice) {
 	for _, id := range s {
 		set[id] = struct{}{}
@@ -215,6 +330,49 @@ func (s IDSet) Clone() IDSet {
 	return maps.Clone(s)
 }
 
+// Union returns a new set containing
+// all IDs from both this set and the other set.
+func (s IDSet) Union(other IDSet) IDSet {
+	union := make(IDSet, max(len(s), len(other)))
+	for id := range s {
+		union.Add(id)
+	}
+	for id := range other {
+		union.Add(id)
+	}
+	return union
+}
+
+// Intersection returns a new set containing
+// only the IDs that exist in both sets.
+func (s IDSet) Intersection(other IDSet) IDSet {
+	if len(other) < len(s) {
+		s, other = other, s
+	}
+	inter := make(IDSet, len(s))
+	for id := range s {
+		if other.Contains(id) {
+			inter.Add(id)
+		}
+	}
+	return inter
+}
+
+// Subtract returns a new set containing the IDs
+// of this set that don't exist in the other set.
+// See Diff for the symmetric difference.
+func (s IDSet) Subtract(other IDSet) IDSet {
+	result := make(IDSet, len(s))
+	for id := range s {
+		if !other.Contains(id) {
+			result.Add(id)
+		}
+	}
+	return result
+}
+
+// Diff returns a new set containing the IDs
+// that exist in either set but not in both.
 func (s IDSet) Diff(other IDSet) IDSet {
 	diff := make(IDSet)
 	for id := range s {
@@ -258,6 +416,13 @@ func (s IDSet) IsNull() bool {
 	return s == nil
 }
 
+// IsZero returns true if the set is nil or empty
+// so that encoding/json omits it with the omitzero tag option
+// like with omitempty.
+func (s IDSet) IsZero() bool {
+	return len(s) == 0
+}
+
 // MarshalText implements the encoding.TextMarshaler interface
 func (s IDSet) MarshalText() (text []byte, err error) {
 	return []byte(s.String()), nil
@@ -273,14 +438,72 @@ func (s *IDSet) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// AppendBinary implements the encoding.BinaryAppender interface
+// with the format of IDSlice.AppendBinary.
+// The IDs are appended in undefined order.
+func (s IDSet) AppendBinary(b []byte) ([]byte, error) {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	for id := range s {
+		b = append(b, id[:]...)
+	}
+	return b, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface
+// with the format of IDSlice.MarshalBinary.
+// The IDs are marshalled in undefined order.
+//
+// Like for IDSlice, GobEncode and GobDecode keep gob
+// on the text format used before MarshalBinary was added.
+func (s IDSet) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(make([]byte, 0, binary.MaxVarintLen64+len(s)*16))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+// for data created with MarshalBinary.
+// Zero IDs are unmarshalled as nil set.
+// The validity of the IDs is not checked.
+func (s *IDSet) UnmarshalBinary(data []byte) error {
+	parsed, err := idSliceFromBinary(data, "uu.IDSet")
+	if err != nil {
+		return err
+	}
+	if parsed == nil {
+		*s = nil
+		return nil
+	}
+	*s = parsed.AsSet()
+	return nil
+}
+
+// GobEncode implements the encoding/gob.GobEncoder interface
+// with the text format of MarshalText to keep
+// the gob wire format compatible with earlier versions.
+func (s IDSet) GobEncode() ([]byte, error) {
+	return s.MarshalText()
+}
+
+// GobDecode implements the encoding/gob.GobDecoder interface
+// for data created with GobEncode.
+func (s *IDSet) GobDecode(data []byte) error {
+	return s.UnmarshalText(data)
+}
+
 // Scan implements the database/sql.Scanner interface
 // with the nil map value used as SQL NULL.
 // Id does assign a new IDSet to *set instead of modifying the existing map,
 // so it can be used with uninitialized IDSet variable.
+// PostgreSQL array literals are parsed directly into the set
+// without an intermediate IDSlice.
 func (s *IDSet) Scan(value any) error {
-	if value == nil {
+	switch x := value.(type) {
+	case nil:
 		*s = nil
 		return nil
+	case string:
+		return s.scanBytes([]byte(x))
+	case []byte:
+		return s.scanBytes(x)
 	}
 	var idSlice IDSlice
 	err := idSlice.Scan(value)
@@ -291,6 +514,33 @@ func (s *IDSet) Scan(value any) error {
 	return nil
 }
 
+func (s *IDSet) scanBytes(src []byte) error {
+	if len(src) == 0 {
+		*s = make(IDSet)
+		return nil
+	}
+	elements, err := arrayElements(src, "uu.IDSet")
+	if err != nil {
+		return err
+	}
+	var set IDSet
+	if len(elements) > 0 {
+		set = make(IDSet, bytes.Count(elements, []byte{','})+1)
+	} else {
+		set = make(IDSet)
+	}
+	for len(elements) > 0 {
+		var id ID
+		id, elements, err = nextArrayElement(elements, src, "uu.IDSet")
+		if err != nil {
+			return err
+		}
+		set[id] = struct{}{}
+	}
+	*s = set
+	return nil
+}
+
 // Value implements the driver database/sql/driver.Valuer interface
 // with the nil map value used as SQL NULL
 func (s IDSet) Value() (driver.Value, error) {
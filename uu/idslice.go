@@ -3,10 +3,16 @@ package uu
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
+	"math/rand/v2"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/domonda/go-types/strutil"
@@ -133,6 +139,38 @@ func (s IDSlice) Strings() []string {
 	return ss
 }
 
+// Filter returns a new slice with the IDs
+// for which keep returns true in the same order.
+// Returns nil if no ID is kept.
+func (s IDSlice) Filter(keep func(ID) bool) IDSlice {
+	var filtered IDSlice
+	for _, id := range s {
+		if keep(id) {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// MapIDSlice returns a slice with the results
+// of calling f for every ID of s in order.
+// Returns nil for an empty slice.
+func MapIDSlice[T any](s IDSlice, f func(ID) T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	mapped := make([]T, len(s))
+	for i, id := range s {
+		mapped[i] = f(id)
+	}
+	return mapped
+}
+
+// All returns an iterator over the IDs of the slice in order.
+func (s IDSlice) All() iter.Seq[ID] {
+	return slices.Values(s)
+}
+
 // Sort the slice in place.
 func (s IDSlice) Sort() {
 	sort.Sort(s)
@@ -145,6 +183,75 @@ func (s IDSlice) SortedClone() IDSlice {
 	return c
 }
 
+// SortByTime sorts the slice in place chronologically
+// by the timestamps of time based IDs of version 1, 6, and 7
+// so that event IDs are ordered by their creation.
+// IDs with the same timestamp are ordered by their bytes.
+// IDs of other versions are sorted by their bytes
+// after the time based IDs.
+func (s IDSlice) SortByTime() {
+	s.sortByTime(func(keys []idTimeKey, cmp func(a, b idTimeKey) int) {
+		slices.SortFunc(keys, func(a, b idTimeKey) int {
+			if c := cmp(a, b); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.id[:], b.id[:])
+		})
+	})
+}
+
+// SortByTimeStable sorts the slice in place chronologically
+// by the timestamps of time based IDs of version 1, 6, and 7
+// while keeping the original order of IDs with the same timestamp.
+// IDs of other versions are moved after the time based IDs
+// in their original order.
+func (s IDSlice) SortByTimeStable() {
+	s.sortByTime(func(keys []idTimeKey, cmp func(a, b idTimeKey) int) {
+		slices.SortStableFunc(keys, cmp)
+	})
+}
+
+type idTimeKey struct {
+	id     ID
+	time   time.Time
+	noTime bool
+}
+
+func (s IDSlice) sortByTime(sortFunc func(keys []idTimeKey, cmp func(a, b idTimeKey) int)) {
+	// Extract the timestamps once instead of for every comparison
+	keys := make([]idTimeKey, len(s))
+	for i, id := range s {
+		t, err := id.Time()
+		keys[i] = idTimeKey{id: id, time: t, noTime: err != nil}
+	}
+	sortFunc(keys, func(a, b idTimeKey) int {
+		switch {
+		case a.noTime && b.noTime:
+			return 0
+		case a.noTime:
+			return 1
+		case b.noTime:
+			return -1
+		}
+		return a.time.Compare(b.time)
+	})
+	for i, key := range keys {
+		s[i] = key.id
+	}
+}
+
+// Shuffle the slice in place using rng as source of randomness.
+// If rng is nil, then the top-level functions
+// of the math/rand/v2 package are used.
+// Pass a rand.Rand with a fixed seed for reproducible results.
+func (s IDSlice) Shuffle(rng *rand.Rand) {
+	if rng == nil {
+		rand.Shuffle(len(s), s.Swap)
+		return
+	}
+	rng.Shuffle(len(s), s.Swap)
+}
+
 // Len is the number of elements in the collection.
 // One of the methods to implement sort.Interface.
 func (s IDSlice) Len() int { return len(s) }
@@ -201,6 +308,22 @@ func (s IDSlice) Equal(other IDSlice) bool {
 	return true
 }
 
+// CheckUnique returns an ErrDuplicateID error
+// for the first ID that occurs more than once in the slice.
+func (s IDSlice) CheckUnique() error {
+	if len(s) < 2 {
+		return nil
+	}
+	firstIndex := make(map[ID]int, len(s))
+	for i, id := range s {
+		if first, ok := firstIndex[id]; ok {
+			return ErrDuplicateID{ID: id, Index: i, FirstIndex: first}
+		}
+		firstIndex[id] = i
+	}
+	return nil
+}
+
 func (s IDSlice) ContainsAnyFromSet(set IDSet) bool {
 	for _, id := range s {
 		if set.Contains(id) {
@@ -249,6 +372,29 @@ func (s IDSlice) Clone() IDSlice {
 	return clone
 }
 
+// Chunk splits the slice into consecutive chunks
+// of n IDs for batched queries and API calls
+// where the last chunk may be shorter.
+// The chunks are sub-slices sharing the memory of s
+// with their capacity limited to their length,
+// so appending to a chunk does not modify the following one.
+// Returns nil for an empty slice.
+// Panics if n is less than 1.
+func (s IDSlice) Chunk(n int) []IDSlice {
+	if n < 1 {
+		panic("uu.IDSlice.Chunk: n must be at least 1")
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	chunks := make([]IDSlice, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
 // MarshalText implements the encoding.TextMarshaler interface
 func (s IDSlice) MarshalText() (text []byte, err error) {
 	return []byte(s.String()), nil
@@ -265,10 +411,79 @@ func (s *IDSlice) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// AppendBinary implements the encoding.BinaryAppender interface
+// by appending the number of IDs as unsigned varint
+// followed by the packed 16 byte values of the IDs.
+func (s IDSlice) AppendBinary(b []byte) ([]byte, error) {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	if len(s) == 0 {
+		return b, nil
+	}
+	return append(b, unsafe.Slice(&s[0][0], len(s)*16)...), nil //#nosec G103 -- unsafe OK
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface
+// with the format described at AppendBinary.
+// The binary format is about 2.3 times smaller than the text format
+// and much faster to unmarshal.
+//
+// Note that encoding/gob prefers encoding.BinaryMarshaler
+// over encoding.TextMarshaler, so GobEncode and GobDecode
+// keep gob on the text format used before MarshalBinary was added.
+func (s IDSlice) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(make([]byte, 0, binary.MaxVarintLen64+len(s)*16))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+// for data created with MarshalBinary.
+// Zero IDs are unmarshalled as nil slice.
+// The validity of the IDs is not checked.
+func (s *IDSlice) UnmarshalBinary(data []byte) error {
+	parsed, err := idSliceFromBinary(data, "uu.IDSlice")
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// GobEncode implements the encoding/gob.GobEncoder interface
+// with the text format of MarshalText to keep
+// the gob wire format compatible with earlier versions.
+func (s IDSlice) GobEncode() ([]byte, error) {
+	return s.MarshalText()
+}
+
+// GobDecode implements the encoding/gob.GobDecoder interface
+// for data created with GobEncode.
+func (s *IDSlice) GobDecode(data []byte) error {
+	return s.UnmarshalText(data)
+}
+
+func idSliceFromBinary(data []byte, typeName string) (IDSlice, error) {
+	n, prefixLen := binary.Uvarint(data)
+	if prefixLen <= 0 {
+		return nil, fmt.Errorf("invalid %s binary length prefix", typeName)
+	}
+	data = data[prefixLen:]
+	if n != uint64(len(data)/16) || len(data)%16 != 0 {
+		return nil, fmt.Errorf("%s binary data of %d bytes does not match the length of %d IDs", typeName, len(data), n)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	s := make(IDSlice, n)
+	copy(unsafe.Slice(&s[0][0], len(data)), data) //#nosec G103 -- unsafe OK
+	return s, nil
+}
+
 // Scan implements the database/sql.Scanner interface
 // with the nil map value used as SQL NULL.
 // Does *s = make(Slice) if *s == nil
-// so it can be used with an not initialized Slice variable
+// so it can be used with an not initialized Slice variable.
+// Besides the PostgreSQL array text format, the [][16]byte values
+// and [][]byte values with binary or textual elements
+// returned by some drivers for uuid[] columns are accepted.
 func (s *IDSlice) Scan(value any) (err error) {
 	switch x := value.(type) {
 	case string:
@@ -277,6 +492,14 @@ func (s *IDSlice) Scan(value any) (err error) {
 	case []byte:
 		return s.scanBytes(x)
 
+	case [][16]byte, [][]byte:
+		ids, err := make(IDSlice, 0).AppendScan(x)
+		if err != nil {
+			return err
+		}
+		*s = ids
+		return nil
+
 	case nil:
 		*s = nil
 		return nil
@@ -290,26 +513,93 @@ func (s *IDSlice) scanBytes(src []byte) (err error) {
 		*s = nil
 		return nil
 	}
-
-	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
-		return fmt.Errorf("can't parse %q as uu.IDSlice", string(src))
+	ids, err := appendScanBytes(make(IDSlice, 0), src)
+	if err != nil {
+		return err
 	}
+	*s = ids
+	return nil
+}
 
-	ids := make(IDSlice, 0)
-
-	if len(src) > 2 {
-		elements := bytes.Split(src[1:len(src)-1], []byte{','})
-		for _, elem := range elements {
-			id, err := IDFromBytes(bytes.Trim(elem, `'"`))
-			if err != nil {
-				return err
+// AppendScan appends the IDs of a PostgreSQL array value
+// as accepted by Scan to the slice and returns the extended slice.
+// SQL NULL appends nothing and on error the slice
+// is returned unchanged.
+// Use it with a reused slice like s[:0] to avoid allocating
+// a new IDSlice for every scanned row.
+func (s IDSlice) AppendScan(value any) (IDSlice, error) {
+	switch x := value.(type) {
+	case string:
+		return appendScanBytes(s, []byte(x))
+	case []byte:
+		return appendScanBytes(s, x)
+	case [][16]byte:
+		for _, id := range x {
+			s = append(s, id)
+		}
+		return s, nil
+	case [][]byte:
+		n := len(s)
+		for _, b := range x {
+			var id ID
+			if err := id.Scan(b); err != nil {
+				return s[:n], err
 			}
-			ids = append(ids, id)
+			s = append(s, id)
 		}
+		return s, nil
+	case nil:
+		return s, nil
 	}
+	return s, fmt.Errorf("can't scan value '%#v' of type %T as uu.IDSlice", value, value)
+}
 
-	*s = ids
-	return nil
+// appendScanBytes appends the IDs of a PostgreSQL array
+// to dst without allocating for the array elements.
+// On error dst is returned with its original length.
+func appendScanBytes(dst IDSlice, src []byte) (IDSlice, error) {
+	if len(src) == 0 {
+		return dst, nil
+	}
+	n := len(dst)
+	elements, err := arrayElements(src, "uu.IDSlice")
+	if err != nil {
+		return dst, err
+	}
+	for len(elements) > 0 {
+		var id ID
+		id, elements, err = nextArrayElement(elements, src, "uu.IDSlice")
+		if err != nil {
+			return dst[:n], err
+		}
+		dst = append(dst, id)
+	}
+	return dst, nil
+}
+
+// arrayElements returns the comma separated elements
+// between the curly braces of a PostgreSQL array literal.
+func arrayElements(src []byte, typeName string) ([]byte, error) {
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, fmt.Errorf("can't parse %q as %s", string(src), typeName)
+	}
+	return src[1 : len(src)-1], nil
+}
+
+// nextArrayElement parses the first of the non empty
+// comma separated elements as ID and returns the remaining elements.
+// src is the complete array literal used for error messages.
+func nextArrayElement(elements, src []byte, typeName string) (id ID, rest []byte, err error) {
+	elem := elements
+	if i := bytes.IndexByte(elements, ','); i >= 0 {
+		elem, rest = elements[:i], elements[i+1:]
+		if len(rest) == 0 {
+			// Trailing comma
+			return IDNil, nil, fmt.Errorf("can't parse %q as %s", string(src), typeName)
+		}
+	}
+	id, err = IDFromBytes(bytes.Trim(elem, `'"`))
+	return id, rest, err
 }
 
 // Value implements the driver database/sql/driver.Valuer interface
@@ -326,11 +616,14 @@ func (s IDSlice) Value() (driver.Value, error) {
 	b := strings.Builder{}
 	b.Grow(2 + (36 + 2) + (l-1)*(1+36+2))
 
+	var id [36]byte
 	b.WriteString(`{"`)
-	b.Write(s[0].StringBytes())
+	s[0].encodeString(&id, hexDigitsLower)
+	b.Write(id[:])
 	for i := 1; i < l; i++ {
 		b.WriteString(`","`)
-		b.Write(s[i].StringBytes())
+		s[i].encodeString(&id, hexDigitsLower)
+		b.Write(id[:])
 	}
 	b.WriteString(`"}`)
 
@@ -350,10 +643,10 @@ func (s IDSlice) MarshalJSON() ([]byte, error) {
 	b := make([]byte, 0, 2+(36+2)+(l-1)*(1+36+2))
 
 	b = append(b, `["`...)
-	b = append(b, s[0].StringBytes()...)
+	b = s[0].AppendString(b)
 	for i := 1; i < l; i++ {
 		b = append(b, `","`...)
-		b = append(b, s[i].StringBytes()...)
+		b = s[i].AppendString(b)
 	}
 	b = append(b, `"]`...)
 
@@ -393,3 +686,61 @@ func (s *IDSlice) UnmarshalJSON(data []byte) error {
 	*s = ids
 	return nil
 }
+
+// DecodeJSONStream decodes the next JSON value of dec
+// which must be an array of UUID strings or null
+// element by element without reading the whole
+// JSON array into memory first like UnmarshalJSON.
+// An empty array results in a non nil empty slice
+// and null in a nil slice.
+// The error for an invalid element is an ErrInvalidIDAtIndex.
+func (s *IDSlice) DecodeJSONStream(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("error decoding uu.IDSlice from JSON stream: %w", err)
+	}
+	if tok == nil {
+		*s = nil
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("can't decode as uu.IDSlice because not a JSON array: %v", tok)
+	}
+
+	ids := make(IDSlice, 0)
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return fmt.Errorf("error decoding uu.IDSlice from JSON stream: %w", err)
+		}
+		str, ok := tok.(string)
+		if !ok {
+			return ErrInvalidIDAtIndex{Index: len(ids), Err: fmt.Errorf("not a JSON string: %v", tok)}
+		}
+		id, err := IDFromString(str)
+		if err != nil {
+			return ErrInvalidIDAtIndex{Index: len(ids), Err: err}
+		}
+		ids = append(ids, id)
+	}
+	// Consume closing bracket
+	if _, err = dec.Token(); err != nil {
+		return fmt.Errorf("error decoding uu.IDSlice from JSON stream: %w", err)
+	}
+
+	*s = ids
+	return nil
+}
+
+// IDSliceFromJSONReader decodes a JSON array of UUID strings or null
+// from r using IDSlice.DecodeJSONStream.
+// Use it for huge arrays that would need too much memory
+// when read completely for unmarshalling.
+func IDSliceFromJSONReader(r io.Reader) (IDSlice, error) {
+	var s IDSlice
+	err := s.DecodeJSONStream(json.NewDecoder(r))
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -1,12 +1,19 @@
 package uu
 
 import (
+	"bytes"
 	"database/sql/driver"
+	"encoding/gob"
 	"encoding/json"
+	"math/rand/v2"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIDSlice(t *testing.T) {
@@ -110,6 +117,21 @@ func TestIDSlice_Scan(t *testing.T) {
 			value: `{"21e70a0c-7f0e-44d8-8ae5-48992b89d0c5"}`,
 			want:  IDSlice{IDMust("21e70a0c-7f0e-44d8-8ae5-48992b89d0c5")},
 		},
+		{
+			name:  "[][16]byte",
+			value: [][16]byte{IDMust("21e70a0c-7f0e-44d8-8ae5-48992b89d0c5")},
+			want:  IDSlice{IDMust("21e70a0c-7f0e-44d8-8ae5-48992b89d0c5")},
+		},
+		{name: "empty [][16]byte", value: [][16]byte{}, want: IDSlice{}},
+		{
+			name: "[][]byte",
+			value: [][]byte{
+				IDMust("21e70a0c-7f0e-44d8-8ae5-48992b89d0c5").Bytes(),
+				[]byte("ec449f0f-e10c-4edb-8b59-0e6c896fdca5"),
+			},
+			want: IDSlice{IDMust("21e70a0c-7f0e-44d8-8ae5-48992b89d0c5"), IDMust("ec449f0f-e10c-4edb-8b59-0e6c896fdca5")},
+		},
+		{name: "invalid [][]byte", value: [][]byte{{1, 2, 3}}, want: nil, wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,3 +146,395 @@ func TestIDSlice_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestUniqueIDSlice_UnmarshalJSON(t *testing.T) {
+	var s UniqueIDSlice
+	err := json.Unmarshal([]byte(`["cc5873e6-286d-48cd-ae88-bda3a1e986e3","ec449f0f-e10c-4edb-8b59-0e6c896fdca5"]`), &s)
+	assert.NoError(t, err)
+	assert.Equal(t, IDSliceMustFromStrings("cc5873e6-286d-48cd-ae88-bda3a1e986e3", "ec449f0f-e10c-4edb-8b59-0e6c896fdca5"), s.IDSlice(), "order preserved")
+
+	err = json.Unmarshal([]byte(`["cc5873e6-286d-48cd-ae88-bda3a1e986e3","ec449f0f-e10c-4edb-8b59-0e6c896fdca5","cc5873e6-286d-48cd-ae88-bda3a1e986e3"]`), &s)
+	var dup ErrDuplicateID
+	assert.ErrorAs(t, err, &dup)
+	assert.Equal(t, ErrDuplicateID{ID: IDMustFromString("cc5873e6-286d-48cd-ae88-bda3a1e986e3"), Index: 2, FirstIndex: 0}, dup)
+
+	err = json.Unmarshal([]byte(`null`), &s)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestIDSlice_AppendScan(t *testing.T) {
+	id1 := IDMust("ec449f0f-e10c-4edb-8b59-0e6c896fdca5")
+	id2 := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+
+	s, err := IDSlice{id1}.AppendScan(`{"2d6a2c10-e4a6-45a3-a705-8115214a3778",ec449f0f-e10c-4edb-8b59-0e6c896fdca5}`)
+	assert.NoError(t, err)
+	assert.Equal(t, IDSlice{id1, id2, id1}, s)
+
+	s, err = s[:1].AppendScan(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, IDSlice{id1}, s)
+
+	s, err = s.AppendScan([]byte("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, IDSlice{id1}, s)
+
+	for _, invalid := range []any{"[]", "{,}", "{ec449f0f-e10c-4edb-8b59-0e6c896fdca5,}", "{invalid}", 123} {
+		s, err = IDSlice{id1}.AppendScan(invalid)
+		assert.Error(t, err, "AppendScan(%#v)", invalid)
+		assert.Equal(t, IDSlice{id1}, s, "unchanged after AppendScan(%#v)", invalid)
+	}
+}
+
+func TestIDSliceBuffer(t *testing.T) {
+	row := []byte(`{ec449f0f-e10c-4edb-8b59-0e6c896fdca5,2d6a2c10-e4a6-45a3-a705-8115214a3778}`)
+
+	buf := GetIDSliceBuffer()
+	assert.Equal(t, 0, buf.Len())
+	assert.NoError(t, buf.Scan(row))
+	assert.Equal(t, 2, buf.Len())
+	clone := buf.Clone()
+	assert.NoError(t, buf.AppendScan(row))
+	assert.Equal(t, append(clone, clone...), buf.IDs)
+	assert.NoError(t, buf.Scan(nil))
+	assert.Equal(t, 0, buf.Len())
+	assert.Nil(t, buf.Clone())
+	buf.Append(clone...)
+	assert.Equal(t, clone, buf.IDs)
+	PutIDSliceBuffer(buf)
+
+	var value any = row // database/sql passes values as interface
+	buf = GetIDSliceBuffer()
+	defer PutIDSliceBuffer(buf)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = buf.Scan(value)
+	})
+	assert.Zero(t, allocs, "allocations per scanned row")
+}
+
+func TestIDSlice_Shuffle(t *testing.T) {
+	s := make(IDSlice, 100)
+	for i := range s {
+		s[i] = IDv4()
+	}
+	a := s.Clone()
+	a.Shuffle(rand.New(rand.NewPCG(1, 2)))
+	b := s.Clone()
+	b.Shuffle(rand.New(rand.NewPCG(1, 2)))
+	assert.Equal(t, a, b, "same seed must shuffle the same")
+	assert.NotEqual(t, s, a)
+	assert.Equal(t, s.SortedClone(), a.SortedClone())
+
+	var empty IDSlice
+	empty.Shuffle(nil)
+	assert.Empty(t, empty)
+}
+
+func TestIDSet_Sample(t *testing.T) {
+	set := make(IDSet)
+	for range 100 {
+		set.Add(IDv4())
+	}
+
+	a := set.Sample(10, rand.New(rand.NewPCG(1, 2)))
+	b := set.Clone().Sample(10, rand.New(rand.NewPCG(1, 2)))
+	assert.Len(t, a, 10)
+	assert.Equal(t, a, b, "same seed must sample the same")
+	assert.NoError(t, a.CheckUnique())
+	for _, id := range a {
+		assert.True(t, set.Contains(id))
+	}
+
+	all := set.Sample(1000, nil)
+	assert.Len(t, all, 100)
+	assert.True(t, all.AsSet().Equal(set))
+
+	assert.Nil(t, set.Sample(0, nil))
+	assert.Nil(t, IDSet(nil).Sample(10, nil))
+}
+
+func TestIDSliceAndSet_All(t *testing.T) {
+	ids := IDSlice{IDv4(), IDv4(), IDv4()}
+	assert.Equal(t, []ID(ids), slices.Collect(ids.All()))
+	assert.Empty(t, slices.Collect(IDSlice(nil).All()))
+
+	set := ids.AsSet()
+	assert.ElementsMatch(t, ids, slices.Collect(set.All()))
+	assert.Equal(t, []ID(ids.SortedClone()), slices.Collect(set.Sorted()))
+	assert.Empty(t, slices.Collect(IDSet(nil).Sorted()))
+
+	for range set.Sorted() {
+		break // stops without panic
+	}
+}
+
+func TestIDSliceAndSet_Chunk(t *testing.T) {
+	ids := make(IDSlice, 7)
+	for i := range ids {
+		ids[i] = IDv4()
+	}
+	chunks := ids.Chunk(3)
+	require.Len(t, chunks, 3)
+	assert.Equal(t, ids[0:3], chunks[0])
+	assert.Equal(t, ids[3:6], chunks[1])
+	assert.Equal(t, ids[6:7], chunks[2])
+	assert.Equal(t, ids, slices.Concat(chunks...), "order preserved")
+
+	// Appending to a chunk does not overwrite the next one
+	next := chunks[1][0]
+	_ = append(chunks[0], IDv4())
+	assert.Equal(t, next, ids[3])
+
+	assert.Equal(t, []IDSlice{ids}, ids.Chunk(7))
+	assert.Equal(t, []IDSlice{ids}, ids.Chunk(100))
+	assert.Len(t, ids.Chunk(1), 7)
+	assert.Nil(t, IDSlice(nil).Chunk(3))
+	assert.Nil(t, IDSlice{}.Chunk(3))
+	assert.Panics(t, func() { ids.Chunk(0) })
+
+	set := ids.AsSet()
+	setChunks := set.Chunks(4)
+	require.Len(t, setChunks, 2)
+	assert.Equal(t, ids.SortedClone(), slices.Concat(setChunks...))
+	assert.Nil(t, IDSet(nil).Chunks(4))
+	assert.Panics(t, func() { set.Chunks(-1) })
+}
+
+func TestIDSliceAndSet_FilterMap(t *testing.T) {
+	ids := IDSlice{IDv4(), IDv7(), IDv4(), IDv7()}
+	isV7 := func(id ID) bool { return id.Version() == 7 }
+	assert.Equal(t, IDSlice{ids[1], ids[3]}, ids.Filter(isV7))
+	assert.Nil(t, ids.Filter(func(ID) bool { return false }))
+	assert.Nil(t, IDSlice(nil).Filter(isV7))
+
+	set := ids.AsSet()
+	assert.Equal(t, IDSlice{ids[1], ids[3]}.AsSet(), set.Filter(isV7))
+	assert.NotNil(t, set.Filter(func(ID) bool { return false }))
+	assert.Nil(t, IDSet(nil).Filter(isV7))
+
+	assert.Equal(t, ids.Strings(), MapIDSlice(ids, ID.String))
+	assert.Equal(t, []int{4, 7, 4, 7}, MapIDSlice(ids, ID.Version))
+	assert.Nil(t, MapIDSlice(IDSlice{}, ID.String))
+	assert.Equal(t, set.AsSortedSlice().Strings(), MapIDSet(set, ID.String))
+	assert.Nil(t, MapIDSet(IDSet(nil), ID.String))
+}
+
+func TestIDSlice_SortByTime(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v7a := IDv7WithTime(base)
+	v7b := IDv7WithTime(base.Add(time.Millisecond))
+	v7c := IDv7WithTime(base.Add(time.Millisecond)) // same time as v7b
+	v1Later := IDv1()
+	v1Time, err := v1Later.Time()
+	require.NoError(t, err)
+	require.True(t, v1Time.After(base))
+	v4a := IDv4Sequential(2)
+	v4b := IDv4Sequential(1)
+
+	ids := IDSlice{v4a, v1Later, v7c, v4b, v7b, v7a}
+	ids.SortByTimeStable()
+	assert.Equal(t, IDSlice{v7a, v7c, v7b, v1Later, v4a, v4b}, ids)
+
+	ids = IDSlice{v4a, v1Later, v7c, v4b, v7b, v7a}
+	ids.SortByTime()
+	first, second := v7b, v7c
+	if bytes.Compare(first[:], second[:]) > 0 {
+		first, second = second, first
+	}
+	assert.Equal(t, IDSlice{v7a, first, second, v1Later, v4b, v4a}, ids)
+
+	IDSlice(nil).SortByTime()
+}
+
+func TestIDSet_Algebra(t *testing.T) {
+	a, b, c := IDv4(), IDv4(), IDv4()
+	s1 := MakeIDSet(a, b)
+	s2 := MakeIDSet(b, c)
+
+	assert.Equal(t, MakeIDSet(a, b, c), s1.Union(s2))
+	assert.Equal(t, MakeIDSet(b), s1.Intersection(s2))
+	assert.Equal(t, MakeIDSet(b), s2.Intersection(s1))
+	assert.Equal(t, MakeIDSet(a), s1.Subtract(s2))
+	assert.Equal(t, MakeIDSet(c), s2.Subtract(s1))
+	assert.Equal(t, MakeIDSet(a, c), s1.Diff(s2))
+	assert.Equal(t, MakeIDSet(a, b), s1, "not mutated")
+	assert.Equal(t, MakeIDSet(b, c), s2, "not mutated")
+
+	assert.Equal(t, s1, s1.Union(nil))
+	assert.Empty(t, s1.Intersection(nil))
+	assert.Equal(t, s1, s1.Subtract(nil))
+	assert.Empty(t, IDSet(nil).Subtract(s1))
+}
+
+func TestIDSlice_MarshalBinary(t *testing.T) {
+	s := IDSlice{IDv4(), IDv7(), IDNil}
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 1+3*16)
+
+	var parsed IDSlice
+	assert.NoError(t, parsed.UnmarshalBinary(data))
+	assert.Equal(t, s, parsed)
+
+	data, err = IDSlice(nil).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0}, data)
+	assert.NoError(t, parsed.UnmarshalBinary(data))
+	assert.Nil(t, parsed)
+
+	assert.Error(t, parsed.UnmarshalBinary(nil))
+	assert.Error(t, parsed.UnmarshalBinary([]byte{2, 1, 2, 3}))
+	assert.Error(t, parsed.UnmarshalBinary(append([]byte{2}, make([]byte, 48)...)))
+
+	set := MakeIDSet(s...)
+	data, err = set.MarshalBinary()
+	assert.NoError(t, err)
+	var parsedSet IDSet
+	assert.NoError(t, parsedSet.UnmarshalBinary(data))
+	assert.Equal(t, set, parsedSet)
+
+	data, err = IDSet(nil).MarshalBinary()
+	assert.NoError(t, err)
+	assert.NoError(t, parsedSet.UnmarshalBinary(data))
+	assert.Nil(t, parsedSet)
+}
+
+func TestIDSlice_Gob(t *testing.T) {
+	// Gob keeps using the text format of earlier versions
+	s := IDSlice{IDv4(), IDv7()}
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(s))
+	assert.Contains(t, buf.String(), s.String())
+	var parsed IDSlice
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&parsed))
+	assert.Equal(t, s, parsed)
+
+	set := MakeIDSet(s...)
+	buf.Reset()
+	require.NoError(t, gob.NewEncoder(&buf).Encode(set))
+	var parsedSet IDSet
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&parsedSet))
+	assert.Equal(t, set, parsedSet)
+}
+
+func TestIDSet_PopTakeN(t *testing.T) {
+	a, b, c := IDv4(), IDv4(), IDv4()
+	s := MakeIDSet(a, b, c)
+
+	id, ok := s.Pop()
+	assert.True(t, ok)
+	assert.True(t, MakeIDSet(a, b, c).Contains(id))
+	assert.False(t, s.Contains(id))
+	assert.Equal(t, 2, s.Len())
+
+	assert.Nil(t, s.TakeN(0))
+	assert.Nil(t, s.TakeN(-1))
+	taken := s.TakeN(5)
+	assert.Len(t, taken, 2)
+	assert.Equal(t, MakeIDSet(a, b, c), MakeIDSet(append(taken, id)...))
+	assert.True(t, s.IsEmpty())
+
+	id, ok = s.Pop()
+	assert.False(t, ok)
+	assert.Equal(t, IDNil, id)
+	assert.Nil(t, s.TakeN(1))
+
+	s = MakeIDSet(a, b, c)
+	assert.Len(t, s.TakeN(2), 2)
+	assert.Equal(t, 1, s.Len())
+
+	id, ok = IDSet(nil).Pop()
+	assert.False(t, ok)
+	assert.Equal(t, IDNil, id)
+	assert.Nil(t, IDSet(nil).TakeN(3))
+}
+
+func TestIDSet_Scan(t *testing.T) {
+	id1 := IDMust("ec449f0f-e10c-4edb-8b59-0e6c896fdca5")
+	id2 := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+
+	var s IDSet
+	assert.NoError(t, s.Scan(`{"2d6a2c10-e4a6-45a3-a705-8115214a3778",ec449f0f-e10c-4edb-8b59-0e6c896fdca5,2d6a2c10-e4a6-45a3-a705-8115214a3778}`))
+	assert.Equal(t, MakeIDSet(id1, id2), s)
+
+	assert.NoError(t, s.Scan([]byte("{}")))
+	assert.Equal(t, IDSet{}, s)
+	assert.NoError(t, s.Scan(""))
+	assert.Equal(t, IDSet{}, s)
+	assert.NoError(t, s.Scan(nil))
+	assert.Nil(t, s)
+	assert.NoError(t, s.Scan([][16]byte{id1}))
+	assert.Equal(t, MakeIDSet(id1), s)
+
+	for _, invalid := range []any{"xxx", "{", `{"ec449f0f-e10c-4edb-8b59-0e6c896fdca5",}`, "{xxx}", true} {
+		s = MakeIDSet(id2)
+		assert.Error(t, s.Scan(invalid), "Scan(%#v)", invalid)
+		assert.Equal(t, MakeIDSet(id2), s, "unchanged after Scan(%#v)", invalid)
+	}
+}
+
+func BenchmarkIDSetScan(b *testing.B) {
+	s := make(IDSlice, 10_000)
+	for i := range s {
+		s[i] = IDv4()
+	}
+	value, _ := s.Value()
+	b.ReportAllocs()
+	for b.Loop() {
+		var set IDSet
+		_ = set.Scan(value)
+	}
+}
+
+func TestIDSlice_DecodeJSONStream(t *testing.T) {
+	ids := IDSliceMustFromStrings(
+		"ec449f0f-e10c-4edb-8b59-0e6c896fdca5",
+		"2d6a2c10-e4a6-45a3-a705-8115214a3778",
+	)
+	data, err := json.Marshal(ids)
+	require.NoError(t, err)
+
+	s, err := IDSliceFromJSONReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, ids, s)
+
+	s, err = IDSliceFromJSONReader(strings.NewReader(" [ ] "))
+	require.NoError(t, err)
+	assert.Equal(t, IDSlice{}, s)
+
+	s, err = IDSliceFromJSONReader(strings.NewReader("null"))
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	// Decodes the next value of a stream of multiple values
+	dec := json.NewDecoder(strings.NewReader(`["ec449f0f-e10c-4edb-8b59-0e6c896fdca5"] ["2d6a2c10-e4a6-45a3-a705-8115214a3778"]`))
+	require.NoError(t, s.DecodeJSONStream(dec))
+	assert.Equal(t, ids[:1], s)
+	require.NoError(t, s.DecodeJSONStream(dec))
+	assert.Equal(t, ids[1:], s)
+
+	var invalidAt ErrInvalidIDAtIndex
+	_, err = IDSliceFromJSONReader(strings.NewReader(`["ec449f0f-e10c-4edb-8b59-0e6c896fdca5", "xxx"]`))
+	require.ErrorAs(t, err, &invalidAt)
+	assert.Equal(t, 1, invalidAt.Index)
+	_, err = IDSliceFromJSONReader(strings.NewReader(`[1]`))
+	require.ErrorAs(t, err, &invalidAt)
+	assert.Equal(t, 0, invalidAt.Index)
+
+	for _, invalid := range []string{``, `{}`, `"ec449f0f-e10c-4edb-8b59-0e6c896fdca5"`, `["ec449f0f-e10c-4edb-8b59-0e6c896fdca5"`} {
+		_, err = IDSliceFromJSONReader(strings.NewReader(invalid))
+		assert.Error(t, err, "IDSliceFromJSONReader(%#q)", invalid)
+	}
+}
+
+func BenchmarkIDSliceFromJSONReader(b *testing.B) {
+	s := make(IDSlice, 10_000)
+	for i := range s {
+		s[i] = IDv4()
+	}
+	data, _ := s.MarshalJSON()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = IDSliceFromJSONReader(bytes.NewReader(data))
+	}
+}
@@ -0,0 +1,83 @@
+package uu
+
+import "sync"
+
+// maxPooledIDSliceCap is the maximum capacity of an IDSliceBuffer
+// that is put back into the pool, so that single
+// huge results don't keep their memory allocated.
+const maxPooledIDSliceCap = 1 << 16
+
+var idSliceBufferPool = sync.Pool{
+	New: func() any { return new(IDSliceBuffer) },
+}
+
+// IDSliceBuffer is a reusable IDSlice for hot paths
+// like row-scanning loops that would otherwise allocate
+// a new IDSlice per row.
+//
+// IDSliceBuffer implements the database/sql.Scanner interface
+// by resetting the buffer and appending the scanned IDs,
+// SQL NULL results in an empty buffer.
+//
+// Get a buffer from the pool with GetIDSliceBuffer
+// and return it with PutIDSliceBuffer when the IDs
+// are no longer referenced. Use Clone to keep the IDs.
+type IDSliceBuffer struct {
+	IDs IDSlice
+}
+
+// GetIDSliceBuffer returns an empty IDSliceBuffer from a sync.Pool.
+func GetIDSliceBuffer() *IDSliceBuffer {
+	buf := idSliceBufferPool.Get().(*IDSliceBuffer)
+	buf.Reset()
+	return buf
+}
+
+// PutIDSliceBuffer puts a buffer back into the pool
+// of GetIDSliceBuffer. The buffer and its IDs
+// must not be used after calling this function.
+func PutIDSliceBuffer(buf *IDSliceBuffer) {
+	if buf == nil || cap(buf.IDs) > maxPooledIDSliceCap {
+		return
+	}
+	idSliceBufferPool.Put(buf)
+}
+
+// Reset empties the buffer keeping the allocated capacity.
+func (buf *IDSliceBuffer) Reset() {
+	buf.IDs = buf.IDs[:0]
+}
+
+// Len returns the number of IDs in the buffer.
+func (buf *IDSliceBuffer) Len() int {
+	return len(buf.IDs)
+}
+
+// Append appends ids to the buffer.
+func (buf *IDSliceBuffer) Append(ids ...ID) {
+	buf.IDs = append(buf.IDs, ids...)
+}
+
+// AppendScan appends the IDs of a PostgreSQL array value
+// to the buffer, see IDSlice.AppendScan.
+func (buf *IDSliceBuffer) AppendScan(value any) (err error) {
+	buf.IDs, err = buf.IDs.AppendScan(value)
+	return err
+}
+
+// Scan implements the database/sql.Scanner interface
+// by resetting the buffer and appending the IDs
+// of a PostgreSQL array value.
+func (buf *IDSliceBuffer) Scan(value any) error {
+	buf.Reset()
+	return buf.AppendScan(value)
+}
+
+// Clone returns a newly allocated copy of the IDs
+// or nil if the buffer is empty.
+func (buf *IDSliceBuffer) Clone() IDSlice {
+	if len(buf.IDs) == 0 {
+		return nil
+	}
+	return buf.IDs.Clone()
+}
@@ -0,0 +1,56 @@
+package uu
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// TimePrefixed returns a new version 8 ID with the first 48 bits
+// containing a big-endian Unix Epoch timestamp in milliseconds
+// of the passed time like a version 7 ID, followed by
+// the last 74 bits of entropy of the original ID
+// excluding the version and variant bits.
+//
+// Meant to gradually migrate tables with random version 4 primary keys
+// to time-ordered keys that cluster well in SQL indexes
+// by using the creation time of a row as timestamp.
+// The first 48 bits of the original ID are lost,
+// so a mapping from the original to the new ID
+// has to be kept during the migration.
+// Use [ID.IsTimePrefixedOf] to check if an ID was derived from another.
+//
+// See [ID.TimePrefixedTime] for the reverse operation.
+func (id ID) TimePrefixed(t time.Time) ID {
+	putV7Milli(&id, t.UnixMilli())
+	id.SetVersion(8)
+	id.SetVariant()
+	return id
+}
+
+// TimePrefixedTime returns the timestamp of an ID
+// returned by [ID.TimePrefixed] or an ErrInvalidVersion error
+// if the ID is not a version 8 UUID.
+//
+// Note that the precision is only milliseconds
+// and that version 8 UUIDs created by other means
+// may not contain a timestamp.
+func (id ID) TimePrefixedTime() (time.Time, error) {
+	if v := id.Version(); v != 8 {
+		return time.Time{}, ErrInvalidVersion(v)
+	}
+	var b [8]byte
+	copy(b[2:], id[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(b[:]))), nil //#nosec G115 -- integer conversion OK
+}
+
+// IsTimePrefixedOf returns if the ID is a version 8 UUID
+// that could have been returned by [ID.TimePrefixed]
+// for the original ID because the entropy bits are equal.
+func (id ID) IsTimePrefixedOf(original ID) bool {
+	if id.Version() != 8 {
+		return false
+	}
+	original.SetVersion(8)
+	original.SetVariant()
+	return [10]byte(id[6:]) == [10]byte(original[6:])
+}
@@ -0,0 +1,32 @@
+package uu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestID_TimePrefixed(t *testing.T) {
+	legacy := IDMustFromString("a8d2f6e4-3b1c-4f5e-9a7b-0c1d2e3f4a5b")
+	created := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+
+	id := legacy.TimePrefixed(created)
+	require.Equal(t, "017f22e2-79b0-8f5e-9a7b-0c1d2e3f4a5b", id.String())
+	require.NoError(t, id.Validate())
+	require.Equal(t, 8, id.Version())
+	require.True(t, id.IsTimePrefixedOf(legacy))
+	require.False(t, id.IsTimePrefixedOf(IDv4()))
+	require.False(t, legacy.IsTimePrefixedOf(legacy), "not version 8")
+	require.Equal(t, id, legacy.TimePrefixed(created), "deterministic")
+
+	ts, err := id.TimePrefixedTime()
+	require.NoError(t, err)
+	require.True(t, created.Equal(ts), "TimePrefixedTime")
+
+	_, err = legacy.TimePrefixedTime()
+	require.ErrorIs(t, err, ErrInvalidVersion(4))
+
+	later := IDv4().TimePrefixed(created.Add(time.Millisecond))
+	require.Less(t, id.String(), later.String(), "sorted by time")
+}
@@ -0,0 +1,85 @@
+package uu
+
+import "fmt"
+
+// crockfordBase32Alphabet is the Crockford base32 alphabet used by ULIDs
+const crockfordBase32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordBase32Values maps upper and lower case characters
+// including the Crockford aliases I, L, and O
+// to their values or 0xff for invalid characters
+var crockfordBase32Values = func() (values [256]byte) {
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i := range len(crockfordBase32Alphabet) {
+		c := crockfordBase32Alphabet[i]
+		values[c] = byte(i)
+		values[c|0x20] = byte(i) // lower case, no effect for digits
+	}
+	for _, alias := range []struct{ c, v byte }{{'I', 1}, {'L', 1}, {'O', 0}} {
+		values[alias.c] = alias.v
+		values[alias.c|0x20] = alias.v
+	}
+	return values
+}()
+
+// ULIDString returns the ID as 26 character ULID string
+// in upper case Crockford base32 encoding of the 16 bytes.
+//
+// The first 48 bits of a ULID are a big-endian Unix Epoch
+// timestamp in milliseconds like in version 7 UUIDs,
+// so the ULID string of a version 7 UUID has a valid ULID timestamp.
+//
+// See [IDFromULID] for the reverse operation.
+func (id ID) ULIDString() string {
+	var b [26]byte
+	// 130 bits for 26 characters, the first character holds only 3 bits
+	b[0] = crockfordBase32Alphabet[id[0]>>5]
+	bitPos := 3 // bit position in id of the next character
+	for i := 1; i < 26; i++ {
+		byteIndex, shift := bitPos/8, bitPos%8
+		v := uint16(id[byteIndex]) << 8
+		if byteIndex+1 < 16 {
+			v |= uint16(id[byteIndex+1])
+		}
+		b[i] = crockfordBase32Alphabet[(v>>(11-shift))&0x1f]
+		bitPos += 5
+	}
+	return string(b[:])
+}
+
+// IDFromULID parses a 26 character ULID string as ID.
+// Lower case characters and the Crockford base32 aliases
+// I and L for 1 and O for 0 are accepted.
+//
+// Note that the version and variant bits of the returned ID
+// are the random bits of the ULID, so ID.Validate
+// may return an error for IDs from ULIDs.
+func IDFromULID(s string) (ID, error) {
+	if len(s) != 26 {
+		return IDNil, fmt.Errorf("ULID must be 26 characters long, got %d: %q", len(s), s)
+	}
+	var id ID
+	first := crockfordBase32Values[s[0]]
+	if first > 7 {
+		return IDNil, fmt.Errorf("invalid ULID %q, first character must be 0 to 7", s)
+	}
+	id[0] = first << 5
+	bitPos := 3
+	for i := 1; i < 26; i++ {
+		v := crockfordBase32Values[s[i]]
+		if v == 0xff {
+			return IDNil, fmt.Errorf("invalid character %q in ULID %q", s[i], s)
+		}
+		byteIndex, shift := bitPos/8, bitPos%8
+		// Place the 5 bits at bitPos, possibly spanning two bytes
+		w := uint16(v) << (11 - shift)
+		id[byteIndex] |= byte(w >> 8)
+		if byteIndex+1 < 16 {
+			id[byteIndex+1] |= byte(w)
+		}
+		bitPos += 5
+	}
+	return id, nil
+}
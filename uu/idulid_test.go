@@ -0,0 +1,46 @@
+package uu
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDFromULID(t *testing.T) {
+	// Example from the ULID specification
+	id, err := IDFromULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	require.Equal(t, "01563e3a-b5d3-d676-4c61-efb99302bd5b", id.String())
+	require.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", id.ULIDString())
+
+	lower, err := IDFromULID(strings.ToLower("01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	require.NoError(t, err)
+	require.Equal(t, id, lower)
+
+	alias, err := IDFromULID("OlARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	require.Equal(t, id, alias, "Crockford aliases")
+
+	require.Equal(t, "00000000000000000000000000", IDNil.ULIDString())
+	maxID := ID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	require.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", maxID.ULIDString())
+
+	for range 100 {
+		id := IDv7()
+		parsed, err := IDFromULID(id.ULIDString())
+		require.NoError(t, err)
+		require.Equal(t, id, parsed)
+	}
+
+	v7 := IDv7WithTime(time.UnixMilli(1469918176385))
+	require.Equal(t, "01ARYZ6S41", v7.ULIDString()[:10], "ULID timestamp of version 7 UUID")
+
+	_, err = IDFromULID("81ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.Error(t, err, "overflow")
+	_, err = IDFromULID("01ARZ3NDEKTSV4RRFFQ69G5FAU")
+	require.Error(t, err, "invalid character U")
+	_, err = IDFromULID("01ARZ3NDEK")
+	require.Error(t, err, "too short")
+}
@@ -0,0 +1,266 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package uu
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"fmt"
+)
+
+// Compile-time checks for the encoding/json/v2 interfaces
+var (
+	_ jsonv2.MarshalerTo     = ID{}
+	_ jsonv2.UnmarshalerFrom = new(ID)
+	_ jsonv2.MarshalerTo     = NullableID{}
+	_ jsonv2.UnmarshalerFrom = new(NullableID)
+	_ jsonv2.MarshalerTo     = NullableIDEmptyAsNull{}
+	_ jsonv2.UnmarshalerFrom = new(NullableIDEmptyAsNull)
+	_ jsonv2.MarshalerTo     = IDSlice(nil)
+	_ jsonv2.UnmarshalerFrom = new(IDSlice)
+	_ jsonv2.MarshalerTo     = IDSet(nil)
+	_ jsonv2.UnmarshalerFrom = new(IDSet)
+	_ jsonv2.MarshalerTo     = FrozenIDSet{}
+	_ jsonv2.UnmarshalerFrom = new(FrozenIDSet)
+)
+
+// writeJSONString writes the ID as quoted JSON string
+// without allocating memory.
+func (id ID) writeJSONString(enc *jsontext.Encoder) error {
+	var b [38]byte
+	b[0] = '"'
+	id.encodeString((*[36]byte)(b[1:37]), hexDigitsLower)
+	b[37] = '"'
+	return enc.WriteValue(b[:])
+}
+
+// readJSONString parses a JSON string value as ID.
+func readJSONString(val jsontext.Value) (ID, error) {
+	if val.Kind() != '"' {
+		return IDNil, fmt.Errorf("can't unmarshal JSON %s as uu.ID", val.Kind())
+	}
+	str := val[1 : len(val)-1]
+	if bytes.IndexByte(str, '\\') != -1 {
+		// Rare case of escaped characters
+		unquoted, err := jsontext.AppendUnquote(nil, val)
+		if err != nil {
+			return IDNil, err
+		}
+		str = unquoted
+	}
+	var id ID
+	if err := id.UnmarshalText(str); err != nil {
+		return IDNil, err
+	}
+	return id, nil
+}
+
+// MarshalJSONTo implements encoding/json/v2.MarshalerTo
+// by writing the ID as JSON string without allocations.
+func (id ID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return id.writeJSONString(enc)
+}
+
+// UnmarshalJSONFrom implements encoding/json/v2.UnmarshalerFrom
+// by parsing a JSON string in a format supported by UnmarshalText.
+// JSON null leaves the ID unchanged like with encoding/json.
+func (id *ID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	if val.Kind() == 'n' {
+		return nil
+	}
+	*id, err = readJSONString(val)
+	return err
+}
+
+// MarshalJSONTo implements encoding/json/v2.MarshalerTo
+// by writing JSON null for IDNull or else the ID as JSON string.
+func (n NullableID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if n == IDNull {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return ID(n).writeJSONString(enc)
+}
+
+// UnmarshalJSONFrom implements encoding/json/v2.UnmarshalerFrom
+// accepting the same JSON values as UnmarshalJSON.
+func (n *NullableID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch val.Kind() {
+	case 'n':
+		*n = IDNull
+		return nil
+	case '"':
+		id, err := readJSONString(val)
+		if err != nil {
+			return err
+		}
+		*n = NullableID(id)
+		return nil
+	default:
+		// sql.NullString like objects are handled by UnmarshalJSON
+		return n.UnmarshalJSON(val)
+	}
+}
+
+// MarshalJSONTo implements encoding/json/v2.MarshalerTo
+// like NullableID.MarshalJSONTo.
+func (n NullableIDEmptyAsNull) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return NullableID(n).MarshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements encoding/json/v2.UnmarshalerFrom
+// accepting the same JSON values as UnmarshalJSON.
+// Strings are unquoted before checking if they are blank,
+// so escaped whitespace is handled like with UnmarshalJSON.
+func (n *NullableIDEmptyAsNull) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch val.Kind() {
+	case 'n':
+		*n = NullableIDEmptyAsNull(IDNull)
+		return nil
+	case '"':
+		unquoted, err := jsontext.AppendUnquote(nil, val)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(unquoted)) == 0 {
+			*n = NullableIDEmptyAsNull(IDNull)
+			return nil
+		}
+		var id ID
+		if err := id.UnmarshalText(unquoted); err != nil {
+			return err
+		}
+		*n = NullableIDEmptyAsNull(id)
+		return nil
+	default:
+		// sql.NullString like objects are handled by UnmarshalJSON
+		return n.UnmarshalJSON(val)
+	}
+}
+
+// MarshalJSONTo implements encoding/json/v2.MarshalerTo
+// by streaming the IDs as JSON array of strings.
+// A nil slice is written as JSON null.
+func (s IDSlice) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if s == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+		return err
+	}
+	for _, id := range s {
+		if err := id.writeJSONString(enc); err != nil {
+			return err
+		}
+	}
+	return enc.WriteToken(jsontext.EndArray)
+}
+
+// UnmarshalJSONFrom implements encoding/json/v2.UnmarshalerFrom
+// by reading a JSON array of ID strings.
+// JSON null results in a nil slice.
+func (s *IDSlice) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	ids, err := readJSONArray(dec)
+	if err != nil {
+		return err
+	}
+	*s = ids
+	return nil
+}
+
+// readJSONArray reads a JSON array of ID strings or JSON null.
+func readJSONArray(dec *jsontext.Decoder) (IDSlice, error) {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return nil, err
+	}
+	switch tok.Kind() {
+	case 'n':
+		return nil, nil
+	case '[':
+	default:
+		return nil, fmt.Errorf("can't parse JSON %s as uu.IDSlice because not a JSON array", tok.Kind())
+	}
+	ids := make(IDSlice, 0)
+	for dec.PeekKind() != ']' {
+		val, err := dec.ReadValue()
+		if err != nil {
+			return nil, err
+		}
+		id, err := readJSONString(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing uu.IDSlice from JSON: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err = dec.ReadToken(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// MarshalJSONTo implements encoding/json/v2.MarshalerTo
+// by writing the sorted IDs as JSON array of strings.
+// A nil set is written as JSON null.
+func (s IDSet) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if s == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return s.AsSortedSlice().MarshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements encoding/json/v2.UnmarshalerFrom
+// by reading a JSON array of ID strings.
+// It does assign a new IDSet to *s instead of modifying the existing map.
+// JSON null results in a nil set.
+func (s *IDSet) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	ids, err := readJSONArray(dec)
+	if err != nil {
+		return err
+	}
+	if ids == nil {
+		*s = nil
+		return nil
+	}
+	*s = ids.AsSet()
+	return nil
+}
+
+// MarshalJSONTo implements encoding/json/v2.MarshalerTo
+// by writing the sorted IDs as JSON array of strings
+// without copying them.
+func (f FrozenIDSet) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+		return err
+	}
+	for id := range f.All() {
+		if err := id.writeJSONString(enc); err != nil {
+			return err
+		}
+	}
+	return enc.WriteToken(jsontext.EndArray)
+}
+
+// UnmarshalJSONFrom implements encoding/json/v2.UnmarshalerFrom
+// by assigning a new FrozenIDSet to *f.
+// JSON null results in an empty set.
+func (f *FrozenIDSet) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	ids, err := readJSONArray(dec)
+	if err != nil {
+		return err
+	}
+	*f = MakeFrozenIDSet(ids...)
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package uu
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONv2(t *testing.T) {
+	a := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+	b := IDMust("ec449f0f-e10c-4edb-8b59-0e6c896fdca5")
+
+	type values struct {
+		ID       ID          `json:"id"`
+		Nullable NullableID  `json:"nullable"`
+		Null     NullableID  `json:"null"`
+		Slice    IDSlice     `json:"slice"`
+		NilSlice IDSlice     `json:"nilSlice"`
+		Set      IDSet       `json:"set"`
+		Frozen   FrozenIDSet `json:"frozen"`
+	}
+	v := values{
+		ID:       a,
+		Nullable: NullableID(b),
+		Slice:    IDSlice{b, a},
+		Set:      MakeIDSet(b, a),
+		Frozen:   MakeFrozenIDSet(b, a),
+	}
+
+	data, err := jsonv2.Marshal(v)
+	require.NoError(t, err)
+	v1Data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(v1Data), string(data), "same JSON as encoding/json")
+
+	var parsed values
+	require.NoError(t, jsonv2.Unmarshal(data, &parsed))
+	assert.Equal(t, v.ID, parsed.ID)
+	assert.Equal(t, v.Nullable, parsed.Nullable)
+	assert.Equal(t, IDNull, parsed.Null)
+	assert.Equal(t, v.Slice, parsed.Slice)
+	assert.Nil(t, parsed.NilSlice)
+	assert.Equal(t, v.Set, parsed.Set)
+	assert.True(t, v.Frozen.Equal(parsed.Frozen))
+}
+
+func TestID_UnmarshalJSONFrom(t *testing.T) {
+	a := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+
+	var id ID
+	require.NoError(t, jsonv2.Unmarshal([]byte(`"2d6a2c10-e4a6-45a3-a705-8115214a377\u0038"`), &id), "escaped string")
+	assert.Equal(t, a, id)
+	require.NoError(t, jsonv2.Unmarshal([]byte(`null`), &id))
+	assert.Equal(t, a, id, "null leaves ID unchanged")
+	assert.Error(t, jsonv2.Unmarshal([]byte(`123`), &id))
+	assert.Error(t, jsonv2.Unmarshal([]byte(`"invalid"`), &id))
+
+	var n NullableID
+	require.NoError(t, jsonv2.Unmarshal([]byte(`{"String":"2d6a2c10-e4a6-45a3-a705-8115214a3778","Valid":true}`), &n))
+	assert.Equal(t, NullableID(a), n)
+
+	var s IDSlice
+	require.NoError(t, jsonv2.Unmarshal([]byte(` [ ] `), &s))
+	assert.Equal(t, IDSlice{}, s)
+	assert.Error(t, jsonv2.Unmarshal([]byte(`["invalid"]`), &s))
+	assert.Error(t, jsonv2.Unmarshal([]byte(`{}`), &s))
+}
+
+func TestID_MarshalJSONTo_Allocs(t *testing.T) {
+	id := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+	ids := IDSlice{id, id, id}
+	var buf bytes.Buffer
+	buf.Grow(1024)
+	enc := jsontext.NewEncoder(&buf)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		enc.Reset(&buf)
+		_ = id.MarshalJSONTo(enc)
+		_ = NullableID(id).MarshalJSONTo(enc)
+		_ = ids.MarshalJSONTo(enc)
+	})
+	assert.Zero(t, allocs)
+}
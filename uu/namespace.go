@@ -0,0 +1,79 @@
+package uu
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Registry of named namespaces for IDv5Named
+// initialized with the predefined namespaces.
+var (
+	namespacesMtx  sync.RWMutex
+	namespaceIDs   = map[string]ID{"dns": NamespaceDNS, "url": NamespaceURL, "oid": NamespaceOID, "x500": NamespaceX500}
+	namespaceNames = map[ID]string{NamespaceDNS: "dns", NamespaceURL: "url", NamespaceOID: "oid", NamespaceX500: "x500"}
+)
+
+// RegisterNamespace registers the namespace id under name
+// so that deterministic IDs can be created with IDv5Named
+// without sharing the namespace UUID constant.
+//
+// The predefined namespaces are registered as "dns", "url", "oid", and "x500".
+// Registering the same name and id again is a no-op.
+// An ErrNamespaceCollision is returned if the name is already
+// registered with another id or the id under another name.
+func RegisterNamespace(name string, id ID) error {
+	if name == "" {
+		return errors.New("empty UUID namespace name")
+	}
+	if id.IsNil() {
+		return ErrNilID
+	}
+
+	namespacesMtx.Lock()
+	defer namespacesMtx.Unlock()
+
+	registeredID, nameExists := namespaceIDs[name]
+	registeredName, idExists := namespaceNames[id]
+	if nameExists || idExists {
+		if registeredID == id && registeredName == name {
+			return nil
+		}
+		return ErrNamespaceCollision{Name: name, ID: id, RegisteredName: registeredName, RegisteredID: registeredID}
+	}
+	namespaceIDs[name] = id
+	namespaceNames[id] = name
+	return nil
+}
+
+// MustRegisterNamespace calls RegisterNamespace
+// and panics on an error.
+// It returns id to be used for initializing package variables.
+func MustRegisterNamespace(name string, id ID) ID {
+	err := RegisterNamespace(name, id)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// NamespaceByName returns the namespace registered under name.
+func NamespaceByName(name string) (id ID, ok bool) {
+	namespacesMtx.RLock()
+	defer namespacesMtx.RUnlock()
+
+	id, ok = namespaceIDs[name]
+	return id, ok
+}
+
+// IDv5Named returns a version 5 UUID of data
+// in the namespace registered under name
+// with RegisterNamespace.
+// It panics if no namespace is registered under name.
+func IDv5Named(name string, data []byte) ID {
+	namespace, ok := NamespaceByName(name)
+	if !ok {
+		panic(fmt.Sprintf("uu.IDv5Named: UUID namespace %q not registered", name))
+	}
+	return IDv5(namespace, data)
+}
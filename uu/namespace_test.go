@@ -0,0 +1,49 @@
+package uu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterNamespace(t *testing.T) {
+	invoices := IDMustFromString("1b4e28ba-2fa1-11d2-883f-0016d3cca427")
+	documents := IDMustFromString("2c5f39cb-3fb2-42e3-994f-1127e4ddb538")
+
+	require.NoError(t, RegisterNamespace("test-invoices", invoices))
+	require.NoError(t, RegisterNamespace("test-invoices", invoices), "same name and ID again")
+	assert.Equal(t, documents, MustRegisterNamespace("test-documents", documents))
+
+	var collision ErrNamespaceCollision
+	err := RegisterNamespace("test-invoices", documents)
+	require.True(t, errors.As(err, &collision), "name registered with other ID")
+	assert.Equal(t, invoices, collision.RegisteredID)
+
+	err = RegisterNamespace("test-other", invoices)
+	require.True(t, errors.As(err, &collision), "ID registered under other name")
+	assert.Equal(t, "test-invoices", collision.RegisteredName)
+
+	assert.Error(t, RegisterNamespace("dns", invoices), "predefined namespace")
+	assert.ErrorIs(t, RegisterNamespace("test-nil", IDNil), ErrNilID)
+	assert.Error(t, RegisterNamespace("", IDv4()))
+	assert.Panics(t, func() { MustRegisterNamespace("url", invoices) })
+
+	id, ok := NamespaceByName("test-invoices")
+	assert.True(t, ok)
+	assert.Equal(t, invoices, id)
+	_, ok = NamespaceByName("test-other")
+	assert.False(t, ok)
+}
+
+func TestIDv5Named(t *testing.T) {
+	assert.Equal(t, IDv5(NamespaceDNS, []byte("example.com")), IDv5Named("dns", []byte("example.com")))
+	assert.Equal(t, IDv5(NamespaceURL, []byte("https://example.com")), IDv5Named("url", []byte("https://example.com")))
+
+	namespace := IDMustFromString("3d6a4adc-4fc3-43f4-8a5a-2238f5eec649")
+	MustRegisterNamespace("test-named", namespace)
+	assert.Equal(t, IDv5(namespace, []byte("R-2024-0001")), IDv5Named("test-named", []byte("R-2024-0001")))
+
+	assert.Panics(t, func() { IDv5Named("test-not-registered", nil) })
+}
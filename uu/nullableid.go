@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,12 @@ type NullableID [16]byte
 // Compile-time check that NullableID implements nullable.NullSetable[ID]
 var _ nullable.NullSetable[ID] = (*NullableID)(nil)
 
+// Compile-time check that NullableID implements the encoding appender interfaces
+var (
+	_ encoding.TextAppender   = NullableID{}
+	_ encoding.BinaryAppender = NullableID{}
+)
+
 // NullableIDFromString parses a string as NullableID.
 // The Nil UUID "00000000-0000-0000-0000-000000000000"
 // is interpreted as NULL.
@@ -229,6 +236,12 @@ func (n NullableID) IsNull() bool {
 	return n == IDNull
 }
 
+// IsZero returns true if the NullableID is null.
+// IsZero is used by encoding/json for the omitzero tag option.
+func (n NullableID) IsZero() bool {
+	return n == IDNull
+}
+
 // IsNotNull returns true if the NullableID is not null.
 func (n NullableID) IsNotNull() bool {
 	return n != IDNull
@@ -335,7 +348,7 @@ func (n NullableID) MarshalJSON() ([]byte, error) {
 	}
 	b := make([]byte, 1, 38)
 	b[0] = '"'
-	b = append(b, n.StringBytes()...)
+	b = ID(n).AppendString(b)
 	b = append(b, '"')
 	return b, nil
 }
@@ -363,6 +376,15 @@ func (n NullableID) MarshalText() ([]byte, error) {
 	return ID(n).MarshalText()
 }
 
+// AppendText implements the encoding.TextAppender interface.
+// It will append nothing when this NullableID is null.
+func (n NullableID) AppendText(dst []byte) ([]byte, error) {
+	if n == IDNull {
+		return dst, nil
+	}
+	return ID(n).AppendText(dst)
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
 // It will unmarshal to a null String if the input is a blank string.
 func (n *NullableID) UnmarshalText(text []byte) (err error) {
@@ -378,6 +400,11 @@ func (n NullableID) MarshalBinary() (data []byte, err error) {
 	return ID(n).MarshalBinary()
 }
 
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (n NullableID) AppendBinary(dst []byte) ([]byte, error) {
+	return ID(n).AppendBinary(dst)
+}
+
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 // It will return error if the slice isn't 16 bytes long,
 // but does not check the validity of the UUID.
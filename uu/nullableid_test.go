@@ -145,6 +145,43 @@ func TestNullableID_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestNullableIDEmptyAsNull(t *testing.T) {
+	blanks := []string{`{"n":""}`, `{"n":" "}`, `{"n":"\t"}`, `{"n":"\u0020"}`, `{"n":{"String":"","Valid":true}}`}
+	for _, data := range blanks {
+		var out struct {
+			N NullableID `json:"n"`
+		}
+		if err := json.Unmarshal([]byte(data), &out); err == nil {
+			t.Errorf("expected error for JSON unmarshaling %s as NullableID", data)
+		}
+	}
+
+	type testStruct struct {
+		N NullableIDEmptyAsNull `json:"n"`
+	}
+	for _, data := range append(blanks, `{"n":null}`) {
+		out := testStruct{N: NullableIDEmptyAsNull(IDv4())}
+		if err := json.Unmarshal([]byte(data), &out); err != nil {
+			t.Errorf("Error JSON unmarshaling %s: %s", data, err)
+		}
+		if !out.N.IsNull() {
+			t.Errorf("JSON unmarshaling %s: expected null, got %s", data, out.N)
+		}
+	}
+
+	ref := IDMust("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	var out testStruct
+	if err := json.Unmarshal([]byte(`{"n":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`), &out); err != nil || out.N.NullableID() != ref.Nullable() {
+		t.Errorf("JSON unmarshaling valid UUID: %v, %s", err, out.N)
+	}
+	if data, err := json.Marshal(out); err != nil || string(data) != `{"n":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}` {
+		t.Errorf("JSON marshaling: %v, %s", err, data)
+	}
+	if err := json.Unmarshal([]byte(`{"n":"xxx"}`), &out); err == nil {
+		t.Errorf("expected error for JSON unmarshaling invalid UUID")
+	}
+}
+
 func TestNullableID_PrettyPrint(t *testing.T) {
 	tests := []struct {
 		id   NullableID
@@ -197,3 +234,44 @@ func ExampleNullableID_JSONSchema() {
 	//   "default": null
 	// }
 }
+
+func TestOmitZero(t *testing.T) {
+	type omitZero struct {
+		ID     NullableID  `json:"id,omitzero"`
+		Set    IDSet       `json:"set,omitzero"`
+		Frozen FrozenIDSet `json:"frozen,omitzero"`
+	}
+	j, err := json.Marshal(omitZero{Set: IDSet{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(j) != `{}` {
+		t.Errorf("expected empty JSON object, got %s", j)
+	}
+
+	id := IDMust("3a3e7ba3-2b5c-4b1a-8c5c-5f3a3e7ba32b")
+	j, err = json.Marshal(omitZero{ID: id.Nullable(), Set: MakeIDSet(id), Frozen: MakeFrozenIDSet(id)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"id":"3a3e7ba3-2b5c-4b1a-8c5c-5f3a3e7ba32b","set":["3a3e7ba3-2b5c-4b1a-8c5c-5f3a3e7ba32b"],"frozen":["3a3e7ba3-2b5c-4b1a-8c5c-5f3a3e7ba32b"]}`
+	if string(j) != expected {
+		t.Errorf("expected %s, got %s", expected, j)
+	}
+}
+
+func TestNullableID_Append(t *testing.T) {
+	id := NullableID(IDv4())
+	text, err := id.AppendText([]byte("id="))
+	if err != nil || string(text) != "id="+id.String() {
+		t.Errorf("AppendText returned %q, %v", text, err)
+	}
+	text, err = IDNull.AppendText([]byte("id="))
+	if err != nil || string(text) != "id=" {
+		t.Errorf("AppendText of IDNull returned %q, %v", text, err)
+	}
+	bin, err := id.AppendBinary(nil)
+	if err != nil || string(bin) != string(id[:]) {
+		t.Errorf("AppendBinary returned %x, %v", bin, err)
+	}
+}
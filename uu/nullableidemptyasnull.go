@@ -0,0 +1,65 @@
+package uu
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+)
+
+// NullableIDEmptyAsNull is a NullableID that unmarshals blank JSON strings
+// as null instead of returning an error like NullableID,
+// for upstream systems sending empty strings for absent UUIDs.
+//
+// Use it for the fields of types decoded from such systems
+// so that the strict unmarshalling of NullableID stays unchanged
+// for all other code.
+type NullableIDEmptyAsNull NullableID
+
+// NullableID returns the value as NullableID.
+func (n NullableIDEmptyAsNull) NullableID() NullableID {
+	return NullableID(n)
+}
+
+// String returns the ID as string or "NULL".
+func (n NullableIDEmptyAsNull) String() string {
+	return NullableID(n).String()
+}
+
+// IsNull returns true if the ID is null.
+func (n NullableIDEmptyAsNull) IsNull() bool {
+	return NullableID(n).IsNull()
+}
+
+// Value implements the driver.Valuer interface like NullableID.Value.
+func (n NullableIDEmptyAsNull) Value() (driver.Value, error) {
+	return NullableID(n).Value()
+}
+
+// Scan implements the sql.Scanner interface like NullableID.Scan.
+func (n *NullableIDEmptyAsNull) Scan(src any) error {
+	return (*NullableID)(n).Scan(src)
+}
+
+// MarshalJSON implements json.Marshaler like NullableID.MarshalJSON.
+func (n NullableIDEmptyAsNull) MarshalJSON() ([]byte, error) {
+	return NullableID(n).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler like NullableID.UnmarshalJSON
+// but unmarshals blank strings, also within a sql.NullString, as null.
+// Missing JSON object fields don't call UnmarshalJSON
+// and leave the zero value that is already null.
+func (n *NullableIDEmptyAsNull) UnmarshalJSON(data []byte) error {
+	var str string
+	if json.Unmarshal(data, &str) == nil && strings.TrimSpace(str) == "" {
+		*n = NullableIDEmptyAsNull(IDNull)
+		return nil
+	}
+	var ns sql.NullString
+	if json.Unmarshal(data, &ns) == nil && ns.Valid && strings.TrimSpace(ns.String) == "" {
+		*n = NullableIDEmptyAsNull(IDNull)
+		return nil
+	}
+	return (*NullableID)(n).UnmarshalJSON(data)
+}
@@ -0,0 +1,87 @@
+package uu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ObjectIDSet is an IDSet that is marshalled to JSON
+// as object with the IDs as keys and true as values
+// like {"<id>":true} instead of an array of IDs.
+//
+// Use it for APIs and document stores that model sets
+// as keyed objects. Keys with the value false
+// are not part of the set when unmarshalled.
+type ObjectIDSet IDSet
+
+// IDSet returns the IDs as IDSet.
+func (s ObjectIDSet) IDSet() IDSet {
+	return IDSet(s)
+}
+
+// String implements the fmt.Stringer interface.
+func (s ObjectIDSet) String() string {
+	return IDSet(s).String()
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// using IDSet.MarshalJSONObject.
+func (s ObjectIDSet) MarshalJSON() ([]byte, error) {
+	return IDSet(s).MarshalJSONObject()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// using IDSet.UnmarshalJSONObject.
+func (s *ObjectIDSet) UnmarshalJSON(data []byte) error {
+	return (*IDSet)(s).UnmarshalJSONObject(data)
+}
+
+// MarshalJSONObject returns the set as JSON object
+// with the sorted IDs as keys and true as values
+// like {"<id>":true}, or null for a nil set.
+func (s IDSet) MarshalJSONObject() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	b := make([]byte, 0, 2+len(s)*(38+6))
+	b = append(b, '{')
+	for i, id := range s.AsSortedSlice() {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = id.AppendString(b)
+		b = append(b, `":true`...)
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSONObject parses a JSON object with IDs as keys
+// and boolean values as returned by MarshalJSONObject.
+// Keys with the value false are not added to the set.
+// JSON null results in a nil set.
+// It does assign a new IDSet to *s instead of modifying the existing map.
+func (s *IDSet) UnmarshalJSONObject(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*s = nil
+		return nil
+	}
+	var obj map[string]bool
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("can't parse JSON object as uu.IDSet: %w", err)
+	}
+	set := make(IDSet, len(obj))
+	for key, member := range obj {
+		id, err := IDFromString(key)
+		if err != nil {
+			return err
+		}
+		if member {
+			set[id] = struct{}{}
+		}
+	}
+	*s = set
+	return nil
+}
@@ -0,0 +1,48 @@
+package uu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectIDSet_JSON(t *testing.T) {
+	id1 := IDMust("2d6a2c10-e4a6-45a3-a705-8115214a3778")
+	id2 := IDMust("ec449f0f-e10c-4edb-8b59-0e6c896fdca5")
+	set := MakeIDSet(id1, id2)
+
+	data, err := set.MarshalJSONObject()
+	require.NoError(t, err)
+	sorted := set.AsSortedSlice()
+	assert.Equal(t, `{"`+sorted[0].String()+`":true,"`+sorted[1].String()+`":true}`, string(data))
+
+	var parsed IDSet
+	require.NoError(t, parsed.UnmarshalJSONObject(data))
+	assert.Equal(t, set, parsed)
+
+	type doc struct {
+		Members ObjectIDSet `json:"members"`
+	}
+	data, err = json.Marshal(doc{Members: ObjectIDSet(MakeIDSet(id1))})
+	require.NoError(t, err)
+	assert.Equal(t, `{"members":{"2d6a2c10-e4a6-45a3-a705-8115214a3778":true}}`, string(data))
+
+	var d doc
+	require.NoError(t, json.Unmarshal([]byte(`{"members":{"2d6a2c10-e4a6-45a3-a705-8115214a3778":true,"ec449f0f-e10c-4edb-8b59-0e6c896fdca5":false}}`), &d))
+	assert.Equal(t, MakeIDSet(id1), d.Members.IDSet())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"members":{}}`), &d))
+	assert.Equal(t, IDSet{}, d.Members.IDSet())
+	require.NoError(t, json.Unmarshal([]byte(`{"members":null}`), &d))
+	assert.Nil(t, d.Members)
+
+	data, err = json.Marshal(doc{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"members":null}`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"members":{"xxx":true}}`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`{"members":{"2d6a2c10-e4a6-45a3-a705-8115214a3778":1}}`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`{"members":["2d6a2c10-e4a6-45a3-a705-8115214a3778"]}`), &d))
+}
@@ -0,0 +1,130 @@
+package uu
+
+import (
+	"sync"
+)
+
+// SyncIDSet is a set of uu.IDs that is safe for concurrent use.
+// It wraps an IDSet with a sync.RWMutex.
+// The zero value is an empty set ready to use.
+// A SyncIDSet must not be copied after first use.
+type SyncIDSet struct {
+	mtx sync.RWMutex
+	set IDSet
+}
+
+// NewSyncIDSet returns a SyncIDSet with
+// the optional passed ids added to it.
+func NewSyncIDSet(ids ...ID) *SyncIDSet {
+	return &SyncIDSet{set: MakeIDSet(ids...)}
+}
+
+// Add adds an id to the set.
+func (s *SyncIDSet) Add(id ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.set == nil {
+		s.set = make(IDSet)
+	}
+	s.set[id] = struct{}{}
+}
+
+// AddNew adds an id to the set if it is not already
+// contained and returns if it was added.
+// Checking and adding is one atomic operation,
+// so only one of multiple concurrent callers
+// for the same id will get true as result.
+func (s *SyncIDSet) AddNew(id ID) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.set[id]; ok {
+		return false
+	}
+	if s.set == nil {
+		s.set = make(IDSet)
+	}
+	s.set[id] = struct{}{}
+	return true
+}
+
+// AddSlice adds all IDs of the slice to the set.
+func (s *SyncIDSet) AddSlice(ids IDSlice) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.set == nil {
+		s.set = make(IDSet, len(ids))
+	}
+	s.set.AddSlice(ids)
+}
+
+// Contains returns if the set contains the id.
+func (s *SyncIDSet) Contains(id ID) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.set.Contains(id)
+}
+
+// Delete removes an id from the set.
+func (s *SyncIDSet) Delete(id ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.set, id)
+}
+
+// Clear removes all IDs from the set.
+func (s *SyncIDSet) Clear() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	clear(s.set)
+}
+
+// Len returns the number of IDs in the set.
+func (s *SyncIDSet) Len() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return len(s.set)
+}
+
+// ForEach calls the passed function for each ID
+// while holding a read lock, so the callback
+// must not call methods of the set that modify it.
+// Any error from the callback function is returned
+// by ForEach immediatly.
+// Use Snapshot to iterate without holding the lock.
+func (s *SyncIDSet) ForEach(callback func(ID) error) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.set.ForEach(callback)
+}
+
+// Clone returns a new SyncIDSet with the IDs of the set.
+func (s *SyncIDSet) Clone() *SyncIDSet {
+	return &SyncIDSet{set: s.Snapshot()}
+}
+
+// Snapshot returns a copy of the current IDs of the set
+// as IDSet that is not affected by later modifications.
+// Returns an empty non nil IDSet for an empty set.
+func (s *SyncIDSet) Snapshot() IDSet {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	snapshot := make(IDSet, len(s.set))
+	for id := range s.set {
+		snapshot[id] = struct{}{}
+	}
+	return snapshot
+}
+
+// String implements the fmt.Stringer interface.
+func (s *SyncIDSet) String() string {
+	return s.Snapshot().String()
+}
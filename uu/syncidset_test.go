@@ -0,0 +1,65 @@
+package uu
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncIDSet(t *testing.T) {
+	var s SyncIDSet
+	id1, id2 := IDv4(), IDv4()
+	require.False(t, s.Contains(id1), "zero value")
+	require.Equal(t, 0, s.Len())
+
+	s.Add(id1)
+	require.True(t, s.Contains(id1))
+	require.False(t, s.AddNew(id1))
+	require.True(t, s.AddNew(id2))
+	require.Equal(t, MakeIDSet(id1, id2), s.Snapshot())
+
+	clone := s.Clone()
+	s.Delete(id1)
+	require.False(t, s.Contains(id1))
+	require.True(t, clone.Contains(id1), "clone not affected")
+
+	var visited IDSlice
+	require.NoError(t, clone.ForEach(func(id ID) error {
+		visited = append(visited, id)
+		return nil
+	}))
+	require.ElementsMatch(t, IDSlice{id1, id2}, visited)
+
+	s.Clear()
+	require.Equal(t, 0, s.Len())
+	require.NotNil(t, s.Snapshot())
+
+	require.Equal(t, 2, NewSyncIDSet(id1, id2, id1).Len())
+}
+
+func TestSyncIDSet_Concurrent(t *testing.T) {
+	ids := make(IDSlice, 100)
+	for i := range ids {
+		ids[i] = IDv4()
+	}
+	s := NewSyncIDSet()
+	var added atomic.Int64
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, id := range ids {
+				if s.AddNew(id) {
+					added.Add(1)
+				}
+				s.Contains(id)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(len(ids)), added.Load(), "every ID added exactly once")
+	require.Equal(t, ids.AsSet(), s.Snapshot())
+}
@@ -0,0 +1,61 @@
+package uu
+
+import "fmt"
+
+// UniqueIDSlice is an IDSlice that rejects duplicate IDs
+// when unmarshalled from JSON or text with an ErrDuplicateID error
+// instead of silently keeping them.
+//
+// Use it for API inputs where duplicates indicate a client bug.
+// The order of the IDs is preserved.
+type UniqueIDSlice IDSlice
+
+// IDSlice returns the IDs as IDSlice.
+func (s UniqueIDSlice) IDSlice() IDSlice {
+	return IDSlice(s)
+}
+
+// String implements the fmt.Stringer interface.
+func (s UniqueIDSlice) String() string {
+	return IDSlice(s).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (s UniqueIDSlice) MarshalText() (text []byte, err error) {
+	return IDSlice(s).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+// and returns an ErrDuplicateID error for duplicate IDs.
+func (s *UniqueIDSlice) UnmarshalText(text []byte) error {
+	var ids IDSlice
+	err := ids.UnmarshalText(text)
+	if err != nil {
+		return err
+	}
+	if err = ids.CheckUnique(); err != nil {
+		return fmt.Errorf("can't parse as uu.UniqueIDSlice: %w", err)
+	}
+	*s = UniqueIDSlice(ids)
+	return nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+func (s UniqueIDSlice) MarshalJSON() ([]byte, error) {
+	return IDSlice(s).MarshalJSON()
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler
+// and returns an ErrDuplicateID error for duplicate IDs.
+func (s *UniqueIDSlice) UnmarshalJSON(data []byte) error {
+	var ids IDSlice
+	err := ids.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if err = ids.CheckUnique(); err != nil {
+		return fmt.Errorf("can't parse as uu.UniqueIDSlice: %w", err)
+	}
+	*s = UniqueIDSlice(ids)
+	return nil
+}
@@ -0,0 +1,179 @@
+// Package uufuzz provides seed corpora and property checks
+// for fuzz testing the parsers of the uu package.
+//
+// The parsers of package uu never panic on malformed input,
+// this package is used by the fuzz targets of go-types to enforce that
+// and can be reused by downstream projects in their own fuzz targets
+// for types that embed or wrap uu types:
+//
+//	func FuzzMyID(f *testing.F) {
+//		uufuzz.AddIDStringSeeds(f)
+//		f.Fuzz(func(t *testing.T, s string) {
+//			uufuzz.CheckIDFromString(t, s)
+//			// Checks of own parsers
+//		})
+//	}
+package uufuzz
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/domonda/go-types/uu"
+)
+
+const validID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+// IDStringSeeds returns valid and malformed strings
+// for fuzzing ID parsers in all supported formats.
+func IDStringSeeds() []string {
+	return []string{
+		validID,
+		`"` + validID + `"`,
+		"{" + validID + "}",
+		"urn:uuid:" + validID,
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"a6e4EJ2tEdGAtADAT9QwyA",
+		"00000000-0000-0000-0000-000000000000",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"",
+		`""`,
+		"null",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b810x9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cg",
+		"urn:uuid:",
+		`"{}"`,
+		"{" + validID,
+		"\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f",
+	}
+}
+
+// IDSliceStringSeeds returns valid and malformed strings
+// for fuzzing IDSlice and IDSet parsers from
+// text, JSON and PostgreSQL array representations.
+func IDSliceStringSeeds() []string {
+	return []string{
+		"[]",
+		"[" + validID + "]",
+		"[" + validID + ", 00000000-0000-0000-0000-000000000000]",
+		"set[" + validID + "]",
+		`["` + validID + `"]`,
+		`["` + validID + `","` + validID + `"]`,
+		`[ "` + validID + `" , "` + validID + `" ]`,
+		"{}",
+		"{" + validID + "," + validID + "}",
+		`{"` + validID + `"}`,
+		"",
+		"null",
+		"NULL",
+		"[",
+		"]",
+		"[,]",
+		`[""]`,
+		`["`,
+		`[",]`,
+		"{,}",
+		"{",
+	}
+}
+
+// AddIDStringSeeds adds IDStringSeeds to the seed corpus of f.
+func AddIDStringSeeds(f *testing.F) {
+	for _, seed := range IDStringSeeds() {
+		f.Add(seed)
+	}
+}
+
+// AddIDSliceStringSeeds adds IDSliceStringSeeds to the seed corpus of f.
+func AddIDSliceStringSeeds(f *testing.F) {
+	for _, seed := range IDSliceStringSeeds() {
+		f.Add(seed)
+	}
+}
+
+// CheckIDFromString checks that parsing s as uu.ID
+// and uu.NullableID does not panic and that a parsed
+// ID survives a round trip through its string representation.
+func CheckIDFromString(t testing.TB, s string) {
+	t.Helper()
+	defer recoverFatal(t, "uu.IDFromString", s)
+
+	id, err := uu.IDFromString(s)
+	if err != nil {
+		if id != uu.IDNil {
+			t.Fatalf("uu.IDFromString(%q) returned %s with error: %s", s, id, err)
+		}
+	} else {
+		checkIDRoundTrip(t, id)
+	}
+
+	_, _ = uu.IDFromBytes([]byte(s))
+	_, _ = uu.NullableIDFromString(s)
+	var nullable uu.NullableID
+	_ = nullable.UnmarshalJSON([]byte(s))
+	_ = nullable.Scan(s)
+	var scanned uu.ID
+	_ = scanned.Scan([]byte(s))
+}
+
+// CheckIDSliceFromString checks that parsing s as uu.IDSlice
+// and uu.IDSet from text, JSON and SQL does not panic and that
+// parsed IDs survive a round trip through their text representation.
+func CheckIDSliceFromString(t testing.TB, s string) {
+	t.Helper()
+	defer recoverFatal(t, "uu.IDSlice parsing", s)
+
+	slice, err := uu.IDSliceFromString(s)
+	if err == nil {
+		reparsed, err := uu.IDSliceFromString(slice.String())
+		if err != nil || !reparsed.Equal(slice) {
+			t.Fatalf("uu.IDSlice %s from %q does not survive round trip: %v", slice, s, err)
+		}
+	}
+	set, err := uu.IDSetFromString(s)
+	if err == nil {
+		reparsed, err := uu.IDSetFromString(set.String())
+		if err != nil || !reparsed.Equal(set) {
+			t.Fatalf("uu.IDSet %s from %q does not survive round trip: %v", set, s, err)
+		}
+	}
+
+	var jsonSlice uu.IDSlice
+	if jsonSlice.UnmarshalJSON([]byte(s)) == nil {
+		data, err := json.Marshal(jsonSlice)
+		if err != nil {
+			t.Fatalf("can't marshal uu.IDSlice %s parsed from JSON %q: %s", jsonSlice, s, err)
+		}
+		var reparsed uu.IDSlice
+		if err = json.Unmarshal(data, &reparsed); err != nil || !reparsed.Equal(jsonSlice) {
+			t.Fatalf("uu.IDSlice %s from JSON %q does not survive round trip: %v", jsonSlice, s, err)
+		}
+	}
+	var jsonSet uu.IDSet
+	_ = jsonSet.UnmarshalJSON([]byte(s))
+	var unique uu.UniqueIDSlice
+	_ = unique.UnmarshalJSON([]byte(s))
+
+	var sqlSlice uu.IDSlice
+	_ = sqlSlice.Scan(s)
+	var sqlSet uu.IDSet
+	_ = sqlSet.Scan([]byte(s))
+}
+
+func checkIDRoundTrip(t testing.TB, id uu.ID) {
+	t.Helper()
+	for _, str := range []string{id.String(), id.Hex(), id.Base64()} {
+		reparsed, err := uu.IDFromString(str)
+		if err != nil || reparsed != id {
+			t.Fatalf("uu.ID %s does not survive round trip through %q: %v", id, str, err)
+		}
+	}
+}
+
+func recoverFatal(t testing.TB, what, input string) {
+	if p := recover(); p != nil {
+		t.Helper()
+		t.Fatalf("%s panicked for input %q: %v", what, input, p)
+	}
+}
@@ -0,0 +1,18 @@
+package uufuzz
+
+import "testing"
+
+func FuzzIDFromString(f *testing.F) {
+	AddIDStringSeeds(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		CheckIDFromString(t, s)
+	})
+}
+
+func FuzzIDSliceFromString(f *testing.F) {
+	AddIDSliceStringSeeds(f)
+	AddIDStringSeeds(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		CheckIDSliceFromString(t, s)
+	})
+}
@@ -0,0 +1,23 @@
+module github.com/domonda/go-types/uupgx
+
+go 1.24.0
+
+// Parent module in same repo
+replace github.com/domonda/go-types => ..
+
+require github.com/domonda/go-types v0.0.0-00010101000000-000000000000 // replaced
+
+// External
+require (
+	github.com/jackc/pgx/v5 v5.8.0
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.30.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,319 @@
+// Package uupgx registers github.com/jackc/pgx/v5/pgtype codecs
+// for the types of the package github.com/domonda/go-types/uu
+// so that uu.ID and uu.NullableID are sent and received in the
+// binary Postgres uuid format and uu.IDSlice and uu.IDSet as uuid[]
+// without the text round-trip through the
+// database/sql Scanner and Valuer implementations.
+//
+// The package is a separate module to keep pgx
+// out of the dependencies of github.com/domonda/go-types.
+//
+// Usage with a pgxpool.Config:
+//
+//	config.AfterConnect = uupgx.AfterConnect
+//
+// or for a single connection:
+//
+//	uupgx.RegisterConn(conn)
+package uupgx
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/domonda/go-types/uu"
+)
+
+// Register replaces the codecs of the uuid and uuid[] types
+// of the map with codecs that also handle uu.ID, uu.NullableID,
+// uu.IDSlice, and uu.IDSet values and scan targets directly.
+// All other values are handled like by the default pgtype codecs.
+func Register(m *pgtype.Map) {
+	idType := &pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: IDCodec{}}
+	m.RegisterType(idType)
+	m.RegisterType(&pgtype.Type{
+		Name:  "_uuid",
+		OID:   pgtype.UUIDArrayOID,
+		Codec: IDSliceCodec{ArrayCodec: &pgtype.ArrayCodec{ElementType: idType}},
+	})
+	m.RegisterDefaultPgType(uu.ID{}, "uuid")
+	m.RegisterDefaultPgType(uu.NullableID{}, "uuid")
+	m.RegisterDefaultPgType(uu.IDSlice(nil), "_uuid")
+	m.RegisterDefaultPgType(uu.IDSet(nil), "_uuid")
+}
+
+// RegisterConn registers the codecs of Register
+// with the type map of a connection.
+func RegisterConn(conn *pgx.Conn) {
+	Register(conn.TypeMap())
+}
+
+// AfterConnect calls RegisterConn and can be used
+// as pgxpool.Config.AfterConnect function
+// so that every connection of the pool uses the codecs.
+func AfterConnect(ctx context.Context, conn *pgx.Conn) error {
+	RegisterConn(conn)
+	return nil
+}
+
+// IDCodec is a pgtype.Codec for the Postgres uuid type
+// that handles uu.ID and uu.NullableID values and scan targets
+// and delegates all other types to the embedded pgtype.UUIDCodec.
+type IDCodec struct {
+	pgtype.UUIDCodec
+}
+
+// PlanEncode implements pgtype.Codec.
+func (c IDCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case uu.ID, uu.NullableID:
+		if format == pgtype.BinaryFormatCode {
+			return encodePlanIDBinary{}
+		}
+		return encodePlanIDText{}
+	}
+	return c.UUIDCodec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements pgtype.Codec.
+func (c IDCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *uu.ID, *uu.NullableID:
+		return scanPlanID{format: format}
+	}
+	return c.UUIDCodec.PlanScan(m, oid, format, target)
+}
+
+type encodePlanIDBinary struct{}
+
+func (encodePlanIDBinary) Encode(value any, buf []byte) ([]byte, error) {
+	switch id := value.(type) {
+	case uu.ID:
+		return append(buf, id[:]...), nil
+	case uu.NullableID:
+		if id.IsNull() {
+			return nil, nil
+		}
+		return append(buf, id[:]...), nil
+	}
+	return nil, fmt.Errorf("can't encode %T as uuid", value)
+}
+
+type encodePlanIDText struct{}
+
+func (encodePlanIDText) Encode(value any, buf []byte) ([]byte, error) {
+	switch id := value.(type) {
+	case uu.ID:
+		return append(buf, id.String()...), nil
+	case uu.NullableID:
+		if id.IsNull() {
+			return nil, nil
+		}
+		return append(buf, id.String()...), nil
+	}
+	return nil, fmt.Errorf("can't encode %T as uuid", value)
+}
+
+type scanPlanID struct {
+	format int16
+}
+
+func (p scanPlanID) Scan(src []byte, target any) error {
+	var id *uu.ID
+	switch t := target.(type) {
+	case *uu.ID:
+		if src == nil {
+			return errors.New("can't scan NULL into uu.ID")
+		}
+		id = t
+	case *uu.NullableID:
+		if src == nil {
+			*t = uu.IDNull
+			return nil
+		}
+		id = (*uu.ID)(t)
+	default:
+		return fmt.Errorf("can't scan uuid into %T", target)
+	}
+	if p.format == pgtype.BinaryFormatCode {
+		if len(src) != 16 {
+			return fmt.Errorf("invalid length for binary uuid: %d", len(src))
+		}
+		*id = uu.ID(src)
+		return nil
+	}
+	parsed, err := uu.IDFromBytes(src)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// IDSliceCodec is a pgtype.Codec for the Postgres uuid[] type
+// that handles uu.IDSlice and uu.IDSet values and scan targets
+// and delegates all other types to the embedded pgtype.ArrayCodec.
+//
+// A nil uu.IDSlice or uu.IDSet is encoded as SQL NULL
+// and SQL NULL is scanned as nil.
+type IDSliceCodec struct {
+	*pgtype.ArrayCodec
+}
+
+// PlanEncode implements pgtype.Codec.
+func (c IDSliceCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case uu.IDSlice, uu.IDSet:
+		if format == pgtype.BinaryFormatCode {
+			return encodePlanIDSliceBinary{}
+		}
+		next := c.ArrayCodec.PlanEncode(m, oid, format, pgtype.FlatArray[uu.ID](nil))
+		if next == nil {
+			return nil
+		}
+		return encodePlanIDSlice{next: next}
+	}
+	return c.ArrayCodec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements pgtype.Codec.
+func (c IDSliceCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *uu.IDSlice, *uu.IDSet:
+		if format == pgtype.BinaryFormatCode {
+			return scanPlanIDSlice{next: scanPlanIDSliceBinary{}}
+		}
+		next := c.ArrayCodec.PlanScan(m, oid, format, new(pgtype.FlatArray[uu.ID]))
+		if next == nil {
+			return nil
+		}
+		return scanPlanIDSlice{next: next}
+	}
+	return c.ArrayCodec.PlanScan(m, oid, format, target)
+}
+
+type encodePlanIDSlice struct {
+	next pgtype.EncodePlan
+}
+
+func (p encodePlanIDSlice) Encode(value any, buf []byte) ([]byte, error) {
+	switch ids := value.(type) {
+	case uu.IDSlice:
+		return p.next.Encode(pgtype.FlatArray[uu.ID](ids), buf)
+	case uu.IDSet:
+		if ids == nil {
+			return p.next.Encode(pgtype.FlatArray[uu.ID](nil), buf)
+		}
+		return p.next.Encode(pgtype.FlatArray[uu.ID](ids.AsSortedSlice()), buf)
+	}
+	return nil, fmt.Errorf("can't encode %T as uuid[]", value)
+}
+
+// encodePlanIDSliceBinary writes the binary array format
+// without boxing every element like pgtype.ArrayCodec:
+// a one dimensional array header followed
+// by the length prefixed 16 byte elements.
+type encodePlanIDSliceBinary struct{}
+
+func (encodePlanIDSliceBinary) Encode(value any, buf []byte) ([]byte, error) {
+	var ids uu.IDSlice
+	switch v := value.(type) {
+	case uu.IDSlice:
+		ids = v
+	case uu.IDSet:
+		if v != nil {
+			ids = v.AsSortedSlice()
+		}
+	default:
+		return nil, fmt.Errorf("can't encode %T as uuid[]", value)
+	}
+	if ids == nil {
+		return nil, nil
+	}
+	buf = binary.BigEndian.AppendUint32(buf, 1) // dimensions
+	buf = binary.BigEndian.AppendUint32(buf, 0) // no nulls
+	buf = binary.BigEndian.AppendUint32(buf, pgtype.UUIDOID)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(ids))) //#nosec G115 -- integer conversion OK
+	buf = binary.BigEndian.AppendUint32(buf, 1)                // lower bound
+	buf = slices.Grow(buf, len(ids)*(4+16))
+	for _, id := range ids {
+		buf = binary.BigEndian.AppendUint32(buf, 16)
+		buf = append(buf, id[:]...)
+	}
+	return buf, nil
+}
+
+type scanPlanIDSliceBinary struct{}
+
+func (scanPlanIDSliceBinary) Scan(src []byte, target any) error {
+	ids, ok := target.(*pgtype.FlatArray[uu.ID])
+	if !ok {
+		return fmt.Errorf("can't scan uuid[] into %T", target)
+	}
+	if src == nil {
+		*ids = nil
+		return nil
+	}
+	if len(src) < 12 {
+		return fmt.Errorf("invalid length for binary uuid[]: %d", len(src))
+	}
+	dims := binary.BigEndian.Uint32(src)
+	if hasNull := binary.BigEndian.Uint32(src[4:]); hasNull != 0 {
+		return errors.New("can't scan uuid[] with NULL element into uu.IDSlice")
+	}
+	src = src[12:] // skip element OID
+	if dims == 0 {
+		*ids = pgtype.FlatArray[uu.ID]{}
+		return nil
+	}
+	if dims != 1 {
+		return fmt.Errorf("can't scan %d dimensional uuid[] into uu.IDSlice", dims)
+	}
+	if len(src) < 8 {
+		return fmt.Errorf("invalid length for binary uuid[] dimension: %d", len(src))
+	}
+	n := int(binary.BigEndian.Uint32(src))
+	src = src[8:]
+	if len(src) != n*(4+16) {
+		return fmt.Errorf("invalid length for binary uuid[] with %d elements: %d", n, len(src))
+	}
+	result := make(pgtype.FlatArray[uu.ID], n)
+	for i := range result {
+		if elemLen := binary.BigEndian.Uint32(src); elemLen != 16 {
+			return fmt.Errorf("invalid length for binary uuid: %d", elemLen)
+		}
+		result[i] = uu.ID(src[4:20])
+		src = src[20:]
+	}
+	*ids = result
+	return nil
+}
+
+type scanPlanIDSlice struct {
+	next pgtype.ScanPlan
+}
+
+func (p scanPlanIDSlice) Scan(src []byte, target any) error {
+	switch t := target.(type) {
+	case *uu.IDSlice:
+		return p.next.Scan(src, (*pgtype.FlatArray[uu.ID])(t))
+	case *uu.IDSet:
+		var ids pgtype.FlatArray[uu.ID]
+		if err := p.next.Scan(src, &ids); err != nil {
+			return err
+		}
+		if ids == nil {
+			*t = nil
+			return nil
+		}
+		*t = uu.IDSlice(ids).AsSet()
+		return nil
+	}
+	return fmt.Errorf("can't scan uuid[] into %T", target)
+}
@@ -0,0 +1,142 @@
+package uupgx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/uu"
+)
+
+func TestRegister(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	id := uu.IDMustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.UUIDOID, format, id, nil)
+		require.NoError(t, err)
+		if format == pgtype.BinaryFormatCode {
+			require.Equal(t, id[:], buf)
+		} else {
+			require.Equal(t, id.String(), string(buf))
+		}
+		var scanned uu.ID
+		require.NoError(t, m.Scan(pgtype.UUIDOID, format, buf, &scanned))
+		require.Equal(t, id, scanned)
+		var nullable uu.NullableID
+		require.NoError(t, m.Scan(pgtype.UUIDOID, format, buf, &nullable))
+		require.Equal(t, uu.NullableID(id), nullable)
+
+		// Other types still work with the default codec
+		var pgUUID pgtype.UUID
+		require.NoError(t, m.Scan(pgtype.UUIDOID, format, buf, &pgUUID))
+		require.Equal(t, [16]byte(id), pgUUID.Bytes)
+	}
+
+	buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, uu.IDNull, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf, "NullableID null as SQL NULL")
+	nullable := uu.NullableID(id)
+	require.NoError(t, m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &nullable))
+	require.Equal(t, uu.IDNull, nullable)
+	var notNull uu.ID
+	require.Error(t, m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &notNull))
+	require.Error(t, m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, []byte{1, 2, 3}, &notNull))
+
+	ids := uu.IDSlice{id, uu.IDv4(), uu.IDv7()}
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.UUIDArrayOID, format, ids, nil)
+		require.NoError(t, err)
+		var scanned uu.IDSlice
+		require.NoError(t, m.Scan(pgtype.UUIDArrayOID, format, buf, &scanned))
+		require.Equal(t, ids, scanned)
+
+		buf, err = m.Encode(pgtype.UUIDArrayOID, format, ids.AsSet(), nil)
+		require.NoError(t, err)
+		var set uu.IDSet
+		require.NoError(t, m.Scan(pgtype.UUIDArrayOID, format, buf, &set))
+		require.Equal(t, ids.AsSet(), set)
+	}
+
+	buf, err = m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, uu.IDSlice(nil), nil)
+	require.NoError(t, err)
+	require.Nil(t, buf, "nil IDSlice as SQL NULL")
+	scanned := ids
+	require.NoError(t, m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, nil, &scanned))
+	require.Nil(t, scanned)
+
+	buf, err = m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, uu.IDSlice{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, buf, &scanned))
+	require.NotNil(t, scanned)
+	require.Empty(t, scanned)
+}
+
+func benchmarkIDSlice(b *testing.B, m *pgtype.Map, format int16) {
+	ids := make(uu.IDSlice, 100_000)
+	for i := range ids {
+		ids[i] = uu.IDv4()
+	}
+	buf, err := m.Encode(pgtype.UUIDArrayOID, format, ids, nil)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf, err = m.Encode(pgtype.UUIDArrayOID, format, ids, buf[:0])
+		if err != nil {
+			b.Fatal(err)
+		}
+		var scanned uu.IDSlice
+		err = m.Scan(pgtype.UUIDArrayOID, format, buf, &scanned)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIDSlice_Registered(b *testing.B) {
+	m := pgtype.NewMap()
+	Register(m)
+	benchmarkIDSlice(b, m, pgtype.BinaryFormatCode)
+}
+
+// BenchmarkIDSlice_Default uses the text format
+// of the uu.IDSlice database/sql interfaces.
+func BenchmarkIDSlice_Default(b *testing.B) {
+	benchmarkIDSlice(b, pgtype.NewMap(), pgtype.TextFormatCode)
+}
+
+func TestIDSliceCodec_BinaryCompatibility(t *testing.T) {
+	registered := pgtype.NewMap()
+	Register(registered)
+	defaultMap := pgtype.NewMap()
+
+	ids := uu.IDSlice{uu.IDv4(), uu.IDv7()}
+	for _, ids := range []uu.IDSlice{ids, {}} {
+		buf, err := registered.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, ids, nil)
+		require.NoError(t, err)
+		expected, err := defaultMap.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, pgtype.FlatArray[[16]byte](idsToArrays(ids)), nil)
+		require.NoError(t, err)
+		require.Equal(t, expected, buf, "same binary format as pgtype.ArrayCodec")
+	}
+
+	buf, err := defaultMap.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, []*[16]byte{(*[16]byte)(&ids[0]), nil}, nil)
+	require.NoError(t, err)
+	var scanned uu.IDSlice
+	require.Error(t, registered.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, buf, &scanned), "NULL element")
+}
+
+func idsToArrays(ids uu.IDSlice) [][16]byte {
+	arrays := make([][16]byte, len(ids))
+	for i, id := range ids {
+		arrays[i] = id
+	}
+	return arrays
+}
+
+// Compile-time check that AfterConnect can be used as pgxpool.Config.AfterConnect
+var _ func(context.Context, *pgx.Conn) error = AfterConnect
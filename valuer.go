@@ -0,0 +1,73 @@
+package types
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// ValuerContext can be implemented by types whose SQL value
+// depends on settings of a context like a time zone
+// or a rounding rule that can differ per request.
+//
+// The database/sql package has no context aware counterpart
+// of driver.Valuer, so values implementing ValuerContext
+// have to be wrapped with ValuerWithContext or ArgsWithContext
+// before passing them as query arguments.
+type ValuerContext interface {
+	// ValueContext returns the driver.Value for the settings of ctx.
+	ValueContext(ctx context.Context) (driver.Value, error)
+}
+
+// ValuerWithContext returns a driver.Valuer that calls
+// the ValueContext method of v with the passed ctx.
+func ValuerWithContext(ctx context.Context, v ValuerContext) driver.Valuer {
+	return valuerWithContext{ctx: ctx, v: v}
+}
+
+type valuerWithContext struct {
+	ctx context.Context
+	v   ValuerContext
+}
+
+func (w valuerWithContext) Value() (driver.Value, error) {
+	return w.v.ValueContext(w.ctx)
+}
+
+// ArgsWithContext returns the passed query arguments with all
+// ValuerContext implementations, also as value of a sql.NamedArg,
+// wrapped by ValuerWithContext using ctx.
+// The args slice is returned unchanged if no argument
+// implements ValuerContext, else a modified copy is returned.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, query, types.ArgsWithContext(ctx, args...)...)
+func ArgsWithContext(ctx context.Context, args ...any) []any {
+	var result []any
+	for i, arg := range args {
+		var wrapped any
+		switch x := arg.(type) {
+		case ValuerContext:
+			wrapped = ValuerWithContext(ctx, x)
+		case sql.NamedArg:
+			v, ok := x.Value.(ValuerContext)
+			if !ok {
+				continue
+			}
+			x.Value = ValuerWithContext(ctx, v)
+			wrapped = x
+		default:
+			continue
+		}
+		if result == nil {
+			result = make([]any, len(args))
+			copy(result, args)
+		}
+		result[i] = wrapped
+	}
+	if result == nil {
+		return args
+	}
+	return result
+}
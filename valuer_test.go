@@ -0,0 +1,44 @@
+package types
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCtxKey struct{}
+
+type testValuerContext string
+
+func (v testValuerContext) ValueContext(ctx context.Context) (driver.Value, error) {
+	if suffix, ok := ctx.Value(testCtxKey{}).(string); ok {
+		return string(v) + suffix, nil
+	}
+	return string(v), nil
+}
+
+func TestArgsWithContext(t *testing.T) {
+	ctx := context.WithValue(t.Context(), testCtxKey{}, "!")
+
+	args := []any{1, "a"}
+	require.Equal(t, args, ArgsWithContext(ctx, args...), "unchanged without ValuerContext")
+
+	args = []any{1, testValuerContext("b"), sql.Named("c", testValuerContext("c"))}
+	wrapped := ArgsWithContext(ctx, args...)
+	require.Len(t, wrapped, 3)
+	require.Equal(t, 1, wrapped[0])
+	require.Equal(t, testValuerContext("b"), args[1], "args not modified")
+
+	value, err := wrapped[1].(driver.Valuer).Value()
+	require.NoError(t, err)
+	require.Equal(t, "b!", value)
+
+	named := wrapped[2].(sql.NamedArg)
+	require.Equal(t, "c", named.Name)
+	value, err = named.Value.(driver.Valuer).Value()
+	require.NoError(t, err)
+	require.Equal(t, "c!", value)
+}
@@ -0,0 +1,102 @@
+package vat
+
+import (
+	"fmt"
+
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/date"
+)
+
+// vatEUPeriod is the period in which VAT IDs with a country prefix
+// were EU VAT IDs. Empty dates mean an open period.
+type vatEUPeriod struct {
+	from, until date.Date
+}
+
+// vatEUPeriods holds the VAT ID prefixes that were not EU VAT IDs
+// for all of the time since the introduction of the VAT Information
+// Exchange System (VIES) in 1993.
+// Other prefixes are EU VAT IDs if their country is currently an EU member.
+var vatEUPeriods = map[country.Code]vatEUPeriod{
+	"CY": {from: "2004-05-01"},
+	"CZ": {from: "2004-05-01"},
+	"EE": {from: "2004-05-01"},
+	"HU": {from: "2004-05-01"},
+	"LT": {from: "2004-05-01"},
+	"LV": {from: "2004-05-01"},
+	"MT": {from: "2004-05-01"},
+	"PL": {from: "2004-05-01"},
+	"SI": {from: "2004-05-01"},
+	"SK": {from: "2004-05-01"},
+	"BG": {from: "2007-01-01"},
+	"RO": {from: "2007-01-01"},
+	"HR": {from: "2013-07-01"},
+	// End of the Brexit transition period
+	"GB": {until: "2020-12-31"},
+	// Northern Ireland Protocol for the trade of goods
+	NorthernIrelandVATCountryCode: {from: "2021-01-01"},
+}
+
+// IsEUAt returns if the ID was a valid EU VAT ID at the passed date,
+// taking into account the accession dates of member states
+// and Brexit, so that for example a GB VAT ID is an EU VAT ID
+// at a date before 2021-01-01.
+// The current date is used if atDate is zero.
+func (id ID) IsEUAt(atDate date.Date) bool {
+	norm, err := id.Normalized()
+	if err != nil {
+		return false
+	}
+	if atDate.IsZero() {
+		atDate = date.OfToday()
+	}
+	prefix := country.Code(norm[:2])
+	if prefix == MOSSSchemaVATCountryCode {
+		return true
+	}
+	if period, ok := vatEUPeriods[prefix]; ok {
+		return (period.from.IsZero() || atDate.EqualOrAfter(period.from)) &&
+			(period.until.IsZero() || atDate.EqualOrBefore(period.until))
+	}
+	return id.CountryCode().IsEU()
+}
+
+// ValidateAt returns an error if id is not a valid VAT ID
+// at the passed date, ignoring normalization.
+// Northern Ireland VAT IDs with the prefix "XI" are not valid
+// before their introduction on 2021-01-01.
+// The current date is used if atDate is zero.
+//
+// Returns a wrapped ErrInvalidID error if the VAT ID is not valid.
+func (id ID) ValidateAt(atDate date.Date) error {
+	norm, err := id.Normalized()
+	if err != nil {
+		return err
+	}
+	if atDate.IsZero() {
+		atDate = date.OfToday()
+	}
+	if norm[:2] == NorthernIrelandVATCountryCode && atDate.Before(vatEUPeriods[NorthernIrelandVATCountryCode].from) {
+		return fmt.Errorf("%w: %q Northern Ireland VAT IDs are not valid before %s", ErrInvalidID, string(id), vatEUPeriods[NorthernIrelandVATCountryCode].from)
+	}
+	return nil
+}
+
+// ValidateEUAt returns an error if id is not a valid EU VAT ID
+// at the passed date, see ID.IsEUAt.
+// The current date is used if atDate is zero.
+//
+// Returns a wrapped ErrInvalidID error if the VAT ID is not valid.
+func (id ID) ValidateEUAt(atDate date.Date) error {
+	err := id.ValidateAt(atDate)
+	if err != nil {
+		return err
+	}
+	if !id.IsEUAt(atDate) {
+		if atDate.IsZero() {
+			return fmt.Errorf("%w: %q is not an EU VAT ID", ErrInvalidID, string(id))
+		}
+		return fmt.Errorf("%w: %q was not an EU VAT ID at %s", ErrInvalidID, string(id), atDate)
+	}
+	return nil
+}
@@ -56,6 +56,7 @@ var idRegex = map[country.Code]*regexp.Regexp{
 	"SE": regexp.MustCompile(`^SE\d{12}$`),
 	"SI": regexp.MustCompile(`^SI\d{8}$`),
 	"SK": regexp.MustCompile(`^SK\d{10}$`),
+	"XI": regexp.MustCompile(`^XI(?:\d{9}|\d{12}|GD\d{3}|HA\d{3})$`), // Northern Ireland, see NorthernIrelandVATCountryCode
 	// > For the non-Union scheme, the taxable person can choose any Member State to be
 	// > the Member State of identification. That Member State will allocate an individual
 	// > VAT identification number to the taxable person (using the format EUxxxyyyyyz).
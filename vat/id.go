@@ -22,8 +22,33 @@ var _ types.NormalizableValidator[ID] = ID("")
 // https://europa.eu/youreurope/business/taxation/vat/vat-digital-services-moss-scheme/index_en.htm
 const MOSSSchemaVATCountryCode = "EU"
 
+// NorthernIrelandVATCountryCode is used for VAT IDs of Northern Ireland
+// businesses trading goods with the EU since the end of the
+// Brexit transition period on 2021-01-01 under the Northern Ireland Protocol.
+const NorthernIrelandVATCountryCode = "XI"
+
 const ErrInvalidID errs.Sentinel = "invalid VAT ID"
 
+// Error codes of VAT ID validation errors
+// that can be used to look up localized messages.
+// All errors with these codes wrap ErrInvalidID.
+// See types.CodedError.
+const (
+	ErrCodeIDTooShort           types.ErrorCode = "vat.id.too_short"
+	ErrCodeIDTooLong            types.ErrorCode = "vat.id.too_long"
+	ErrCodeIDCountryCode        types.ErrorCode = "vat.id.country_code"
+	ErrCodeIDUnsupportedCountry types.ErrorCode = "vat.id.unsupported_country"
+	ErrCodeIDFormat             types.ErrorCode = "vat.id.format"
+	ErrCodeIDCheckSum           types.ErrorCode = "vat.id.checksum"
+)
+
+// invalidIDError returns a types.CodedError wrapping ErrInvalidID
+// with the parameter "value" and the message "invalid VAT ID: <value> <problem>"
+func invalidIDError(code types.ErrorCode, id ID, problem string, params ...any) error {
+	msg := fmt.Sprintf("%s: %q %s", ErrInvalidID, string(id), problem)
+	return types.NewCodedError(code, msg, append([]any{"value", string(id)}, params...)...).Wrap(ErrInvalidID)
+}
+
 // ID is a european VAT ID.
 // ID implements the database/sql.Scanner and database/sql/driver.Valuer interfaces,
 // returning errors when the ID is not valid and can't be normalized.
@@ -67,31 +92,31 @@ func (id ID) Normalized() (ID, error) {
 
 	// Check length
 	if len(normalized) < IDMinLength {
-		return id, fmt.Errorf("%w: %q is too short", ErrInvalidID, string(id))
+		return id, invalidIDError(ErrCodeIDTooShort, id, "is too short", "minLength", IDMinLength)
 	}
 	if len(normalized) > IDMaxLength {
-		return id, fmt.Errorf("%w: %q is too long", ErrInvalidID, string(id))
+		return id, invalidIDError(ErrCodeIDTooLong, id, "is too long", "maxLength", IDMaxLength)
 	}
 
 	// Check country code
 	countryCode := country.Code(normalized[:2])
-	if countryCode != MOSSSchemaVATCountryCode && !countryCode.Valid() {
-		return id, fmt.Errorf("%w: %q has an invalid country code: %q", ErrInvalidID, string(id), string(countryCode))
+	if countryCode != MOSSSchemaVATCountryCode && countryCode != NorthernIrelandVATCountryCode && !countryCode.Valid() {
+		return id, invalidIDError(ErrCodeIDCountryCode, id, fmt.Sprintf("has an invalid country code: %q", string(countryCode)), "countryCode", string(countryCode))
 	}
 
 	// Check format with country specific regex
 	regex, ok := idRegex[countryCode]
 	if !ok {
-		return id, fmt.Errorf("%w: %q has an unsupported country code: %q", ErrInvalidID, string(id), string(countryCode))
+		return id, invalidIDError(ErrCodeIDUnsupportedCountry, id, fmt.Sprintf("has an unsupported country code: %q", string(countryCode)), "countryCode", string(countryCode))
 	}
 	if !regex.MatchString(string(normalized)) {
-		return id, fmt.Errorf("%w: %q has an invalid format", ErrInvalidID, string(id))
+		return id, invalidIDError(ErrCodeIDFormat, id, "has an invalid format")
 	}
 
 	// Test checkFunc-sum if a function is available for the country
 	checkFunc, ok := checkSumFuncs[countryCode]
 	if ok && !checkFunc(id, normalized) {
-		return id, fmt.Errorf("%w: %q has an invalid check-sum", ErrInvalidID, string(id))
+		return id, invalidIDError(ErrCodeIDCheckSum, id, "has an invalid check-sum")
 	}
 
 	return normalized, nil
@@ -154,18 +179,25 @@ func (id ID) Nullable() NullableID {
 // For a VAT Mini One Stop Shop (MOSS) ID that begins with "EU"
 // the EU's capital Brussels' country Belgum's
 // code country.BE will be returned.
+// The VAT prefix "EL" of Greece is returned as country.GR
+// and the Northern Ireland prefix "XI" as country.GB.
 // See also ID.IsMOSS.
 func (id ID) CountryCode() country.Code {
 	norm, err := id.Normalized()
 	if err != nil {
 		return country.Invalid
 	}
-	code := country.Code(norm[:2])
-	if code == MOSSSchemaVATCountryCode {
+	switch code := country.Code(norm[:2]); code {
+	case MOSSSchemaVATCountryCode:
 		// MOSS VAT begins with "EU" - Europe is not a country
 		return country.BE
+	case country.EL:
+		return country.GR
+	case NorthernIrelandVATCountryCode:
+		return country.GB
+	default:
+		return code
 	}
-	return code
 }
 
 // IsMOSS returns true if the ID follows the
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/date"
 )
 
 var validVATIDs = map[string]string{
@@ -101,3 +104,45 @@ func Test_VATIDFinder_FindAllIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestID_CountryCode(t *testing.T) {
+	assert.Equal(t, country.AT, ID("ATU10223006").CountryCode())
+	assert.Equal(t, country.GR, ID("EL123456789").CountryCode())
+	assert.Equal(t, country.GB, ID("XI123456789").CountryCode())
+	assert.Equal(t, country.GB, ID("GB123456789").CountryCode())
+	assert.Equal(t, country.BE, ID("EU123456789").CountryCode())
+	assert.Equal(t, country.Invalid, ID("XX123456789").CountryCode())
+}
+
+func TestID_ValidateEUAt(t *testing.T) {
+	tests := []struct {
+		id      ID
+		atDate  date.Date
+		wantErr bool
+	}{
+		{id: "GB123456789", atDate: "2019-06-30"},
+		{id: "GB123456789", atDate: "2020-12-31"},
+		{id: "GB123456789", atDate: "2021-01-01", wantErr: true},
+		{id: "XI123456789", atDate: "2020-12-31", wantErr: true},
+		{id: "XI123456789", atDate: "2021-01-01"},
+		{id: "HR12345678901", atDate: "2013-06-30", wantErr: true},
+		{id: "HR12345678901", atDate: "2013-07-01"},
+		{id: "ATU10223006", atDate: "2000-01-01"},
+		{id: "EL123456789", atDate: "2024-01-01"},
+		{id: "NO916634773", atDate: "2024-01-01", wantErr: true},
+		{id: "ATU10223007", atDate: "2024-01-01", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.id)+"@"+string(tt.atDate), func(t *testing.T) {
+			err := tt.id.ValidateEUAt(tt.atDate)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidID)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.NoError(t, ID("XI123456789").ValidateAt(""), "current date")
+	assert.NoError(t, ID("GB123456789").ValidateAt("2024-01-01"), "valid non EU VAT ID")
+}
@@ -0,0 +1,246 @@
+package vat
+
+import (
+	"slices"
+
+	"github.com/domonda/go-types/country"
+	"github.com/domonda/go-types/money"
+)
+
+// InvoiceField is a field of an invoice
+// that can be mandatory for VAT purposes.
+type InvoiceField string
+
+const (
+	InvoiceFieldIssueDate         InvoiceField = "ISSUE_DATE"
+	InvoiceFieldInvoiceNumber     InvoiceField = "INVOICE_NUMBER"
+	InvoiceFieldSupplierName      InvoiceField = "SUPPLIER_NAME"
+	InvoiceFieldSupplierAddress   InvoiceField = "SUPPLIER_ADDRESS"
+	InvoiceFieldSupplierVATID     InvoiceField = "SUPPLIER_VAT_ID"
+	InvoiceFieldBuyerName         InvoiceField = "BUYER_NAME"
+	InvoiceFieldBuyerAddress      InvoiceField = "BUYER_ADDRESS"
+	InvoiceFieldBuyerVATID        InvoiceField = "BUYER_VAT_ID"
+	InvoiceFieldDescription       InvoiceField = "DESCRIPTION"
+	InvoiceFieldSupplyDate        InvoiceField = "SUPPLY_DATE"
+	InvoiceFieldNetAmount         InvoiceField = "NET_AMOUNT"
+	InvoiceFieldVATRate           InvoiceField = "VAT_RATE"
+	InvoiceFieldVATAmount         InvoiceField = "VAT_AMOUNT"
+	InvoiceFieldGrossAmount       InvoiceField = "GROSS_AMOUNT"
+	InvoiceFieldReverseChargeNote InvoiceField = "REVERSE_CHARGE_NOTE"
+	InvoiceFieldExemptionNote     InvoiceField = "EXEMPTION_NOTE"
+)
+
+// InvoiceCondition is a condition under which
+// an InvoiceRule applies.
+type InvoiceCondition string
+
+const (
+	// InvoiceConditionReverseCharge applies if the buyer is liable
+	// for the VAT of the invoice.
+	InvoiceConditionReverseCharge InvoiceCondition = "REVERSE_CHARGE"
+	// InvoiceConditionIntraCommunitySupply applies to VAT exempt
+	// supplies of goods to a buyer in another EU member state.
+	InvoiceConditionIntraCommunitySupply InvoiceCondition = "INTRA_COMMUNITY_SUPPLY"
+)
+
+// InvoiceRule is a mandatory field of an invoice.
+type InvoiceRule struct {
+	Field InvoiceField `json:"field"`
+	// Condition under which the field is mandatory,
+	// empty means always.
+	Condition InvoiceCondition `json:"condition,omitempty"`
+	// Simplified is true if the field is also
+	// mandatory for simplified invoices.
+	Simplified bool `json:"simplified,omitempty"`
+}
+
+// InvoiceRules are the VAT invoice requirements of a country
+// for an invoice amount. See InvoiceRequirements.
+type InvoiceRules struct {
+	Country country.Code `json:"country"`
+	// SimplifiedThreshold is the maximum gross amount
+	// of a simplified invoice, zero if not available.
+	SimplifiedThreshold money.CurrencyAmount `json:"simplifiedThreshold"`
+	// SimplifiedAllowed is true if the invoice amount
+	// does not exceed the SimplifiedThreshold.
+	SimplifiedAllowed bool `json:"simplifiedAllowed"`
+	// ReverseChargeNote is the note text that has to be
+	// on reverse charge invoices in the language of the country.
+	ReverseChargeNote string        `json:"reverseChargeNote"`
+	Rules             []InvoiceRule `json:"rules"`
+}
+
+// MandatoryFields returns the mandatory fields of an invoice
+// for which the passed conditions apply.
+// Simplified invoices are only possible if SimplifiedAllowed
+// is true and no conditions are passed, because reverse charge
+// and intra-community supplies always require a full invoice.
+func (r *InvoiceRules) MandatoryFields(conditions ...InvoiceCondition) []InvoiceField {
+	simplified := r.SimplifiedAllowed && len(conditions) == 0
+	var fields []InvoiceField
+	for _, rule := range r.Rules {
+		if rule.Condition != "" && !slices.Contains(conditions, rule.Condition) {
+			continue
+		}
+		if simplified && !rule.Simplified {
+			continue
+		}
+		if !slices.Contains(fields, rule.Field) {
+			fields = append(fields, rule.Field)
+		}
+	}
+	return fields
+}
+
+// IsMandatory returns true if the field is mandatory
+// for an invoice for which the passed conditions apply.
+func (r *InvoiceRules) IsMandatory(field InvoiceField, conditions ...InvoiceCondition) bool {
+	return slices.Contains(r.MandatoryFields(conditions...), field)
+}
+
+// countryInvoiceRules are the differences of countries
+// to the EU VAT directive 2006/112/EC.
+type countryInvoiceRules struct {
+	simplifiedThreshold money.CurrencyAmount
+	reverseChargeNote   string
+	// simplifiedFields replace the fields of
+	// defaultInvoiceRules that are mandatory
+	// for simplified invoices if not nil
+	simplifiedFields []InvoiceField
+}
+
+// euSimplifiedThreshold is the amount up to which member states
+// have to allow simplified invoices by Article 220a of 2006/112/EC.
+var euSimplifiedThreshold = money.CurrencyAmount{Currency: money.EUR, Amount: 100}
+
+// defaultInvoiceRules are the mandatory invoice details
+// of Article 226 and simplified invoice details
+// of Article 226b of the EU VAT directive 2006/112/EC.
+var defaultInvoiceRules = []InvoiceRule{
+	{Field: InvoiceFieldIssueDate, Simplified: true},
+	{Field: InvoiceFieldInvoiceNumber},
+	{Field: InvoiceFieldSupplierName, Simplified: true},
+	{Field: InvoiceFieldSupplierAddress},
+	{Field: InvoiceFieldSupplierVATID, Simplified: true},
+	{Field: InvoiceFieldBuyerName},
+	{Field: InvoiceFieldBuyerAddress},
+	{Field: InvoiceFieldBuyerVATID, Condition: InvoiceConditionReverseCharge},
+	{Field: InvoiceFieldBuyerVATID, Condition: InvoiceConditionIntraCommunitySupply},
+	{Field: InvoiceFieldDescription, Simplified: true},
+	{Field: InvoiceFieldSupplyDate},
+	{Field: InvoiceFieldNetAmount},
+	{Field: InvoiceFieldVATRate},
+	{Field: InvoiceFieldVATAmount, Simplified: true},
+	{Field: InvoiceFieldGrossAmount, Simplified: true},
+	{Field: InvoiceFieldReverseChargeNote, Condition: InvoiceConditionReverseCharge},
+	{Field: InvoiceFieldExemptionNote, Condition: InvoiceConditionIntraCommunitySupply},
+}
+
+var countriesInvoiceRules = map[country.Code]countryInvoiceRules{
+	"AT": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: money.EUR, Amount: 400},
+		reverseChargeNote:   "Übergang der Steuerschuld",
+		// Kleinbetragsrechnung § 11 Abs. 6 UStG
+		simplifiedFields: []InvoiceField{
+			InvoiceFieldIssueDate,
+			InvoiceFieldSupplierName,
+			InvoiceFieldSupplierAddress,
+			InvoiceFieldDescription,
+			InvoiceFieldSupplyDate,
+			InvoiceFieldVATRate,
+			InvoiceFieldGrossAmount,
+		},
+	},
+	"BE": {reverseChargeNote: "Autoliquidation"},
+	"CZ": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: "CZK", Amount: 10000},
+		reverseChargeNote:   "Daň odvede zákazník",
+	},
+	"DE": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: money.EUR, Amount: 250},
+		reverseChargeNote:   "Steuerschuldnerschaft des Leistungsempfängers",
+		// Kleinbetragsrechnung § 33 UStDV
+		simplifiedFields: []InvoiceField{
+			InvoiceFieldIssueDate,
+			InvoiceFieldSupplierName,
+			InvoiceFieldSupplierAddress,
+			InvoiceFieldDescription,
+			InvoiceFieldVATRate,
+			InvoiceFieldGrossAmount,
+		},
+	},
+	"DK": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: "DKK", Amount: 3000},
+		reverseChargeNote:   "Omvendt betalingspligt",
+	},
+	"ES": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: money.EUR, Amount: 400},
+		reverseChargeNote:   "Inversión del sujeto pasivo",
+	},
+	"FI": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: money.EUR, Amount: 400},
+		reverseChargeNote:   "Käännetty verovelvollisuus",
+	},
+	"FR": {reverseChargeNote: "Autoliquidation"},
+	"IT": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: money.EUR, Amount: 400},
+		reverseChargeNote:   "Inversione contabile",
+	},
+	"NL": {reverseChargeNote: "Btw verlegd"},
+	"PL": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: "PLN", Amount: 450},
+		reverseChargeNote:   "Odwrotne obciążenie",
+	},
+	"PT": {reverseChargeNote: "Autoliquidação"},
+	"SE": {
+		simplifiedThreshold: money.CurrencyAmount{Currency: "SEK", Amount: 4000},
+		reverseChargeNote:   "Omvänd betalningsskyldighet",
+	},
+}
+
+// InvoiceRequirements returns the VAT invoice requirements
+// of a country for an invoice with the passed gross amount.
+//
+// The rules are based on the EU VAT directive 2006/112/EC
+// with the simplified invoice thresholds and reverse charge note texts
+// of member states where they are known.
+// Simplified invoices are only allowed for EU member states
+// and if the gross amount has the currency of the threshold.
+// Countries outside of the EU get the rules of full invoices
+// without a simplified invoice threshold and
+// "Reverse charge" as note text.
+//
+// Returns an error if the country code is not valid.
+func InvoiceRequirements(countryCode country.Code, grossAmount money.CurrencyAmount) (*InvoiceRules, error) {
+	countryCode, err := countryCode.Normalized()
+	if err != nil {
+		return nil, err
+	}
+	rules := &InvoiceRules{
+		Country:           countryCode,
+		ReverseChargeNote: "Reverse charge",
+		Rules:             slices.Clone(defaultInvoiceRules),
+	}
+	if !countryCode.IsEU() {
+		return rules, nil
+	}
+
+	rules.SimplifiedThreshold = euSimplifiedThreshold
+	if c, ok := countriesInvoiceRules[countryCode]; ok {
+		if c.simplifiedThreshold.Amount != 0 {
+			rules.SimplifiedThreshold = c.simplifiedThreshold
+		}
+		if c.reverseChargeNote != "" {
+			rules.ReverseChargeNote = c.reverseChargeNote
+		}
+		if c.simplifiedFields != nil {
+			for i, rule := range rules.Rules {
+				rules.Rules[i].Simplified = rule.Condition == "" && slices.Contains(c.simplifiedFields, rule.Field)
+			}
+		}
+	}
+	if currency, err := grossAmount.Currency.Normalized(); err == nil && currency == rules.SimplifiedThreshold.Currency {
+		rules.SimplifiedAllowed = grossAmount.Amount.Abs() <= rules.SimplifiedThreshold.Amount
+	}
+	return rules, nil
+}
@@ -0,0 +1,89 @@
+package vat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-types/money"
+)
+
+func TestInvoiceRequirements(t *testing.T) {
+	t.Run("DE simplified", func(t *testing.T) {
+		rules, err := InvoiceRequirements("de", money.CurrencyAmount{Currency: "eur", Amount: 249.99})
+		require.NoError(t, err)
+		assert.Equal(t, "DE", string(rules.Country))
+		assert.Equal(t, money.CurrencyAmount{Currency: money.EUR, Amount: 250}, rules.SimplifiedThreshold)
+		assert.True(t, rules.SimplifiedAllowed)
+		assert.Equal(t, "Steuerschuldnerschaft des Leistungsempfängers", rules.ReverseChargeNote)
+		assert.Equal(t,
+			[]InvoiceField{
+				InvoiceFieldIssueDate,
+				InvoiceFieldSupplierName,
+				InvoiceFieldSupplierAddress,
+				InvoiceFieldDescription,
+				InvoiceFieldVATRate,
+				InvoiceFieldGrossAmount,
+			},
+			rules.MandatoryFields(),
+		)
+		assert.False(t, rules.IsMandatory(InvoiceFieldSupplierVATID))
+
+		// Reverse charge always requires a full invoice
+		assert.True(t, rules.IsMandatory(InvoiceFieldBuyerVATID, InvoiceConditionReverseCharge))
+		assert.True(t, rules.IsMandatory(InvoiceFieldReverseChargeNote, InvoiceConditionReverseCharge))
+		assert.True(t, rules.IsMandatory(InvoiceFieldInvoiceNumber, InvoiceConditionReverseCharge))
+		assert.False(t, rules.IsMandatory(InvoiceFieldExemptionNote, InvoiceConditionReverseCharge))
+	})
+
+	t.Run("DE full", func(t *testing.T) {
+		rules, err := InvoiceRequirements("DE", money.CurrencyAmount{Currency: money.EUR, Amount: 250.01})
+		require.NoError(t, err)
+		assert.False(t, rules.SimplifiedAllowed)
+		fields := rules.MandatoryFields()
+		assert.Contains(t, fields, InvoiceFieldInvoiceNumber)
+		assert.Contains(t, fields, InvoiceFieldBuyerName)
+		assert.NotContains(t, fields, InvoiceFieldBuyerVATID)
+		assert.NotContains(t, fields, InvoiceFieldReverseChargeNote)
+
+		fields = rules.MandatoryFields(InvoiceConditionIntraCommunitySupply)
+		assert.Contains(t, fields, InvoiceFieldBuyerVATID)
+		assert.Contains(t, fields, InvoiceFieldExemptionNote)
+	})
+
+	t.Run("EU default", func(t *testing.T) {
+		rules, err := InvoiceRequirements("LU", money.CurrencyAmount{Currency: money.EUR, Amount: 80})
+		require.NoError(t, err)
+		assert.Equal(t, euSimplifiedThreshold, rules.SimplifiedThreshold)
+		assert.True(t, rules.SimplifiedAllowed)
+		assert.Equal(t, "Reverse charge", rules.ReverseChargeNote)
+		assert.True(t, rules.IsMandatory(InvoiceFieldSupplierVATID))
+		assert.False(t, rules.IsMandatory(InvoiceFieldBuyerName))
+	})
+
+	t.Run("threshold currency mismatch", func(t *testing.T) {
+		rules, err := InvoiceRequirements("PL", money.CurrencyAmount{Currency: money.EUR, Amount: 10})
+		require.NoError(t, err)
+		assert.False(t, rules.SimplifiedAllowed)
+		assert.Equal(t, "Odwrotne obciążenie", rules.ReverseChargeNote)
+	})
+
+	t.Run("non EU", func(t *testing.T) {
+		rules, err := InvoiceRequirements("US", money.CurrencyAmount{Currency: "USD", Amount: 1})
+		require.NoError(t, err)
+		assert.False(t, rules.SimplifiedAllowed)
+		assert.Zero(t, rules.SimplifiedThreshold)
+		assert.True(t, rules.IsMandatory(InvoiceFieldBuyerName))
+	})
+
+	t.Run("rules are copies", func(t *testing.T) {
+		rules, err := InvoiceRequirements("AT", money.CurrencyAmount{})
+		require.NoError(t, err)
+		rules.Rules[0].Field = "CHANGED"
+		assert.Equal(t, InvoiceFieldIssueDate, defaultInvoiceRules[0].Field)
+	})
+
+	_, err := InvoiceRequirements("XX", money.CurrencyAmount{})
+	assert.Error(t, err)
+}